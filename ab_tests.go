@@ -0,0 +1,143 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// WinningCriteria decides which A/B test variant wins.
+type WinningCriteria string
+
+// A/B test winning criteria.
+const (
+	WinByOpenRate  WinningCriteria = "open_rate"
+	WinByClickRate WinningCriteria = "click_rate"
+	WinByRevenue   WinningCriteria = "revenue"
+	// WinManually waits for PickABTestWinner.
+	WinManually WinningCriteria = "manual"
+)
+
+// CampaignVariant overrides parts of a campaign's content for one A/B test arm.
+// Empty fields fall back to the campaign content.
+type CampaignVariant struct {
+	ID           string `json:"id,omitempty"`
+	Name         string `json:"name"`
+	Subject      string `json:"subject,omitempty"`
+	PreviewText  string `json:"preview_text,omitempty"`
+	FromName     string `json:"from_name,omitempty"`
+	TemplateSlug string `json:"template_slug,omitempty"`
+	Body         string `json:"body,omitempty"`
+	TextBody     string `json:"text_body,omitempty"`
+}
+
+// ABTest configures an A/B test on a campaign. SamplePercent of the audience is
+// split evenly across the variants; after TestDurationHours the winner, chosen by
+// WinningCriteria, is sent to the rest of the audience.
+type ABTest struct {
+	Variants          []CampaignVariant `json:"variants"`
+	SamplePercent     int               `json:"sample_percent"`
+	WinningCriteria   WinningCriteria   `json:"winning_criteria"`
+	TestDurationHours int               `json:"test_duration_hours"`
+}
+
+// ABTestStatus is the lifecycle state of an A/B test.
+type ABTestStatus string
+
+// A/B test statuses.
+const (
+	ABTestPending ABTestStatus = "pending"
+	ABTestTesting ABTestStatus = "testing"
+	ABTestDecided ABTestStatus = "decided"
+)
+
+// VariantResult is the performance of one A/B test variant.
+type VariantResult struct {
+	VariantID    string  `json:"variant_id"`
+	Name         string  `json:"name"`
+	Sent         int     `json:"sent"`
+	UniqueOpens  int     `json:"unique_opens"`
+	UniqueClicks int     `json:"unique_clicks"`
+	OpenRate     float64 `json:"open_rate"`
+	ClickRate    float64 `json:"click_rate"`
+	Revenue      int64   `json:"revenue"`
+	Winner       bool    `json:"winner"`
+}
+
+// ABTestResults are the per-variant results of a campaign's A/B test.
+type ABTestResults struct {
+	Status          ABTestStatus    `json:"status"`
+	WinningCriteria WinningCriteria `json:"winning_criteria"`
+	// WinnerID is set once the test is decided.
+	WinnerID  string          `json:"winner_id,omitempty"`
+	DecidedAt string          `json:"decided_at,omitempty"`
+	Variants  []VariantResult `json:"variants"`
+}
+
+// Winner returns the winning variant's result, or nil while the test is running.
+func (r *ABTestResults) Winner() *VariantResult {
+	if r.WinnerID == "" {
+		return nil
+	}
+	for i := range r.Variants {
+		if r.Variants[i].VariantID == r.WinnerID {
+			return &r.Variants[i]
+		}
+	}
+	return nil
+}
+
+// ConfigureABTest sets the A/B test of a draft or scheduled campaign.
+func (c *Client) ConfigureABTest(ctx context.Context, campaignID string, test *ABTest) (*ABTest, error) {
+	if err := validateABTest(test); err != nil {
+		return nil, err
+	}
+
+	var result ABTest
+	if err := c.do(ctx, http.MethodPut, campaignPath(campaignID, "ab-test"), nil, test, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetABTestResults returns the per-variant results and the winner, if decided.
+func (c *Client) GetABTestResults(ctx context.Context, campaignID string) (*ABTestResults, error) {
+	var result ABTestResults
+	if err := c.do(ctx, http.MethodGet, campaignPath(campaignID, "ab-test/results"), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PickABTestWinner decides a test manually (or early) and sends the variant to the rest of the audience.
+func (c *Client) PickABTestWinner(ctx context.Context, campaignID, variantID string) (*ABTestResults, error) {
+	body := map[string]string{"variant_id": variantID}
+
+	var result ABTestResults
+	if err := c.do(ctx, http.MethodPost, campaignPath(campaignID, "ab-test/winner"), nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// validateABTest checks an A/B test configuration.
+func validateABTest(test *ABTest) error {
+	if test == nil {
+		return fmt.Errorf("A/B test is required")
+	}
+	if len(test.Variants) < 2 {
+		return fmt.Errorf("A/B test needs at least two variants")
+	}
+	if test.SamplePercent < 1 || test.SamplePercent > 100 {
+		return fmt.Errorf("sample percent must be between 1 and 100")
+	}
+	switch test.WinningCriteria {
+	case WinByOpenRate, WinByClickRate, WinByRevenue, WinManually:
+	default:
+		return fmt.Errorf("unknown winning criteria %q", test.WinningCriteria)
+	}
+	if test.WinningCriteria != WinManually && test.TestDurationHours <= 0 && test.SamplePercent < 100 {
+		return fmt.Errorf("test duration is required to pick a winner automatically")
+	}
+	return nil
+}
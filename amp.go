@@ -0,0 +1,153 @@
+package levee
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// AMP actions recorded by HandleAMP.
+const (
+	AMPActionRSVP     = "rsvp"
+	AMPActionFeedback = "feedback"
+	AMPActionVote     = "vote"
+)
+
+// RecordAMPInteraction records an interaction submitted from an AMP email
+// (e.g. an RSVP or feedback form). fields carries the submitted form values.
+func (c *Client) RecordAMPInteraction(ctx context.Context, token, action string, fields map[string]string) error {
+	resp, err := c.doRequest(ctx, http.MethodPost, "/sdk/v1/tracking/amp", map[string]interface{}{
+		"token":  token,
+		"action": action,
+		"fields": fields,
+	})
+	if err != nil {
+		return err
+	}
+	return decodeResponse(resp, nil)
+}
+
+// HandleAMP returns a handler for amp-form submissions from AMP emails.
+// It applies AMP for Email CORS (AMP-Email-Sender / AMP-Email-Allow-Sender, with the
+// legacy __amp_source_origin scheme as fallback), reads the "action" field plus any
+// other form fields, and records them with RecordAMPInteraction.
+// Restrict accepted senders with WithAMPAllowedSenders.
+// Route: POST /your-prefix/amp/:token
+func (c *Client) HandleAMP(cfg *HandlerConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !applyAMPCORS(cfg, w, r) {
+			writeAMPJSON(w, http.StatusForbidden, map[string]interface{}{"success": false, "error": "sender not allowed"})
+			return
+		}
+
+		if r.Method != http.MethodPost {
+			cfg.renderError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed)
+			return
+		}
+
+		token := getToken(r, "/amp/")
+		if token == "" {
+			writeAMPJSON(w, http.StatusBadRequest, map[string]interface{}{"success": false, "error": "missing token"})
+			return
+		}
+
+		// amp-form posts multipart/form-data or application/x-www-form-urlencoded
+		r.Body = http.MaxBytesReader(w, r.Body, 64<<10)
+		var err error
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+			err = r.ParseMultipartForm(64 << 10)
+		} else {
+			err = r.ParseForm()
+		}
+		if err != nil {
+			writeAMPJSON(w, http.StatusBadRequest, map[string]interface{}{"success": false, "error": "invalid form"})
+			return
+		}
+
+		fields := make(map[string]string, len(r.PostForm))
+		for key, values := range r.PostForm {
+			if key != "action" && len(values) > 0 {
+				fields[key] = values[0]
+			}
+		}
+		action := r.PostForm.Get("action")
+		if action == "" {
+			action = AMPActionFeedback
+		}
+
+		if err := c.RecordAMPInteraction(r.Context(), token, action, fields); err != nil {
+			writeAMPJSON(w, http.StatusInternalServerError, map[string]interface{}{"success": false, "error": "failed to record"})
+			return
+		}
+
+		writeAMPJSON(w, http.StatusOK, map[string]interface{}{"success": true, "action": action})
+	}
+}
+
+// ampEmailOrigins are the origins AMP emails are rendered from, the only ones
+// whose credentialed v1 CORS requests are answered. Subdomains of
+// ampproject.org (the AMP cache) are allowed as well.
+var ampEmailOrigins = map[string]bool{
+	"https://mail.google.com":  true,
+	"https://outlook.live.com": true,
+	"https://mail.yahoo.com":   true,
+	"https://e.mail.ru":        true,
+}
+
+// ampEmailOrigin reports whether origin renders AMP emails.
+func ampEmailOrigin(origin string) bool {
+	return ampEmailOrigins[origin] ||
+		(strings.HasPrefix(origin, "https://") && strings.HasSuffix(origin, ".ampproject.org"))
+}
+
+// applyAMPCORS sets the AMP for Email CORS response headers.
+// Returns false when the sender is not allowed, or when an allow list is
+// configured and the request names no sender.
+func applyAMPCORS(cfg *HandlerConfig, w http.ResponseWriter, r *http.Request) bool {
+	// AMP for Email v2: single header, no preflight
+	if sender := r.Header.Get("AMP-Email-Sender"); sender != "" {
+		if !ampSenderAllowed(cfg, sender) {
+			return false
+		}
+		w.Header().Set("AMP-Email-Allow-Sender", sender)
+		return true
+	}
+
+	// v1: Origin + __amp_source_origin query parameter
+	if source := r.URL.Query().Get("__amp_source_origin"); source != "" {
+		if !ampSenderAllowed(cfg, source) {
+			return false
+		}
+		if origin := r.Header.Get("Origin"); ampEmailOrigin(origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+		w.Header().Set("AMP-Access-Control-Allow-Source-Origin", source)
+		w.Header().Set("Access-Control-Expose-Headers", "AMP-Access-Control-Allow-Source-Origin")
+		return true
+	}
+
+	// Without a sender the allow list cannot be checked
+	return len(cfg.AMPAllowedSenders) == 0
+}
+
+// ampSenderAllowed reports whether an AMP sender is in the allow list (empty allows all).
+func ampSenderAllowed(cfg *HandlerConfig, sender string) bool {
+	if len(cfg.AMPAllowedSenders) == 0 {
+		return true
+	}
+	for _, allowed := range cfg.AMPAllowedSenders {
+		if strings.EqualFold(allowed, sender) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeAMPJSON writes an amp-form JSON response.
+func writeAMPJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,90 @@
+package levee
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestApplyAMPCORS(t *testing.T) {
+	tests := []struct {
+		name        string
+		allowed     []string
+		header      http.Header
+		query       string
+		ok          bool
+		allowOrigin string
+	}{
+		{
+			name:   "v2 sender",
+			header: http.Header{"Amp-Email-Sender": {"events@example.com"}},
+			ok:     true,
+		},
+		{
+			name:    "v2 allowed sender",
+			allowed: []string{"Events@example.com"},
+			header:  http.Header{"Amp-Email-Sender": {"events@example.com"}},
+			ok:      true,
+		},
+		{
+			name:    "v2 sender not allowed",
+			allowed: []string{"events@example.com"},
+			header:  http.Header{"Amp-Email-Sender": {"other@example.com"}},
+		},
+		{
+			name:        "v1 from gmail",
+			header:      http.Header{"Origin": {"https://mail.google.com"}},
+			query:       "__amp_source_origin=events@example.com",
+			ok:          true,
+			allowOrigin: "https://mail.google.com",
+		},
+		{
+			name:        "v1 from the AMP cache",
+			header:      http.Header{"Origin": {"https://example-com.cdn.ampproject.org"}},
+			query:       "__amp_source_origin=events@example.com",
+			ok:          true,
+			allowOrigin: "https://example-com.cdn.ampproject.org",
+		},
+		{
+			name:   "v1 from another origin is not reflected",
+			header: http.Header{"Origin": {"https://evil.example"}},
+			query:  "__amp_source_origin=events@example.com",
+			ok:     true,
+		},
+		{
+			name:    "v1 source not allowed",
+			allowed: []string{"events@example.com"},
+			header:  http.Header{"Origin": {"https://mail.google.com"}},
+			query:   "__amp_source_origin=other@example.com",
+		},
+		{
+			name: "no sender without allow list",
+			ok:   true,
+		},
+		{
+			name:    "no sender with allow list",
+			allowed: []string{"events@example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/levee/amp/tok?"+tt.query, nil)
+			for k, v := range tt.header {
+				r.Header[k] = v
+			}
+			w := httptest.NewRecorder()
+
+			cfg := NewHandlerConfig(WithAMPAllowedSenders(tt.allowed...))
+			if got := applyAMPCORS(cfg, w, r); got != tt.ok {
+				t.Errorf("applyAMPCORS() = %v, want %v", got, tt.ok)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.allowOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.allowOrigin)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Credentials"); (got != "") != (tt.allowOrigin != "") {
+				t.Errorf("Access-Control-Allow-Credentials = %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// APIKeyScope limits what an API key can do.
+type APIKeyScope string
+
+// API key scopes. A key with ScopeAll can do everything, including managing keys.
+const (
+	ScopeAll            APIKeyScope = "*"
+	ScopeContactsRead   APIKeyScope = "contacts:read"
+	ScopeContactsWrite  APIKeyScope = "contacts:write"
+	ScopeEmailsSend     APIKeyScope = "emails:send"
+	ScopeCampaignsWrite APIKeyScope = "campaigns:write"
+	ScopeTemplatesWrite APIKeyScope = "templates:write"
+	ScopeTrackingWrite  APIKeyScope = "tracking:write"
+	ScopeWebhooksWrite  APIKeyScope = "webhooks:write"
+	ScopeBillingRead    APIKeyScope = "billing:read"
+	ScopeBillingWrite   APIKeyScope = "billing:write"
+	ScopeStatsRead      APIKeyScope = "stats:read"
+	ScopeLLM            APIKeyScope = "llm"
+	ScopeAPIKeysWrite   APIKeyScope = "api_keys:write"
+)
+
+// APIKey is an API key's metadata. The secret is only available at creation.
+type APIKey struct {
+	ID     string        `json:"id"`
+	Name   string        `json:"name"`
+	Scopes []APIKeyScope `json:"scopes"`
+	// Prefix is the first characters of the key, for identifying it in logs and dashboards.
+	Prefix     string `json:"prefix"`
+	CreatedAt  string `json:"created_at,omitempty"`
+	LastUsedAt string `json:"last_used_at,omitempty"`
+	ExpiresAt  string `json:"expires_at,omitempty"`
+	RevokedAt  string `json:"revoked_at,omitempty"`
+}
+
+// CreateAPIKeyRequest describes a new API key.
+type CreateAPIKeyRequest struct {
+	Name   string        `json:"name"`
+	Scopes []APIKeyScope `json:"scopes"`
+	// ExpiresAt optionally expires the key (RFC 3339).
+	ExpiresAt string `json:"expires_at,omitempty"`
+}
+
+// CreatedAPIKey is a newly created key with its secret.
+type CreatedAPIKey struct {
+	APIKey
+	// Key is the secret to pass to NewClient. Levee does not store it; it cannot be retrieved again.
+	Key string `json:"key"`
+}
+
+// CreateAPIKey creates a scoped API key. The calling key needs ScopeAPIKeysWrite.
+//
+// To rotate a key, create its replacement, deploy it, then revoke the old one:
+//
+//	newKey, err := client.CreateAPIKey(ctx, &levee.CreateAPIKeyRequest{
+//		Name:   "worker (2026-10)",
+//		Scopes: []levee.APIKeyScope{levee.ScopeEmailsSend, levee.ScopeContactsRead},
+//	})
+//	// ... deploy newKey.Key ...
+//	err = client.RevokeAPIKey(ctx, oldKeyID)
+func (c *Client) CreateAPIKey(ctx context.Context, req *CreateAPIKeyRequest) (*CreatedAPIKey, error) {
+	if req == nil || req.Name == "" {
+		return nil, fmt.Errorf("api key name is required")
+	}
+	if len(req.Scopes) == 0 {
+		return nil, fmt.Errorf("at least one scope is required")
+	}
+
+	var result CreatedAPIKey
+	if err := c.do(ctx, http.MethodPost, "/sdk/v1/api-keys", nil, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListAPIKeys returns the organization's API keys, including revoked ones.
+func (c *Client) ListAPIKeys(ctx context.Context) ([]APIKey, error) {
+	var resp struct {
+		Keys []APIKey `json:"keys"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/sdk/v1/api-keys", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Keys, nil
+}
+
+// RevokeAPIKey revokes a key immediately. Requests made with it fail with 401.
+func (c *Client) RevokeAPIKey(ctx context.Context, keyID string) error {
+	if keyID == "" {
+		return fmt.Errorf("api key id is required")
+	}
+	return c.do(ctx, http.MethodDelete, "/sdk/v1/api-keys/"+url.PathEscape(keyID), nil, nil, nil)
+}
@@ -0,0 +1,180 @@
+package levee
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+)
+
+// Attachment size limits.
+const (
+	// MaxAttachmentSize is the maximum total size of a message's attachments.
+	MaxAttachmentSize = 25 << 20
+	// inlineAttachmentLimit is the total size above which attachments are uploaded
+	// as multipart form data instead of base64 inside the JSON body.
+	inlineAttachmentLimit = 5 << 20
+)
+
+// Attachment is a file sent with SendEmail.
+type Attachment struct {
+	Filename string
+	// ContentType is detected from the filename or content when empty.
+	ContentType string
+	Content     io.Reader
+	// ContentID makes the attachment an inline image, referenced from the HTML
+	// body as <img src="cid:ContentID">.
+	ContentID string
+}
+
+// CID returns the URL that references an inline attachment from the HTML body.
+func (a *Attachment) CID() string {
+	return "cid:" + a.ContentID
+}
+
+// encodedAttachment is an attachment read into memory for sending.
+type encodedAttachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Content     string `json:"content,omitempty"` // base64; empty for multipart uploads
+	ContentID   string `json:"content_id,omitempty"`
+	Disposition string `json:"disposition"`
+
+	data []byte
+}
+
+// sendEmailWithAttachments is the JSON body of a send with attachments.
+type sendEmailWithAttachments struct {
+	*OutgoingEmail
+	Attachments []encodedAttachment `json:"attachments"`
+}
+
+// readAttachments reads and validates attachments, enforcing MaxAttachmentSize.
+func readAttachments(attachments []Attachment) ([]encodedAttachment, int, error) {
+	encoded := make([]encodedAttachment, 0, len(attachments))
+	total := 0
+
+	for i, a := range attachments {
+		if a.Filename == "" {
+			return nil, 0, fmt.Errorf("attachment %d has no filename", i)
+		}
+		if a.Content == nil {
+			return nil, 0, fmt.Errorf("attachment %q has no content", a.Filename)
+		}
+		if strings.ContainsAny(a.ContentID, "<>\r\n ") {
+			return nil, 0, fmt.Errorf("attachment %q has an invalid content ID", a.Filename)
+		}
+
+		data, err := io.ReadAll(io.LimitReader(a.Content, int64(MaxAttachmentSize-total)+1))
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read attachment %q: %w", a.Filename, err)
+		}
+		total += len(data)
+		if total > MaxAttachmentSize {
+			return nil, 0, fmt.Errorf("attachments exceed %d MB", MaxAttachmentSize>>20)
+		}
+
+		contentType := a.ContentType
+		if contentType == "" {
+			contentType = mime.TypeByExtension(filepath.Ext(a.Filename))
+		}
+		if contentType == "" {
+			contentType = http.DetectContentType(data)
+		}
+
+		disposition := "attachment"
+		if a.ContentID != "" {
+			disposition = "inline"
+		}
+
+		encoded = append(encoded, encodedAttachment{
+			Filename:    filepath.Base(a.Filename),
+			ContentType: contentType,
+			ContentID:   a.ContentID,
+			Disposition: disposition,
+			data:        data,
+		})
+	}
+	return encoded, total, nil
+}
+
+// sendWithAttachments sends a message with attachments: base64 in the JSON body
+// for small messages, multipart form data above inlineAttachmentLimit.
+func (c *Client) sendWithAttachments(ctx context.Context, req *OutgoingEmail) (*SendEmailResponse, error) {
+	attachments, total, err := readAttachments(req.Attachments)
+	if err != nil {
+		return nil, err
+	}
+
+	var result SendEmailResponse
+	if total <= inlineAttachmentLimit {
+		for i := range attachments {
+			attachments[i].Content = base64.StdEncoding.EncodeToString(attachments[i].data)
+		}
+		body := &sendEmailWithAttachments{OutgoingEmail: req, Attachments: attachments}
+		if err := c.do(ctx, http.MethodPost, "/sdk/v1/emails/", nil, body, &result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	}
+
+	if err := c.sendMultipart(ctx, req, attachments, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// sendMultipart uploads the message as a "message" JSON part followed by one file
+// part per attachment, in order.
+func (c *Client) sendMultipart(ctx context.Context, req *OutgoingEmail, attachments []encodedAttachment, result interface{}) error {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	message, err := json.Marshal(&sendEmailWithAttachments{OutgoingEmail: req, Attachments: attachments})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+	part, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Disposition": {`form-data; name="message"`},
+		"Content-Type":        {"application/json"},
+	})
+	if err != nil {
+		return err
+	}
+	part.Write(message)
+
+	for _, a := range attachments {
+		part, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Disposition": {mime.FormatMediaType("form-data", map[string]string{"name": "attachments", "filename": a.Filename})},
+			"Content-Type":        {a.ContentType},
+		})
+		if err != nil {
+			return err
+		}
+		part.Write(a.data)
+	}
+	if err := mw.Close(); err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/sdk/v1/emails/", &buf)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("X-API-Key", c.apiKey)
+	httpReq.Header.Set("Content-Type", mw.FormDataContentType())
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	return decodeResponse(resp, result)
+}
@@ -0,0 +1,105 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// AutomationRun is one execution of an automation for a contact.
+type AutomationRun struct {
+	RunID        string `json:"run_id"`
+	AutomationID string `json:"automation_id"`
+	Email        string `json:"email"`
+	Status       string `json:"status"`
+	StartedAt    string `json:"started_at,omitempty"`
+}
+
+// automationContactRequest is the body of automation calls.
+type automationContactRequest struct {
+	Email   string                 `json:"email"`
+	Payload map[string]interface{} `json:"payload,omitempty"`
+}
+
+// TriggerAutomation starts an automation for a contact from an application event
+// (signup, cart abandoned, ...). The payload is available to the automation's
+// conditions and templates.
+//
+//	run, err := client.TriggerAutomation(ctx, "cart-abandoned", "user@example.com", map[string]any{
+//		"cart_url": cartURL,
+//		"items":    items,
+//	})
+func (c *Client) TriggerAutomation(ctx context.Context, automationID, contactEmail string, payload map[string]interface{}) (*AutomationRun, error) {
+	if err := validateAutomationContact(automationID, contactEmail); err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/sdk/v1/automations/%s/trigger", url.PathEscape(automationID))
+	var result AutomationRun
+	err := c.do(ctx, http.MethodPost, path, nil, &automationContactRequest{Email: contactEmail, Payload: payload}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// EnrollInJourney adds a contact to a journey (a sequence, by slug) at its
+// first step, with payload as the sequence's template variables. It calls
+// Sequences.EnrollInSequence.
+func (c *Client) EnrollInJourney(ctx context.Context, journeyID, contactEmail string, payload map[string]interface{}) (*EnrollSequenceResponse, error) {
+	if err := validateAutomationContact(journeyID, contactEmail); err != nil {
+		return nil, err
+	}
+
+	var variables map[string]string
+	if len(payload) > 0 {
+		variables = make(map[string]string, len(payload))
+		for k, v := range payload {
+			variables[k] = fmt.Sprint(v)
+		}
+	}
+	resp, err := c.Sequences.EnrollInSequence(ctx, &EnrollSequenceRequest{
+		SequenceSlug: journeyID,
+		Email:        contactEmail,
+		Variables:    variables,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success {
+		return nil, fmt.Errorf("failed to enroll in journey %s: %s", journeyID, resp.Message)
+	}
+	return resp, nil
+}
+
+// RemoveFromJourney exits a contact from a journey; pending steps are not
+// sent. It calls Sequences.UnenrollFromSequence.
+func (c *Client) RemoveFromJourney(ctx context.Context, journeyID, contactEmail string) error {
+	if err := validateAutomationContact(journeyID, contactEmail); err != nil {
+		return err
+	}
+
+	resp, err := c.Sequences.UnenrollFromSequence(ctx, &UnenrollSequenceRequest{
+		Email:        contactEmail,
+		SequenceSlug: journeyID,
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("failed to remove from journey %s: %s", journeyID, resp.Message)
+	}
+	return nil
+}
+
+func validateAutomationContact(id, email string) error {
+	if id == "" {
+		return fmt.Errorf("automation or journey ID is required")
+	}
+	if !strings.Contains(email, "@") {
+		return fmt.Errorf("contact email %q is invalid", email)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package levee
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestAutomationRequests(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		response string
+		call     func(*Client) error
+		wantCall apiCall
+		wantErr  bool
+	}{
+		{
+			name:     "trigger",
+			response: `{"run_id":"run_1","status":"running"}`,
+			call: func(c *Client) error {
+				_, err := c.TriggerAutomation(ctx, "cart-abandoned", "ada@example.com", map[string]interface{}{"items": 2})
+				return err
+			},
+			wantCall: apiCall{"POST", "/sdk/v1/automations/cart-abandoned/trigger",
+				`{"email":"ada@example.com","payload":{"items":2}}`},
+		},
+		{
+			name:     "enroll stringifies the payload",
+			response: `{"success":true}`,
+			call: func(c *Client) error {
+				_, err := c.EnrollInJourney(ctx, "onboarding", "ada@example.com", map[string]interface{}{"trial_days": 14})
+				return err
+			},
+			wantCall: apiCall{"POST", "/sdk/v1/sequences/enroll",
+				`{"sequence_slug":"onboarding","email":"ada@example.com","variables":{"trial_days":"14"}}`},
+		},
+		{
+			name:     "enroll rejected",
+			response: `{"success":false,"message":"already enrolled"}`,
+			call: func(c *Client) error {
+				_, err := c.EnrollInJourney(ctx, "onboarding", "ada@example.com", nil)
+				return err
+			},
+			wantCall: apiCall{"POST", "/sdk/v1/sequences/enroll", `{"sequence_slug":"onboarding","email":"ada@example.com"}`},
+			wantErr:  true,
+		},
+		{
+			name:     "remove",
+			response: `{"success":true}`,
+			call:     func(c *Client) error { return c.RemoveFromJourney(ctx, "onboarding", "ada@example.com") },
+			wantCall: apiCall{"POST", "/sdk/v1/sequences/unenroll", `{"email":"ada@example.com","sequence_slug":"onboarding"}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, calls := newTestClient(t, http.StatusOK, tt.response)
+
+			if err := tt.call(client); (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+			if len(*calls) != 1 || (*calls)[0] != tt.wantCall {
+				t.Errorf("requests = %+v, want %+v", *calls, tt.wantCall)
+			}
+		})
+	}
+}
+
+func TestValidateAutomationContact(t *testing.T) {
+	tests := []struct {
+		id, email string
+		ok        bool
+	}{
+		{"welcome", "ada@example.com", true},
+		{"", "ada@example.com", false},
+		{"welcome", "ada", false},
+	}
+
+	for _, tt := range tests {
+		if err := validateAutomationContact(tt.id, tt.email); (err == nil) != tt.ok {
+			t.Errorf("validateAutomationContact(%q, %q) = %v, want ok %v", tt.id, tt.email, err, tt.ok)
+		}
+	}
+}
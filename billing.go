@@ -0,0 +1,202 @@
+package levee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// CreatePortalSession returns the URL of a Stripe billing portal session where
+// the customer can update payment methods, change plans, and cancel.
+// customer is a contact email address or a Levee customer ID; returnURL is
+// where the portal's back link goes.
+//
+//	url, err := client.CreatePortalSession(ctx, user.Email, "https://example.com/account")
+//	http.Redirect(w, r, url, http.StatusSeeOther)
+func (c *Client) CreatePortalSession(ctx context.Context, customer, returnURL string) (string, error) {
+	customerID, err := c.resolveCustomerID(ctx, customer)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.Billing.GetCustomerPortal(ctx, &PortalRequest{
+		CustomerID: customerID,
+		ReturnUrl:  returnURL,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create portal session: %w", err)
+	}
+	if resp.PortalUrl == "" {
+		return "", fmt.Errorf("portal session has no URL")
+	}
+	return resp.PortalUrl, nil
+}
+
+// resolveCustomerID returns the Levee customer ID for an email address or customer ID.
+func (c *Client) resolveCustomerID(ctx context.Context, customer string) (string, error) {
+	customer = strings.TrimSpace(customer)
+	if customer == "" {
+		return "", fmt.Errorf("customer email or ID is required")
+	}
+	if !strings.Contains(customer, "@") {
+		return customer, nil
+	}
+
+	info, err := c.Customers.GetCustomerByEmail(ctx, customer)
+	if err != nil {
+		return "", fmt.Errorf("failed to find customer %s: %w", customer, err)
+	}
+	return info.ID, nil
+}
+
+// Invoice statuses, as reported by Stripe, of SDKInvoiceInfo.Status.
+const (
+	InvoiceDraft         = "draft"
+	InvoiceOpen          = "open"
+	InvoicePaid          = "paid"
+	InvoiceVoid          = "void"
+	InvoiceUncollectible = "uncollectible"
+)
+
+// ListInvoices returns up to limit invoices (0 for the API default) of a
+// contact email or customer ID, with status, amounts, and hosted invoice and
+// PDF URLs. It calls Customers.ListCustomerInvoicesById.
+//
+//	invoices, err := client.ListInvoices(ctx, user.Email, 0)
+//	for _, inv := range invoices {
+//		log.Printf("%s %s %d %s", inv.Number, inv.Status, inv.AmountDue, inv.HostedUrl)
+//	}
+func (c *Client) ListInvoices(ctx context.Context, customer string, limit int) ([]SDKInvoiceInfo, error) {
+	customerID, err := c.resolveCustomerID(ctx, customer)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Customers.ListCustomerInvoicesById(ctx, customerID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invoices: %w", err)
+	}
+	return resp.Invoices, nil
+}
+
+// GetInvoice returns one invoice of a contact email or customer ID, by its
+// Levee or Stripe invoice ID.
+func (c *Client) GetInvoice(ctx context.Context, customer, invoiceID string) (*SDKInvoiceInfo, error) {
+	if invoiceID == "" {
+		return nil, fmt.Errorf("invoice ID is required")
+	}
+
+	invoices, err := c.ListInvoices(ctx, customer, 0)
+	if err != nil {
+		return nil, err
+	}
+	for i := range invoices {
+		if invoices[i].ID == invoiceID || invoices[i].StripeInvoiceID == invoiceID {
+			return &invoices[i], nil
+		}
+	}
+	return nil, fmt.Errorf("invoice %s not found", invoiceID)
+}
+
+// DownloadInvoicePDF streams an invoice PDF, e.g. to serve it from your own
+// account page without exposing Stripe URLs. The caller must close it.
+func (c *Client) DownloadInvoicePDF(ctx context.Context, customer, invoiceID string) (io.ReadCloser, error) {
+	inv, err := c.GetInvoice(ctx, customer, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if inv.InvoicePdfUrl == "" {
+		return nil, fmt.Errorf("invoice %s has no PDF", invoiceID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, inv.InvoicePdfUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download invoice %s: status %d", invoiceID, resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+// StripeLink ties a Stripe customer to a Levee contact, so webhook events and
+// automations resolve to the same person even when the email addresses differ.
+type StripeLink struct {
+	StripeCustomerID string `json:"stripe_customer_id"`
+	Email            string `json:"email"`
+	ContactID        string `json:"contact_id,omitempty"`
+	LinkedAt         string `json:"linked_at,omitempty"`
+}
+
+// LinkStripeCustomer links a Stripe customer ID (cus_...) to the contact with
+// the given email, replacing any previous link of that customer.
+func (c *Client) LinkStripeCustomer(ctx context.Context, email, stripeCustomerID string) (*StripeLink, error) {
+	if !strings.Contains(email, "@") {
+		return nil, fmt.Errorf("email %q is invalid", email)
+	}
+	if !strings.HasPrefix(stripeCustomerID, "cus_") {
+		return nil, fmt.Errorf("stripe customer ID %q is invalid", stripeCustomerID)
+	}
+
+	var result StripeLink
+	err := c.do(ctx, http.MethodPut, stripeLinkPath(stripeCustomerID), nil, &StripeLink{
+		StripeCustomerID: stripeCustomerID,
+		Email:            email,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UnlinkStripeCustomer removes the link of a Stripe customer ID.
+func (c *Client) UnlinkStripeCustomer(ctx context.Context, stripeCustomerID string) error {
+	if stripeCustomerID == "" {
+		return fmt.Errorf("stripe customer ID is required")
+	}
+	return c.do(ctx, http.MethodDelete, stripeLinkPath(stripeCustomerID), nil, nil, nil)
+}
+
+// StripeLinkByCustomer returns the contact linked to a Stripe customer ID, or
+// nil if it is not linked.
+func (c *Client) StripeLinkByCustomer(ctx context.Context, stripeCustomerID string) (*StripeLink, error) {
+	if stripeCustomerID == "" {
+		return nil, fmt.Errorf("stripe customer ID is required")
+	}
+	return c.getStripeLink(ctx, stripeLinkPath(stripeCustomerID), nil)
+}
+
+// StripeLinkByEmail returns the Stripe customer linked to a contact email, or
+// nil if it is not linked.
+func (c *Client) StripeLinkByEmail(ctx context.Context, email string) (*StripeLink, error) {
+	if email == "" {
+		return nil, fmt.Errorf("email is required")
+	}
+	return c.getStripeLink(ctx, "/sdk/v1/billing/stripe-links", url.Values{"email": {email}})
+}
+
+func (c *Client) getStripeLink(ctx context.Context, path string, query url.Values) (*StripeLink, error) {
+	var result StripeLink
+	err := c.do(ctx, http.MethodGet, path, query, nil, &result)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func stripeLinkPath(stripeCustomerID string) string {
+	return "/sdk/v1/billing/stripe-links/" + url.PathEscape(stripeCustomerID)
+}
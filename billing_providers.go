@@ -0,0 +1,425 @@
+package levee
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxBillingWebhookSize is the largest billing webhook payload accepted.
+const maxBillingWebhookSize = 1 << 20
+
+// BillingEvent is a subscription or payment event normalized across billing
+// providers. Type is one of the Levee event types EventSubscriptionCreated,
+// EventSubscriptionUpdated, EventSubscriptionCancelled, EventSubscriptionRenewed,
+// EventPaymentSucceeded, EventPaymentFailed, or EventPaymentRefunded.
+type BillingEvent struct {
+	ID       string `json:"id"`
+	Provider string `json:"provider"`
+	Type     string `json:"type"`
+	// ProviderType is the provider's own event name, e.g. "invoice.payment_failed".
+	ProviderType   string `json:"provider_type"`
+	CustomerID     string `json:"customer_id,omitempty"`
+	Email          string `json:"email,omitempty"`
+	SubscriptionID string `json:"subscription_id,omitempty"`
+	// Status is the provider's subscription or payment status.
+	Status string `json:"status,omitempty"`
+	// AmountCents is in the smallest currency unit.
+	AmountCents int64     `json:"amount_cents,omitempty"`
+	Currency    string    `json:"currency,omitempty"`
+	PeriodEnd   time.Time `json:"period_end,omitzero"`
+	OccurredAt  time.Time `json:"occurred_at"`
+	// Raw is the provider's original payload.
+	Raw json.RawMessage `json:"raw,omitempty"`
+}
+
+// BillingProvider verifies and normalizes the webhooks of one billing provider.
+type BillingProvider interface {
+	// Name identifies the provider and its route: /webhooks/billing/{name}.
+	Name() string
+	// Verify checks the signature of a webhook delivery.
+	Verify(header http.Header, payload []byte) error
+	// Parse normalizes a webhook payload. It returns nil for event types
+	// without a BillingEvent equivalent.
+	Parse(payload []byte) (*BillingEvent, error)
+}
+
+// BillingEventHandler handles a normalized billing event after it is forwarded to Levee.
+// The delivery is acknowledged regardless, so the provider does not retry it
+// and forward it twice; returned errors are logged and counted as
+// MetricWebhookCallbackErrors.
+type BillingEventHandler func(ctx context.Context, event *BillingEvent) error
+
+// ForwardBillingEvent sends a normalized billing event to Levee, where it drives
+// subscription state, entitlements, and billing automations.
+func (c *Client) ForwardBillingEvent(ctx context.Context, event *BillingEvent) error {
+	if event == nil || event.Type == "" {
+		return fmt.Errorf("billing event type is required")
+	}
+	return c.do(ctx, http.MethodPost, "/sdk/v1/billing/events", nil, event, nil)
+}
+
+// HandleBillingWebhook returns a handler that verifies a provider's webhooks,
+// normalizes them to BillingEvent, forwards them to Levee, and runs the
+// callbacks registered with WithBillingEventHandler.
+// Route: POST /your-prefix/webhooks/billing/{provider}
+func (c *Client) HandleBillingWebhook(provider BillingProvider, cfg *HandlerConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			cfg.renderError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed)
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxBillingWebhookSize)
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			cfg.renderError(w, r, http.StatusBadRequest, ErrCodeInvalidBody)
+			return
+		}
+		if err := provider.Verify(r.Header, body); err != nil {
+			c.log().Warn("rejected billing webhook", "provider", provider.Name(), "error", err)
+			cfg.renderError(w, r, http.StatusUnauthorized, ErrCodeInvalidSignature)
+			return
+		}
+
+		event, err := provider.Parse(body)
+		if err != nil {
+			cfg.renderError(w, r, http.StatusBadRequest, ErrCodeInvalidPayload)
+			return
+		}
+		if event == nil {
+			w.WriteHeader(http.StatusOK) // not a subscription or payment event
+			return
+		}
+
+		ctx := r.Context()
+		if err := c.ForwardBillingEvent(ctx, event); err != nil {
+			cfg.renderError(w, r, http.StatusInternalServerError, ErrCodeWebhookFailed)
+			return
+		}
+		c.invalidateBillingEmail(event.Email)
+
+		// Levee has the event now, so it is acknowledged even if a callback
+		// fails: a provider retry would forward it again.
+		for _, h := range cfg.BillingEventHandlers {
+			if err := h(ctx, event); err != nil {
+				c.webhookCallbackFailed("billing_webhook", event.ID, event.Type, err)
+			}
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"received": true}`))
+	}
+}
+
+// StripeBilling returns the Stripe billing provider. secret is the webhook
+// signing secret (whsec_...); without it every delivery is rejected.
+func StripeBilling(secret string) BillingProvider {
+	return stripeBilling{secret: secret}
+}
+
+type stripeBilling struct{ secret string }
+
+func (stripeBilling) Name() string { return "stripe" }
+
+func (p stripeBilling) Verify(header http.Header, payload []byte) error {
+	if p.secret == "" {
+		return fmt.Errorf("stripe webhook secret is not configured")
+	}
+	if !verifyStripeSignature(payload, header.Get("Stripe-Signature"), p.secret) {
+		return fmt.Errorf("invalid stripe signature")
+	}
+	return nil
+}
+
+func (stripeBilling) Parse(payload []byte) (*BillingEvent, error) {
+	e, err := ParseStripeEvent(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &BillingEvent{
+		ID:           e.ID,
+		Provider:     "stripe",
+		ProviderType: e.Type,
+		OccurredAt:   unixTime(e.Created),
+		Raw:          payload,
+	}
+	switch {
+	case e.Subscription != nil:
+		s := e.Subscription
+		event.CustomerID, event.SubscriptionID, event.Status = s.Customer, s.ID, s.Status
+		event.PeriodEnd = unixTime(s.CurrentPeriodEnd)
+		switch e.Type {
+		case StripeSubscriptionCreated:
+			event.Type = EventSubscriptionCreated
+		case StripeSubscriptionDeleted:
+			event.Type = EventSubscriptionCancelled
+		default:
+			event.Type = EventSubscriptionUpdated
+		}
+	case e.Invoice != nil:
+		inv := e.Invoice
+		event.CustomerID, event.Email, event.SubscriptionID, event.Status = inv.Customer, inv.CustomerEmail, inv.Subscription, inv.Status
+		event.Currency = strings.ToUpper(inv.Currency)
+		switch {
+		case e.Type == StripeInvoicePaid && inv.BillingReason == "subscription_cycle":
+			event.Type, event.AmountCents = EventSubscriptionRenewed, inv.AmountPaid
+		case e.Type == StripeInvoicePaid:
+			event.Type, event.AmountCents = EventPaymentSucceeded, inv.AmountPaid
+		case e.Type == StripeInvoicePaymentFailed:
+			event.Type, event.AmountCents = EventPaymentFailed, inv.AmountDue
+		default:
+			return nil, nil
+		}
+	case e.Type == "charge.refunded":
+		var charge struct {
+			ID             string `json:"id"`
+			Customer       string `json:"customer"`
+			AmountRefunded int64  `json:"amount_refunded"`
+			Currency       string `json:"currency"`
+			BillingDetails struct {
+				Email string `json:"email"`
+			} `json:"billing_details"`
+		}
+		if err := e.Decode(&charge); err != nil {
+			return nil, err
+		}
+		event.Type = EventPaymentRefunded
+		event.CustomerID, event.Email = charge.Customer, charge.BillingDetails.Email
+		event.AmountCents, event.Currency = charge.AmountRefunded, strings.ToUpper(charge.Currency)
+	default:
+		return nil, nil
+	}
+	return event, nil
+}
+
+// PaddleBilling returns the Paddle Billing provider. secret is the
+// notification destination's secret key; without it every delivery is rejected.
+func PaddleBilling(secret string) BillingProvider {
+	return paddleBilling{secret: secret}
+}
+
+type paddleBilling struct{ secret string }
+
+func (paddleBilling) Name() string { return "paddle" }
+
+// Verify checks the Paddle-Signature header: "ts=<unix>;h1=<hex hmac-sha256 of ts:payload>".
+func (p paddleBilling) Verify(header http.Header, payload []byte) error {
+	if p.secret == "" {
+		return fmt.Errorf("paddle webhook secret is not configured")
+	}
+
+	var ts, h1 string
+	for _, part := range strings.Split(header.Get("Paddle-Signature"), ";") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "ts":
+			ts = value
+		case "h1":
+			h1 = value
+		}
+	}
+	if ts == "" || h1 == "" {
+		return fmt.Errorf("missing paddle signature")
+	}
+	if sec, err := strconv.ParseInt(ts, 10, 64); err != nil || time.Since(time.Unix(sec, 0)).Abs() > DefaultWebhookTolerance {
+		return fmt.Errorf("paddle signature timestamp out of tolerance")
+	}
+
+	mac := hmac.New(sha256.New, []byte(p.secret))
+	mac.Write([]byte(ts + ":" + string(payload)))
+	if !hmac.Equal([]byte(hex.EncodeToString(mac.Sum(nil))), []byte(h1)) {
+		return fmt.Errorf("invalid paddle signature")
+	}
+	return nil
+}
+
+func (paddleBilling) Parse(payload []byte) (*BillingEvent, error) {
+	var n struct {
+		EventID    string    `json:"event_id"`
+		EventType  string    `json:"event_type"`
+		OccurredAt time.Time `json:"occurred_at"`
+		Data       struct {
+			ID                   string `json:"id"`
+			Status               string `json:"status"`
+			CustomerID           string `json:"customer_id"`
+			SubscriptionID       string `json:"subscription_id"`
+			CurrencyCode         string `json:"currency_code"`
+			Origin               string `json:"origin"`
+			Action               string `json:"action"`
+			CurrentBillingPeriod *struct {
+				EndsAt time.Time `json:"ends_at"`
+			} `json:"current_billing_period"`
+			Details *struct {
+				Totals struct {
+					GrandTotal string `json:"grand_total"`
+					Total      string `json:"total"`
+				} `json:"totals"`
+			} `json:"details"`
+			Totals *struct {
+				Total string `json:"total"`
+			} `json:"totals"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &n); err != nil {
+		return nil, fmt.Errorf("failed to parse paddle event: %w", err)
+	}
+	if n.EventType == "" {
+		return nil, fmt.Errorf("paddle event is missing type")
+	}
+
+	d := n.Data
+	event := &BillingEvent{
+		ID:           n.EventID,
+		Provider:     "paddle",
+		ProviderType: n.EventType,
+		CustomerID:   d.CustomerID,
+		Status:       d.Status,
+		Currency:     d.CurrencyCode,
+		OccurredAt:   n.OccurredAt,
+		Raw:          payload,
+	}
+	if d.CurrentBillingPeriod != nil {
+		event.PeriodEnd = d.CurrentBillingPeriod.EndsAt
+	}
+	if d.Details != nil {
+		event.AmountCents, _ = strconv.ParseInt(d.Details.Totals.GrandTotal, 10, 64)
+	}
+
+	switch n.EventType {
+	case "subscription.created":
+		event.Type, event.SubscriptionID = EventSubscriptionCreated, d.ID
+	case "subscription.updated", "subscription.paused", "subscription.resumed", "subscription.past_due":
+		event.Type, event.SubscriptionID = EventSubscriptionUpdated, d.ID
+	case "subscription.canceled":
+		event.Type, event.SubscriptionID = EventSubscriptionCancelled, d.ID
+	case "transaction.completed":
+		event.SubscriptionID = d.SubscriptionID
+		event.Type = EventPaymentSucceeded
+		if d.Origin == "subscription_recurring" {
+			event.Type = EventSubscriptionRenewed
+		}
+	case "transaction.payment_failed":
+		event.Type, event.SubscriptionID = EventPaymentFailed, d.SubscriptionID
+	case "adjustment.created":
+		if d.Action != "refund" {
+			return nil, nil
+		}
+		event.Type, event.SubscriptionID = EventPaymentRefunded, d.SubscriptionID
+		if d.Totals != nil {
+			event.AmountCents, _ = strconv.ParseInt(d.Totals.Total, 10, 64)
+		}
+	default:
+		return nil, nil
+	}
+	return event, nil
+}
+
+// LemonSqueezyBilling returns the Lemon Squeezy provider. secret is the
+// webhook signing secret; without it every delivery is rejected.
+func LemonSqueezyBilling(secret string) BillingProvider {
+	return lemonSqueezyBilling{secret: secret}
+}
+
+type lemonSqueezyBilling struct{ secret string }
+
+func (lemonSqueezyBilling) Name() string { return "lemonsqueezy" }
+
+// Verify checks the X-Signature header: the hex HMAC-SHA256 of the payload.
+func (p lemonSqueezyBilling) Verify(header http.Header, payload []byte) error {
+	if p.secret == "" {
+		return fmt.Errorf("lemon squeezy webhook secret is not configured")
+	}
+	mac := hmac.New(sha256.New, []byte(p.secret))
+	mac.Write(payload)
+	if !hmac.Equal([]byte(hex.EncodeToString(mac.Sum(nil))), []byte(header.Get("X-Signature"))) {
+		return fmt.Errorf("invalid lemon squeezy signature")
+	}
+	return nil
+}
+
+func (lemonSqueezyBilling) Parse(payload []byte) (*BillingEvent, error) {
+	var n struct {
+		Meta struct {
+			EventName string `json:"event_name"`
+		} `json:"meta"`
+		Data struct {
+			ID         string `json:"id"`
+			Attributes struct {
+				CustomerID     json.Number `json:"customer_id"`
+				SubscriptionID json.Number `json:"subscription_id"`
+				UserEmail      string      `json:"user_email"`
+				Status         string      `json:"status"`
+				Currency       string      `json:"currency"`
+				Total          int64       `json:"total"`
+				RefundedAmount int64       `json:"refunded_amount"`
+				RenewsAt       *time.Time  `json:"renews_at"`
+				BillingReason  string      `json:"billing_reason"`
+				UpdatedAt      time.Time   `json:"updated_at"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &n); err != nil {
+		return nil, fmt.Errorf("failed to parse lemon squeezy event: %w", err)
+	}
+	if n.Meta.EventName == "" {
+		return nil, fmt.Errorf("lemon squeezy event is missing event name")
+	}
+
+	a := n.Data.Attributes
+	event := &BillingEvent{
+		// Lemon Squeezy has no event ID; the object and event name identify the delivery.
+		ID:           n.Meta.EventName + ":" + n.Data.ID + ":" + strconv.FormatInt(a.UpdatedAt.Unix(), 10),
+		Provider:     "lemonsqueezy",
+		ProviderType: n.Meta.EventName,
+		CustomerID:   a.CustomerID.String(),
+		Email:        a.UserEmail,
+		Status:       a.Status,
+		Currency:     a.Currency,
+		OccurredAt:   a.UpdatedAt,
+		Raw:          payload,
+	}
+	if a.RenewsAt != nil {
+		event.PeriodEnd = *a.RenewsAt
+	}
+
+	switch n.Meta.EventName {
+	case "subscription_created":
+		event.Type, event.SubscriptionID = EventSubscriptionCreated, n.Data.ID
+	case "subscription_updated", "subscription_paused", "subscription_unpaused", "subscription_resumed":
+		event.Type, event.SubscriptionID = EventSubscriptionUpdated, n.Data.ID
+	case "subscription_cancelled", "subscription_expired":
+		event.Type, event.SubscriptionID = EventSubscriptionCancelled, n.Data.ID
+	case "subscription_payment_success":
+		event.Type, event.SubscriptionID, event.AmountCents = EventSubscriptionRenewed, a.SubscriptionID.String(), a.Total
+		if a.BillingReason == "initial" {
+			event.Type = EventPaymentSucceeded
+		}
+	case "order_created":
+		event.Type, event.AmountCents = EventPaymentSucceeded, a.Total
+	case "subscription_payment_failed":
+		event.Type, event.SubscriptionID, event.AmountCents = EventPaymentFailed, a.SubscriptionID.String(), a.Total
+	case "order_refunded", "subscription_payment_refunded":
+		event.Type, event.SubscriptionID, event.AmountCents = EventPaymentRefunded, a.SubscriptionID.String(), a.RefundedAmount
+	default:
+		return nil, nil
+	}
+	return event, nil
+}
+
+// unixTime converts Unix seconds to a time, keeping zero as the zero time.
+func unixTime(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0).UTC()
+}
@@ -0,0 +1,108 @@
+package levee
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestBillingProviderVerify(t *testing.T) {
+	const secret = "whsec_test"
+	payload := []byte(`{"id":"evt_1"}`)
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-2*DefaultWebhookTolerance).Unix(), 10)
+
+	tests := []struct {
+		name     string
+		provider BillingProvider
+		header   http.Header
+		ok       bool
+	}{
+		{
+			name:     "stripe valid",
+			provider: StripeBilling(secret),
+			header:   http.Header{"Stripe-Signature": {"t=" + now + ",v1=" + hmacHex(secret, now+"."+string(payload))}},
+			ok:       true,
+		},
+		{
+			name:     "stripe wrong secret",
+			provider: StripeBilling(secret),
+			header:   http.Header{"Stripe-Signature": {"t=" + now + ",v1=" + hmacHex("other", now+"."+string(payload))}},
+		},
+		{
+			name:     "stripe missing timestamp",
+			provider: StripeBilling(secret),
+			header:   http.Header{"Stripe-Signature": {"v1=" + hmacHex(secret, now+"."+string(payload))}},
+		},
+		{
+			name:     "stripe missing header",
+			provider: StripeBilling(secret),
+			header:   http.Header{},
+		},
+		{
+			name:     "stripe without secret",
+			provider: StripeBilling(""),
+			header:   http.Header{"Stripe-Signature": {"t=" + now + ",v1=" + hmacHex("", now+"."+string(payload))}},
+		},
+		{
+			name:     "paddle valid",
+			provider: PaddleBilling(secret),
+			header:   http.Header{"Paddle-Signature": {"ts=" + now + ";h1=" + hmacHex(secret, now+":"+string(payload))}},
+			ok:       true,
+		},
+		{
+			name:     "paddle tampered",
+			provider: PaddleBilling(secret),
+			header:   http.Header{"Paddle-Signature": {"ts=" + now + ";h1=" + hmacHex(secret, now+":{}")}},
+		},
+		{
+			name:     "paddle stale timestamp",
+			provider: PaddleBilling(secret),
+			header:   http.Header{"Paddle-Signature": {"ts=" + stale + ";h1=" + hmacHex(secret, stale+":"+string(payload))}},
+		},
+		{
+			name:     "paddle missing h1",
+			provider: PaddleBilling(secret),
+			header:   http.Header{"Paddle-Signature": {"ts=" + now}},
+		},
+		{
+			name:     "paddle without secret",
+			provider: PaddleBilling(""),
+			header:   http.Header{"Paddle-Signature": {"ts=" + now + ";h1=" + hmacHex("", now+":"+string(payload))}},
+		},
+		{
+			name:     "lemon squeezy valid",
+			provider: LemonSqueezyBilling(secret),
+			header:   http.Header{"X-Signature": {hmacHex(secret, string(payload))}},
+			ok:       true,
+		},
+		{
+			name:     "lemon squeezy wrong secret",
+			provider: LemonSqueezyBilling(secret),
+			header:   http.Header{"X-Signature": {hmacHex("other", string(payload))}},
+		},
+		{
+			name:     "lemon squeezy missing header",
+			provider: LemonSqueezyBilling(secret),
+			header:   http.Header{},
+		},
+		{
+			name:     "lemon squeezy without secret",
+			provider: LemonSqueezyBilling(""),
+			header:   http.Header{"X-Signature": {hmacHex("", string(payload))}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.provider.Verify(tt.header, payload)
+			if tt.ok && err != nil {
+				t.Errorf("Verify() = %v, want nil", err)
+			}
+			if !tt.ok && err == nil {
+				t.Error("Verify() = nil, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// BroadcastRequest is a one-off send of a template to a segment.
+type BroadcastRequest struct {
+	// Name identifies the underlying campaign (default: the subject).
+	Name       string
+	SegmentID  string
+	Subject    string
+	TemplateID string // template ID or slug
+	Variables  map[string]interface{}
+	// SendAt schedules the broadcast; zero sends immediately.
+	SendAt time.Time
+}
+
+// Broadcast is a running broadcast, backed by a campaign.
+type Broadcast struct {
+	Campaign *Campaign
+	client   *Client
+}
+
+// Broadcast sends a template to a segment, creating and scheduling (or sending)
+// the campaign in one call, for when all you need is "send this to this segment".
+// Use the campaign methods on Broadcast.Campaign.ID for anything more advanced.
+// If the campaign is created but cannot be sent or scheduled, the Broadcast of
+// the draft is returned with the error, so it can be retried with
+// SendCampaignNow or ScheduleCampaign rather than created again.
+//
+//	b, err := client.Broadcast(ctx, levee.BroadcastRequest{
+//		SegmentID:  "seg_active_pro",
+//		Subject:    "New in October",
+//		TemplateID: "monthly-update",
+//	})
+//	stats, err := b.Wait(ctx, time.Minute)
+func (c *Client) Broadcast(ctx context.Context, req BroadcastRequest) (*Broadcast, error) {
+	if req.SegmentID == "" {
+		return nil, fmt.Errorf("segment is required")
+	}
+	if req.Subject == "" {
+		return nil, fmt.Errorf("subject is required")
+	}
+	if req.TemplateID == "" {
+		return nil, fmt.Errorf("template is required")
+	}
+	name := req.Name
+	if name == "" {
+		name = req.Subject
+	}
+
+	campaign, err := c.CreateCampaign(ctx, &Campaign{
+		Name:     name,
+		Audience: CampaignAudience{Segments: []string{req.SegmentID}},
+		Content: CampaignContent{
+			Subject:      req.Subject,
+			TemplateSlug: req.TemplateID,
+			Variables:    req.Variables,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create broadcast campaign: %w", err)
+	}
+
+	var started *Campaign
+	if req.SendAt.IsZero() {
+		started, err = c.SendCampaignNow(ctx, campaign.ID)
+	} else {
+		started, err = c.ScheduleCampaign(ctx, campaign.ID, CampaignSchedule{
+			SendAt: req.SendAt.UTC().Format(time.RFC3339),
+		})
+	}
+	if err != nil {
+		return &Broadcast{Campaign: campaign, client: c}, fmt.Errorf("failed to start broadcast campaign %s: %w", campaign.ID, err)
+	}
+
+	return &Broadcast{Campaign: started, client: c}, nil
+}
+
+// Refresh reloads the broadcast's campaign.
+func (b *Broadcast) Refresh(ctx context.Context) error {
+	campaign, err := b.client.GetCampaign(ctx, b.Campaign.ID)
+	if err != nil {
+		return err
+	}
+	b.Campaign = campaign
+	return nil
+}
+
+// Stats returns the broadcast's current delivery and engagement totals.
+func (b *Broadcast) Stats(ctx context.Context) (*CampaignStats, error) {
+	return b.client.GetCampaignStats(ctx, b.Campaign.ID)
+}
+
+// Wait polls every interval (default 10s) until the broadcast has finished
+// sending (or was cancelled), then returns its stats. Scheduled broadcasts
+// wait for their send time; bound the wait with ctx.
+func (b *Broadcast) Wait(ctx context.Context, interval time.Duration) (*CampaignStats, error) {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		switch b.Campaign.Status {
+		case CampaignSent, CampaignCancelled:
+			return b.Stats(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+		if err := b.Refresh(ctx); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package levee
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// LinkClicks is the click count of one link in a campaign.
+type LinkClicks struct {
+	URL          string `json:"url"`
+	Clicks       int    `json:"clicks"`
+	UniqueClicks int    `json:"unique_clicks"`
+}
+
+// CampaignStats are the delivery, engagement, and revenue totals of a campaign.
+type CampaignStats struct {
+	CampaignID   string       `json:"campaign_id"`
+	Sent         int          `json:"sent"`
+	Delivered    int          `json:"delivered"`
+	Opens        int          `json:"opens"`
+	UniqueOpens  int          `json:"unique_opens"`
+	Clicks       int          `json:"clicks"`
+	UniqueClicks int          `json:"unique_clicks"`
+	Bounces      int          `json:"bounces"`
+	Complaints   int          `json:"complaints"`
+	Unsubscribes int          `json:"unsubscribes"`
+	OpenRate     float64      `json:"open_rate"`
+	ClickRate    float64      `json:"click_rate"`
+	Links        []LinkClicks `json:"links"`
+	// Revenue is attributed revenue in the smallest currency unit (e.g. cents).
+	Revenue  int64  `json:"revenue"`
+	Orders   int    `json:"orders"`
+	Currency string `json:"currency,omitempty"`
+}
+
+// CampaignStatsPoint is one interval of a campaign's stats time series.
+type CampaignStatsPoint struct {
+	Date         string `json:"date"`
+	Delivered    int    `json:"delivered"`
+	Opens        int    `json:"opens"`
+	UniqueOpens  int    `json:"unique_opens"`
+	Clicks       int    `json:"clicks"`
+	Bounces      int    `json:"bounces"`
+	Unsubscribes int    `json:"unsubscribes"`
+	Revenue      int64  `json:"revenue"`
+}
+
+// GetCampaignStats returns the totals of a campaign, including clicks per link and
+// attributed revenue.
+func (c *Client) GetCampaignStats(ctx context.Context, campaignID string) (*CampaignStats, error) {
+	var result CampaignStats
+	if err := c.do(ctx, http.MethodGet, campaignPath(campaignID, "stats"), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetCampaignStatsSeries returns a campaign's stats over time for charting.
+// groupBy is "hour" or "day" (default: the API picks based on campaign age).
+func (c *Client) GetCampaignStatsSeries(ctx context.Context, campaignID, groupBy string) ([]CampaignStatsPoint, error) {
+	query := url.Values{}
+	if groupBy != "" {
+		query.Set("group_by", groupBy)
+	}
+
+	var result struct {
+		Stats []CampaignStatsPoint `json:"stats"`
+	}
+	if err := c.do(ctx, http.MethodGet, campaignPath(campaignID, "stats/series"), query, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Stats, nil
+}
+
+// CampaignLink is a tracked link in a campaign's content.
+type CampaignLink struct {
+	URL string `json:"url"`
+	// Text is the link's anchor text, when it has any.
+	Text string `json:"text,omitempty"`
+	// Position is the link's order of appearance in the email, starting at 1.
+	Position int `json:"position"`
+	Clicks   int `json:"clicks"`
+	// UniqueClickers is the number of distinct contacts who clicked.
+	UniqueClickers int    `json:"unique_clickers"`
+	FirstClickAt   string `json:"first_click_at,omitempty"`
+	LastClickAt    string `json:"last_click_at,omitempty"`
+}
+
+// ListCampaignLinks returns every tracked link in a campaign, including links
+// that were never clicked, with click counts and unique clickers.
+func (c *Client) ListCampaignLinks(ctx context.Context, campaignID string) ([]CampaignLink, error) {
+	var result struct {
+		Links []CampaignLink `json:"links"`
+	}
+	if err := c.do(ctx, http.MethodGet, campaignPath(campaignID, "links"), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Links, nil
+}
@@ -0,0 +1,160 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// CampaignStatus is the lifecycle state of a campaign.
+type CampaignStatus string
+
+// Campaign statuses.
+const (
+	CampaignDraft     CampaignStatus = "draft"
+	CampaignScheduled CampaignStatus = "scheduled"
+	CampaignSending   CampaignStatus = "sending"
+	CampaignPaused    CampaignStatus = "paused"
+	CampaignSent      CampaignStatus = "sent"
+	CampaignCancelled CampaignStatus = "cancelled"
+)
+
+// CampaignAudience selects a campaign's recipients. Contacts matching any list,
+// segment, or tag receive the campaign, minus those with an excluded tag.
+// Suppressed and unsubscribed contacts are always skipped.
+type CampaignAudience struct {
+	Lists       []string `json:"lists,omitempty"`
+	Segments    []string `json:"segments,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	ExcludeTags []string `json:"exclude_tags,omitempty"`
+	// Condition narrows the audience further with an ad-hoc segment condition.
+	Condition *Condition `json:"condition,omitempty"`
+}
+
+// CampaignContent is the message a campaign sends: a template or inline content.
+type CampaignContent struct {
+	Subject      string                 `json:"subject"`
+	PreviewText  string                 `json:"preview_text,omitempty"`
+	FromName     string                 `json:"from_name,omitempty"`
+	FromEmail    string                 `json:"from_email,omitempty"`
+	ReplyTo      string                 `json:"reply_to,omitempty"`
+	TemplateSlug string                 `json:"template_slug,omitempty"`
+	Body         string                 `json:"body,omitempty"`
+	TextBody     string                 `json:"text_body,omitempty"`
+	Variables    map[string]interface{} `json:"variables,omitempty"`
+}
+
+// CampaignSchedule is when a campaign goes out: at SendAt (RFC 3339), or at
+// DeliverAtLocalTime ("HH:MM") in each recipient's timezone on SendAt's date.
+type CampaignSchedule struct {
+	SendAt             string `json:"send_at,omitempty"`
+	DeliverAtLocalTime string `json:"deliver_at_local_time,omitempty"`
+}
+
+// Campaign is a one-off email sent to an audience.
+type Campaign struct {
+	ID        string            `json:"id,omitempty"`
+	Name      string            `json:"name"`
+	Status    CampaignStatus    `json:"status,omitempty"`
+	Audience  CampaignAudience  `json:"audience"`
+	Content   CampaignContent   `json:"content"`
+	Schedule  *CampaignSchedule `json:"schedule,omitempty"`
+	ABTest    *ABTest           `json:"ab_test,omitempty"`
+	Tags      []string          `json:"tags,omitempty"`
+	CreatedAt string            `json:"created_at,omitempty"`
+	UpdatedAt string            `json:"updated_at,omitempty"`
+	SentAt    string            `json:"sent_at,omitempty"`
+}
+
+// CreateCampaign creates a draft campaign, or a scheduled one if Schedule is set.
+func (c *Client) CreateCampaign(ctx context.Context, campaign *Campaign) (*Campaign, error) {
+	if campaign == nil || campaign.Name == "" {
+		return nil, fmt.Errorf("campaign name is required")
+	}
+	if campaign.ABTest != nil {
+		if err := validateABTest(campaign.ABTest); err != nil {
+			return nil, err
+		}
+	}
+
+	var result Campaign
+	if err := c.do(ctx, http.MethodPost, "/sdk/v1/campaigns", nil, campaign, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetCampaign returns a campaign by ID.
+func (c *Client) GetCampaign(ctx context.Context, campaignID string) (*Campaign, error) {
+	var result Campaign
+	if err := c.do(ctx, http.MethodGet, campaignPath(campaignID, ""), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateCampaign replaces the audience, content, and schedule of a draft or scheduled campaign.
+func (c *Client) UpdateCampaign(ctx context.Context, campaignID string, campaign *Campaign) (*Campaign, error) {
+	if campaign == nil {
+		return nil, fmt.Errorf("campaign is required")
+	}
+
+	var result Campaign
+	if err := c.do(ctx, http.MethodPut, campaignPath(campaignID, ""), nil, campaign, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ScheduleCampaign schedules a draft campaign, or reschedules a scheduled one.
+func (c *Client) ScheduleCampaign(ctx context.Context, campaignID string, schedule CampaignSchedule) (*Campaign, error) {
+	if err := validateSchedule(schedule.SendAt, ""); err != nil {
+		return nil, err
+	}
+	if err := validateSchedule("", schedule.DeliverAtLocalTime); err != nil {
+		return nil, err
+	}
+	if schedule.SendAt == "" && schedule.DeliverAtLocalTime == "" {
+		return nil, fmt.Errorf("send at or deliver at local time is required")
+	}
+	return c.campaignAction(ctx, campaignID, "schedule", &schedule)
+}
+
+// SendCampaignNow starts sending a draft or scheduled campaign immediately.
+func (c *Client) SendCampaignNow(ctx context.Context, campaignID string) (*Campaign, error) {
+	return c.campaignAction(ctx, campaignID, "send", nil)
+}
+
+// PauseCampaign stops a sending campaign; recipients not yet sent to are kept for ResumeCampaign.
+func (c *Client) PauseCampaign(ctx context.Context, campaignID string) (*Campaign, error) {
+	return c.campaignAction(ctx, campaignID, "pause", nil)
+}
+
+// ResumeCampaign continues a paused campaign.
+func (c *Client) ResumeCampaign(ctx context.Context, campaignID string) (*Campaign, error) {
+	return c.campaignAction(ctx, campaignID, "resume", nil)
+}
+
+// CancelCampaign cancels a scheduled, sending, or paused campaign. Emails already sent are not recalled.
+func (c *Client) CancelCampaign(ctx context.Context, campaignID string) (*Campaign, error) {
+	return c.campaignAction(ctx, campaignID, "cancel", nil)
+}
+
+// campaignAction performs a lifecycle transition and returns the updated campaign.
+func (c *Client) campaignAction(ctx context.Context, campaignID, action string, body interface{}) (*Campaign, error) {
+	var result Campaign
+	if err := c.do(ctx, http.MethodPost, campaignPath(campaignID, action), nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// campaignPath returns the API path of a campaign, or of one of its sub-resources.
+func campaignPath(campaignID, sub string) string {
+	path := "/sdk/v1/campaigns/" + url.PathEscape(campaignID)
+	if sub != "" {
+		path += "/" + sub
+	}
+	return path
+}
@@ -0,0 +1,180 @@
+package levee
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// apiCall is a request received by a test server from newTestClient.
+type apiCall struct {
+	method string
+	path   string
+	body   string
+}
+
+// newTestClient returns a client whose requests go to a test server that
+// records them and answers each with status and response.
+func newTestClient(t *testing.T, status int, response string) (*Client, *[]apiCall) {
+	t.Helper()
+	var calls []apiCall
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		calls = append(calls, apiCall{r.Method, r.URL.RequestURI(), strings.TrimSpace(string(body))})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		io.WriteString(w, response)
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := NewClient("key", srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, &calls
+}
+
+func TestCampaignRequests(t *testing.T) {
+	ctx := context.Background()
+	draft := &Campaign{
+		Name:     "Weekly digest",
+		Audience: CampaignAudience{Lists: []string{"newsletter"}},
+		Content:  CampaignContent{Subject: "This week", TemplateSlug: "digest"},
+	}
+
+	tests := []struct {
+		name     string
+		call     func(*Client) (*Campaign, error)
+		wantCall apiCall
+	}{
+		{
+			name: "create",
+			call: func(c *Client) (*Campaign, error) { return c.CreateCampaign(ctx, draft) },
+			wantCall: apiCall{"POST", "/sdk/v1/campaigns",
+				`{"name":"Weekly digest","audience":{"lists":["newsletter"]},"content":{"subject":"This week","template_slug":"digest"}}`},
+		},
+		{
+			name:     "get",
+			call:     func(c *Client) (*Campaign, error) { return c.GetCampaign(ctx, "cmp_1") },
+			wantCall: apiCall{"GET", "/sdk/v1/campaigns/cmp_1", ""},
+		},
+		{
+			name: "update",
+			call: func(c *Client) (*Campaign, error) { return c.UpdateCampaign(ctx, "cmp_1", draft) },
+			wantCall: apiCall{"PUT", "/sdk/v1/campaigns/cmp_1",
+				`{"name":"Weekly digest","audience":{"lists":["newsletter"]},"content":{"subject":"This week","template_slug":"digest"}}`},
+		},
+		{
+			name: "schedule",
+			call: func(c *Client) (*Campaign, error) {
+				return c.ScheduleCampaign(ctx, "cmp_1", CampaignSchedule{SendAt: "2026-11-02T09:00:00Z"})
+			},
+			wantCall: apiCall{"POST", "/sdk/v1/campaigns/cmp_1/schedule", `{"send_at":"2026-11-02T09:00:00Z"}`},
+		},
+		{
+			name: "schedule in local time",
+			call: func(c *Client) (*Campaign, error) {
+				return c.ScheduleCampaign(ctx, "cmp_1", CampaignSchedule{SendAt: "2026-11-02T00:00:00Z", DeliverAtLocalTime: "09:00"})
+			},
+			wantCall: apiCall{"POST", "/sdk/v1/campaigns/cmp_1/schedule",
+				`{"send_at":"2026-11-02T00:00:00Z","deliver_at_local_time":"09:00"}`},
+		},
+		{
+			name:     "send now",
+			call:     func(c *Client) (*Campaign, error) { return c.SendCampaignNow(ctx, "cmp_1") },
+			wantCall: apiCall{"POST", "/sdk/v1/campaigns/cmp_1/send", ""},
+		},
+		{
+			name:     "pause",
+			call:     func(c *Client) (*Campaign, error) { return c.PauseCampaign(ctx, "cmp_1") },
+			wantCall: apiCall{"POST", "/sdk/v1/campaigns/cmp_1/pause", ""},
+		},
+		{
+			name:     "resume",
+			call:     func(c *Client) (*Campaign, error) { return c.ResumeCampaign(ctx, "cmp_1") },
+			wantCall: apiCall{"POST", "/sdk/v1/campaigns/cmp_1/resume", ""},
+		},
+		{
+			name:     "cancel",
+			call:     func(c *Client) (*Campaign, error) { return c.CancelCampaign(ctx, "cmp_1") },
+			wantCall: apiCall{"POST", "/sdk/v1/campaigns/cmp_1/cancel", ""},
+		},
+		{
+			name:     "ID is escaped",
+			call:     func(c *Client) (*Campaign, error) { return c.GetCampaign(ctx, "a/b") },
+			wantCall: apiCall{"GET", "/sdk/v1/campaigns/a%2Fb", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, calls := newTestClient(t, http.StatusOK, `{"id":"cmp_1","name":"Weekly digest","status":"scheduled"}`)
+
+			campaign, err := tt.call(client)
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if campaign.ID != "cmp_1" || campaign.Status != CampaignScheduled {
+				t.Errorf("campaign = %+v", campaign)
+			}
+			if len(*calls) != 1 || (*calls)[0] != tt.wantCall {
+				t.Errorf("requests = %+v, want %+v", *calls, tt.wantCall)
+			}
+		})
+	}
+}
+
+func TestCampaignValidation(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func(*Client) (*Campaign, error)
+	}{
+		{"create without campaign", func(c *Client) (*Campaign, error) { return c.CreateCampaign(ctx, nil) }},
+		{"create without name", func(c *Client) (*Campaign, error) { return c.CreateCampaign(ctx, &Campaign{}) }},
+		{"create with one-variant A/B test", func(c *Client) (*Campaign, error) {
+			return c.CreateCampaign(ctx, &Campaign{Name: "x", ABTest: &ABTest{Variants: []CampaignVariant{{}}}})
+		}},
+		{"update without campaign", func(c *Client) (*Campaign, error) { return c.UpdateCampaign(ctx, "cmp_1", nil) }},
+		{"schedule without time", func(c *Client) (*Campaign, error) {
+			return c.ScheduleCampaign(ctx, "cmp_1", CampaignSchedule{})
+		}},
+		{"schedule with bad timestamp", func(c *Client) (*Campaign, error) {
+			return c.ScheduleCampaign(ctx, "cmp_1", CampaignSchedule{SendAt: "tomorrow"})
+		}},
+		{"schedule with bad local time", func(c *Client) (*Campaign, error) {
+			return c.ScheduleCampaign(ctx, "cmp_1", CampaignSchedule{DeliverAtLocalTime: "9am"})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, calls := newTestClient(t, http.StatusOK, `{}`)
+
+			if _, err := tt.call(client); err == nil {
+				t.Error("error = nil, want a validation error")
+			}
+			if len(*calls) != 0 {
+				t.Errorf("sent %d requests, want none", len(*calls))
+			}
+		})
+	}
+}
+
+func TestCampaignAPIError(t *testing.T) {
+	client, _ := newTestClient(t, http.StatusConflict, `{"code":"invalid_state","message":"campaign already sent"}`)
+
+	_, err := client.PauseCampaign(context.Background(), "cmp_1")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("error = %v, want *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusConflict || apiErr.Code != "invalid_state" {
+		t.Errorf("APIError = %+v", apiErr)
+	}
+}
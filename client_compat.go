@@ -6,9 +6,62 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"runtime"
+	"sync"
+	"weak"
 )
 
+// clientState is SDK state kept outside the generated Client struct, so that
+// regenerating client.go cannot drop it.
+type clientState struct {
+	eventMu       sync.RWMutex
+	eventHandlers map[string][]EventHandler
+
+	logger  *slog.Logger
+	metrics Metrics
+
+	fields        fieldSchema
+	queues        clientQueues
+	subscriptions subscriptionCache
+}
+
+// clientStates holds the state of each Client, keyed weakly so that an entry
+// is removed once its Client is garbage collected.
+var clientStates sync.Map // weak.Pointer[Client] -> *clientState
+
+// state returns c's clientState, creating it on first use.
+func (c *Client) state() *clientState {
+	key := weak.Make(c)
+	if s, ok := clientStates.Load(key); ok {
+		return s.(*clientState)
+	}
+	s, loaded := clientStates.LoadOrStore(key, new(clientState))
+	if !loaded {
+		runtime.AddCleanup(c, func(key weak.Pointer[Client]) { clientStates.Delete(key) }, key)
+	}
+	return s.(*clientState)
+}
+
+// do performs an API request like the generated request method, but returns
+// error responses as *APIError. Hand-written methods use it so callers can
+// inspect failures with errors.As.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body interface{}, result interface{}) error {
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	resp, err := c.doRequest(ctx, method, path, body)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	if resp.StatusCode == http.StatusNoContent {
+		result = nil
+	}
+	return decodeResponse(resp, result)
+}
+
 // doRequest performs an HTTP request with the API key header.
 // This is a compatibility method for handlers.go.
 func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
@@ -38,11 +91,8 @@ func decodeResponse(resp *http.Response, target interface{}) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
-		var errResp Response
-		if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
-			return fmt.Errorf("API error (status %d)", resp.StatusCode)
-		}
-		return fmt.Errorf("API error: %s", errResp.Message)
+		body, _ := io.ReadAll(resp.Body)
+		return newAPIError(resp.StatusCode, body)
 	}
 
 	if target != nil {
@@ -0,0 +1,102 @@
+package levee
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// ClientIP returns the IP address of the client that made r. When the request
+// arrives from one of cfg.TrustedProxies, the address is taken from
+// ClientIPHeader (if set), the Forwarded header, or X-Forwarded-For, skipping
+// proxies from the right; otherwise it is the peer address.
+func (cfg *HandlerConfig) ClientIP(r *http.Request) string {
+	peer := remoteIP(r)
+	if len(cfg.TrustedProxies) == 0 || !cfg.trustedProxy(peer) {
+		return peer
+	}
+
+	if cfg.ClientIPHeader != "" {
+		if ip, ok := parseIP(r.Header.Get(cfg.ClientIPHeader)); ok {
+			return ip
+		}
+	}
+
+	hops := forwardedFor(r.Header.Values("Forwarded"))
+	if len(hops) == 0 {
+		for _, v := range r.Header.Values("X-Forwarded-For") {
+			hops = append(hops, strings.Split(v, ",")...)
+		}
+	}
+
+	// Walk right to left: the first untrusted hop is the client
+	client := peer
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip, ok := parseIP(hops[i])
+		if !ok {
+			break
+		}
+		client = ip
+		if !cfg.trustedProxy(ip) {
+			break
+		}
+	}
+	return client
+}
+
+// trustedProxy reports whether ip is in one of the trusted proxy prefixes.
+func (cfg *HandlerConfig) trustedProxy(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range cfg.TrustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// forwardedFor extracts the for= parameters of RFC 7239 Forwarded headers in order.
+func forwardedFor(values []string) []string {
+	var hops []string
+	for _, v := range values {
+		for _, element := range strings.Split(v, ",") {
+			for _, pair := range strings.Split(element, ";") {
+				key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if ok && strings.EqualFold(key, "for") {
+					hops = append(hops, value)
+				}
+			}
+		}
+	}
+	return hops
+}
+
+// parseIP normalizes an address from a forwarding header, which may be quoted,
+// bracketed, or carry a port ("[2001:db8::1]:4711", "192.0.2.1:8080").
+func parseIP(s string) (string, bool) {
+	s = strings.Trim(strings.TrimSpace(s), `"`)
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "["), "]")
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return "", false
+	}
+	return addr.Unmap().String(), true
+}
+
+// remoteIP returns the IP address of the request's direct peer.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
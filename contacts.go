@@ -0,0 +1,284 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SubscriptionStatus is the email subscription state of a contact.
+type SubscriptionStatus string
+
+// Contact subscription statuses.
+const (
+	SubscriptionPending      SubscriptionStatus = "pending"
+	SubscriptionSubscribed   SubscriptionStatus = "subscribed"
+	SubscriptionUnsubscribed SubscriptionStatus = "unsubscribed"
+	SubscriptionBounced      SubscriptionStatus = "bounced"
+	SubscriptionComplained   SubscriptionStatus = "complained"
+)
+
+// ConsentState records how and when a contact agreed to receive email.
+type ConsentState struct {
+	// Marketing is true when the contact agreed to marketing email.
+	Marketing bool `json:"marketing"`
+	// DoubleOptIn is true when consent was confirmed by email.
+	DoubleOptIn bool   `json:"double_opt_in,omitempty"`
+	Source      string `json:"source,omitempty"`
+	IP          string `json:"ip,omitempty"`
+	GrantedAt   string `json:"granted_at,omitempty"`
+	RevokedAt   string `json:"revoked_at,omitempty"`
+}
+
+// Contact is a subscriber managed with CreateContact, UpdateContact, and UpsertContact.
+// Empty fields are left unchanged on update.
+type Contact struct {
+	ID      string `json:"id,omitempty"`
+	Email   string `json:"email"`
+	Name    string `json:"name,omitempty"`
+	Phone   string `json:"phone,omitempty"`
+	Company string `json:"company,omitempty"`
+	// Attributes holds custom field values keyed by field name.
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Tags       []string               `json:"tags,omitempty"`
+	Lists      []string               `json:"lists,omitempty"`
+	Status     SubscriptionStatus     `json:"status,omitempty"`
+	Consent    *ConsentState          `json:"consent,omitempty"`
+	Source     string                 `json:"source,omitempty"`
+	CreatedAt  string                 `json:"created_at,omitempty"`
+	UpdatedAt  string                 `json:"updated_at,omitempty"`
+}
+
+// CreateContact creates a contact. It fails if a contact with the email already exists;
+// use UpsertContact to create or update.
+func (c *Client) CreateContact(ctx context.Context, contact *Contact) (*Contact, error) {
+	if err := validateContact(contact); err != nil {
+		return nil, err
+	}
+
+	body, err := c.encodeContact(ctx, contact)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Contact
+	if err := c.do(ctx, http.MethodPost, "/sdk/v1/contacts", nil, body, &result); err != nil {
+		return nil, err
+	}
+	c.decodeAttributes(ctx, &result)
+	return &result, nil
+}
+
+// GetContact returns a contact by ID or email address.
+func (c *Client) GetContact(ctx context.Context, idOrEmail string) (*Contact, error) {
+	if idOrEmail == "" {
+		return nil, fmt.Errorf("contact ID or email is required")
+	}
+
+	var result Contact
+	if err := c.do(ctx, http.MethodGet, contactPath(idOrEmail), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	c.decodeAttributes(ctx, &result)
+	return &result, nil
+}
+
+// UpdateContact updates the non-empty fields of a contact by ID or email address.
+func (c *Client) UpdateContact(ctx context.Context, idOrEmail string, contact *Contact) (*Contact, error) {
+	if idOrEmail == "" {
+		return nil, fmt.Errorf("contact ID or email is required")
+	}
+	if contact == nil {
+		return nil, fmt.Errorf("contact is required")
+	}
+
+	body, err := c.encodeContact(ctx, contact)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Contact
+	if err := c.do(ctx, http.MethodPut, contactPath(idOrEmail), nil, body, &result); err != nil {
+		return nil, err
+	}
+	c.decodeAttributes(ctx, &result)
+	return &result, nil
+}
+
+// DeleteContact deletes a contact by ID or email address.
+func (c *Client) DeleteContact(ctx context.Context, idOrEmail string) error {
+	if idOrEmail == "" {
+		return fmt.Errorf("contact ID or email is required")
+	}
+	return c.do(ctx, http.MethodDelete, contactPath(idOrEmail), nil, nil, nil)
+}
+
+// UpsertContact creates the contact, or updates the existing contact with the same email.
+func (c *Client) UpsertContact(ctx context.Context, contact *Contact) (*Contact, error) {
+	if err := validateContact(contact); err != nil {
+		return nil, err
+	}
+
+	body, err := c.encodeContact(ctx, contact)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Contact
+	if err := c.do(ctx, http.MethodPut, "/sdk/v1/contacts/upsert", nil, body, &result); err != nil {
+		return nil, err
+	}
+	c.decodeAttributes(ctx, &result)
+	return &result, nil
+}
+
+// encodeContact returns a copy of contact with attributes converted to their
+// declared custom field types.
+func (c *Client) encodeContact(ctx context.Context, contact *Contact) (*Contact, error) {
+	attrs, err := c.encodeAttributes(ctx, contact.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	encoded := *contact
+	encoded.Attributes = attrs
+	return &encoded, nil
+}
+
+// contactPath returns the API path of a contact by ID or email address.
+func contactPath(idOrEmail string) string {
+	return "/sdk/v1/contacts/" + url.PathEscape(idOrEmail)
+}
+
+// validateContact checks a contact before it is created or upserted.
+func validateContact(contact *Contact) error {
+	if contact == nil {
+		return fmt.Errorf("contact is required")
+	}
+	if !strings.Contains(contact.Email, "@") {
+		return fmt.Errorf("contact email %q is invalid", contact.Email)
+	}
+	return nil
+}
+
+// ContactFilter builds the conditions of a contact search. All conditions must match.
+//
+//	filter := levee.NewContactFilter().
+//		AttrEquals("plan", "pro").
+//		HasTag("beta").
+//		EngagedSince(time.Now().AddDate(0, -1, 0))
+type ContactFilter struct {
+	Conditions []ContactCondition `json:"conditions"`
+}
+
+// ContactCondition is a single contact search condition.
+type ContactCondition struct {
+	Field string      `json:"field"`
+	Op    string      `json:"op"`
+	Value interface{} `json:"value"`
+}
+
+// Contact search operators.
+const (
+	FilterOpEquals   = "eq"
+	FilterOpContains = "contains"
+	FilterOpHasTag   = "has_tag"
+	FilterOpSince    = "since"
+)
+
+// NewContactFilter returns an empty filter, which matches every contact.
+func NewContactFilter() *ContactFilter {
+	return &ContactFilter{}
+}
+
+// AttrEquals matches contacts whose custom attribute equals value.
+func (f *ContactFilter) AttrEquals(name string, value interface{}) *ContactFilter {
+	return f.add("attributes."+name, FilterOpEquals, value)
+}
+
+// AttrContains matches contacts whose custom attribute contains substr (case-insensitive).
+func (f *ContactFilter) AttrContains(name, substr string) *ContactFilter {
+	return f.add("attributes."+name, FilterOpContains, substr)
+}
+
+// FieldEquals matches contacts whose built-in field (email, name, company, status, source) equals value.
+func (f *ContactFilter) FieldEquals(field string, value interface{}) *ContactFilter {
+	return f.add(field, FilterOpEquals, value)
+}
+
+// HasTag matches contacts tagged with tag.
+func (f *ContactFilter) HasTag(tag string) *ContactFilter {
+	return f.add("tags", FilterOpHasTag, tag)
+}
+
+// EngagedSince matches contacts who opened or clicked an email since t.
+func (f *ContactFilter) EngagedSince(t time.Time) *ContactFilter {
+	return f.add("last_engaged_at", FilterOpSince, t.UTC().Format(time.RFC3339))
+}
+
+func (f *ContactFilter) add(field, op string, value interface{}) *ContactFilter {
+	f.Conditions = append(f.Conditions, ContactCondition{Field: field, Op: op, Value: value})
+	return f
+}
+
+// contactSearchPageSize is the number of contacts fetched per search request.
+const contactSearchPageSize = 100
+
+// searchContactsRequest is the body of /sdk/v1/contacts/search.
+type searchContactsRequest struct {
+	*ContactFilter
+	Cursor string `json:"cursor,omitempty"`
+	Limit  int    `json:"limit"`
+}
+
+// searchContactsResponse is the wire format of contact list endpoints.
+type searchContactsResponse struct {
+	Contacts      []Contact `json:"contacts"`
+	NextCursor    string    `json:"next_cursor"`
+	TotalEstimate *int      `json:"total_estimate"`
+}
+
+// SearchContacts returns an iterator over the contacts matching filter (nil matches all).
+// Pages are fetched lazily; Total reports the server's estimate of the match count.
+//
+//	it := client.SearchContacts(ctx, levee.NewContactFilter().HasTag("beta"))
+//	log.Printf("about %d contacts", it.Total())
+//	for contact, err := range it.All() {
+//		if err != nil {
+//			return err
+//		}
+//		log.Println(contact.Email)
+//	}
+func (c *Client) SearchContacts(ctx context.Context, filter *ContactFilter) *Iterator[Contact] {
+	if filter == nil {
+		filter = NewContactFilter()
+	}
+
+	return newIterator(ctx, func(ctx context.Context, cursor string) (*Page[Contact], error) {
+		var resp searchContactsResponse
+		err := c.do(ctx, http.MethodPost, "/sdk/v1/contacts/search", nil, &searchContactsRequest{
+			ContactFilter: filter,
+			Cursor:        cursor,
+			Limit:         contactSearchPageSize,
+		}, &resp)
+		if err != nil {
+			return nil, err
+		}
+		return c.contactPage(ctx, &resp), nil
+	})
+}
+
+// contactPage converts a contact list response to an iterator page with typed attributes.
+func (c *Client) contactPage(ctx context.Context, r *searchContactsResponse) *Page[Contact] {
+	for i := range r.Contacts {
+		c.decodeAttributes(ctx, &r.Contacts[i])
+	}
+
+	page := &Page[Contact]{Items: r.Contacts, NextCursor: r.NextCursor, TotalEstimate: -1}
+	if r.TotalEstimate != nil {
+		page.TotalEstimate = *r.TotalEstimate
+	}
+	return page
+}
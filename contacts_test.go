@@ -0,0 +1,106 @@
+package levee
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestContactRequests(t *testing.T) {
+	ctx := context.Background()
+	ada := &Contact{Email: "ada@example.com", Name: "Ada", Tags: []string{"vip"}}
+
+	tests := []struct {
+		name     string
+		call     func(*Client) error
+		wantCall apiCall
+	}{
+		{
+			name: "create",
+			call: func(c *Client) error { _, err := c.CreateContact(ctx, ada); return err },
+			wantCall: apiCall{"POST", "/sdk/v1/contacts",
+				`{"email":"ada@example.com","name":"Ada","tags":["vip"]}`},
+		},
+		{
+			name:     "get by email",
+			call:     func(c *Client) error { _, err := c.GetContact(ctx, "ada@example.com"); return err },
+			wantCall: apiCall{"GET", "/sdk/v1/contacts/ada@example.com", ""},
+		},
+		{
+			name:     "get by escaped ID",
+			call:     func(c *Client) error { _, err := c.GetContact(ctx, "a/b"); return err },
+			wantCall: apiCall{"GET", "/sdk/v1/contacts/a%2Fb", ""},
+		},
+		{
+			name: "update",
+			call: func(c *Client) error {
+				_, err := c.UpdateContact(ctx, "con_1", &Contact{Company: "Analytical Engines"})
+				return err
+			},
+			wantCall: apiCall{"PUT", "/sdk/v1/contacts/con_1", `{"email":"","company":"Analytical Engines"}`},
+		},
+		{
+			name: "upsert",
+			call: func(c *Client) error { _, err := c.UpsertContact(ctx, ada); return err },
+			wantCall: apiCall{"PUT", "/sdk/v1/contacts/upsert",
+				`{"email":"ada@example.com","name":"Ada","tags":["vip"]}`},
+		},
+		{
+			name:     "delete",
+			call:     func(c *Client) error { return c.DeleteContact(ctx, "con_1") },
+			wantCall: apiCall{"DELETE", "/sdk/v1/contacts/con_1", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, calls := newTestClient(t, http.StatusOK, `{"id":"con_1","email":"ada@example.com","status":"subscribed"}`)
+
+			if err := tt.call(client); err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if len(*calls) != 1 || (*calls)[0] != tt.wantCall {
+				t.Errorf("requests = %+v, want %+v", *calls, tt.wantCall)
+			}
+		})
+	}
+}
+
+func TestContactValidation(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func(*Client) error
+	}{
+		{"create without contact", func(c *Client) error { _, err := c.CreateContact(ctx, nil); return err }},
+		{"create with invalid email", func(c *Client) error {
+			_, err := c.CreateContact(ctx, &Contact{Email: "ada"})
+			return err
+		}},
+		{"upsert with invalid email", func(c *Client) error {
+			_, err := c.UpsertContact(ctx, &Contact{Email: ""})
+			return err
+		}},
+		{"get without ID", func(c *Client) error { _, err := c.GetContact(ctx, ""); return err }},
+		{"update without ID", func(c *Client) error {
+			_, err := c.UpdateContact(ctx, "", &Contact{Name: "Ada"})
+			return err
+		}},
+		{"update without contact", func(c *Client) error { _, err := c.UpdateContact(ctx, "con_1", nil); return err }},
+		{"delete without ID", func(c *Client) error { return c.DeleteContact(ctx, "") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, calls := newTestClient(t, http.StatusOK, `{}`)
+
+			if err := tt.call(client); err == nil {
+				t.Error("error = nil, want a validation error")
+			}
+			if len(*calls) != 0 {
+				t.Errorf("sent %d requests, want none", len(*calls))
+			}
+		})
+	}
+}
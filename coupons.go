@@ -0,0 +1,241 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CouponDuration is how long a subscription discount applies.
+type CouponDuration string
+
+// Coupon durations.
+const (
+	CouponOnce      CouponDuration = "once"
+	CouponRepeating CouponDuration = "repeating"
+	CouponForever   CouponDuration = "forever"
+)
+
+// Coupon is a discount that promo codes redeem. Exactly one of PercentOff and
+// AmountOff is set; AmountOff is in the smallest currency unit (e.g. cents).
+type Coupon struct {
+	ID         string         `json:"id,omitempty"`
+	Name       string         `json:"name"`
+	PercentOff float64        `json:"percent_off,omitempty"`
+	AmountOff  int64          `json:"amount_off,omitempty"`
+	Currency   string         `json:"currency,omitempty"`
+	Duration   CouponDuration `json:"duration,omitempty"`
+	// DurationMonths is required for CouponRepeating.
+	DurationMonths int `json:"duration_months,omitempty"`
+	// MaxRedemptions caps redemptions across all promo codes (0 is unlimited).
+	MaxRedemptions int    `json:"max_redemptions,omitempty"`
+	RedeemBy       string `json:"redeem_by,omitempty"`
+	TimesRedeemed  int    `json:"times_redeemed,omitempty"`
+	Valid          bool   `json:"valid,omitempty"`
+	CreatedAt      string `json:"created_at,omitempty"`
+}
+
+// PromoCode is a customer-facing code that redeems a coupon.
+type PromoCode struct {
+	ID       string `json:"id"`
+	Code     string `json:"code"`
+	CouponID string `json:"coupon_id"`
+	// Email restricts redemption to one contact when set.
+	Email          string `json:"email,omitempty"`
+	MaxRedemptions int    `json:"max_redemptions,omitempty"`
+	TimesRedeemed  int    `json:"times_redeemed"`
+	Active         bool   `json:"active"`
+	ExpiresAt      string `json:"expires_at,omitempty"`
+}
+
+// CreateCoupon creates a coupon. Duration defaults to CouponOnce.
+func (c *Client) CreateCoupon(ctx context.Context, coupon *Coupon) (*Coupon, error) {
+	if err := validateCoupon(coupon); err != nil {
+		return nil, err
+	}
+
+	var result Coupon
+	if err := c.do(ctx, http.MethodPost, "/sdk/v1/billing/coupons", nil, coupon, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetCoupon returns a coupon by ID.
+func (c *Client) GetCoupon(ctx context.Context, couponID string) (*Coupon, error) {
+	if couponID == "" {
+		return nil, fmt.Errorf("coupon ID is required")
+	}
+
+	var result Coupon
+	if err := c.do(ctx, http.MethodGet, couponPath(couponID, ""), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// couponsPageSize is the number of coupons fetched per request.
+const couponsPageSize = 100
+
+// ListCoupons returns an iterator over all coupons.
+func (c *Client) ListCoupons(ctx context.Context) *Iterator[Coupon] {
+	return newIterator(ctx, func(ctx context.Context, cursor string) (*Page[Coupon], error) {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(couponsPageSize))
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+
+		var resp struct {
+			Coupons    []Coupon `json:"coupons"`
+			NextCursor string   `json:"next_cursor"`
+			Total      *int     `json:"total"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/sdk/v1/billing/coupons", query, nil, &resp); err != nil {
+			return nil, err
+		}
+		page := &Page[Coupon]{Items: resp.Coupons, NextCursor: resp.NextCursor, TotalEstimate: -1}
+		if resp.Total != nil {
+			page.TotalEstimate = *resp.Total
+		}
+		return page, nil
+	})
+}
+
+// maxPromoCodesPerRequest is the most promo codes minted per API call.
+const maxPromoCodesPerRequest = 500
+
+// CreatePromoCodes mints a single-use promo code for each email, redeemable
+// only by that contact, and returns the codes keyed by email. A zero expiresAt
+// never expires. On failure the codes already minted are returned with the error.
+func (c *Client) CreatePromoCodes(ctx context.Context, couponID string, emails []string, expiresAt time.Time) (map[string]string, error) {
+	if couponID == "" {
+		return nil, fmt.Errorf("coupon ID is required")
+	}
+
+	codes := make(map[string]string, len(emails))
+	for start := 0; start < len(emails); start += maxPromoCodesPerRequest {
+		chunk := emails[start:min(start+maxPromoCodesPerRequest, len(emails))]
+
+		body := map[string]interface{}{
+			"emails":          chunk,
+			"max_redemptions": 1,
+		}
+		if !expiresAt.IsZero() {
+			body["expires_at"] = expiresAt.UTC().Format(time.RFC3339)
+		}
+		var resp struct {
+			PromoCodes []PromoCode `json:"promo_codes"`
+		}
+		if err := c.do(ctx, http.MethodPost, couponPath(couponID, "promo-codes"), nil, body, &resp); err != nil {
+			return codes, fmt.Errorf("failed to create promo codes: %w", err)
+		}
+		for _, p := range resp.PromoCodes {
+			codes[p.Email] = p.Code
+		}
+	}
+	return codes, nil
+}
+
+// InjectPromoCodes mints a single-use promo code per recipient and sets it as
+// the template variable named variable, ready for SendBatch. Each recipient
+// gets its own copy of Variables, so recipients may share one map.
+// Recipients that already have the variable are skipped. If minting fails
+// partway, the codes already minted are still set before the error is
+// returned, so calling InjectPromoCodes again mints only the rest.
+//
+//	err := client.InjectPromoCodes(ctx, coupon.ID, "promo_code", req.Recipients, time.Now().AddDate(0, 0, 14))
+//	result, err := client.SendBatch(ctx, req) // template uses {{promo_code}}
+func (c *Client) InjectPromoCodes(ctx context.Context, couponID, variable string, recipients []BatchRecipient, expiresAt time.Time) error {
+	if variable == "" {
+		return fmt.Errorf("variable name is required")
+	}
+
+	var emails []string
+	for _, r := range recipients {
+		if _, ok := r.Variables[variable]; !ok {
+			emails = append(emails, r.To)
+		}
+	}
+	if len(emails) == 0 {
+		return nil
+	}
+	codes, mintErr := c.CreatePromoCodes(ctx, couponID, emails, expiresAt)
+
+	var missing []string
+	for i := range recipients {
+		if _, ok := recipients[i].Variables[variable]; ok {
+			continue
+		}
+		code, ok := codes[recipients[i].To]
+		if !ok {
+			code, ok = codes[strings.ToLower(strings.TrimSpace(recipients[i].To))]
+		}
+		if !ok {
+			missing = append(missing, recipients[i].To)
+			continue
+		}
+		vars := maps.Clone(recipients[i].Variables)
+		if vars == nil {
+			vars = map[string]interface{}{}
+		}
+		vars[variable] = code
+		recipients[i].Variables = vars
+	}
+
+	if mintErr != nil {
+		return mintErr
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("no promo code was created for %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// WithPromoCode makes SendTemplate mint a single-use promo code of couponID
+// for the recipient at send time and pass it as the template variable named variable.
+func WithPromoCode(couponID, variable string) SendOption {
+	return func(r *sendTemplateRequest) {
+		r.PromoCode = &promoCodeVariable{CouponID: couponID, Variable: variable}
+	}
+}
+
+// promoCodeVariable asks the API to mint a promo code into a template variable.
+type promoCodeVariable struct {
+	CouponID string `json:"coupon_id"`
+	Variable string `json:"variable"`
+}
+
+// validateCoupon checks a coupon before it is created.
+func validateCoupon(coupon *Coupon) error {
+	if coupon == nil {
+		return fmt.Errorf("coupon is required")
+	}
+	if (coupon.PercentOff > 0) == (coupon.AmountOff > 0) {
+		return fmt.Errorf("coupon needs exactly one of percent off and amount off")
+	}
+	if coupon.PercentOff > 100 {
+		return fmt.Errorf("coupon percent off cannot exceed 100")
+	}
+	if coupon.AmountOff > 0 && coupon.Currency == "" {
+		return fmt.Errorf("coupon currency is required with amount off")
+	}
+	if coupon.Duration == CouponRepeating && coupon.DurationMonths <= 0 {
+		return fmt.Errorf("coupon duration months is required for repeating coupons")
+	}
+	return nil
+}
+
+// couponPath returns the API path of a coupon, or of a sub-resource when sub is set.
+func couponPath(id, sub string) string {
+	p := "/sdk/v1/billing/coupons/" + url.PathEscape(id)
+	if sub != "" {
+		p += "/" + sub
+	}
+	return p
+}
@@ -0,0 +1,263 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// FieldType is the declared type of a custom contact field.
+type FieldType string
+
+// Custom field types.
+const (
+	FieldTypeText    FieldType = "text"
+	FieldTypeNumber  FieldType = "number"
+	FieldTypeBoolean FieldType = "boolean"
+	// FieldTypeDate values are time.Time in Go and RFC 3339 on the wire.
+	FieldTypeDate FieldType = "date"
+	// FieldTypeSelect values must be one of the field's Options.
+	FieldTypeSelect FieldType = "select"
+)
+
+// CustomField declares a custom contact attribute.
+type CustomField struct {
+	Name  string    `json:"name"`
+	Label string    `json:"label,omitempty"`
+	Type  FieldType `json:"type"`
+	// Default is applied by Levee when a contact has no value.
+	Default  interface{} `json:"default,omitempty"`
+	Required bool        `json:"required,omitempty"`
+	// Options are the allowed values of a select field.
+	Options []string `json:"options,omitempty"`
+	// Pattern is a regular expression that text values must match.
+	Pattern   string `json:"pattern,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+// DefineCustomField creates or updates the custom field with the given name.
+func (c *Client) DefineCustomField(ctx context.Context, field *CustomField) (*CustomField, error) {
+	if field == nil || field.Name == "" {
+		return nil, fmt.Errorf("custom field name is required")
+	}
+	switch field.Type {
+	case FieldTypeText, FieldTypeNumber, FieldTypeBoolean, FieldTypeDate:
+	case FieldTypeSelect:
+		if len(field.Options) == 0 {
+			return nil, fmt.Errorf("select field %q needs options", field.Name)
+		}
+	default:
+		return nil, fmt.Errorf("custom field %q has unknown type %q", field.Name, field.Type)
+	}
+	if field.Pattern != "" {
+		if _, err := regexp.Compile(field.Pattern); err != nil {
+			return nil, fmt.Errorf("custom field %q has invalid pattern: %w", field.Name, err)
+		}
+	}
+
+	var result CustomField
+	if err := c.do(ctx, http.MethodPut, customFieldPath(field.Name), nil, field, &result); err != nil {
+		return nil, err
+	}
+	c.state().fields.invalidate()
+	return &result, nil
+}
+
+// ListCustomFields returns the declared custom contact fields.
+func (c *Client) ListCustomFields(ctx context.Context) ([]CustomField, error) {
+	var result struct {
+		Fields []CustomField `json:"fields"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/sdk/v1/custom-fields", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	c.state().fields.store(result.Fields)
+	return result.Fields, nil
+}
+
+// DeleteCustomField deletes a custom field and its values on every contact.
+func (c *Client) DeleteCustomField(ctx context.Context, name string) error {
+	if name == "" {
+		return fmt.Errorf("custom field name is required")
+	}
+	if err := c.do(ctx, http.MethodDelete, customFieldPath(name), nil, nil, nil); err != nil {
+		return err
+	}
+	c.state().fields.invalidate()
+	return nil
+}
+
+func customFieldPath(name string) string {
+	return "/sdk/v1/custom-fields/" + url.PathEscape(name)
+}
+
+// fieldSchema caches the custom field declarations used to type contact attributes.
+type fieldSchema struct {
+	mu     sync.RWMutex
+	fields map[string]CustomField
+}
+
+func (s *fieldSchema) store(fields []CustomField) {
+	m := make(map[string]CustomField, len(fields))
+	for _, f := range fields {
+		m[f.Name] = f
+	}
+	s.mu.Lock()
+	s.fields = m
+	s.mu.Unlock()
+}
+
+func (s *fieldSchema) invalidate() {
+	s.mu.Lock()
+	s.fields = nil
+	s.mu.Unlock()
+}
+
+// schema returns the cached field declarations, loading them on first use.
+func (c *Client) schema(ctx context.Context) (map[string]CustomField, error) {
+	schema := &c.state().fields
+	schema.mu.RLock()
+	fields := schema.fields
+	schema.mu.RUnlock()
+	if fields != nil {
+		return fields, nil
+	}
+
+	if _, err := c.ListCustomFields(ctx); err != nil {
+		return nil, err
+	}
+	schema.mu.RLock()
+	defer schema.mu.RUnlock()
+	return schema.fields, nil
+}
+
+// encodeAttributes converts attributes to their declared wire types and validates
+// them, so type errors are reported before the request is sent. If the field
+// declarations cannot be loaded, attributes are sent unchanged for the API to validate.
+func (c *Client) encodeAttributes(ctx context.Context, attrs map[string]interface{}) (map[string]interface{}, error) {
+	if len(attrs) == 0 {
+		return attrs, nil
+	}
+	schema, err := c.schema(ctx)
+	if err != nil {
+		return attrs, nil
+	}
+
+	encoded := make(map[string]interface{}, len(attrs))
+	for name, value := range attrs {
+		field, ok := schema[name]
+		if !ok || value == nil {
+			encoded[name] = value
+			continue
+		}
+		v, err := coerceAttribute(field, value)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %q: %w", name, err)
+		}
+		if t, ok := v.(time.Time); ok {
+			v = t.UTC().Format(time.RFC3339)
+		}
+		encoded[name] = v
+	}
+	return encoded, nil
+}
+
+// decodeAttributes converts attribute values returned by the API to their declared
+// Go types (float64, bool, time.Time, string). Values that fail to convert are kept.
+func (c *Client) decodeAttributes(ctx context.Context, contacts ...*Contact) {
+	var schema map[string]CustomField
+	for _, contact := range contacts {
+		if len(contact.Attributes) == 0 {
+			continue
+		}
+		if schema == nil {
+			var err error
+			if schema, err = c.schema(ctx); err != nil {
+				return
+			}
+		}
+		for name, value := range contact.Attributes {
+			if field, ok := schema[name]; ok && value != nil {
+				if v, err := coerceAttribute(field, value); err == nil {
+					contact.Attributes[name] = v
+				}
+			}
+		}
+	}
+}
+
+// coerceAttribute converts value to the Go type of the field and validates it.
+func coerceAttribute(field CustomField, value interface{}) (interface{}, error) {
+	switch field.Type {
+	case FieldTypeNumber:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case float32:
+			return float64(v), nil
+		case int:
+			return float64(v), nil
+		case int32:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case string:
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a number", v)
+			}
+			return f, nil
+		}
+		return nil, fmt.Errorf("expected a number, got %T", value)
+
+	case FieldTypeBoolean:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a boolean", v)
+			}
+			return b, nil
+		}
+		return nil, fmt.Errorf("expected a boolean, got %T", value)
+
+	case FieldTypeDate:
+		switch v := value.(type) {
+		case time.Time:
+			return v, nil
+		case string:
+			for _, layout := range []string{time.RFC3339, time.DateOnly} {
+				if t, err := time.Parse(layout, v); err == nil {
+					return t, nil
+				}
+			}
+			return nil, fmt.Errorf("%q is not a date", v)
+		}
+		return nil, fmt.Errorf("expected a date, got %T", value)
+
+	case FieldTypeSelect:
+		s := fmt.Sprint(value)
+		if !slices.Contains(field.Options, s) {
+			return nil, fmt.Errorf("%q is not one of %v", s, field.Options)
+		}
+		return s, nil
+
+	default:
+		s := fmt.Sprint(value)
+		if field.Pattern != "" {
+			re, err := regexp.Compile(field.Pattern)
+			if err == nil && !re.MatchString(s) {
+				return nil, fmt.Errorf("%q does not match %s", s, field.Pattern)
+			}
+		}
+		return s, nil
+	}
+}
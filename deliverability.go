@@ -0,0 +1,132 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DeliverabilityRates are the delivery health rates of a set of messages.
+// Rates are fractions (0.02 is 2%).
+type DeliverabilityRates struct {
+	Sent           int     `json:"sent"`
+	Delivered      int     `json:"delivered"`
+	BounceRate     float64 `json:"bounce_rate"`
+	HardBounceRate float64 `json:"hard_bounce_rate"`
+	SoftBounceRate float64 `json:"soft_bounce_rate"`
+	ComplaintRate  float64 `json:"complaint_rate"`
+	// InboxRate and SpamRate are placement estimates from seed tests and engagement signals.
+	InboxRate float64 `json:"inbox_rate"`
+	SpamRate  float64 `json:"spam_rate"`
+}
+
+// ISPDeliverability is the deliverability of mail to one mailbox provider.
+type ISPDeliverability struct {
+	// ISP is the mailbox provider, e.g. "gmail", "outlook", "yahoo", "apple".
+	ISP string `json:"isp"`
+	DeliverabilityRates
+}
+
+// DeliverabilityPoint is one day of a deliverability trend.
+type DeliverabilityPoint struct {
+	Date time.Time `json:"date"`
+	DeliverabilityRates
+}
+
+// DeliverabilityReport summarizes sending reputation over a time range.
+type DeliverabilityReport struct {
+	DeliverabilityRates
+	ISPs  []ISPDeliverability   `json:"isps"`
+	Trend []DeliverabilityPoint `json:"trend"`
+}
+
+// GetDeliverabilityReport returns inbox placement estimates, bounce and complaint
+// rates, a daily trend, and a per-ISP breakdown for rng.
+func (c *Client) GetDeliverabilityReport(ctx context.Context, rng TimeRange) (*DeliverabilityReport, error) {
+	query := url.Values{}
+	if !rng.Start.IsZero() {
+		query.Set("start_date", rng.Start.UTC().Format(time.RFC3339))
+	}
+	if !rng.End.IsZero() {
+		query.Set("end_date", rng.End.UTC().Format(time.RFC3339))
+	}
+
+	var result DeliverabilityReport
+	if err := c.do(ctx, http.MethodGet, "/sdk/v1/stats/deliverability", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeliverabilityThresholds are the limits Check alerts on. Zero disables a check.
+type DeliverabilityThresholds struct {
+	MaxBounceRate    float64
+	MaxComplaintRate float64
+	MinInboxRate     float64
+	// MinSent skips ISPs with fewer messages, whose rates are too noisy to alert on.
+	// Account-wide rates are always checked.
+	MinSent int
+}
+
+// DefaultDeliverabilityThresholds reflect common mailbox provider guidance:
+// under 2% bounces and 0.1% spam complaints.
+var DefaultDeliverabilityThresholds = DeliverabilityThresholds{
+	MaxBounceRate:    0.02,
+	MaxComplaintRate: 0.001,
+	MinInboxRate:     0.9,
+	MinSent:          100,
+}
+
+// DeliverabilityAlert is a threshold breached in a deliverability report.
+type DeliverabilityAlert struct {
+	// ISP is empty for account-wide alerts.
+	ISP       string
+	Metric    string // "bounce_rate", "complaint_rate", "inbox_rate"
+	Value     float64
+	Threshold float64
+}
+
+func (a DeliverabilityAlert) String() string {
+	scope := "overall"
+	if a.ISP != "" {
+		scope = a.ISP
+	}
+	return fmt.Sprintf("%s %s %.2f%% (threshold %.2f%%)", scope, a.Metric, a.Value*100, a.Threshold*100)
+}
+
+// Check returns the thresholds breached account-wide and per ISP, for alerting
+// when reputation degrades.
+//
+//	report, err := client.GetDeliverabilityReport(ctx, levee.LastDays(1))
+//	for _, alert := range report.Check(levee.DefaultDeliverabilityThresholds) {
+//		pager.Notify(alert.String())
+//	}
+func (r *DeliverabilityReport) Check(t DeliverabilityThresholds) []DeliverabilityAlert {
+	alerts := checkRates("", r.DeliverabilityRates, t)
+	for _, isp := range r.ISPs {
+		alerts = append(alerts, checkRates(isp.ISP, isp.DeliverabilityRates, t)...)
+	}
+	return alerts
+}
+
+// checkRates compares one set of rates against the thresholds. MinSent
+// applies to per-ISP rates only.
+func checkRates(isp string, rates DeliverabilityRates, t DeliverabilityThresholds) []DeliverabilityAlert {
+	if rates.Sent == 0 || (isp != "" && rates.Sent < t.MinSent) {
+		return nil
+	}
+
+	var alerts []DeliverabilityAlert
+	if t.MaxBounceRate > 0 && rates.BounceRate > t.MaxBounceRate {
+		alerts = append(alerts, DeliverabilityAlert{ISP: isp, Metric: "bounce_rate", Value: rates.BounceRate, Threshold: t.MaxBounceRate})
+	}
+	if t.MaxComplaintRate > 0 && rates.ComplaintRate > t.MaxComplaintRate {
+		alerts = append(alerts, DeliverabilityAlert{ISP: isp, Metric: "complaint_rate", Value: rates.ComplaintRate, Threshold: t.MaxComplaintRate})
+	}
+	if t.MinInboxRate > 0 && rates.InboxRate > 0 && rates.InboxRate < t.MinInboxRate {
+		alerts = append(alerts, DeliverabilityAlert{ISP: isp, Metric: "inbox_rate", Value: rates.InboxRate, Threshold: t.MinInboxRate})
+	}
+	return alerts
+}
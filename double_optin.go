@@ -0,0 +1,103 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// doubleOptInRequest is the body of a double opt-in start request.
+type doubleOptInRequest struct {
+	Email        string                 `json:"email"`
+	Name         string                 `json:"name,omitempty"`
+	ListSlug     string                 `json:"list_slug,omitempty"`
+	Tags         []string               `json:"tags,omitempty"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	TemplateSlug string                 `json:"template_slug,omitempty"`
+	ConfirmURL   string                 `json:"confirm_url,omitempty"`
+}
+
+// OptInOption configures StartDoubleOptIn.
+type OptInOption func(*doubleOptInRequest)
+
+// WithOptInList subscribes the contact to a list once they confirm.
+func WithOptInList(listSlug string) OptInOption {
+	return func(r *doubleOptInRequest) {
+		r.ListSlug = listSlug
+	}
+}
+
+// WithOptInName sets the pending contact's name.
+func WithOptInName(name string) OptInOption {
+	return func(r *doubleOptInRequest) {
+		r.Name = name
+	}
+}
+
+// WithOptInTags tags the pending contact.
+func WithOptInTags(tags ...string) OptInOption {
+	return func(r *doubleOptInRequest) {
+		r.Tags = append(r.Tags, tags...)
+	}
+}
+
+// WithOptInAttributes sets custom attributes on the pending contact.
+func WithOptInAttributes(attrs map[string]interface{}) OptInOption {
+	return func(r *doubleOptInRequest) {
+		r.Attributes = attrs
+	}
+}
+
+// WithOptInTemplate sends the confirmation email using a custom template.
+// The template receives the confirmation link as {{confirm_url}}.
+func WithOptInTemplate(templateSlug string) OptInOption {
+	return func(r *doubleOptInRequest) {
+		r.TemplateSlug = templateSlug
+	}
+}
+
+// WithConfirmURL sets the link in the confirmation email, normally the absolute
+// URL of HandleConfirmEmail (e.g. "https://example.com/levee/confirm-email").
+// Levee appends ?token=. Defaults to the confirm URL configured in Levee.
+func WithConfirmURL(confirmURL string) OptInOption {
+	return func(r *doubleOptInRequest) {
+		r.ConfirmURL = confirmURL
+	}
+}
+
+// DoubleOptInResult is the result of StartDoubleOptIn.
+type DoubleOptInResult struct {
+	ContactID string `json:"contact_id"`
+	// AlreadyConfirmed is true if the contact had already confirmed; no email is sent.
+	AlreadyConfirmed bool   `json:"already_confirmed"`
+	MessageID        string `json:"message_id,omitempty"`
+	// ExpiresAt is when the confirmation token expires (RFC 3339).
+	ExpiresAt string `json:"expires_at,omitempty"`
+}
+
+// StartDoubleOptIn creates a pending contact and sends the confirmation email.
+// The contact becomes active when the link is opened and HandleConfirmEmail
+// consumes the token; HandleResendConfirmation sends a fresh one if it expires.
+//
+//	_, err := client.StartDoubleOptIn(ctx, form.Email,
+//		levee.WithOptInList("newsletter"),
+//		levee.WithConfirmURL("https://example.com/levee/confirm-email"),
+//	)
+func (c *Client) StartDoubleOptIn(ctx context.Context, email string, opts ...OptInOption) (*DoubleOptInResult, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return nil, fmt.Errorf("email is required")
+	}
+
+	req := &doubleOptInRequest{Email: email}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	var result DoubleOptInResult
+	if err := c.do(ctx, http.MethodPost, "/sdk/v1/tracking/confirm/start", nil, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
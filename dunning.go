@@ -0,0 +1,154 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// DunningStep is one email of a dunning sequence.
+type DunningStep struct {
+	// DaysAfterFailure is when the email goes out, counted from the first failed payment.
+	DaysAfterFailure int    `json:"days_after_failure"`
+	TemplateSlug     string `json:"template_slug"`
+}
+
+// DunningConfig controls the emails Levee sends when invoice.payment_failed
+// events arrive, and when it gives up on the invoice.
+type DunningConfig struct {
+	Enabled bool          `json:"enabled"`
+	Steps   []DunningStep `json:"steps"`
+	// SuspendAfterDays stops the emails and marks the run DunningSuspended this
+	// many days after the first failure (0 never suspends).
+	SuspendAfterDays int `json:"suspend_after_days,omitempty"`
+	// CancelAfterDays cancels the subscription in Stripe (0 never cancels).
+	CancelAfterDays int `json:"cancel_after_days,omitempty"`
+}
+
+// DunningStatus is the state of a dunning run.
+type DunningStatus string
+
+// Dunning run statuses.
+const (
+	DunningActive    DunningStatus = "active"
+	DunningRecovered DunningStatus = "recovered"
+	DunningSuspended DunningStatus = "suspended"
+	DunningCancelled DunningStatus = "cancelled"
+	DunningStopped   DunningStatus = "stopped"
+)
+
+// DunningRun is the dunning sequence of one failed invoice. Runs start
+// automatically from invoice.payment_failed and end when the invoice is paid.
+type DunningRun struct {
+	ID               string        `json:"id"`
+	InvoiceID        string        `json:"invoice_id"`
+	Email            string        `json:"email"`
+	Status           DunningStatus `json:"status"`
+	EmailsSent       int           `json:"emails_sent"`
+	NextEmailAt      string        `json:"next_email_at,omitempty"`
+	FirstFailedAt    string        `json:"first_failed_at"`
+	ResolvedAt       string        `json:"resolved_at,omitempty"`
+	HostedInvoiceURL string        `json:"hosted_invoice_url,omitempty"`
+}
+
+// GetDunningConfig returns the dunning configuration.
+func (c *Client) GetDunningConfig(ctx context.Context) (*DunningConfig, error) {
+	var result DunningConfig
+	if err := c.do(ctx, http.MethodGet, "/sdk/v1/billing/dunning/config", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateDunningConfig replaces the dunning configuration.
+//
+//	_, err := client.UpdateDunningConfig(ctx, &levee.DunningConfig{
+//		Enabled: true,
+//		Steps: []levee.DunningStep{
+//			{DaysAfterFailure: 0, TemplateSlug: "payment-failed"},
+//			{DaysAfterFailure: 3, TemplateSlug: "payment-reminder"},
+//			{DaysAfterFailure: 7, TemplateSlug: "final-notice"},
+//		},
+//		SuspendAfterDays: 10,
+//	})
+func (c *Client) UpdateDunningConfig(ctx context.Context, cfg *DunningConfig) (*DunningConfig, error) {
+	if err := validateDunningConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	var result DunningConfig
+	if err := c.do(ctx, http.MethodPut, "/sdk/v1/billing/dunning/config", nil, cfg, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// StartDunning starts the dunning sequence for a failed invoice, for payment
+// failures Levee did not receive from Stripe (e.g. other processors). It is a
+// no-op if a run for the invoice is already active.
+func (c *Client) StartDunning(ctx context.Context, invoiceID, email string) (*DunningRun, error) {
+	if invoiceID == "" {
+		return nil, fmt.Errorf("invoice ID is required")
+	}
+
+	var result DunningRun
+	err := c.do(ctx, http.MethodPost, "/sdk/v1/billing/dunning/runs", nil, map[string]string{
+		"invoice_id": invoiceID,
+		"email":      email,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// StopDunning stops the dunning emails for an invoice, e.g. after support
+// arranged payment another way.
+func (c *Client) StopDunning(ctx context.Context, invoiceID string) error {
+	if invoiceID == "" {
+		return fmt.Errorf("invoice ID is required")
+	}
+	return c.do(ctx, http.MethodPost, dunningRunPath(invoiceID)+"/stop", nil, nil, nil)
+}
+
+// GetDunningRun returns the dunning run of an invoice.
+func (c *Client) GetDunningRun(ctx context.Context, invoiceID string) (*DunningRun, error) {
+	if invoiceID == "" {
+		return nil, fmt.Errorf("invoice ID is required")
+	}
+
+	var result DunningRun
+	if err := c.do(ctx, http.MethodGet, dunningRunPath(invoiceID), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func dunningRunPath(invoiceID string) string {
+	return "/sdk/v1/billing/dunning/runs/" + url.PathEscape(invoiceID)
+}
+
+// validateDunningConfig checks a dunning configuration before it is saved.
+func validateDunningConfig(cfg *DunningConfig) error {
+	if cfg == nil {
+		return fmt.Errorf("dunning config is required")
+	}
+	if cfg.Enabled && len(cfg.Steps) == 0 {
+		return fmt.Errorf("dunning config needs at least one step when enabled")
+	}
+	last := -1
+	for i, step := range cfg.Steps {
+		if step.TemplateSlug == "" {
+			return fmt.Errorf("dunning step %d: template slug is required", i)
+		}
+		if step.DaysAfterFailure < 0 || step.DaysAfterFailure < last {
+			return fmt.Errorf("dunning step %d: days after failure must be non-negative and ascending", i)
+		}
+		last = step.DaysAfterFailure
+	}
+	if cfg.CancelAfterDays > 0 && cfg.SuspendAfterDays > cfg.CancelAfterDays {
+		return fmt.Errorf("suspend after days cannot be later than cancel after days")
+	}
+	return nil
+}
@@ -0,0 +1,331 @@
+package emailbuild
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// cssRule is a stylesheet rule with a single selector.
+type cssRule struct {
+	selector    *selector
+	decls       []cssDecl
+	specificity int
+	order       int
+}
+
+// cssDecl is one property declaration.
+type cssDecl struct {
+	property  string
+	value     string
+	important bool
+}
+
+// compound is a simple selector sequence such as "td.cell#main".
+type compound struct {
+	tag     string
+	id      string
+	classes []string
+}
+
+// selector is a chain of compounds joined by descendant (' ') or child ('>') combinators.
+type selector struct {
+	parts       []compound
+	combinators []byte // combinators[i] joins parts[i] and parts[i+1]
+}
+
+var (
+	cssComment   = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	compoundExpr = regexp.MustCompile(`^(\*|[a-zA-Z][a-zA-Z0-9-]*)?((?:[.#][a-zA-Z_][\w-]*)*)$`)
+	simpleExpr   = regexp.MustCompile(`[.#][^.#]+`)
+)
+
+// inlineStyles moves stylesheet rules from <style> elements into style
+// attributes. Rules that cannot be inlined are kept in one <style> element
+// in the head; existing inline styles take precedence over stylesheet rules
+// unless the rule is !important.
+func inlineStyles(doc *html.Node) {
+	var styles []*html.Node
+	walk(doc, func(n *html.Node) {
+		if n.Type == html.ElementNode && n.DataAtom == atom.Style {
+			styles = append(styles, n)
+		}
+	})
+	if len(styles) == 0 {
+		return
+	}
+
+	var rules []cssRule
+	var kept strings.Builder
+	for _, style := range styles {
+		var css strings.Builder
+		for c := style.FirstChild; c != nil; c = c.NextSibling {
+			css.WriteString(c.Data)
+		}
+		rules = parseStylesheet(css.String(), len(rules), rules, &kept)
+		style.Parent.RemoveChild(style)
+	}
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].specificity != rules[j].specificity {
+			return rules[i].specificity < rules[j].specificity
+		}
+		return rules[i].order < rules[j].order
+	})
+
+	walk(doc, func(n *html.Node) {
+		if n.Type != html.ElementNode {
+			return
+		}
+		var matched []cssDecl
+		for _, rule := range rules {
+			if rule.selector.matches(n) {
+				matched = append(matched, rule.decls...)
+			}
+		}
+		if len(matched) > 0 {
+			applyDecls(n, matched)
+		}
+	})
+
+	if kept.Len() > 0 {
+		if head := findElement(doc, atom.Head); head != nil {
+			style := &html.Node{Type: html.ElementNode, Data: "style", DataAtom: atom.Style}
+			style.AppendChild(&html.Node{Type: html.TextNode, Data: kept.String()})
+			head.AppendChild(style)
+		}
+	}
+}
+
+// parseStylesheet appends the inlinable rules of css to rules and writes
+// everything else (at-rules, unsupported selectors) to kept.
+func parseStylesheet(css string, order int, rules []cssRule, kept *strings.Builder) []cssRule {
+	css = cssComment.ReplaceAllString(css, "")
+
+	for {
+		css = strings.TrimSpace(css)
+		if css == "" {
+			return rules
+		}
+
+		if strings.HasPrefix(css, "@") {
+			end := atRuleEnd(css)
+			kept.WriteString(css[:end])
+			kept.WriteString("\n")
+			css = css[end:]
+			continue
+		}
+
+		open := strings.IndexByte(css, '{')
+		if open < 0 {
+			return rules
+		}
+		end := strings.IndexByte(css[open:], '}')
+		if end < 0 {
+			return rules
+		}
+		selectors, body := css[:open], css[open+1:open+end]
+		css = css[open+end+1:]
+
+		decls := parseDecls(body)
+		var unsupported []string
+		for _, s := range strings.Split(selectors, ",") {
+			s = strings.TrimSpace(s)
+			sel, spec, ok := parseSelector(s)
+			if !ok {
+				unsupported = append(unsupported, s)
+				continue
+			}
+			rules = append(rules, cssRule{selector: sel, decls: decls, specificity: spec, order: order})
+			order++
+		}
+		if len(unsupported) > 0 {
+			kept.WriteString(strings.Join(unsupported, ", ") + " {" + strings.TrimSpace(body) + "}\n")
+		}
+	}
+}
+
+// atRuleEnd returns the end of the at-rule starting css: either its
+// terminating semicolon or its balanced block.
+func atRuleEnd(css string) int {
+	depth := 0
+	for i := 0; i < len(css); i++ {
+		switch css[i] {
+		case ';':
+			if depth == 0 {
+				return i + 1
+			}
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(css)
+}
+
+// parseDecls parses a declaration block body.
+func parseDecls(body string) []cssDecl {
+	var decls []cssDecl
+	for _, part := range strings.Split(body, ";") {
+		prop, value, ok := strings.Cut(part, ":")
+		if !ok {
+			continue
+		}
+		prop = strings.ToLower(strings.TrimSpace(prop))
+		value = strings.TrimSpace(value)
+		if prop == "" || value == "" {
+			continue
+		}
+		decl := cssDecl{property: prop, value: value}
+		if v, found := strings.CutSuffix(value, "!important"); found {
+			decl.value = strings.TrimSpace(v)
+			decl.important = true
+		}
+		decls = append(decls, decl)
+	}
+	return decls
+}
+
+// parseSelector parses a selector made of type, class, and ID selectors joined
+// by descendant or child combinators, and returns its specificity.
+func parseSelector(s string) (*selector, int, bool) {
+	fields := strings.Fields(strings.ReplaceAll(s, ">", " > "))
+	if len(fields) == 0 {
+		return nil, 0, false
+	}
+
+	sel := &selector{}
+	spec := 0
+	combinator := byte(' ')
+	for i, field := range fields {
+		if field == ">" {
+			if i == 0 || i == len(fields)-1 || combinator == '>' {
+				return nil, 0, false
+			}
+			combinator = '>'
+			continue
+		}
+
+		m := compoundExpr.FindStringSubmatch(field)
+		if m == nil {
+			return nil, 0, false
+		}
+		var c compound
+		if m[1] != "" && m[1] != "*" {
+			c.tag = strings.ToLower(m[1])
+			spec++
+		}
+		for _, part := range simpleExpr.FindAllString(m[2], -1) {
+			if part[0] == '#' {
+				c.id = part[1:]
+				spec += 100
+			} else {
+				c.classes = append(c.classes, part[1:])
+				spec += 10
+			}
+		}
+
+		if len(sel.parts) > 0 {
+			sel.combinators = append(sel.combinators, combinator)
+		}
+		sel.parts = append(sel.parts, c)
+		combinator = ' '
+	}
+	return sel, spec, true
+}
+
+// matches reports whether the element n matches the selector.
+func (s *selector) matches(n *html.Node) bool {
+	return s.matchAt(n, len(s.parts)-1)
+}
+
+func (s *selector) matchAt(n *html.Node, i int) bool {
+	if !s.parts[i].matches(n) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	if s.combinators[i-1] == '>' {
+		parent := n.Parent
+		return parent != nil && parent.Type == html.ElementNode && s.matchAt(parent, i-1)
+	}
+	for p := n.Parent; p != nil && p.Type == html.ElementNode; p = p.Parent {
+		if s.matchAt(p, i-1) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether the element n matches the compound selector.
+func (c *compound) matches(n *html.Node) bool {
+	if c.tag != "" && n.Data != c.tag {
+		return false
+	}
+	if c.id != "" {
+		if id, _ := getAttr(n, "id"); id != c.id {
+			return false
+		}
+	}
+	if len(c.classes) > 0 {
+		class, _ := getAttr(n, "class")
+		have := strings.Fields(class)
+		for _, want := range c.classes {
+			found := false
+			for _, h := range have {
+				if h == want {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// applyDecls merges matched declarations into the element's style attribute.
+// Order of precedence: normal rules, then the existing inline style, then
+// !important rules.
+func applyDecls(n *html.Node, matched []cssDecl) {
+	existing, _ := getAttr(n, "style")
+
+	var props []string
+	values := make(map[string]string)
+	set := func(d cssDecl) {
+		if _, ok := values[d.property]; !ok {
+			props = append(props, d.property)
+		}
+		values[d.property] = d.value
+	}
+
+	for _, d := range matched {
+		if !d.important {
+			set(d)
+		}
+	}
+	for _, d := range parseDecls(existing) {
+		set(d)
+	}
+	for _, d := range matched {
+		if d.important {
+			set(d)
+		}
+	}
+
+	parts := make([]string, len(props))
+	for i, p := range props {
+		parts[i] = p + ": " + values[p]
+	}
+	setAttr(n, "style", strings.Join(parts, "; "))
+}
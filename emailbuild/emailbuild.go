@@ -0,0 +1,281 @@
+// Package emailbuild prepares email HTML locally for sending through Levee.
+//
+// It rewrites links into click-tracked URLs, injects the open pixel, inlines
+// CSS, and produces List-Unsubscribe headers, all pointing at the tracking
+// handlers registered by levee.Client.RegisterHandlers:
+//
+//	b := emailbuild.New("https://example.com/levee")
+//	msg, err := b.Build(html, token)
+//	if err != nil {
+//		return err
+//	}
+//	_, err = client.SendEmail(ctx, &levee.OutgoingEmail{
+//		SendEmailRequest: levee.SendEmailRequest{
+//			To:      "user@example.com",
+//			Subject: "Hello",
+//			Body:    msg.HTML,
+//		},
+//		Headers: msg.Headers,
+//	})
+package emailbuild
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// UnsubscribePlaceholder is replaced with the unsubscribe URL wherever it appears
+// as a link href, e.g. <a href="{{unsubscribe_url}}">Unsubscribe</a>.
+const UnsubscribePlaceholder = "{{unsubscribe_url}}"
+
+// NoTrackAttr excludes a link from click tracking: <a href="..." data-levee-notrack>.
+// The attribute is removed from the output.
+const NoTrackAttr = "data-levee-notrack"
+
+// Message is an email body ready to send.
+type Message struct {
+	HTML string
+	// Headers holds List-Unsubscribe and List-Unsubscribe-Post; pass them as
+	// levee.OutgoingEmail.Headers.
+	Headers map[string]string
+}
+
+// Builder instruments email HTML for one Levee handler mount point.
+// A Builder is safe for concurrent use.
+type Builder struct {
+	baseURL     string
+	trackClicks bool
+	trackOpens  bool
+	inlineCSS   bool
+	mailto      string
+	mjml        MJMLCompiler
+}
+
+// Option configures a Builder.
+type Option func(*Builder)
+
+// WithoutClickTracking leaves links unchanged.
+func WithoutClickTracking() Option {
+	return func(b *Builder) {
+		b.trackClicks = false
+	}
+}
+
+// WithoutOpenTracking omits the open tracking pixel.
+func WithoutOpenTracking() Option {
+	return func(b *Builder) {
+		b.trackOpens = false
+	}
+}
+
+// WithoutCSSInlining leaves <style> blocks as they are.
+func WithoutCSSInlining() Option {
+	return func(b *Builder) {
+		b.inlineCSS = false
+	}
+}
+
+// WithUnsubscribeMailto adds a mailto: address to the List-Unsubscribe header,
+// for mailbox providers that do not support one-click HTTPS unsubscribe.
+func WithUnsubscribeMailto(address string) Option {
+	return func(b *Builder) {
+		b.mailto = address
+	}
+}
+
+// WithMJMLCompiler sets the compiler used by BuildMJML.
+func WithMJMLCompiler(compile MJMLCompiler) Option {
+	return func(b *Builder) {
+		b.mjml = compile
+	}
+}
+
+// New returns a Builder for handlers mounted at baseURL, the absolute URL of
+// the prefix passed to RegisterHandlers (e.g. "https://example.com/levee").
+func New(baseURL string, opts ...Option) *Builder {
+	b := &Builder{
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		trackClicks: true,
+		trackOpens:  true,
+		inlineCSS:   true,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// ClickURL returns the click-tracked URL that redirects to dest.
+func (b *Builder) ClickURL(token, dest string) string {
+	return b.baseURL + "/e/c/" + url.PathEscape(token) + "?url=" + url.QueryEscape(dest)
+}
+
+// OpenURL returns the URL of the open tracking pixel.
+func (b *Builder) OpenURL(token string) string {
+	return b.baseURL + "/e/o/" + url.PathEscape(token)
+}
+
+// UnsubscribeURL returns the one-click unsubscribe URL.
+func (b *Builder) UnsubscribeURL(token string) string {
+	return b.baseURL + "/e/u/" + url.PathEscape(token)
+}
+
+// Build instruments htmlBody for the recipient identified by token, the value
+// the handlers report as TrackingEvent.Token.
+//
+// Links are rewritten to ClickURL, except mailto:/tel:/fragment links, links
+// already under the base URL, links containing merge tags ("{{"), and links
+// marked with NoTrackAttr. Stylesheet rules are inlined into style attributes;
+// rules that cannot be inlined, such as media queries and pseudo-classes, are
+// kept in a <style> block in the head.
+func (b *Builder) Build(htmlBody, token string) (*Message, error) {
+	if token == "" {
+		return nil, fmt.Errorf("token is required")
+	}
+
+	doc, err := html.Parse(strings.NewReader(htmlBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse html: %w", err)
+	}
+
+	if b.inlineCSS {
+		inlineStyles(doc)
+	}
+	b.rewriteLinks(doc, token)
+	if b.trackOpens {
+		if body := findElement(doc, atom.Body); body != nil {
+			body.AppendChild(b.pixel(token))
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := html.Render(&buf, doc); err != nil {
+		return nil, fmt.Errorf("failed to render html: %w", err)
+	}
+
+	return &Message{
+		HTML:    buf.String(),
+		Headers: b.Headers(token),
+	}, nil
+}
+
+// Headers returns the RFC 2369 and RFC 8058 unsubscribe headers for token.
+func (b *Builder) Headers(token string) map[string]string {
+	unsubscribe := "<" + b.UnsubscribeURL(token) + ">"
+	if b.mailto != "" {
+		unsubscribe += ", <mailto:" + b.mailto + "?subject=unsubscribe>"
+	}
+	return map[string]string{
+		"List-Unsubscribe":      unsubscribe,
+		"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+	}
+}
+
+// rewriteLinks replaces unsubscribe placeholders and click-tracks links.
+func (b *Builder) rewriteLinks(doc *html.Node, token string) {
+	walk(doc, func(n *html.Node) {
+		if n.DataAtom != atom.A && n.DataAtom != atom.Area {
+			return
+		}
+
+		notrack := removeAttr(n, NoTrackAttr)
+		for i, attr := range n.Attr {
+			if attr.Namespace != "" || attr.Key != "href" {
+				continue
+			}
+			href := strings.TrimSpace(attr.Val)
+			switch {
+			case href == UnsubscribePlaceholder:
+				n.Attr[i].Val = b.UnsubscribeURL(token)
+			case b.trackClicks && !notrack && b.trackable(href):
+				n.Attr[i].Val = b.ClickURL(token, href)
+			}
+		}
+	})
+}
+
+// trackable reports whether href should be click-tracked.
+func (b *Builder) trackable(href string) bool {
+	if href == "" || strings.Contains(href, "{{") || strings.HasPrefix(href, b.baseURL+"/") {
+		return false
+	}
+	u, err := url.Parse(href)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "http" || u.Scheme == "https"
+}
+
+// pixel returns the open tracking <img> element.
+func (b *Builder) pixel(token string) *html.Node {
+	return &html.Node{
+		Type:     html.ElementNode,
+		Data:     "img",
+		DataAtom: atom.Img,
+		Attr: []html.Attribute{
+			{Key: "src", Val: b.OpenURL(token)},
+			{Key: "width", Val: "1"},
+			{Key: "height", Val: "1"},
+			{Key: "alt", Val: ""},
+			{Key: "style", Val: "display:block;border:0;width:1px;height:1px"},
+		},
+	}
+}
+
+// walk calls fn for n and each of its descendants in document order.
+func walk(n *html.Node, fn func(*html.Node)) {
+	fn(n)
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		walk(c, fn)
+	}
+}
+
+// findElement returns the first element with the given atom.
+func findElement(n *html.Node, a atom.Atom) *html.Node {
+	if n.Type == html.ElementNode && n.DataAtom == a {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findElement(c, a); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// getAttr returns the value of an attribute.
+func getAttr(n *html.Node, key string) (string, bool) {
+	for _, attr := range n.Attr {
+		if attr.Namespace == "" && attr.Key == key {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
+
+// setAttr sets an attribute, adding it if absent.
+func setAttr(n *html.Node, key, val string) {
+	for i, attr := range n.Attr {
+		if attr.Namespace == "" && attr.Key == key {
+			n.Attr[i].Val = val
+			return
+		}
+	}
+	n.Attr = append(n.Attr, html.Attribute{Key: key, Val: val})
+}
+
+// removeAttr removes an attribute and reports whether it was present.
+func removeAttr(n *html.Node, key string) bool {
+	for i, attr := range n.Attr {
+		if attr.Namespace == "" && attr.Key == key {
+			n.Attr = append(n.Attr[:i], n.Attr[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
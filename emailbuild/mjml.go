@@ -0,0 +1,45 @@
+package emailbuild
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// MJMLCompiler compiles MJML markup to HTML.
+type MJMLCompiler func(ctx context.Context, mjml string) (string, error)
+
+// MJMLCommand returns a compiler that runs the mjml CLI (npm install mjml)
+// found at path, or on $PATH if path is empty.
+func MJMLCommand(path string) MJMLCompiler {
+	if path == "" {
+		path = "mjml"
+	}
+	return func(ctx context.Context, mjml string) (string, error) {
+		var stdout, stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, path, "-i", "-s")
+		cmd.Stdin = strings.NewReader(mjml)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return "", fmt.Errorf("mjml failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return stdout.String(), nil
+	}
+}
+
+// BuildMJML compiles mjml with the compiler set by WithMJMLCompiler and
+// instruments the result like Build.
+func (b *Builder) BuildMJML(ctx context.Context, mjml, token string) (*Message, error) {
+	if b.mjml == nil {
+		return nil, fmt.Errorf("no MJML compiler configured")
+	}
+
+	htmlBody, err := b.mjml(ctx, mjml)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile mjml: %w", err)
+	}
+	return b.Build(htmlBody, token)
+}
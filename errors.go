@@ -0,0 +1,45 @@
+package levee
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// APIError is returned when the Levee API responds with a non-2xx status.
+// Use errors.As to inspect the status and error code.
+type APIError struct {
+	StatusCode int
+	// Code is the machine-readable error code, when the API provides one.
+	Code    string
+	Message string
+	// Details carries code-specific information, such as missing template variables.
+	Details json.RawMessage
+	// Body is the raw response body.
+	Body []byte
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, strings.TrimSpace(string(e.Body)))
+}
+
+// newAPIError builds an APIError from an error response, reading the
+// {"code", "message", "details"} envelope when the body is JSON.
+func newAPIError(status int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: status, Body: body}
+
+	var envelope struct {
+		Code    string          `json:"code"`
+		Message string          `json:"message"`
+		Details json.RawMessage `json:"details"`
+	}
+	if json.Unmarshal(body, &envelope) == nil {
+		apiErr.Code = envelope.Code
+		apiErr.Message = envelope.Message
+		apiErr.Details = envelope.Details
+	}
+	return apiErr
+}
@@ -0,0 +1,257 @@
+package levee
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// On registers a handler for Levee events of the given type on the client.
+// Handlers run for events received by HandleLeveeWebhook and ConsumeEvents.
+// Use EventAll to receive every event.
+func (c *Client) On(eventType string, handler EventHandler) {
+	s := c.state()
+	s.eventMu.Lock()
+	defer s.eventMu.Unlock()
+
+	if s.eventHandlers == nil {
+		s.eventHandlers = make(map[string][]EventHandler)
+	}
+	s.eventHandlers[eventType] = append(s.eventHandlers[eventType], handler)
+}
+
+// dispatch runs the client-level handlers registered with On.
+func (c *Client) dispatch(ctx context.Context, event *WebhookEvent) error {
+	s := c.state()
+	s.eventMu.RLock()
+	defer s.eventMu.RUnlock()
+
+	return dispatchEvent(ctx, s.eventHandlers, event)
+}
+
+// PollEventsResponse is a page of events returned by the long-poll endpoint.
+type PollEventsResponse struct {
+	Events []*WebhookEvent
+	// Invalid holds the events of the page that could not be parsed. They are
+	// left out of Events, and Cursor moves past them.
+	Invalid []*InvalidEventError
+	// Cursor is passed to the next PollEvents call to resume after these events.
+	Cursor string
+}
+
+// InvalidEventError reports a polled event that could not be parsed.
+type InvalidEventError struct {
+	Raw json.RawMessage // the event as received
+	Err error
+}
+
+func (e *InvalidEventError) Error() string {
+	return "invalid polled event: " + e.Err.Error()
+}
+
+func (e *InvalidEventError) Unwrap() error {
+	return e.Err
+}
+
+// pollEventsResult is the wire format of /sdk/v1/events/poll.
+type pollEventsResult struct {
+	Events []json.RawMessage `json:"events"`
+	Cursor string            `json:"cursor"`
+}
+
+// PollEvents long-polls Levee for events after cursor (empty starts from now).
+// The call blocks for up to wait while no events are available. Events that
+// cannot be parsed are returned in Invalid rather than failing the page.
+func (c *Client) PollEvents(ctx context.Context, cursor string, wait time.Duration) (*PollEventsResponse, error) {
+	query := url.Values{}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	if wait > 0 {
+		query.Set("wait", strconv.Itoa(int(wait.Seconds())))
+	}
+
+	var result pollEventsResult
+	if err := c.do(ctx, http.MethodGet, "/sdk/v1/events/poll", query, nil, &result); err != nil {
+		return nil, err
+	}
+
+	resp := &PollEventsResponse{
+		Events: make([]*WebhookEvent, 0, len(result.Events)),
+		Cursor: result.Cursor,
+	}
+	for _, raw := range result.Events {
+		event, err := ParseWebhookEvent(raw)
+		if err != nil {
+			resp.Invalid = append(resp.Invalid, &InvalidEventError{Raw: raw, Err: err})
+			continue
+		}
+		resp.Events = append(resp.Events, event)
+	}
+
+	return resp, nil
+}
+
+// eventPollWait is how long each long-poll request waits for new events.
+// Kept below the default HTTP client timeout.
+const eventPollWait = 25 * time.Second
+
+// EventStream returns an iterator over Levee events delivered by long-polling,
+// for applications that cannot expose a public webhook endpoint.
+// Iteration stops when ctx is cancelled or the loop body breaks; request
+// errors are yielded and polling resumes with backoff if iteration continues.
+// Events that cannot be parsed are yielded as *InvalidEventError and skipped.
+// (It is not named Events because Client.Events is the events API resource.)
+//
+//	for event, err := range client.EventStream(ctx) {
+//		if err != nil {
+//			log.Printf("poll failed: %v", err)
+//			continue
+//		}
+//		log.Printf("%s: %s", event.Type, event.ID)
+//	}
+func (c *Client) EventStream(ctx context.Context) iter.Seq2[*WebhookEvent, error] {
+	return func(yield func(*WebhookEvent, error) bool) {
+		c.pollLoop(ctx, "", func(resp *PollEventsResponse) bool {
+			for _, event := range resp.Events {
+				if !yield(event, nil) {
+					return false
+				}
+			}
+			for _, invalid := range resp.Invalid {
+				if !yield(nil, invalid) {
+					return false
+				}
+			}
+			return true
+		}, func(err error) bool {
+			return yield(nil, err)
+		})
+	}
+}
+
+// pollLoop long-polls from cursor until ctx is cancelled or page or fail
+// returns false. fail is told about request errors, which are retried with
+// backoff; page gets each response, and the cursor advances after it returns.
+func (c *Client) pollLoop(ctx context.Context, cursor string, page func(*PollEventsResponse) bool, fail func(error) bool) {
+	backoff := time.Second
+
+	for ctx.Err() == nil {
+		resp, err := c.PollEvents(ctx, cursor, eventPollWait)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if !fail(err) {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, time.Minute)
+			continue
+		}
+		backoff = time.Second
+
+		if !page(resp) {
+			return
+		}
+		if resp.Cursor != "" {
+			cursor = resp.Cursor
+		}
+	}
+}
+
+// ConsumeOption configures ConsumeEvents.
+type ConsumeOption func(*consumeOptions)
+
+type consumeOptions struct {
+	cursor      string
+	saveCursor  func(ctx context.Context, cursor string) error
+	onPollError func(err error)
+}
+
+// ConsumeFromCursor resumes after cursor, e.g. one saved by OnCursor before a
+// restart. By default consuming starts from now.
+func ConsumeFromCursor(cursor string) ConsumeOption {
+	return func(o *consumeOptions) {
+		o.cursor = cursor
+	}
+}
+
+// OnCursor is called with the cursor after each page of events has been
+// handled. Persist it and pass it to ConsumeFromCursor to resume without
+// missing events. An error stops ConsumeEvents.
+func OnCursor(save func(ctx context.Context, cursor string) error) ConsumeOption {
+	return func(o *consumeOptions) {
+		o.saveCursor = save
+	}
+}
+
+// OnPollError is told about each failed poll before it is retried with
+// backoff, and about each skipped event that could not be parsed, as an
+// *InvalidEventError. Both are also logged with the client logger.
+func OnPollError(fn func(err error)) ConsumeOption {
+	return func(o *consumeOptions) {
+		o.onPollError = fn
+	}
+}
+
+// ConsumeEvents streams events and dispatches them to handlers registered with On.
+// It blocks until ctx is cancelled. Poll errors are logged and retried, and
+// events that cannot be parsed are logged and skipped; handler and OnCursor
+// errors are returned.
+//
+//	err := client.ConsumeEvents(ctx,
+//		levee.ConsumeFromCursor(loadCursor()),
+//		levee.OnCursor(func(ctx context.Context, cursor string) error {
+//			return saveCursor(ctx, cursor)
+//		}),
+//	)
+func (c *Client) ConsumeEvents(ctx context.Context, opts ...ConsumeOption) error {
+	var o consumeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var handleErr error
+	c.pollLoop(ctx, o.cursor, func(resp *PollEventsResponse) bool {
+		for _, event := range resp.Events {
+			if err := c.dispatch(ctx, event); err != nil {
+				handleErr = fmt.Errorf("failed to handle event %s: %w", event.ID, err)
+				return false
+			}
+		}
+		for _, invalid := range resp.Invalid {
+			c.log().Warn("skipped invalid event", "error", invalid.Err)
+			if o.onPollError != nil {
+				o.onPollError(invalid)
+			}
+		}
+		if o.saveCursor != nil && resp.Cursor != "" {
+			if err := o.saveCursor(ctx, resp.Cursor); err != nil {
+				handleErr = fmt.Errorf("failed to save event cursor: %w", err)
+				return false
+			}
+		}
+		return true
+	}, func(err error) bool {
+		c.log().Warn("event poll failed", "error", err)
+		if o.onPollError != nil {
+			o.onPollError(err)
+		}
+		return true
+	})
+
+	if handleErr != nil {
+		return handleErr
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,46 @@
+package levee
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPollEventsSkipsInvalidEvents(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sdk/v1/events/poll" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"events": [
+			{"id": "evt_1", "type": "custom.event"},
+			{"id": "evt_2"},
+			"not an event",
+			{"id": "evt_3", "type": "custom.event"}
+		], "cursor": "c2"}`))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient("key", srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.PollEvents(context.Background(), "c1", 0)
+	if err != nil {
+		t.Fatalf("PollEvents() = %v", err)
+	}
+
+	if len(resp.Events) != 2 || resp.Events[0].ID != "evt_1" || resp.Events[1].ID != "evt_3" {
+		t.Errorf("events = %v, want evt_1 and evt_3", resp.Events)
+	}
+	if len(resp.Invalid) != 2 {
+		t.Fatalf("invalid = %d events, want 2", len(resp.Invalid))
+	}
+	if raw := string(resp.Invalid[0].Raw); raw != `{"id": "evt_2"}` {
+		t.Errorf("invalid[0].Raw = %s", raw)
+	}
+	if resp.Cursor != "c2" {
+		t.Errorf("cursor = %q, want c2", resp.Cursor)
+	}
+}
@@ -0,0 +1,204 @@
+package levee
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ExportFormat is the archive format of ExportContactData.
+type ExportFormat string
+
+// Contact export formats.
+const (
+	// ExportNDJSON is newline-delimited JSON, one ContactExportRecord per line.
+	ExportNDJSON ExportFormat = "ndjson"
+	// ExportZIP is a ZIP archive with one JSON file per record type and the
+	// rendered HTML of each message.
+	ExportZIP ExportFormat = "zip"
+)
+
+// ContactExportRecord is one line of an NDJSON contact export.
+type ContactExportRecord struct {
+	// Type is "profile", "subscription", "event", "message", "order", or "consent".
+	Type      string          `json:"type"`
+	CreatedAt string          `json:"created_at,omitempty"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// ExportContactData streams everything Levee holds about a contact (profile,
+// subscriptions, events, messages, orders) for a subject-access request.
+// The caller must close the returned reader. Large exports may take longer
+// than the client timeout; use WithTimeout or a client without one.
+//
+//	rc, err := client.ExportContactData(ctx, "user@example.com", levee.ExportZIP)
+//	if err != nil {
+//		return err
+//	}
+//	defer rc.Close()
+//	_, err = io.Copy(file, rc)
+func (c *Client) ExportContactData(ctx context.Context, email string, format ExportFormat) (io.ReadCloser, error) {
+	if email == "" {
+		return nil, fmt.Errorf("email is required")
+	}
+	if format == "" {
+		format = ExportNDJSON
+	}
+
+	query := url.Values{}
+	query.Set("email", email)
+	query.Set("format", string(format))
+
+	resp, err := c.doRequest(ctx, http.MethodGet, "/sdk/v1/privacy/export?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp.StatusCode, body)
+	}
+	return resp.Body, nil
+}
+
+// ReadContactExport iterates the records of an NDJSON contact export.
+//
+//	for rec, err := range levee.ReadContactExport(rc) {
+//		if err != nil {
+//			return err
+//		}
+//		log.Println(rec.Type, string(rec.Data))
+//	}
+func ReadContactExport(r io.Reader) iter.Seq2[*ContactExportRecord, error] {
+	return func(yield func(*ContactExportRecord, error) bool) {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			if len(line) == 0 {
+				continue
+			}
+			var rec ContactExportRecord
+			if err := json.Unmarshal(line, &rec); err != nil {
+				yield(nil, fmt.Errorf("failed to parse export record: %w", err))
+				return
+			}
+			if !yield(&rec, nil) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield(nil, fmt.Errorf("failed to read export: %w", err))
+		}
+	}
+}
+
+// ErasureStatus is the state of an erasure job.
+type ErasureStatus string
+
+// Erasure job statuses.
+const (
+	ErasurePending   ErasureStatus = "pending"
+	ErasureRunning   ErasureStatus = "running"
+	ErasureCompleted ErasureStatus = "completed"
+	ErasureFailed    ErasureStatus = "failed"
+)
+
+// ErasureJob tracks the permanent deletion of a contact's data.
+type ErasureJob struct {
+	ID           string        `json:"id"`
+	Status       ErasureStatus `json:"status"`
+	DoNotContact bool          `json:"do_not_contact"`
+	RequestedAt  string        `json:"requested_at,omitempty"`
+	CompletedAt  string        `json:"completed_at,omitempty"`
+	Error        string        `json:"error,omitempty"`
+}
+
+// eraseRequest is the body of an erasure request.
+type eraseRequest struct {
+	Email        string `json:"email"`
+	DoNotContact bool   `json:"do_not_contact,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+}
+
+// EraseOption configures EraseContact.
+type EraseOption func(*eraseRequest)
+
+// WithDoNotContact adds a one-way hash of the address to the do-not-contact
+// list, so it cannot be re-imported or mailed after the contact is erased.
+func WithDoNotContact() EraseOption {
+	return func(r *eraseRequest) {
+		r.DoNotContact = true
+	}
+}
+
+// WithErasureReason records why the erasure was requested, e.g. a ticket reference.
+func WithErasureReason(reason string) EraseOption {
+	return func(r *eraseRequest) {
+		r.Reason = reason
+	}
+}
+
+// EraseContact requests permanent deletion of a contact and all its data,
+// including event and message history. Erasure runs in the background; poll
+// the returned job with GetErasureJob or WaitForErasure. It cannot be undone.
+func (c *Client) EraseContact(ctx context.Context, email string, opts ...EraseOption) (*ErasureJob, error) {
+	if email == "" {
+		return nil, fmt.Errorf("email is required")
+	}
+
+	req := &eraseRequest{Email: email}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	var result ErasureJob
+	if err := c.do(ctx, http.MethodPost, "/sdk/v1/privacy/erasures", nil, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetErasureJob returns the status of an erasure job.
+func (c *Client) GetErasureJob(ctx context.Context, jobID string) (*ErasureJob, error) {
+	var result ErasureJob
+	if err := c.do(ctx, http.MethodGet, "/sdk/v1/privacy/erasures/"+url.PathEscape(jobID), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// WaitForErasure polls an erasure job every interval (default 10s) until it
+// completes or fails, or ctx is done. A failed job is returned with an error.
+func (c *Client) WaitForErasure(ctx context.Context, jobID string, interval time.Duration) (*ErasureJob, error) {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		job, err := c.GetErasureJob(ctx, jobID)
+		if err != nil {
+			return nil, err
+		}
+		switch job.Status {
+		case ErasureCompleted:
+			return job, nil
+		case ErasureFailed:
+			return job, fmt.Errorf("erasure %s failed: %s", job.ID, job.Error)
+		}
+
+		select {
+		case <-ctx.Done():
+			return job, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -6,10 +6,15 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"html/template"
 	"io"
 	"net/http"
+	"net/netip"
+	"net/url"
 	"strings"
+	"time"
 )
 
 // HandlerConfig configures the embedded HTTP handlers.
@@ -20,14 +25,102 @@ type HandlerConfig struct {
 	ConfirmRedirect string
 	// ConfirmExpiredRedirect is the URL to redirect to if confirmation token expired (default: /confirm-expired)
 	ConfirmExpiredRedirect string
+	// ConfirmResentRedirect is the URL to redirect to after a confirmation email is resent (default: /confirm-resent)
+	ConfirmResentRedirect string
+	// RedirectStatus is the status code for click, unsubscribe, and confirm redirects (default: 307)
+	RedirectStatus int
+	// JSONResponses makes handlers respond with JSON instead of redirecting (for SPA frontends)
+	JSONResponses bool
+	// ResendConfirmationInterval is the minimum time between resends to the same email (default: 5m)
+	ResendConfirmationInterval time.Duration
 	// StripeWebhookSecret is the Stripe webhook signing secret for signature verification
 	StripeWebhookSecret string
+	// StripeEventHandlers maps Stripe event types to callbacks invoked after events are forwarded to Levee
+	StripeEventHandlers map[string][]StripeEventHandler
+	// BillingProviders mounts a normalized billing webhook receiver per provider
+	BillingProviders []BillingProvider
+	// BillingEventHandlers are called with each normalized billing event after it is forwarded to Levee
+	BillingEventHandlers []BillingEventHandler
+	// LeveeWebhookSecret is the Levee webhook signing secret for signature verification
+	LeveeWebhookSecret string
+	// LeveeWebhookPreviousSecrets are also accepted, so deliveries keep verifying while a secret is rotated
+	LeveeWebhookPreviousSecrets []string
+	// LeveeEventHandlers maps event types to callbacks invoked by the Levee webhook handler
+	LeveeEventHandlers map[string][]EventHandler
+	// InboundEmailHandler receives parsed inbound email; the inbound route is mounted only when set
+	InboundEmailHandler InboundEmailHandler
+	// InboundEmailSecret is the shared secret inbound email deliveries must carry (empty disables the check)
+	InboundEmailSecret string
+	// InboundAuthServIDs are the receiving servers whose Authentication-Results headers are trusted
+	InboundAuthServIDs []string
+	// InboundSNSTopicARNs are the SNS topics SES inbound email may arrive from
+	InboundSNSTopicARNs []string
+	// MailgunSigningKey verifies inbound deliveries from Mailgun routes (unsigned deliveries are rejected)
+	MailgunSigningKey string
 	// LLMClient is the optional LLM client for WebSocket chat handler
 	LLMClient *LLMClient
 	// WSCheckOrigin is the origin checker for WebSocket connections (nil allows all)
 	WSCheckOrigin func(r *http.Request) bool
+	// PixelFormat is the image format served by the open tracking pixel (default: PixelGIF)
+	PixelFormat PixelFormat
+	// TrustedProxies are the proxies whose forwarding headers are trusted for the client IP
+	TrustedProxies []netip.Prefix
+	// ClientIPHeader is a single-IP header set by a trusted proxy, e.g. CF-Connecting-IP
+	ClientIPHeader string
+	// OpenDedupWindow ignores repeat opens of a token from the same IP and user agent within this window (0 disables)
+	OpenDedupWindow time.Duration
+	// EnrichEvent runs before a tracking event is recorded and may attach custom fields
+	EnrichEvent func(r *http.Request, e *TrackingEvent)
+	// AMPAllowedSenders restricts which email senders may submit AMP forms (empty allows all)
+	AMPAllowedSenders []string
+	// TrackingDomainToken is the verification token served by HandleTrackingDomainVerification
+	TrackingDomainToken string
+	// Middleware wraps every handler mounted by RegisterHandlers (first is outermost)
+	Middleware []func(http.Handler) http.Handler
+	// Handlers selects which endpoints RegisterHandlers mounts (default: HandlersAll)
+	Handlers HandlerSet
+	// UnsubscribeSurvey renders a one-question reason form after unsubscribing instead of redirecting
+	UnsubscribeSurvey bool
+	// UnsubscribeSurveyTemplate overrides the built-in survey page (see UnsubscribeSurveyData)
+	UnsubscribeSurveyTemplate *template.Template
+	// UnsubscribeConfirmation makes GET render a confirm button; the unsubscribe happens on POST
+	UnsubscribeConfirmation bool
+	// UnsubscribeConfirmTemplate overrides the built-in confirm page (see UnsubscribeConfirmData)
+	UnsubscribeConfirmTemplate *template.Template
+	// ErrorRenderer writes handler error responses (default: plain text via http.Error)
+	ErrorRenderer func(w http.ResponseWriter, r *http.Request, status int, code string)
 }
 
+// HandlerSet is a bitmask of embedded handlers.
+type HandlerSet uint32
+
+// Embedded handlers selectable with WithHandlers and WithoutHandlers.
+const (
+	HandlerOpenTracking HandlerSet = 1 << iota
+	HandlerClickTracking
+	HandlerUnsubscribe
+	HandlerConfirmEmail
+	HandlerStripeWebhook
+	HandlerSESWebhook
+	HandlerLeveeWebhook
+	HandlerChatWebSocket
+	HandlerHealth
+	HandlerResendConfirmation
+	HandlerValidateConfirmToken
+	HandlerAMP
+	HandlerInboundEmail
+	HandlerBillingWebhook
+
+	// HandlersTracking covers open, click, and unsubscribe tracking.
+	HandlersTracking = HandlerOpenTracking | HandlerClickTracking | HandlerUnsubscribe
+	// HandlersWebhooks covers the Stripe, SES, Levee, and billing provider webhook
+	// receivers and inbound email.
+	HandlersWebhooks = HandlerStripeWebhook | HandlerSESWebhook | HandlerLeveeWebhook | HandlerInboundEmail | HandlerBillingWebhook
+	// HandlersAll mounts every handler (the chat WebSocket still requires WithLLMClient,
+	// and inbound email WithInboundEmailHandler).
+	HandlersAll = ^HandlerSet(0)
+)
+
 // HandlerOption is a functional option for configuring handlers.
 type HandlerOption func(*HandlerConfig)
 
@@ -52,6 +145,38 @@ func WithConfirmExpiredRedirect(url string) HandlerOption {
 	}
 }
 
+// WithConfirmResentRedirect sets the redirect URL after a confirmation email is resent.
+func WithConfirmResentRedirect(url string) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.ConfirmResentRedirect = url
+	}
+}
+
+// WithRedirectStatus sets the status code used by the click, unsubscribe, and confirm
+// redirects, e.g. http.StatusFound for clients that mishandle 307/308.
+// Form submissions (POST) always redirect with 303 See Other.
+func WithRedirectStatus(code int) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.RedirectStatus = code
+	}
+}
+
+// WithJSONResponses makes the redirecting handlers respond with a HandlerResult JSON
+// body instead, so SPA frontends can call them with fetch and route client-side.
+// The unsubscribe survey page is not rendered in this mode.
+func WithJSONResponses() HandlerOption {
+	return func(c *HandlerConfig) {
+		c.JSONResponses = true
+	}
+}
+
+// WithResendConfirmationInterval sets the minimum time between confirmation resends per email.
+func WithResendConfirmationInterval(d time.Duration) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.ResendConfirmationInterval = d
+	}
+}
+
 // WithStripeWebhookSecret sets the Stripe webhook signing secret.
 func WithStripeWebhookSecret(secret string) HandlerOption {
 	return func(c *HandlerConfig) {
@@ -59,6 +184,55 @@ func WithStripeWebhookSecret(secret string) HandlerOption {
 	}
 }
 
+// WithLeveeWebhookSecret sets the Levee webhook signing secret. Pass the
+// previous secret as well while a RotateWebhookSecret grace period is running.
+func WithLeveeWebhookSecret(secret string, previous ...string) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.LeveeWebhookSecret = secret
+		c.LeveeWebhookPreviousSecrets = previous
+	}
+}
+
+// WithEventHandler registers a callback for Levee webhook events of the given type.
+// Use EventAll to receive every event.
+func WithEventHandler(eventType string, fn EventHandler) HandlerOption {
+	return func(c *HandlerConfig) {
+		if c.LeveeEventHandlers == nil {
+			c.LeveeEventHandlers = make(map[string][]EventHandler)
+		}
+		c.LeveeEventHandlers[eventType] = append(c.LeveeEventHandlers[eventType], fn)
+	}
+}
+
+// WithStripeEventHandler registers a callback for Stripe webhook events of the given
+// type, e.g. StripeInvoicePaymentFailed to pause access while dunning emails go out.
+// Use EventAll to receive every event.
+func WithStripeEventHandler(eventType string, fn StripeEventHandler) HandlerOption {
+	return func(c *HandlerConfig) {
+		if c.StripeEventHandlers == nil {
+			c.StripeEventHandlers = make(map[string][]StripeEventHandler)
+		}
+		c.StripeEventHandlers[eventType] = append(c.StripeEventHandlers[eventType], fn)
+	}
+}
+
+// WithBillingProvider mounts POST /webhooks/billing/{name} for a billing provider
+// (StripeBilling, PaddleBilling, LemonSqueezyBilling, or your own), which
+// normalizes its webhooks to BillingEvent and forwards them to Levee.
+func WithBillingProvider(p BillingProvider) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.BillingProviders = append(c.BillingProviders, p)
+	}
+}
+
+// WithBillingEventHandler registers a callback for normalized billing events from
+// every provider mounted with WithBillingProvider.
+func WithBillingEventHandler(fn BillingEventHandler) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.BillingEventHandlers = append(c.BillingEventHandlers, fn)
+	}
+}
+
 // WithLLMClient sets the LLM client for WebSocket chat handler.
 func WithLLMClient(llm *LLMClient) HandlerOption {
 	return func(c *HandlerConfig) {
@@ -73,264 +247,388 @@ func WithWSCheckOrigin(fn func(r *http.Request) bool) HandlerOption {
 	}
 }
 
-// 1x1 transparent GIF (43 bytes)
-var transparentGIF = []byte{
-	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00,
-	0x01, 0x00, 0x80, 0x00, 0x00, 0xff, 0xff, 0xff,
-	0x00, 0x00, 0x00, 0x21, 0xf9, 0x04, 0x01, 0x00,
-	0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
-	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44,
-	0x01, 0x00, 0x3b,
+// WithHandlers mounts only the given handlers.
+// Example: levee.WithHandlers(levee.HandlersTracking | levee.HandlerConfirmEmail)
+func WithHandlers(set HandlerSet) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.Handlers = set
+	}
 }
 
-// NewHandlerConfig creates a new HandlerConfig with the given options.
-// Use this when registering handlers individually with custom routers.
-func NewHandlerConfig(opts ...HandlerOption) *HandlerConfig {
-	cfg := &HandlerConfig{
-		UnsubscribeRedirect:    "/unsubscribed",
-		ConfirmRedirect:        "/confirmed",
-		ConfirmExpiredRedirect: "/confirm-expired",
+// WithoutHandlers excludes the given handlers from registration.
+func WithoutHandlers(set HandlerSet) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.Handlers &^= set
 	}
-	for _, opt := range opts {
-		opt(cfg)
+}
+
+// WithOnlyTracking mounts only the open, click, and unsubscribe tracking handlers.
+func WithOnlyTracking() HandlerOption {
+	return WithHandlers(HandlersTracking)
+}
+
+// WithoutStripeWebhook excludes the Stripe webhook receiver.
+func WithoutStripeWebhook() HandlerOption {
+	return WithoutHandlers(HandlerStripeWebhook)
+}
+
+// WithUnsubscribeSurvey enables the unsubscribe reason survey. After unsubscribing, the
+// handler renders a one-question form whose answer is sent with RecordUnsubscribeReason
+// before redirecting to UnsubscribeRedirect. Pass a template to replace the built-in page.
+func WithUnsubscribeSurvey(tmpl ...*template.Template) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.UnsubscribeSurvey = true
+		if len(tmpl) > 0 {
+			c.UnsubscribeSurveyTemplate = tmpl[0]
+		}
 	}
-	return cfg
 }
 
-// RegisterHandlers registers all Levee HTTP handlers on the given mux with the specified prefix.
-// Example: client.RegisterHandlers(mux, "/levee") registers handlers at /levee/e/o/:token, etc.
-func (c *Client) RegisterHandlers(mux *http.ServeMux, prefix string, opts ...HandlerOption) {
-	cfg := &HandlerConfig{
-		UnsubscribeRedirect:    "/unsubscribed",
-		ConfirmRedirect:        "/confirmed",
-		ConfirmExpiredRedirect: "/confirm-expired",
+// WithUnsubscribeConfirmation enables two-step unsubscribe: GET renders a page with a
+// confirm button and the unsubscribe is recorded only when it is POSTed, so link
+// scanners and prefetchers that follow the link cannot unsubscribe users.
+// An optional template replaces the built-in page.
+func WithUnsubscribeConfirmation(tmpl ...*template.Template) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.UnsubscribeConfirmation = true
+		if len(tmpl) > 0 {
+			c.UnsubscribeConfirmTemplate = tmpl[0]
+		}
 	}
+}
 
-	for _, opt := range opts {
-		opt(cfg)
+// WithErrorRenderer sets a custom renderer for handler error responses,
+// e.g. to return branded HTML pages or JSON instead of plain text.
+func WithErrorRenderer(fn func(w http.ResponseWriter, r *http.Request, status int, code string)) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.ErrorRenderer = fn
 	}
+}
 
-	// Email tracking
-	mux.HandleFunc(prefix+"/e/o/", c.handleOpenTracking())
-	mux.HandleFunc(prefix+"/e/c/", c.handleClickTracking())
-	mux.HandleFunc(prefix+"/e/u/", c.handleUnsubscribe(cfg))
+// WithPixelFormat sets the image format served by the open tracking pixel.
+func WithPixelFormat(format PixelFormat) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.PixelFormat = format
+	}
+}
 
-	// Email confirmation
-	mux.HandleFunc(prefix+"/confirm-email", c.handleConfirmEmail(cfg))
+// WithTrustedProxies sets the load balancers and proxies in front of the application.
+// Requests from these addresses have their client IP, used for tracking metadata, open
+// dedup and access logs, taken from Forwarded / X-Forwarded-For instead of the connection.
+//
+//	levee.WithTrustedProxies(netip.MustParsePrefix("10.0.0.0/8"))
+func WithTrustedProxies(prefixes ...netip.Prefix) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.TrustedProxies = append(c.TrustedProxies, prefixes...)
+	}
+}
 
-	// Webhooks
-	mux.HandleFunc(prefix+"/webhooks/stripe", c.handleStripeWebhook(cfg))
-	mux.HandleFunc(prefix+"/webhooks/ses", c.handleSESWebhook())
+// WithClientIPHeader reads the client IP from a single-value header set by a trusted
+// proxy, such as "CF-Connecting-IP" or "True-Client-IP". Requires WithTrustedProxies.
+func WithClientIPHeader(header string) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.ClientIPHeader = header
+	}
+}
 
-	// WebSocket LLM chat (if LLM client provided)
-	if cfg.LLMClient != nil {
-		var wsOpts []WSOption
-		if cfg.WSCheckOrigin != nil {
-			wsOpts = append(wsOpts, WithCheckOrigin(cfg.WSCheckOrigin))
-		}
-		mux.HandleFunc(prefix+"/ws/chat", c.HandleChatWebSocket(cfg.LLMClient, wsOpts...))
+// WithOpenDedupWindow ignores repeat opens of the same token from the same IP and
+// user agent within d, reducing noise from mail clients that fetch the pixel repeatedly.
+func WithOpenDedupWindow(d time.Duration) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.OpenDedupWindow = d
 	}
 }
 
-// handleOpenTracking handles email open tracking pixel requests.
-// GET /prefix/e/o/:token
-func (c *Client) handleOpenTracking() http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-			return
-		}
+// WithEnrichEvent sets a hook that runs before open, click, and unsubscribe events are
+// recorded. It can attach custom fields (e.g. the logged-in user ID or campaign context
+// from cookies) which are sent to Levee with the event.
+func WithEnrichEvent(fn func(r *http.Request, e *TrackingEvent)) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.EnrichEvent = fn
+	}
+}
 
-		token := extractToken(r.URL.Path, "/e/o/")
-		if token == "" {
-			http.Error(w, "Missing token", http.StatusBadRequest)
-			return
-		}
+// WithAMPAllowedSenders restricts AMP form submissions to emails from the given senders
+// (e.g. "newsletter@example.com"). Origins are matched for the legacy AMP CORS scheme.
+func WithAMPAllowedSenders(senders ...string) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.AMPAllowedSenders = append(c.AMPAllowedSenders, senders...)
+	}
+}
 
-		// Record open asynchronously
-		go func() {
-			ctx := context.Background()
-			c.RecordOpen(ctx, token)
-		}()
+// WithInboundEmailHandler mounts POST /inbound/email and calls fn with each parsed
+// inbound message (see HandleInboundEmail).
+func WithInboundEmailHandler(fn InboundEmailHandler) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.InboundEmailHandler = fn
+	}
+}
 
-		// Return 1x1 transparent GIF
-		w.Header().Set("Content-Type", "image/gif")
-		w.Header().Set("Cache-Control", "no-store, no-cache, must-revalidate")
-		w.Header().Set("Pragma", "no-cache")
-		w.Header().Set("Expires", "0")
-		w.Write(transparentGIF)
+// WithInboundEmailSecret requires inbound email deliveries to carry secret as
+// ?key=<secret>, an "Authorization: Bearer <secret>" header, or a basic auth password.
+func WithInboundEmailSecret(secret string) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.InboundEmailSecret = secret
 	}
 }
 
-// handleClickTracking handles email click tracking requests.
-// GET /prefix/e/c/:token?url=...
-func (c *Client) handleClickTracking() http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-			return
-		}
+// WithInboundAuthServIDs trusts the Authentication-Results headers added by
+// receiving servers with these authserv-ids (e.g. "mx.example.com"). Headers of
+// any other server may be forged by the sender and are ignored.
+func WithInboundAuthServIDs(ids ...string) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.InboundAuthServIDs = append(c.InboundAuthServIDs, ids...)
+	}
+}
 
-		token := extractToken(r.URL.Path, "/e/c/")
-		if token == "" {
-			http.Error(w, "Missing token", http.StatusBadRequest)
-			return
-		}
+// WithInboundSNSTopicARNs accepts SES inbound email only from these SNS topics.
+func WithInboundSNSTopicARNs(arns ...string) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.InboundSNSTopicARNs = append(c.InboundSNSTopicARNs, arns...)
+	}
+}
 
-		redirectURL := r.URL.Query().Get("url")
-		if redirectURL == "" {
-			http.Error(w, "Missing url parameter", http.StatusBadRequest)
-			return
-		}
+// WithMailgunSigningKey sets the Mailgun HTTP webhook signing key used to verify
+// inbound deliveries from Mailgun routes. Without it they are rejected.
+func WithMailgunSigningKey(key string) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.MailgunSigningKey = key
+	}
+}
 
-		// Record click asynchronously
-		go func() {
-			ctx := context.Background()
-			c.RecordClick(ctx, token, redirectURL)
-		}()
+// WithTrackingDomainToken sets the verification token for a branded tracking domain.
+func WithTrackingDomainToken(token string) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.TrackingDomainToken = token
+	}
+}
 
-		// Redirect to destination
-		http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+// WithMiddleware appends middleware applied to every handler mounted by RegisterHandlers.
+func WithMiddleware(mw ...func(http.Handler) http.Handler) HandlerOption {
+	return func(c *HandlerConfig) {
+		c.Middleware = append(c.Middleware, mw...)
 	}
 }
 
-// handleUnsubscribe handles one-click unsubscribe requests.
-// GET /prefix/e/u/:token
-func (c *Client) handleUnsubscribe(cfg *HandlerConfig) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-			return
-		}
+// Handler error codes passed to HandlerConfig.ErrorRenderer.
+const (
+	ErrCodeMethodNotAllowed  = "method_not_allowed"
+	ErrCodeMissingToken      = "missing_token"
+	ErrCodeMissingURL        = "missing_url"
+	ErrCodeInvalidBody       = "invalid_body"
+	ErrCodeInvalidSignature  = "invalid_signature"
+	ErrCodeInvalidPayload    = "invalid_payload"
+	ErrCodeUnsubscribeFailed = "unsubscribe_failed"
+	ErrCodeWebhookFailed     = "webhook_failed"
+	ErrCodeMissingEmail      = "missing_email"
+	ErrCodeRateLimited       = "rate_limited"
+	ErrCodeResendFailed      = "resend_failed"
+	ErrCodeValidationFailed  = "validation_failed"
+	ErrCodeInvalidToken      = "invalid_token"
+
+	ErrCodeEntitlementRequired    = "entitlement_required"
+	ErrCodeEntitlementCheckFailed = "entitlement_check_failed"
+)
 
-		token := extractToken(r.URL.Path, "/e/u/")
-		if token == "" {
-			http.Error(w, "Missing token", http.StatusBadRequest)
-			return
-		}
+// handlerErrorMessages are the default plain-text messages for handler error codes.
+var handlerErrorMessages = map[string]string{
+	ErrCodeMethodNotAllowed:  "Method not allowed",
+	ErrCodeMissingToken:      "Missing token",
+	ErrCodeMissingURL:        "Missing url parameter",
+	ErrCodeInvalidBody:       "Failed to read body",
+	ErrCodeInvalidSignature:  "Invalid signature",
+	ErrCodeInvalidPayload:    "Invalid event payload",
+	ErrCodeUnsubscribeFailed: "Failed to unsubscribe",
+	ErrCodeWebhookFailed:     "Failed to process webhook",
+	ErrCodeMissingEmail:      "Missing email",
+	ErrCodeRateLimited:       "Too many requests, please try again later",
+	ErrCodeResendFailed:      "Failed to resend confirmation email",
+	ErrCodeValidationFailed:  "Failed to validate token",
+	ErrCodeInvalidToken:      "Invalid or expired token",
+
+	ErrCodeEntitlementRequired:    "Your plan does not include this feature",
+	ErrCodeEntitlementCheckFailed: "Failed to check your plan, please try again later",
+}
 
-		// Record unsubscribe (synchronous - we want to confirm it worked)
-		ctx := r.Context()
-		err := c.RecordUnsubscribe(ctx, token)
-		if err != nil {
-			http.Error(w, "Failed to unsubscribe", http.StatusInternalServerError)
-			return
-		}
+// HandlerErrorMessage returns the default message for a handler error code.
+func HandlerErrorMessage(code string) string {
+	if msg, ok := handlerErrorMessages[code]; ok {
+		return msg
+	}
+	return http.StatusText(http.StatusInternalServerError)
+}
 
-		http.Redirect(w, r, cfg.UnsubscribeRedirect, http.StatusTemporaryRedirect)
+// renderError writes an error response using the configured ErrorRenderer.
+func (cfg *HandlerConfig) renderError(w http.ResponseWriter, r *http.Request, status int, code string) {
+	if cfg.ErrorRenderer != nil {
+		cfg.ErrorRenderer(w, r, status, code)
+		return
 	}
+	http.Error(w, HandlerErrorMessage(code), status)
 }
 
-// handleConfirmEmail handles double opt-in email confirmation.
-// GET /prefix/confirm-email?token=...
-func (c *Client) handleConfirmEmail(cfg *HandlerConfig) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-			return
-		}
+// NewHandlerConfig creates a new HandlerConfig with the given options.
+// Use this when registering handlers individually with custom routers.
+func NewHandlerConfig(opts ...HandlerOption) *HandlerConfig {
+	cfg := &HandlerConfig{
+		UnsubscribeRedirect:        "/unsubscribed",
+		ConfirmRedirect:            "/confirmed",
+		ConfirmExpiredRedirect:     "/confirm-expired",
+		ConfirmResentRedirect:      "/confirm-resent",
+		ResendConfirmationInterval: 5 * time.Minute,
+		RedirectStatus:             http.StatusTemporaryRedirect,
+		Handlers:                   HandlersAll,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
 
-		token := r.URL.Query().Get("token")
-		if token == "" {
-			http.Error(w, "Missing token", http.StatusBadRequest)
-			return
-		}
+// RegisterHandlers registers all Levee HTTP handlers on the given mux with the specified prefix.
+// Example: client.RegisterHandlers(mux, "/levee") registers handlers at /levee/e/o/:token, etc.
+// Middleware configured with WithMiddleware is applied to every registered handler.
+func (c *Client) RegisterHandlers(mux *http.ServeMux, prefix string, opts ...HandlerOption) {
+	cfg := NewHandlerConfig(opts...)
 
-		ctx := r.Context()
-		resp, err := c.ConfirmEmail(ctx, token)
-		if err != nil {
-			http.Redirect(w, r, cfg.ConfirmExpiredRedirect, http.StatusTemporaryRedirect)
-			return
-		}
+	for _, rt := range c.handlerRoutes(cfg) {
+		mux.Handle(prefix+rt.pattern, cfg.Wrap(rt.handler))
+	}
+}
 
-		redirect := cfg.ConfirmRedirect
-		if resp.RedirectURL != "" {
-			redirect = resp.RedirectURL
-		}
+// handlerRoute describes one embedded handler for registration on a router.
+type handlerRoute struct {
+	set     HandlerSet
+	methods []string
+	pattern string // http.ServeMux pattern (trailing slash for token routes)
+	path    string // path with ":token" variable for routers with path parameters
+	handler http.Handler
+}
+
+// handlerRoutes returns the embedded handlers enabled by cfg.
+func (c *Client) handlerRoutes(cfg *HandlerConfig) []handlerRoute {
+	get := []string{http.MethodGet}
+	post := []string{http.MethodPost}
+
+	all := []handlerRoute{
+		// Email tracking
+		{HandlerOpenTracking, []string{http.MethodGet, http.MethodHead}, "/e/o/", "/e/o/:token", c.HandleOpenTracking(cfg)},
+		{HandlerClickTracking, get, "/e/c/", "/e/c/:token", c.HandleClickTracking(cfg)},
+		{HandlerUnsubscribe, []string{http.MethodGet, http.MethodPost}, "/e/u/", "/e/u/:token", c.HandleUnsubscribe(cfg)},
+
+		// AMP for Email
+		{HandlerAMP, post, "/amp/", "/amp/:token", c.HandleAMP(cfg)},
+
+		// Email confirmation
+		{HandlerConfirmEmail, get, "/confirm-email", "/confirm-email", c.HandleConfirmEmail(cfg)},
+		{HandlerResendConfirmation, post, "/confirm-email/resend", "/confirm-email/resend", c.HandleResendConfirmation(cfg)},
+		{HandlerValidateConfirmToken, get, "/confirm-email/validate", "/confirm-email/validate", c.HandleValidateConfirmToken(cfg)},
 
-		http.Redirect(w, r, redirect, http.StatusTemporaryRedirect)
+		// Webhooks
+		{HandlerStripeWebhook, post, "/webhooks/stripe", "/webhooks/stripe", c.HandleStripeWebhook(cfg)},
+		{HandlerSESWebhook, post, "/webhooks/ses", "/webhooks/ses", c.HandleSESWebhook(cfg)},
+		{HandlerLeveeWebhook, post, "/webhooks/levee", "/webhooks/levee", c.HandleLeveeWebhook(cfg)},
+
+		// Health
+		{HandlerHealth, []string{http.MethodGet, http.MethodHead}, "/health", "/health", c.HandleHealth(cfg)},
 	}
-}
 
-// handleStripeWebhook handles Stripe webhook events.
-// POST /prefix/webhooks/stripe
-func (c *Client) handleStripeWebhook(cfg *HandlerConfig) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-			return
-		}
+	// Billing provider webhooks
+	for _, p := range cfg.BillingProviders {
+		path := "/webhooks/billing/" + p.Name()
+		all = append(all, handlerRoute{HandlerBillingWebhook, post, path, path, c.HandleBillingWebhook(p, cfg)})
+	}
 
-		body, err := io.ReadAll(r.Body)
-		if err != nil {
-			http.Error(w, "Failed to read body", http.StatusBadRequest)
-			return
-		}
+	// Inbound email (if a handler is provided)
+	if cfg.InboundEmailHandler != nil {
+		inbound := c.HandleInboundEmail(cfg)
+		all = append(all,
+			handlerRoute{HandlerInboundEmail, post, "/inbound/email", "/inbound/email", inbound},
+			// Mailgun forwards the raw message only to URLs ending in "mime"
+			handlerRoute{HandlerInboundEmail, post, "/inbound/email/mime", "/inbound/email/mime", inbound},
+		)
+	}
 
-		// Verify signature if secret is configured
-		if cfg.StripeWebhookSecret != "" {
-			signature := r.Header.Get("Stripe-Signature")
-			if !verifyStripeSignature(body, signature, cfg.StripeWebhookSecret) {
-				http.Error(w, "Invalid signature", http.StatusUnauthorized)
-				return
-			}
+	// WebSocket LLM chat (if LLM client provided)
+	if cfg.LLMClient != nil {
+		var wsOpts []WSOption
+		if cfg.WSCheckOrigin != nil {
+			wsOpts = append(wsOpts, WithCheckOrigin(cfg.WSCheckOrigin))
 		}
+		all = append(all, handlerRoute{HandlerChatWebSocket, get, "/ws/chat", "/ws/chat", c.HandleChatWebSocket(cfg.LLMClient, wsOpts...)})
+	}
 
-		// Forward to Levee API
-		ctx := r.Context()
-		err = c.ForwardStripeWebhook(ctx, body, r.Header.Get("Stripe-Signature"))
-		if err != nil {
-			http.Error(w, "Failed to process webhook", http.StatusInternalServerError)
-			return
+	routes := all[:0]
+	for _, rt := range all {
+		if cfg.Handlers&rt.set != 0 {
+			rt.handler = c.instrument(cfg, rt.set, rt.handler)
+			routes = append(routes, rt)
 		}
-
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(`{"received": true}`))
 	}
+	return routes
 }
 
-// handleSESWebhook handles AWS SES bounce/complaint notifications.
-// POST /prefix/webhooks/ses
-func (c *Client) handleSESWebhook() http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-			return
-		}
+// Handler returns an http.Handler serving all Levee endpoints under prefix.
+// Mount it on any router that accepts an http.Handler:
+//
+//	mux.Handle("/levee/", client.Handler("/levee"))
+func (c *Client) Handler(prefix string, opts ...HandlerOption) http.Handler {
+	mux := http.NewServeMux()
+	c.RegisterHandlers(mux, prefix, opts...)
+	return mux
+}
 
-		body, err := io.ReadAll(r.Body)
-		if err != nil {
-			http.Error(w, "Failed to read body", http.StatusBadRequest)
-			return
-		}
+// Wrap applies the configured middleware chain to h. The first middleware is the outermost.
+// Use this when mounting individual Handle* handlers on a custom router.
+func (cfg *HandlerConfig) Wrap(h http.Handler) http.Handler {
+	for i := len(cfg.Middleware) - 1; i >= 0; i-- {
+		h = cfg.Middleware[i](h)
+	}
+	return h
+}
 
-		// Check for SNS subscription confirmation
-		var snsMessage struct {
-			Type         string `json:"Type"`
-			SubscribeURL string `json:"SubscribeURL"`
-		}
-		if err := json.Unmarshal(body, &snsMessage); err == nil {
-			if snsMessage.Type == "SubscriptionConfirmation" && snsMessage.SubscribeURL != "" {
-				// Confirm SNS subscription
-				resp, err := http.Get(snsMessage.SubscribeURL)
-				if err == nil {
-					resp.Body.Close()
-				}
-				w.WriteHeader(http.StatusOK)
-				return
-			}
-		}
+// HandlerResult is the response body of the redirecting handlers when JSONResponses is enabled.
+type HandlerResult struct {
+	Success     bool   `json:"success"`
+	RedirectURL string `json:"redirect_url,omitempty"`
+	// ConfirmRequired is set when the action must be repeated as a POST (two-step unsubscribe).
+	ConfirmRequired bool `json:"confirm_required,omitempty"`
+}
 
-		// Forward to Levee API
-		ctx := r.Context()
-		err = c.ForwardSESWebhook(ctx, body)
-		if err != nil {
-			http.Error(w, "Failed to process webhook", http.StatusInternalServerError)
-			return
-		}
+// redirect sends the user to url, or writes a HandlerResult in JSON mode.
+// GET requests use the configured RedirectStatus; POST requests use 303 See Other.
+func (cfg *HandlerConfig) redirect(w http.ResponseWriter, r *http.Request, url string, success bool) {
+	if cfg.JSONResponses {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		json.NewEncoder(w).Encode(HandlerResult{Success: success, RedirectURL: url})
+		return
+	}
 
-		w.WriteHeader(http.StatusOK)
+	status := cfg.RedirectStatus
+	if r.Method == http.MethodPost {
+		status = http.StatusSeeOther
+	} else if status == 0 {
+		status = http.StatusTemporaryRedirect
+	}
+	http.Redirect(w, r, url, status)
+}
+
+// newTrackingEvent builds a tracking event from the request and runs the EnrichEvent hook.
+func (cfg *HandlerConfig) newTrackingEvent(r *http.Request, eventType, token string) *TrackingEvent {
+	event := &TrackingEvent{
+		Type:      eventType,
+		Token:     token,
+		IP:        cfg.ClientIP(r),
+		UserAgent: r.UserAgent(),
+	}
+	if eventType == TrackingEventClick {
+		event.URL = r.URL.Query().Get("url")
 	}
+	if cfg.EnrichEvent != nil {
+		cfg.EnrichEvent(r, event)
+	}
+	return event
 }
 
 // extractToken extracts the token from a URL path after the given prefix.
@@ -343,10 +641,10 @@ func extractToken(path, prefix string) string {
 }
 
 // getToken extracts token from request using multiple methods:
-// 1. r.PathValue("token") - Go 1.22+ / go-zero
-// 2. URL path extraction - http.ServeMux fallback
+// 1. r.PathValue("token") - Go 1.22+ http.ServeMux patterns
+// 2. URL path extraction - other routers, including go-zero
 func getToken(r *http.Request, pathPrefix string) string {
-	// Try PathValue first (Go 1.22+ / go-zero)
+	// Try PathValue first (Go 1.22+ http.ServeMux)
 	if token := r.PathValue("token"); token != "" {
 		return token
 	}
@@ -359,33 +657,31 @@ func getToken(r *http.Request, pathPrefix string) string {
 // ============================================================================
 
 // HandleOpenTracking returns a handler for email open tracking.
-// Serves a 1x1 transparent GIF and records the open event.
+// Serves a 1x1 transparent pixel (see WithPixelFormat) and records the open event.
+// Repeat opens of the same token from the same IP and user agent within
+// OpenDedupWindow are not recorded.
 // Route: GET /your-prefix/e/o/:token
 func (c *Client) HandleOpenTracking(cfg *HandlerConfig) http.HandlerFunc {
+	var dedup *keyedLimiter
+	if cfg.OpenDedupWindow > 0 {
+		dedup = newKeyedLimiter(cfg.OpenDedupWindow)
+	}
+
 	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			cfg.renderError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed)
 			return
 		}
 
+		// Record open asynchronously, skipping repeat fetches within the dedup window.
+		// Bad tokens and HEAD requests still get the pixel so images never appear broken.
 		token := getToken(r, "/e/o/")
-		if token == "" {
-			http.Error(w, "Missing token", http.StatusBadRequest)
-			return
+		if token != "" && r.Method == http.MethodGet &&
+			(dedup == nil || dedup.allow(token+"|"+cfg.ClientIP(r)+"|"+r.UserAgent())) {
+			c.recordInBackground(cfg.newTrackingEvent(r, TrackingEventOpen, token))
 		}
 
-		// Record open asynchronously
-		go func() {
-			ctx := context.Background()
-			c.RecordOpen(ctx, token)
-		}()
-
-		// Return 1x1 transparent GIF
-		w.Header().Set("Content-Type", "image/gif")
-		w.Header().Set("Cache-Control", "no-store, no-cache, must-revalidate")
-		w.Header().Set("Pragma", "no-cache")
-		w.Header().Set("Expires", "0")
-		w.Write(transparentGIF)
+		writePixel(cfg.PixelFormat, w, r)
 	}
 }
 
@@ -395,54 +691,81 @@ func (c *Client) HandleOpenTracking(cfg *HandlerConfig) http.HandlerFunc {
 func (c *Client) HandleClickTracking(cfg *HandlerConfig) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			cfg.renderError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed)
 			return
 		}
 
 		token := getToken(r, "/e/c/")
 		if token == "" {
-			http.Error(w, "Missing token", http.StatusBadRequest)
+			cfg.renderError(w, r, http.StatusBadRequest, ErrCodeMissingToken)
 			return
 		}
 
 		redirectURL := r.URL.Query().Get("url")
 		if redirectURL == "" {
-			http.Error(w, "Missing url parameter", http.StatusBadRequest)
+			cfg.renderError(w, r, http.StatusBadRequest, ErrCodeMissingURL)
 			return
 		}
 
 		// Record click asynchronously
-		go func() {
-			ctx := context.Background()
-			c.RecordClick(ctx, token, redirectURL)
-		}()
+		c.recordInBackground(cfg.newTrackingEvent(r, TrackingEventClick, token))
 
 		// Redirect to destination
-		http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+		cfg.redirect(w, r, redirectURL, true)
 	}
 }
 
 // HandleUnsubscribe returns a handler for one-click unsubscribe.
-// Records the unsubscribe and redirects to the configured URL.
-// Route: GET /your-prefix/e/u/:token
+// Records the unsubscribe and redirects to the configured URL, or renders the
+// reason survey when enabled with WithUnsubscribeSurvey (submitted via POST).
+// GET requests from link scanners and prefetchers (see IsPrefetchRequest), and all
+// GET requests when WithUnsubscribeConfirmation is set, render a confirm page instead;
+// POST performs the unsubscribe, including RFC 8058 List-Unsubscribe-Post requests.
+// Route: GET, POST /your-prefix/e/u/:token
 func (c *Client) HandleUnsubscribe(cfg *HandlerConfig) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		if r.Method != http.MethodGet && r.Method != http.MethodPost {
+			cfg.renderError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed)
 			return
 		}
 
 		token := getToken(r, "/e/u/")
 		if token == "" {
-			http.Error(w, "Missing token", http.StatusBadRequest)
+			cfg.renderError(w, r, http.StatusBadRequest, ErrCodeMissingToken)
+			return
+		}
+
+		if r.Method == http.MethodPost {
+			r.Body = http.MaxBytesReader(w, r.Body, 16<<10)
+			if err := r.ParseForm(); err != nil {
+				cfg.renderError(w, r, http.StatusBadRequest, ErrCodeInvalidBody)
+				return
+			}
+
+			// Reason survey submission
+			if cfg.UnsubscribeSurvey && r.PostForm.Has("reason") {
+				c.handleUnsubscribeReason(cfg, w, r, token)
+				return
+			}
+		} else if cfg.UnsubscribeConfirmation || IsPrefetchRequest(r) {
+			renderUnsubscribeConfirm(cfg, w, r)
 			return
 		}
 
 		// Record unsubscribe (synchronous - we want to confirm it worked)
 		ctx := r.Context()
-		_ = c.RecordUnsubscribe(ctx, token)
+		err := c.RecordTrackingEvent(ctx, cfg.newTrackingEvent(r, TrackingEventUnsubscribe, token))
+		if err != nil {
+			cfg.renderError(w, r, http.StatusInternalServerError, ErrCodeUnsubscribeFailed)
+			return
+		}
+
+		if cfg.UnsubscribeSurvey && !cfg.JSONResponses {
+			renderUnsubscribeSurvey(cfg, w, r)
+			return
+		}
 
-		http.Redirect(w, r, cfg.UnsubscribeRedirect, http.StatusTemporaryRedirect)
+		cfg.redirect(w, r, cfg.UnsubscribeRedirect, true)
 	}
 }
 
@@ -451,20 +774,20 @@ func (c *Client) HandleUnsubscribe(cfg *HandlerConfig) http.HandlerFunc {
 func (c *Client) HandleConfirmEmail(cfg *HandlerConfig) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			cfg.renderError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed)
 			return
 		}
 
 		token := r.URL.Query().Get("token")
 		if token == "" {
-			http.Error(w, "Missing token", http.StatusBadRequest)
+			cfg.renderError(w, r, http.StatusBadRequest, ErrCodeMissingToken)
 			return
 		}
 
 		ctx := r.Context()
 		resp, err := c.ConfirmEmail(ctx, token)
 		if err != nil {
-			http.Redirect(w, r, cfg.ConfirmExpiredRedirect, http.StatusTemporaryRedirect)
+			cfg.redirect(w, r, cfg.ConfirmExpiredRedirect, false)
 			return
 		}
 
@@ -473,23 +796,123 @@ func (c *Client) HandleConfirmEmail(cfg *HandlerConfig) http.HandlerFunc {
 			redirect = resp.RedirectURL
 		}
 
-		http.Redirect(w, r, redirect, http.StatusTemporaryRedirect)
+		cfg.redirect(w, r, redirect, true)
+	}
+}
+
+// HandleResendConfirmation returns a handler that sends a fresh double opt-in email,
+// for users landing on the confirm-expired page. Accepts an "email" form field or a
+// JSON body {"email": "..."}; resends to the same address are rate limited.
+// Form posts redirect to ConfirmResentRedirect; JSON requests receive {"success": true}.
+// Route: POST /your-prefix/confirm-email/resend
+func (c *Client) HandleResendConfirmation(cfg *HandlerConfig) http.HandlerFunc {
+	limiter := newKeyedLimiter(cfg.ResendConfirmationInterval)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			cfg.renderError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed)
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, 16<<10)
+		isJSON := strings.HasPrefix(r.Header.Get("Content-Type"), "application/json")
+
+		var email string
+		if isJSON {
+			var body struct {
+				Email string `json:"email"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				cfg.renderError(w, r, http.StatusBadRequest, ErrCodeInvalidBody)
+				return
+			}
+			email = body.Email
+		} else {
+			if err := r.ParseForm(); err != nil {
+				cfg.renderError(w, r, http.StatusBadRequest, ErrCodeInvalidBody)
+				return
+			}
+			email = r.PostForm.Get("email")
+		}
+
+		email = strings.ToLower(strings.TrimSpace(email))
+		if email == "" {
+			cfg.renderError(w, r, http.StatusBadRequest, ErrCodeMissingEmail)
+			return
+		}
+
+		if !limiter.allow(email) {
+			cfg.renderError(w, r, http.StatusTooManyRequests, ErrCodeRateLimited)
+			return
+		}
+
+		if err := c.ResendConfirmation(r.Context(), email); err != nil {
+			cfg.renderError(w, r, http.StatusInternalServerError, ErrCodeResendFailed)
+			return
+		}
+
+		if isJSON {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"success": true}`))
+			return
+		}
+		cfg.redirect(w, r, cfg.ConfirmResentRedirect, true)
+	}
+}
+
+// HandleValidateConfirmToken returns a handler that checks a confirmation token without
+// consuming it, so SPAs can show the right UI before the user clicks "confirm".
+// Responds with JSON {valid, expires_at, email_masked}, or 403 with {valid: false,
+// reason} for tokens that are expired, used or unknown.
+// Route: GET /your-prefix/confirm-email/validate?token=...
+func (c *Client) HandleValidateConfirmToken(cfg *HandlerConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			cfg.renderError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed)
+			return
+		}
+
+		token := r.URL.Query().Get("token")
+		if token == "" {
+			cfg.renderError(w, r, http.StatusBadRequest, ErrCodeMissingToken)
+			return
+		}
+
+		result, err := c.ValidateConfirmToken(r.Context(), token)
+		if err != nil {
+			// The API rejects malformed and unknown tokens with a 4xx
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode >= 400 && apiErr.StatusCode < 500 {
+				cfg.renderError(w, r, http.StatusForbidden, ErrCodeInvalidToken)
+				return
+			}
+			cfg.renderError(w, r, http.StatusBadGateway, ErrCodeValidationFailed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		if !result.Valid {
+			w.WriteHeader(http.StatusForbidden)
+		}
+		json.NewEncoder(w).Encode(result)
 	}
 }
 
 // HandleStripeWebhook returns a handler for Stripe webhook events.
-// Verifies signature and forwards to Levee API.
+// Verifies signature, forwards to Levee API, then runs the callbacks
+// registered with WithStripeEventHandler.
 // Route: POST /your-prefix/webhooks/stripe
 func (c *Client) HandleStripeWebhook(cfg *HandlerConfig) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			cfg.renderError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed)
 			return
 		}
 
 		body, err := io.ReadAll(r.Body)
 		if err != nil {
-			http.Error(w, "Failed to read body", http.StatusBadRequest)
+			cfg.renderError(w, r, http.StatusBadRequest, ErrCodeInvalidBody)
 			return
 		}
 
@@ -497,7 +920,7 @@ func (c *Client) HandleStripeWebhook(cfg *HandlerConfig) http.HandlerFunc {
 		if cfg.StripeWebhookSecret != "" {
 			signature := r.Header.Get("Stripe-Signature")
 			if !verifyStripeSignature(body, signature, cfg.StripeWebhookSecret) {
-				http.Error(w, "Invalid signature", http.StatusUnauthorized)
+				cfg.renderError(w, r, http.StatusUnauthorized, ErrCodeInvalidSignature)
 				return
 			}
 		}
@@ -506,10 +929,26 @@ func (c *Client) HandleStripeWebhook(cfg *HandlerConfig) http.HandlerFunc {
 		ctx := r.Context()
 		err = c.ForwardStripeWebhook(ctx, body, r.Header.Get("Stripe-Signature"))
 		if err != nil {
-			http.Error(w, "Failed to process webhook", http.StatusInternalServerError)
+			cfg.renderError(w, r, http.StatusInternalServerError, ErrCodeWebhookFailed)
 			return
 		}
 
+		// Levee has the event now, so it is acknowledged even if parsing or a
+		// callback fails below: a Stripe retry would forward it again.
+		// Typed callbacks run after forwarding, so dunning emails and access
+		// changes stay in step.
+		event, err := ParseStripeEvent(body)
+		if err != nil {
+			if len(cfg.StripeEventHandlers) > 0 {
+				c.webhookCallbackFailed("stripe_webhook", "", "", fmt.Errorf("invalid event payload: %w", err))
+			}
+		} else {
+			c.invalidateStripeEvent(event)
+			if err := dispatchStripeEvent(ctx, cfg.StripeEventHandlers, event); err != nil {
+				c.webhookCallbackFailed("stripe_webhook", event.ID, event.Type, err)
+			}
+		}
+
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(`{"received": true}`))
 	}
@@ -521,13 +960,13 @@ func (c *Client) HandleStripeWebhook(cfg *HandlerConfig) http.HandlerFunc {
 func (c *Client) HandleSESWebhook(cfg *HandlerConfig) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			cfg.renderError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed)
 			return
 		}
 
 		body, err := io.ReadAll(r.Body)
 		if err != nil {
-			http.Error(w, "Failed to read body", http.StatusBadRequest)
+			cfg.renderError(w, r, http.StatusBadRequest, ErrCodeInvalidBody)
 			return
 		}
 
@@ -552,7 +991,7 @@ func (c *Client) HandleSESWebhook(cfg *HandlerConfig) http.HandlerFunc {
 		ctx := r.Context()
 		err = c.ForwardSESWebhook(ctx, body)
 		if err != nil {
-			http.Error(w, "Failed to process webhook", http.StatusInternalServerError)
+			cfg.renderError(w, r, http.StatusInternalServerError, ErrCodeWebhookFailed)
 			return
 		}
 
@@ -560,6 +999,59 @@ func (c *Client) HandleSESWebhook(cfg *HandlerConfig) http.HandlerFunc {
 	}
 }
 
+// maxLeveeWebhookSize is the largest Levee webhook payload accepted.
+const maxLeveeWebhookSize = 1 << 20
+
+// HandleLeveeWebhook returns a handler for events pushed by Levee (deliveries, opens,
+// clicks, bounces, contact changes). Verifies the Levee-Signature header, parses the
+// typed event, and dispatches it to handlers registered with WithEventHandler and
+// Client.On. Without WithLeveeWebhookSecret every delivery is rejected.
+// Route: POST /your-prefix/webhooks/levee
+func (c *Client) HandleLeveeWebhook(cfg *HandlerConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			cfg.renderError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed)
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxLeveeWebhookSize)
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			cfg.renderError(w, r, http.StatusBadRequest, ErrCodeInvalidBody)
+			return
+		}
+
+		if cfg.LeveeWebhookSecret == "" {
+			c.log().Warn("rejected levee webhook: no signing secret configured")
+			cfg.renderError(w, r, http.StatusUnauthorized, ErrCodeInvalidSignature)
+			return
+		}
+		if !cfg.verifyLeveeSignature(body, r.Header.Get(LeveeSignatureHeader)) {
+			cfg.renderError(w, r, http.StatusUnauthorized, ErrCodeInvalidSignature)
+			return
+		}
+
+		event, err := ParseWebhookEvent(body)
+		if err != nil {
+			cfg.renderError(w, r, http.StatusBadRequest, ErrCodeInvalidPayload)
+			return
+		}
+
+		ctx := r.Context()
+		if err := dispatchEvent(ctx, cfg.LeveeEventHandlers, event); err != nil {
+			cfg.renderError(w, r, http.StatusInternalServerError, ErrCodeWebhookFailed)
+			return
+		}
+		if err := c.dispatch(ctx, event); err != nil {
+			cfg.renderError(w, r, http.StatusInternalServerError, ErrCodeWebhookFailed)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"received": true}`))
+	}
+}
+
 // verifyStripeSignature verifies a Stripe webhook signature.
 func verifyStripeSignature(payload []byte, signature, secret string) bool {
 	if signature == "" {
@@ -567,7 +1059,36 @@ func verifyStripeSignature(payload []byte, signature, secret string) bool {
 	}
 
 	// Parse signature header
-	var timestamp, sig string
+	timestamp, sig := parseSignatureHeader(signature)
+	if timestamp == "" || sig == "" {
+		return false
+	}
+
+	// Compute expected signature
+	signedPayload := timestamp + "." + string(payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signedPayload))
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	return hmac.Equal([]byte(expected), []byte(sig))
+}
+
+// verifyLeveeSignature checks a Levee signature against the current and previous webhook secrets.
+func (cfg *HandlerConfig) verifyLeveeSignature(payload []byte, signature string) bool {
+	if VerifyWebhookSignature(payload, signature, cfg.LeveeWebhookSecret, DefaultWebhookTolerance) {
+		return true
+	}
+	for _, secret := range cfg.LeveeWebhookPreviousSecrets {
+		if secret != "" && VerifyWebhookSignature(payload, signature, secret, DefaultWebhookTolerance) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseSignatureHeader extracts the timestamp and v1 signature from a
+// "t=...,v1=..." signature header (Stripe and Levee use the same format).
+func parseSignatureHeader(signature string) (timestamp, sig string) {
 	for _, part := range strings.Split(signature, ",") {
 		kv := strings.SplitN(part, "=", 2)
 		if len(kv) != 2 {
@@ -580,49 +1101,67 @@ func verifyStripeSignature(payload []byte, signature, secret string) bool {
 			sig = kv[1]
 		}
 	}
+	return timestamp, sig
+}
 
-	if timestamp == "" || sig == "" {
-		return false
-	}
+// Tracking API methods
 
-	// Compute expected signature
-	signedPayload := timestamp + "." + string(payload)
-	mac := hmac.New(sha256.New, []byte(secret))
-	mac.Write([]byte(signedPayload))
-	expected := hex.EncodeToString(mac.Sum(nil))
+// Tracking event types.
+const (
+	TrackingEventOpen        = "open"
+	TrackingEventClick       = "click"
+	TrackingEventUnsubscribe = "unsubscribe"
+)
 
-	return hmac.Equal([]byte(expected), []byte(sig))
+// TrackingEvent is an email tracking event recorded by the embedded handlers.
+// HandlerConfig.EnrichEvent can attach custom fields before it is sent to Levee.
+type TrackingEvent struct {
+	Type      string            `json:"-"`
+	Token     string            `json:"token"`
+	URL       string            `json:"url,omitempty"`
+	IP        string            `json:"ip,omitempty"`
+	UserAgent string            `json:"user_agent,omitempty"`
+	Fields    map[string]string `json:"fields,omitempty"`
 }
 
-// Tracking API methods
+// RecordTrackingEvent records an open, click, or unsubscribe event including
+// request metadata and custom fields.
+func (c *Client) RecordTrackingEvent(ctx context.Context, event *TrackingEvent) error {
+	switch event.Type {
+	case TrackingEventOpen, TrackingEventClick, TrackingEventUnsubscribe:
+	default:
+		return fmt.Errorf("unknown tracking event type %q", event.Type)
+	}
 
-// RecordOpen records an email open event.
-func (c *Client) RecordOpen(ctx context.Context, token string) error {
-	resp, err := c.doRequest(ctx, http.MethodPost, "/sdk/v1/tracking/open", map[string]string{
-		"token": token,
-	})
+	resp, err := c.doRequest(ctx, http.MethodPost, "/sdk/v1/tracking/"+event.Type, event)
 	if err != nil {
 		return err
 	}
 	return decodeResponse(resp, nil)
 }
 
+// RecordOpen records an email open event.
+func (c *Client) RecordOpen(ctx context.Context, token string) error {
+	return c.RecordTrackingEvent(ctx, &TrackingEvent{Type: TrackingEventOpen, Token: token})
+}
+
 // RecordClick records an email click event.
 func (c *Client) RecordClick(ctx context.Context, token, url string) error {
-	resp, err := c.doRequest(ctx, http.MethodPost, "/sdk/v1/tracking/click", map[string]string{
-		"token": token,
-		"url":   url,
-	})
-	if err != nil {
-		return err
-	}
-	return decodeResponse(resp, nil)
+	return c.RecordTrackingEvent(ctx, &TrackingEvent{Type: TrackingEventClick, Token: token, URL: url})
 }
 
 // RecordUnsubscribe records an unsubscribe event.
 func (c *Client) RecordUnsubscribe(ctx context.Context, token string) error {
-	resp, err := c.doRequest(ctx, http.MethodPost, "/sdk/v1/tracking/unsubscribe", map[string]string{
-		"token": token,
+	return c.RecordTrackingEvent(ctx, &TrackingEvent{Type: TrackingEventUnsubscribe, Token: token})
+}
+
+// RecordUnsubscribeReason records why a contact unsubscribed.
+// reason is one of the UnsubscribeReason* values; comment is optional free text.
+func (c *Client) RecordUnsubscribeReason(ctx context.Context, token, reason, comment string) error {
+	resp, err := c.doRequest(ctx, http.MethodPost, "/sdk/v1/tracking/unsubscribe/reason", map[string]string{
+		"token":   token,
+		"reason":  reason,
+		"comment": comment,
 	})
 	if err != nil {
 		return err
@@ -654,6 +1193,37 @@ func (c *Client) ConfirmEmail(ctx context.Context, token string) (*ConfirmEmailR
 	return &result, nil
 }
 
+// ValidateTokenResponse describes a confirmation token checked without consuming it.
+type ValidateTokenResponse struct {
+	Valid       bool   `json:"valid"`
+	ExpiresAt   string `json:"expires_at,omitempty"`
+	EmailMasked string `json:"email_masked,omitempty"` // e.g. "j***@example.com"
+	Reason      string `json:"reason,omitempty"`       // "expired", "used", "not_found" when invalid
+}
+
+// ValidateConfirmToken checks a double opt-in confirmation token without consuming it.
+func (c *Client) ValidateConfirmToken(ctx context.Context, token string) (*ValidateTokenResponse, error) {
+	query := url.Values{}
+	query.Set("token", token)
+
+	var result ValidateTokenResponse
+	if err := c.do(ctx, http.MethodGet, "/sdk/v1/tracking/confirm/validate", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ResendConfirmation sends a fresh double opt-in confirmation email to a pending contact.
+func (c *Client) ResendConfirmation(ctx context.Context, email string) error {
+	resp, err := c.doRequest(ctx, http.MethodPost, "/sdk/v1/tracking/confirm/resend", map[string]string{
+		"email": email,
+	})
+	if err != nil {
+		return err
+	}
+	return decodeResponse(resp, nil)
+}
+
 // ForwardStripeWebhook forwards a Stripe webhook payload to Levee.
 func (c *Client) ForwardStripeWebhook(ctx context.Context, payload []byte, signature string) error {
 	// Use webhookURL which strips /sdk/v1 from baseURL
@@ -0,0 +1,150 @@
+package levee
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// Health status values reported by HandleHealth.
+const (
+	HealthStatusOK       = "ok"
+	HealthStatusDegraded = "degraded"
+	HealthStatusDown     = "down"
+)
+
+// healthCheckTimeout bounds each component check.
+const healthCheckTimeout = 5 * time.Second
+
+// HealthResponse is the JSON body returned by HandleHealth.
+type HealthResponse struct {
+	Status     string                     `json:"status"`
+	Components map[string]ComponentHealth `json:"components"`
+}
+
+// ComponentHealth is the status of a single dependency. Failure details are
+// logged rather than returned, since the endpoint is unauthenticated.
+type ComponentHealth struct {
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latency_ms"`
+}
+
+// Ping checks connectivity to the Levee API.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.do(ctx, http.MethodGet, "/sdk/v1/health", nil, nil, nil)
+}
+
+// checkConnectivity connects to the LLM gateway and waits until every gRPC
+// connection is ready.
+func (c *LLMClient) checkConnectivity(ctx context.Context) error {
+	if err := c.connect(); err != nil {
+		return err
+	}
+
+	if c.usingHTTP() {
+		// The HTTPS transport is ready when the API answers
+		_, err := c.fetchConfig(ctx)
+		return err
+	}
+
+	c.mu.Lock()
+	pool := c.pool
+	c.mu.Unlock()
+	if pool == nil {
+		return fmt.Errorf("LLM client is closed")
+	}
+
+	for _, conn := range pool.conns {
+		conn.Connect()
+	}
+	for _, conn := range pool.conns {
+		if err := waitReady(ctx, conn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitReady waits until a gRPC connection is ready.
+func waitReady(ctx context.Context, conn *grpc.ClientConn) error {
+	for {
+		state := conn.GetState()
+		if state == connectivity.Ready {
+			return nil
+		}
+		if state == connectivity.Shutdown {
+			return fmt.Errorf("LLM connection is shut down")
+		}
+		if !conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("LLM gateway not ready: %s", state)
+		}
+	}
+}
+
+// HandleHealth returns a handler reporting the health of the embedded integration
+// for load balancer and readiness probes. It checks the Levee API and, when an LLM
+// client is configured, the LLM gRPC gateway. Responds 200 when every component is
+// healthy and 503 otherwise.
+// Route: GET or HEAD /your-prefix/health
+func (c *Client) HandleHealth(cfg *HandlerConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			cfg.renderError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed)
+			return
+		}
+
+		resp := HealthResponse{
+			Status:     HealthStatusOK,
+			Components: make(map[string]ComponentHealth),
+		}
+
+		resp.Components["api"] = c.checkComponent(r.Context(), "api", c.Ping)
+		if cfg.LLMClient != nil {
+			resp.Components["llm"] = c.checkComponent(r.Context(), "llm", cfg.LLMClient.Ping)
+		}
+
+		status := http.StatusOK
+		for name, component := range resp.Components {
+			if component.Status == HealthStatusOK {
+				continue
+			}
+			status = http.StatusServiceUnavailable
+			if name == "api" {
+				resp.Status = HealthStatusDown
+			} else if resp.Status == HealthStatusOK {
+				resp.Status = HealthStatusDegraded
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		if r.Method == http.MethodGet {
+			json.NewEncoder(w).Encode(resp)
+		}
+	}
+}
+
+// checkComponent runs a health check with a timeout and records its latency.
+// Errors are logged, not reported to the caller.
+func (c *Client) checkComponent(ctx context.Context, name string, check func(ctx context.Context) error) ComponentHealth {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	result := ComponentHealth{
+		Status:    HealthStatusOK,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Status = HealthStatusDown
+		c.log().Warn("health check failed", "component", name, "error", err)
+	}
+	return result
+}
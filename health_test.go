@@ -0,0 +1,40 @@
+package levee
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHealthRouteMethods(t *testing.T) {
+	client, err := NewClient("key", "https://levee.test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	methods := map[string]bool{}
+	for _, r := range client.GoZeroRoutes("/levee") {
+		if r.Path == "/levee/health" {
+			methods[r.Method] = true
+		}
+	}
+	for _, method := range []string{http.MethodGet, http.MethodHead} {
+		if !methods[method] {
+			t.Errorf("GoZeroRoutes has no %s /levee/health route", method)
+		}
+	}
+
+}
+
+func TestHandleHealthHead(t *testing.T) {
+	client, _ := newTestClient(t, http.StatusOK, `{}`)
+
+	w := httptest.NewRecorder()
+	client.HandleHealth(NewHandlerConfig())(w, httptest.NewRequest(http.MethodHead, "/levee/health", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("HEAD /levee/health = %d, want %d", w.Code, http.StatusOK)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("HEAD /levee/health has a %d byte body", w.Body.Len())
+	}
+}
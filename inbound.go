@@ -0,0 +1,677 @@
+package levee
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// maxInboundEmailSize is the largest inbound message HandleInboundEmail accepts.
+const maxInboundEmailSize = 30 << 20
+
+// InboundAttachment is a file attached to, or embedded in, an inbound email.
+type InboundAttachment struct {
+	Filename    string
+	ContentType string
+	// ContentID is set for inline parts referenced from HTML as cid:...
+	ContentID string
+	Inline    bool
+	Data      []byte
+}
+
+// AuthResult is one authentication check reported for an inbound email.
+type AuthResult struct {
+	Method string // "dkim", "spf", "dmarc"
+	Result string // "pass", "fail", "softfail", "neutral", "none", ...
+	// Domain is the signing (dkim), envelope (spf), or header From (dmarc) domain.
+	Domain string
+}
+
+// InboundMessage is a parsed inbound email.
+type InboundMessage struct {
+	MessageID  string
+	InReplyTo  string
+	References []string
+	From       *mail.Address
+	To         []*mail.Address
+	Cc         []*mail.Address
+	ReplyTo    []*mail.Address
+	Subject    string
+	Date       time.Time
+	Header     mail.Header
+	Text       string
+	HTML       string
+
+	Attachments []InboundAttachment
+
+	// DKIM holds one result per DKIM signature; SPF and DMARC are the overall results.
+	// They come from the SES receipt verdicts, or from Authentication-Results
+	// headers added by a trusted receiving server (see ParseInboundEmail).
+	DKIM  []AuthResult
+	SPF   string
+	DMARC string
+
+	// Raw is the original MIME message.
+	Raw []byte
+}
+
+// DKIMPassed reports whether any DKIM signature verified, optionally for a
+// specific domain (empty matches any).
+func (m *InboundMessage) DKIMPassed(domain string) bool {
+	for _, r := range m.DKIM {
+		if r.Result == "pass" && (domain == "" || strings.EqualFold(r.Domain, domain)) {
+			return true
+		}
+	}
+	return false
+}
+
+// InboundEmailHandler handles a parsed inbound email. Returning an error makes
+// HandleInboundEmail respond with a 5xx so the provider retries the delivery.
+type InboundEmailHandler func(ctx context.Context, msg *InboundMessage) error
+
+// ParseInboundEmail parses a raw MIME message. Authentication-Results headers
+// are read only when their authserv-id is one of trustedAuthServIDs, the
+// receiving servers you control (RFC 8601 section 5); any other header may have
+// been written by the sender, so with none given DKIM, SPF, and DMARC are empty.
+func ParseInboundEmail(raw []byte, trustedAuthServIDs ...string) (*InboundMessage, error) {
+	m, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse email: %w", err)
+	}
+
+	msg := &InboundMessage{
+		MessageID:  trimAngle(m.Header.Get("Message-Id")),
+		InReplyTo:  trimAngle(m.Header.Get("In-Reply-To")),
+		References: parseMessageIDs(m.Header.Get("References")),
+		Subject:    decodeHeader(m.Header.Get("Subject")),
+		Header:     m.Header,
+		Raw:        raw,
+	}
+	msg.From, _ = mail.ParseAddress(m.Header.Get("From"))
+	msg.To, _ = m.Header.AddressList("To")
+	msg.Cc, _ = m.Header.AddressList("Cc")
+	msg.ReplyTo, _ = m.Header.AddressList("Reply-To")
+	msg.Date, _ = m.Header.Date()
+
+	for _, h := range m.Header["Authentication-Results"] {
+		authservID, results := parseAuthResults(h)
+		if !slices.ContainsFunc(trustedAuthServIDs, func(id string) bool { return strings.EqualFold(id, authservID) }) {
+			continue
+		}
+		for _, r := range results {
+			switch r.Method {
+			case "dkim":
+				msg.DKIM = append(msg.DKIM, r)
+			case "spf":
+				msg.SPF = r.Result
+			case "dmarc":
+				msg.DMARC = r.Result
+			}
+		}
+	}
+
+	if err := msg.readPart(m.Header.Get("Content-Type"), m.Header.Get("Content-Transfer-Encoding"), m.Header.Get("Content-Disposition"), m.Header.Get("Content-Id"), m.Body); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// readPart reads one MIME part into the message, recursing into multiparts.
+func (msg *InboundMessage) readPart(contentType, encoding, disposition, contentID string, body io.Reader) error {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read mime part: %w", err)
+			}
+			err = msg.readPart(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"),
+				part.Header.Get("Content-Disposition"), part.Header.Get("Content-Id"), part)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	data, err := io.ReadAll(decodeTransferEncoding(encoding, body))
+	if err != nil {
+		return fmt.Errorf("failed to decode mime part: %w", err)
+	}
+
+	dispType, dispParams, _ := mime.ParseMediaType(disposition)
+	filename := decodeHeader(dispParams["filename"])
+	if filename == "" {
+		filename = decodeHeader(params["name"])
+	}
+
+	isText := mediaType == "text/plain" || mediaType == "text/html"
+	if isText && dispType != "attachment" && filename == "" {
+		text := decodeCharset(params["charset"], data)
+		if mediaType == "text/html" && msg.HTML == "" {
+			msg.HTML = text
+			return nil
+		}
+		if mediaType == "text/plain" && msg.Text == "" {
+			msg.Text = text
+			return nil
+		}
+	}
+
+	msg.Attachments = append(msg.Attachments, InboundAttachment{
+		Filename:    filename,
+		ContentType: mediaType,
+		ContentID:   trimAngle(contentID),
+		Inline:      dispType == "inline" || (dispType == "" && contentID != ""),
+		Data:        data,
+	})
+	return nil
+}
+
+// decodeTransferEncoding decodes a Content-Transfer-Encoding.
+func decodeTransferEncoding(encoding string, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, r)
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	}
+	return r
+}
+
+// decodeCharset converts text in charset to UTF-8, returning it unchanged if
+// the charset is unknown.
+func decodeCharset(charset string, data []byte) string {
+	charset = strings.ToLower(strings.TrimSpace(charset))
+	if charset == "" || charset == "utf-8" || charset == "us-ascii" {
+		return string(data)
+	}
+	enc, err := htmlindex.Get(charset)
+	if err != nil {
+		return string(data)
+	}
+	out, err := enc.NewDecoder().Bytes(data)
+	if err != nil {
+		return string(data)
+	}
+	return string(out)
+}
+
+// headerDecoder decodes RFC 2047 encoded words in any charset htmlindex knows.
+var headerDecoder = &mime.WordDecoder{
+	CharsetReader: func(charset string, input io.Reader) (io.Reader, error) {
+		enc, err := htmlindex.Get(charset)
+		if err != nil {
+			return nil, err
+		}
+		return enc.NewDecoder().Reader(input), nil
+	},
+}
+
+// decodeHeader decodes RFC 2047 encoded words, returning s unchanged on error.
+func decodeHeader(s string) string {
+	decoded, err := headerDecoder.DecodeHeader(s)
+	if err != nil {
+		return s
+	}
+	return decoded
+}
+
+// trimAngle strips the angle brackets around a message ID.
+func trimAngle(s string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(s), "<"), ">")
+}
+
+// parseMessageIDs parses a References or In-Reply-To header into message IDs.
+func parseMessageIDs(s string) []string {
+	var ids []string
+	for _, f := range strings.Fields(s) {
+		if id := trimAngle(f); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// parseAuthResults parses an RFC 8601 Authentication-Results header value into
+// the authserv-id of the server that added it and its results.
+func parseAuthResults(header string) (string, []AuthResult) {
+	clauses := strings.Split(header, ";")
+	var authservID string
+	if fields := strings.Fields(clauses[0]); len(fields) > 0 {
+		authservID = fields[0]
+	}
+
+	var results []AuthResult
+	for _, clause := range clauses[1:] {
+		fields := strings.Fields(clause)
+		if len(fields) == 0 {
+			continue
+		}
+		method, result, ok := strings.Cut(fields[0], "=")
+		if !ok {
+			continue // "none"
+		}
+
+		r := AuthResult{Method: strings.ToLower(method), Result: strings.ToLower(result)}
+		for _, prop := range fields[1:] {
+			key, val, _ := strings.Cut(prop, "=")
+			switch {
+			case r.Method == "dkim" && (key == "header.d" || key == "header.i"):
+				if r.Domain == "" {
+					_, domain, found := strings.Cut(val, "@")
+					if !found {
+						domain = val
+					}
+					r.Domain = domain
+				}
+			case r.Method == "spf" && (key == "smtp.mailfrom" || key == "smtp.helo"):
+				if _, domain, found := strings.Cut(val, "@"); found {
+					val = domain
+				}
+				r.Domain = val
+			case r.Method == "dmarc" && key == "header.from":
+				r.Domain = val
+			}
+		}
+		results = append(results, r)
+	}
+	return authservID, results
+}
+
+// sesVerdicts are the authentication verdicts of an SES receipt.
+type sesVerdicts struct {
+	DKIM  string
+	SPF   string
+	DMARC string
+}
+
+// errInboundUnverified wraps errors of inbound deliveries that fail verification.
+var errInboundUnverified = errors.New("inbound email not verified")
+
+// readInboundEmail extracts the raw MIME message from an SES (via SNS),
+// Mailgun, or direct delivery, verifying SNS and Mailgun signatures. It returns
+// a nil message for SNS control messages.
+func (cfg *HandlerConfig) readInboundEmail(r *http.Request) ([]byte, *sesVerdicts, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	switch {
+	case mediaType == "multipart/form-data" || mediaType == "application/x-www-form-urlencoded":
+		// Mailgun routes forwarding to a URL ending in "mime"
+		if err := r.ParseMultipartForm(maxInboundEmailSize); err != nil && err != http.ErrNotMultipart {
+			return nil, nil, err
+		}
+		err := verifyMailgunSignature(cfg.MailgunSigningKey, r.PostFormValue("timestamp"), r.PostFormValue("token"), r.PostFormValue("signature"))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", errInboundUnverified, err)
+		}
+		raw := r.PostFormValue("body-mime")
+		if raw == "" {
+			return nil, nil, fmt.Errorf("form is missing body-mime")
+		}
+		return []byte(raw), nil, nil
+
+	case r.Header.Get("X-Amz-Sns-Message-Type") != "":
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, nil, err
+		}
+		return cfg.readSNSInbound(r.Context(), body)
+	}
+
+	// Raw bodies carry no signature, so the shared secret is their only check
+	// (HandleInboundEmail has already validated it when set).
+	if cfg.InboundEmailSecret == "" {
+		return nil, nil, fmt.Errorf("%w: raw deliveries need WithInboundEmailSecret", errInboundUnverified)
+	}
+	body, err := io.ReadAll(r.Body)
+	return body, nil, err
+}
+
+// verifyMailgunSignature checks the signature Mailgun adds to route deliveries:
+// the hex HMAC-SHA256 of timestamp and token under the HTTP webhook signing key.
+func verifyMailgunSignature(key, timestamp, token, signature string) error {
+	if key == "" {
+		return fmt.Errorf("mailgun signing key is not configured")
+	}
+	if timestamp == "" || token == "" || signature == "" {
+		return fmt.Errorf("missing mailgun signature")
+	}
+	if sec, err := strconv.ParseInt(timestamp, 10, 64); err != nil || time.Since(time.Unix(sec, 0)).Abs() > DefaultWebhookTolerance {
+		return fmt.Errorf("mailgun signature timestamp out of tolerance")
+	}
+
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(timestamp + token))
+	if !hmac.Equal([]byte(hex.EncodeToString(mac.Sum(nil))), []byte(signature)) {
+		return fmt.Errorf("invalid mailgun signature")
+	}
+	return nil
+}
+
+// readSNSInbound extracts the message from an SNS notification of an SES
+// receipt rule with an SNS action, after verifying the SNS signature and topic.
+// Subscription confirmations are confirmed.
+func (cfg *HandlerConfig) readSNSInbound(ctx context.Context, body []byte) ([]byte, *sesVerdicts, error) {
+	var sns snsMessage
+	if err := json.Unmarshal(body, &sns); err != nil {
+		return nil, nil, fmt.Errorf("invalid sns message: %w", err)
+	}
+	if err := sns.verify(ctx); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", errInboundUnverified, err)
+	}
+	// Anyone can publish signed messages from their own topic, so the topic
+	// must be allowed, or the URL must carry the shared secret.
+	switch {
+	case len(cfg.InboundSNSTopicARNs) > 0 && !slices.Contains(cfg.InboundSNSTopicARNs, sns.TopicArn):
+		return nil, nil, fmt.Errorf("%w: sns topic %s is not allowed", errInboundUnverified, sns.TopicArn)
+	case len(cfg.InboundSNSTopicARNs) == 0 && cfg.InboundEmailSecret == "":
+		return nil, nil, fmt.Errorf("%w: sns deliveries need WithInboundSNSTopicARNs or WithInboundEmailSecret", errInboundUnverified)
+	}
+
+	switch sns.Type {
+	case "SubscriptionConfirmation":
+		if err := sns.confirm(ctx); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, nil
+	case "Notification":
+	default:
+		return nil, nil, nil
+	}
+
+	var notification struct {
+		Content string `json:"content"`
+		Receipt struct {
+			DKIMVerdict  struct{ Status string } `json:"dkimVerdict"`
+			SPFVerdict   struct{ Status string } `json:"spfVerdict"`
+			DMARCVerdict struct{ Status string } `json:"dmarcVerdict"`
+		} `json:"receipt"`
+	}
+	if err := json.Unmarshal([]byte(sns.Message), &notification); err != nil {
+		return nil, nil, fmt.Errorf("invalid ses notification: %w", err)
+	}
+	if notification.Content == "" {
+		return nil, nil, fmt.Errorf("ses notification has no content; enable content in the SNS action")
+	}
+
+	raw := []byte(notification.Content)
+	if decoded, err := base64.StdEncoding.DecodeString(notification.Content); err == nil {
+		raw = decoded // BASE64 encoding option
+	}
+	return raw, &sesVerdicts{
+		DKIM:  strings.ToLower(notification.Receipt.DKIMVerdict.Status),
+		SPF:   strings.ToLower(notification.Receipt.SPFVerdict.Status),
+		DMARC: strings.ToLower(notification.Receipt.DMARCVerdict.Status),
+	}, nil
+}
+
+// snsMessage is a message delivered by SNS to an HTTP(S) subscription.
+type snsMessage struct {
+	Type             string `json:"Type"`
+	MessageID        string `json:"MessageId"`
+	Token            string `json:"Token"`
+	TopicArn         string `json:"TopicArn"`
+	Subject          string `json:"Subject"`
+	Message          string `json:"Message"`
+	Timestamp        string `json:"Timestamp"`
+	SubscribeURL     string `json:"SubscribeURL"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+}
+
+// snsHost matches the SNS endpoint of any region, e.g. sns.us-east-1.amazonaws.com.
+var snsHost = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// snsHTTPClient fetches SNS signing certificates and confirms subscriptions.
+var snsHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// snsCerts caches SNS signing certificates by URL.
+var snsCerts sync.Map
+
+// snsURL parses rawURL, requiring an https URL on an SNS endpoint.
+func snsURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.Port() != "" || !snsHost.MatchString(u.Hostname()) {
+		return nil, fmt.Errorf("not an sns url: %q", rawURL)
+	}
+	return u, nil
+}
+
+// verify checks the message's signature with its SNS signing certificate.
+func (m *snsMessage) verify(ctx context.Context) error {
+	var hash crypto.Hash
+	switch m.SignatureVersion {
+	case "1":
+		hash = crypto.SHA1
+	case "2":
+		hash = crypto.SHA256
+	default:
+		return fmt.Errorf("unsupported sns signature version %q", m.SignatureVersion)
+	}
+	sig, err := base64.StdEncoding.DecodeString(m.Signature)
+	if err != nil || len(sig) == 0 {
+		return fmt.Errorf("invalid sns signature")
+	}
+
+	cert, err := snsCertificate(ctx, m.SigningCertURL)
+	if err != nil {
+		return err
+	}
+	if now := time.Now(); now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return fmt.Errorf("sns signing certificate expired")
+	}
+	pub, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("sns signing certificate is not rsa")
+	}
+
+	h := hash.New()
+	h.Write([]byte(m.stringToSign()))
+	if err := rsa.VerifyPKCS1v15(pub, hash, h.Sum(nil), sig); err != nil {
+		return fmt.Errorf("invalid sns signature")
+	}
+	return nil
+}
+
+// stringToSign returns the canonical form SNS signs for the message type.
+func (m *snsMessage) stringToSign() string {
+	var b strings.Builder
+	add := func(key, value string) {
+		b.WriteString(key + "\n" + value + "\n")
+	}
+	add("Message", m.Message)
+	add("MessageId", m.MessageID)
+	if m.Type == "Notification" {
+		if m.Subject != "" {
+			add("Subject", m.Subject)
+		}
+		add("Timestamp", m.Timestamp)
+	} else {
+		add("SubscribeURL", m.SubscribeURL)
+		add("Timestamp", m.Timestamp)
+		add("Token", m.Token)
+	}
+	add("TopicArn", m.TopicArn)
+	add("Type", m.Type)
+	return b.String()
+}
+
+// confirm confirms a subscription by fetching its SubscribeURL from SNS.
+func (m *snsMessage) confirm(ctx context.Context) error {
+	u, err := snsURL(m.SubscribeURL)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := snsHTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to confirm sns subscription: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to confirm sns subscription: status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// snsCertificate fetches (or returns the cached) SNS signing certificate.
+func snsCertificate(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	if cert, ok := snsCerts.Load(certURL); ok {
+		return cert.(*x509.Certificate), nil
+	}
+	u, err := snsURL(certURL)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(u.Path, ".pem") {
+		return nil, fmt.Errorf("not an sns certificate url: %q", certURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := snsHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sns certificate: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch sns certificate: status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sns certificate: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("invalid sns certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sns certificate: %w", err)
+	}
+	snsCerts.Store(certURL, cert)
+	return cert, nil
+}
+
+// applyVerdicts gives the SES receipt verdicts precedence over the message's
+// Authentication-Results. A DKIM pass keeps the per-signature results of a
+// trusted header that also passed, since they name the signing domains.
+func (msg *InboundMessage) applyVerdicts(v *sesVerdicts) {
+	if v.DKIM != "" && (v.DKIM != "pass" || !msg.DKIMPassed("")) {
+		msg.DKIM = []AuthResult{{Method: "dkim", Result: v.DKIM}}
+	}
+	msg.SPF = cmp.Or(v.SPF, msg.SPF)
+	msg.DMARC = cmp.Or(v.DMARC, msg.DMARC)
+}
+
+// validInboundKey checks the shared secret passed as ?key=, a bearer token, or
+// a basic auth password (for providers that only support credentials in the URL).
+func validInboundKey(r *http.Request, secret string) bool {
+	key := r.URL.Query().Get("key")
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		key = strings.TrimPrefix(auth, "Bearer ")
+	} else if _, password, ok := r.BasicAuth(); ok {
+		key = password
+	}
+	return subtle.ConstantTimeCompare([]byte(key), []byte(secret)) == 1
+}
+
+// HandleInboundEmail returns a handler that receives inbound email, parses it
+// into an InboundMessage, and passes it to the handler set with
+// WithInboundEmailHandler, for reply detection and support inboxes.
+// It accepts SES receipts delivered through SNS (confirming the subscription),
+// Mailgun routes forwarding raw MIME (body-mime), or a raw message/rfc822 body.
+// SNS signatures are always verified, and SNS deliveries must come from a topic
+// set with WithInboundSNSTopicARNs or carry the shared secret; Mailgun deliveries
+// need WithMailgunSigningKey, and raw bodies need WithInboundEmailSecret. When
+// WithInboundEmailSecret is set, requests must carry ?key=<secret>, an
+// "Authorization: Bearer <secret>" header, or the secret as basic auth password.
+// Route: POST /your-prefix/inbound/email (also /inbound/email/mime for Mailgun)
+func (c *Client) HandleInboundEmail(cfg *HandlerConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			cfg.renderError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed)
+			return
+		}
+		if cfg.InboundEmailSecret != "" && !validInboundKey(r, cfg.InboundEmailSecret) {
+			cfg.renderError(w, r, http.StatusUnauthorized, ErrCodeInvalidSignature)
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxInboundEmailSize)
+		raw, verdicts, err := cfg.readInboundEmail(r)
+		if errors.Is(err, errInboundUnverified) {
+			c.log().Warn("rejected inbound email", "error", err)
+			cfg.renderError(w, r, http.StatusUnauthorized, ErrCodeInvalidSignature)
+			return
+		}
+		if err != nil {
+			cfg.renderError(w, r, http.StatusBadRequest, ErrCodeInvalidBody)
+			return
+		}
+		if raw == nil {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		msg, err := ParseInboundEmail(raw, cfg.InboundAuthServIDs...)
+		if err != nil {
+			cfg.renderError(w, r, http.StatusBadRequest, ErrCodeInvalidPayload)
+			return
+		}
+		if verdicts != nil {
+			msg.applyVerdicts(verdicts)
+		}
+
+		if cfg.InboundEmailHandler != nil {
+			if err := cfg.InboundEmailHandler(r.Context(), msg); err != nil {
+				c.log().Error("inbound email handler failed", "message_id", msg.MessageID, "error", err)
+				cfg.renderError(w, r, http.StatusInternalServerError, ErrCodeWebhookFailed)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,249 @@
+package levee
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerifyMailgunSignature(t *testing.T) {
+	const key = "mailgun-key"
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-2*DefaultWebhookTolerance).Unix(), 10)
+
+	tests := []struct {
+		name                       string
+		key, timestamp, token, sig string
+		ok                         bool
+	}{
+		{"valid", key, now, "tok", hmacHex(key, now+"tok"), true},
+		{"wrong key", key, now, "tok", hmacHex("other", now+"tok"), false},
+		{"token mismatch", key, now, "other", hmacHex(key, now+"tok"), false},
+		{"stale timestamp", key, stale, "tok", hmacHex(key, stale+"tok"), false},
+		{"invalid timestamp", key, "soon", "tok", hmacHex(key, "soontok"), false},
+		{"missing token", key, now, "", hmacHex(key, now), false},
+		{"missing signature", key, now, "tok", "", false},
+		{"no signing key", "", now, "tok", hmacHex("", now+"tok"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyMailgunSignature(tt.key, tt.timestamp, tt.token, tt.sig)
+			if tt.ok && err != nil {
+				t.Errorf("verifyMailgunSignature() = %v, want nil", err)
+			}
+			if !tt.ok && err == nil {
+				t.Error("verifyMailgunSignature() = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestSNSMessageVerify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const (
+		certURL    = "https://sns.us-east-1.amazonaws.com/test-valid.pem"
+		expiredURL = "https://sns.us-east-1.amazonaws.com/test-expired.pem"
+	)
+	snsCerts.Store(certURL, testCertificate(t, key, time.Now().Add(time.Hour)))
+	snsCerts.Store(expiredURL, testCertificate(t, key, time.Now().Add(-time.Hour)))
+	t.Cleanup(func() {
+		snsCerts.Delete(certURL)
+		snsCerts.Delete(expiredURL)
+	})
+
+	notification := func() *snsMessage {
+		return &snsMessage{
+			Type:             "Notification",
+			MessageID:        "msg-1",
+			TopicArn:         "arn:aws:sns:us-east-1:123456789012:inbound",
+			Subject:          "Amazon SES Email Receipt Notification",
+			Message:          `{"notificationType":"Received"}`,
+			Timestamp:        "2026-01-02T15:04:05.000Z",
+			SignatureVersion: "1",
+			SigningCertURL:   certURL,
+		}
+	}
+	sign := func(m *snsMessage) *snsMessage {
+		hash := crypto.SHA1
+		if m.SignatureVersion == "2" {
+			hash = crypto.SHA256
+		}
+		h := hash.New()
+		h.Write([]byte(m.stringToSign()))
+		sig, err := rsa.SignPKCS1v15(rand.Reader, key, hash, h.Sum(nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		m.Signature = base64.StdEncoding.EncodeToString(sig)
+		return m
+	}
+
+	tests := []struct {
+		name string
+		msg  func() *snsMessage
+		ok   bool
+	}{
+		{
+			name: "notification signed with SHA1",
+			msg:  func() *snsMessage { return sign(notification()) },
+			ok:   true,
+		},
+		{
+			name: "notification signed with SHA256",
+			msg: func() *snsMessage {
+				m := notification()
+				m.SignatureVersion = "2"
+				return sign(m)
+			},
+			ok: true,
+		},
+		{
+			name: "subscription confirmation",
+			msg: func() *snsMessage {
+				m := notification()
+				m.Type = "SubscriptionConfirmation"
+				m.Subject = ""
+				m.Token = "token"
+				m.SubscribeURL = "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription"
+				return sign(m)
+			},
+			ok: true,
+		},
+		{
+			name: "tampered message",
+			msg: func() *snsMessage {
+				m := sign(notification())
+				m.Message = `{"notificationType":"Bounce"}`
+				return m
+			},
+		},
+		{
+			name: "version mismatch",
+			msg: func() *snsMessage {
+				m := sign(notification())
+				m.SignatureVersion = "2"
+				return m
+			},
+		},
+		{
+			name: "unsupported version",
+			msg: func() *snsMessage {
+				m := sign(notification())
+				m.SignatureVersion = "3"
+				return m
+			},
+		},
+		{
+			name: "signature not base64",
+			msg: func() *snsMessage {
+				m := notification()
+				m.Signature = "not base64!"
+				return m
+			},
+		},
+		{
+			name: "expired certificate",
+			msg: func() *snsMessage {
+				m := notification()
+				m.SigningCertURL = expiredURL
+				return sign(m)
+			},
+		},
+		{
+			name: "certificate outside SNS",
+			msg: func() *snsMessage {
+				m := notification()
+				m.SigningCertURL = "https://sns.example.com/cert.pem"
+				return sign(m)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.msg().verify(context.Background())
+			if tt.ok && err != nil {
+				t.Errorf("verify() = %v, want nil", err)
+			}
+			if !tt.ok && err == nil {
+				t.Error("verify() = nil, want an error")
+			}
+		})
+	}
+}
+
+// testCertificate returns a self-signed certificate for key expiring at notAfter.
+func testCertificate(t *testing.T, key *rsa.PrivateKey, notAfter time.Time) *x509.Certificate {
+	t.Helper()
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestHandleInboundEmailRaw(t *testing.T) {
+	const raw = "From: ada@example.com\r\nTo: support@example.com\r\nSubject: Hi\r\n\r\nHello\r\n"
+
+	tests := []struct {
+		name string
+		opts []HandlerOption
+		url  string
+		want int
+	}{
+		{"no secret configured", nil, "/levee/inbound/email", http.StatusUnauthorized},
+		{"missing key", []HandlerOption{WithInboundEmailSecret("s3cret")}, "/levee/inbound/email", http.StatusUnauthorized},
+		{"wrong key", []HandlerOption{WithInboundEmailSecret("s3cret")}, "/levee/inbound/email?key=nope", http.StatusUnauthorized},
+		{"valid key", []HandlerOption{WithInboundEmailSecret("s3cret")}, "/levee/inbound/email?key=s3cret", http.StatusOK},
+	}
+
+	client, err := NewClient("key", "https://levee.test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *InboundMessage
+			opts := append(tt.opts, WithInboundEmailHandler(func(ctx context.Context, msg *InboundMessage) error {
+				got = msg
+				return nil
+			}))
+			r := httptest.NewRequest(http.MethodPost, tt.url, strings.NewReader(raw))
+			r.Header.Set("Content-Type", "message/rfc822")
+			w := httptest.NewRecorder()
+
+			client.HandleInboundEmail(NewHandlerConfig(opts...))(w, r)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+			if delivered := got != nil; delivered != (tt.want == http.StatusOK) {
+				t.Errorf("handler called = %v", delivered)
+			}
+		})
+	}
+}
@@ -1,32 +1,83 @@
 package levee
 
 import (
+	"cmp"
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/almatuck/levee-go/llmpb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
 )
 
 // LLMClient provides access to the Levee LLM gateway.
 type LLMClient struct {
-	apiKey     string
-	baseURL    string // Base URL for HTTP API (e.g., "https://levee.example.com")
-	grpcAddr   string // gRPC address override (host:port), if empty uses auto-discovery
-	useTLS     bool   // Determined from baseURL scheme (https = true)
-	httpClient *http.Client
-	conn       *grpc.ClientConn
-	client     llmpb.LLMServiceClient
-	mu         sync.Mutex
+	apiKey        string
+	baseURL       string // Base URL for HTTP API (e.g., "https://levee.example.com")
+	grpcAddr      string // gRPC address override (host:port), if empty uses auto-discovery
+	useTLS        bool   // Determined from baseURL scheme (https = true)
+	httpClient    *http.Client
+	tlsConfig     *tls.Config                     // custom TLS config (private CA, mTLS), nil uses defaults
+	perRPC        []credentials.PerRPCCredentials // extra per-call credentials
+	keepalive     *keepalive.ClientParameters
+	waitForReady  bool
+	reconnectBase time.Duration // reconnect backoff, zero uses gRPC defaults
+	reconnectMax  time.Duration
+	pool          *connPool
+	client        llmpb.LLMServiceClient
+	mu            sync.Mutex
+
+	// Interceptors and extra dial options applied in connect
+	unaryInterceptors  []grpc.UnaryClientInterceptor
+	streamInterceptors []grpc.StreamClientInterceptor
+	extraDialOpts      []grpc.DialOption
+
+	toolsMu sync.RWMutex
+	tools   map[string]*registeredTool
+	prompts promptRegistry
+	store   ConversationStore
+
+	retries        int
+	fallbackModels []string
+
+	cache    ResponseCache
+	cacheTTL time.Duration
+
+	inputFilters  []ContentFilter
+	outputFilters []ContentFilter
+	outputGuards  []OutputGuard
+
+	provider Provider
+	routing  RoutingPolicy
+
+	transport LLMTransport
+
+	metrics Metrics // per-call usage metrics, if set
+
+	auditSink      AuditSink
+	redactionRules []RedactionRule
+
+	limiter      *GenerationLimiter
+	queueTimeout time.Duration
+
+	seed *int64 // default seed of WithReproducible
+
+	toolTimeout time.Duration // default tool execution timeout
+
+	poolSize  int         // gRPC connections to open, see WithConnectionPool
+	connected atomic.Bool // client is set, so connect can skip mu
 }
 
 // LLMOption is a functional option for configuring the LLM client.
@@ -62,6 +113,7 @@ func NewLLMClient(apiKey string, baseURL string, opts ...LLMOption) *LLMClient {
 		baseURL:    strings.TrimSuffix(baseURL, "/"),
 		useTLS:     strings.HasPrefix(baseURL, "https://"),
 		httpClient: http.DefaultClient,
+		retries:    defaultLLMRetries,
 	}
 
 	for _, opt := range opts {
@@ -112,12 +164,21 @@ func (c *LLMClient) fetchConfig(ctx context.Context) (*llmConfigResponse, error)
 	return &config, nil
 }
 
-// connect establishes the gRPC connection if not already connected.
+// connect establishes the gRPC connection if not already connected, or
+// selects the HTTPS transport (see WithTransport).
 func (c *LLMClient) connect() error {
+	if c.connected.Load() {
+		return nil
+	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	defer func() { c.connected.Store(c.client != nil) }()
 
-	if c.conn != nil {
+	if c.client != nil {
+		return nil
+	}
+	if c.transport == TransportHTTP {
+		c.client = &httpLLMService{c: c}
 		return nil
 	}
 
@@ -140,37 +201,42 @@ func (c *LLMClient) connect() error {
 			return fmt.Errorf("failed to parse baseURL: %w", err)
 		}
 
+		if config.GRPCPort == 0 && c.transport == TransportAuto {
+			c.client = &httpLLMService{c: c}
+			return nil
+		}
 		grpcAddr = fmt.Sprintf("%s:%d", parsedURL.Hostname(), config.GRPCPort)
 		c.grpcAddr = grpcAddr // Cache for future connections
 	}
 
-	// Create gRPC connection with appropriate credentials (TLS determined from baseURL scheme)
-	var opts []grpc.DialOption
-	if c.useTLS {
-		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})))
-	} else {
-		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	// Fall back to HTTPS when the gRPC port is blocked
+	if c.transport == TransportAuto && c.baseURL != "" && !grpcReachable(grpcAddr) {
+		c.client = &httpLLMService{c: c}
+		return nil
 	}
 
-	conn, err := grpc.NewClient(grpcAddr, opts...)
+	// Create gRPC connection with appropriate credentials (TLS determined from
+	// baseURL scheme unless overridden with WithTLS/WithInsecure)
+	pool, err := dialPool(grpcAddr, c.poolSize, c.dialOptions()...)
 	if err != nil {
 		return fmt.Errorf("failed to connect to LLM server at %s: %w", grpcAddr, err)
 	}
 
-	c.conn = conn
-	c.client = llmpb.NewLLMServiceClient(conn)
+	c.pool = pool
+	c.client = llmpb.NewLLMServiceClient(pool)
 	return nil
 }
 
-// Close closes the gRPC connection.
+// Close closes the gRPC connections.
 func (c *LLMClient) Close() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.conn != nil {
-		err := c.conn.Close()
-		c.conn = nil
-		c.client = nil
+	c.connected.Store(false)
+	c.client = nil
+	if c.pool != nil {
+		err := c.pool.Close()
+		c.pool = nil
 		return err
 	}
 	return nil
@@ -180,6 +246,10 @@ func (c *LLMClient) Close() error {
 type ChatMessage struct {
 	Role    string `json:"role"`    // "user", "assistant", "system"
 	Content string `json:"content"`
+	// Images are image inputs for vision-capable models (see ImageFile).
+	Images []ImagePart `json:"images,omitempty"`
+	// Audio are speech inputs, transcribed by the gateway (see AudioFile).
+	Audio []AudioPart `json:"audio,omitempty"`
 }
 
 // ChatRequest represents an LLM chat request.
@@ -189,6 +259,55 @@ type ChatRequest struct {
 	Model        string // "haiku", "sonnet", "opus" or full model ID
 	MaxTokens    int32
 	Temperature  float32
+	// SessionID identifies the conversation in the ConversationStore; stored
+	// history is loaded before Messages and each completed turn is saved.
+	SessionID string
+	// History keeps a ChatSession's conversation within the context window
+	// (see SlidingWindow, TokenBudget, and SummarizeHistory). Nil keeps it all.
+	History HistoryStrategy
+	// SkipCache bypasses the ResponseCache for this request.
+	SkipCache bool
+	// RequestID is echoed in logs and batch results, to match results to inputs.
+	RequestID string
+	// Provider pins the provider (see ProviderAnthropic); empty lets the
+	// gateway route by Routing or the client defaults.
+	Provider Provider
+	Routing  RoutingPolicy
+
+	// Sampling parameters; zero values use the model defaults. Providers
+	// ignore parameters they don't support.
+	StopSequences    []string
+	TopP             float32
+	TopK             int32
+	FrequencyPenalty float32
+	PresencePenalty  float32
+	// Seed requests deterministic sampling where the provider supports it.
+	// Deterministic also uses greedy decoding and disables model and provider
+	// fallback (see WithReproducible).
+	Seed          *int64
+	Deterministic bool
+
+	// Examples are few-shot input/reply pairs placed before the conversation.
+	// They are not stored, exported, or passed to a HistoryStrategy, so they
+	// stay in place however long a session grows.
+	Examples []ExamplePair
+}
+
+// paramsToPB returns the sampling parameters of req, or nil if none are set.
+func (req ChatRequest) paramsToPB() *llmpb.GenerationParams {
+	if len(req.StopSequences) == 0 && req.TopP == 0 && req.TopK == 0 &&
+		req.FrequencyPenalty == 0 && req.PresencePenalty == 0 && req.Seed == nil && !req.Deterministic {
+		return nil
+	}
+	return &llmpb.GenerationParams{
+		StopSequences:    req.StopSequences,
+		TopP:             req.TopP,
+		TopK:             req.TopK,
+		FrequencyPenalty: req.FrequencyPenalty,
+		PresencePenalty:  req.PresencePenalty,
+		Seed:             req.Seed,
+		Deterministic:    req.Deterministic,
+	}
 }
 
 // ChatResponse represents an LLM chat response.
@@ -200,36 +319,119 @@ type ChatResponse struct {
 	CostUSD      float64
 	LatencyMs    int64
 	StopReason   string
+	// Attempts lists every request made for the response, including failed
+	// retries and fallbacks; the last one is the successful attempt.
+	Attempts []ChatAttempt
+	// Cached reports a ResponseCache hit; nothing was billed for it.
+	Cached bool
+	// Provider is the provider that served the response.
+	Provider string
+	// QueueWaitMs is how long the request waited for a generation slot
+	// (see WithMaxConcurrentGenerations).
+	QueueWaitMs int64
+	// Fingerprint identifies the backend configuration that served the
+	// response. With the same seed, responses are only expected to repeat
+	// while it stays the same.
+	Fingerprint string
+	// Streaming latency of session turns: TTFTMs is the time from sending
+	// the message to the first chunk, and ChunkGapMs and MaxChunkGapMs are
+	// the mean and longest time between chunks.
+	TTFTMs        int64
+	ChunkGapMs    int64
+	MaxChunkGapMs int64
+}
+
+// attempt returns the accounting of a successful attempt.
+func (r *ChatResponse) attempt(model string) ChatAttempt {
+	return ChatAttempt{
+		Model:        model,
+		InputTokens:  r.InputTokens,
+		OutputTokens: r.OutputTokens,
+		CostUSD:      r.CostUSD,
+		LatencyMs:    r.LatencyMs,
+	}
 }
 
 // Chat sends a simple (non-streaming) chat request.
 func (c *LLMClient) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	if n := len(req.Messages); n > 0 && req.Messages[n-1].Role == "user" && len(c.inputFilters) > 0 {
+		content, err := c.filterInput(ctx, req.Messages[n-1].Content)
+		if err != nil {
+			return nil, err
+		}
+		last := req.Messages[n-1]
+		last.Content = content
+		req.Messages = append(slices.Clip(req.Messages[:n-1]), last)
+	}
+
+	audit := AuditRecord{
+		Source:       AuditChat,
+		SessionID:    req.SessionID,
+		RequestID:    req.RequestID,
+		Model:        req.Model,
+		SystemPrompt: req.SystemPrompt,
+		Messages:     req.Messages,
+	}
+
+	var cacheKey string
+	if c.cacheable(req) {
+		cacheKey = ChatCacheKey(req)
+		if resp, ok := c.cachedResponse(ctx, cacheKey, req); ok {
+			c.audit(ctx, audit.withResult(resp, nil))
+			return resp, nil
+		}
+	}
+
 	if err := c.connect(); err != nil {
 		return nil, err
 	}
 
-	// Convert messages
-	messages := make([]*llmpb.Message, 0, len(req.Messages))
-	for _, msg := range req.Messages {
-		messages = append(messages, &llmpb.Message{
-			Role:    msg.Role,
-			Content: msg.Content,
-		})
+	stored, err := c.loadHistory(ctx, req.SessionID)
+	if err != nil {
+		return nil, err
 	}
 
-	resp, err := c.client.SimpleChat(ctx, &llmpb.SimpleChatRequest{
-		ApiKey:       c.apiKey,
-		Messages:     messages,
-		SystemPrompt: req.SystemPrompt,
-		Model:        req.Model,
-		MaxTokens:    req.MaxTokens,
-		Temperature:  req.Temperature,
-	})
+	// Convert messages
+	messages := withExamples(req.Examples, append(stored, req.Messages...))
+
+	release, wait, err := c.acquireGeneration(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("chat request failed: %w", err)
+		return nil, err
+	}
+	defer release()
+
+	// Retry retryable errors, then fall back to the next model
+	provider, routing := c.route(req)
+	params := c.params(req)
+	plan := c.newRetryPlan(req.Model, params.GetDeterministic())
+	var attempts []ChatAttempt
+	var resp *llmpb.SimpleChatResponse
+	start := time.Now()
+	for {
+		resp, err = c.client.SimpleChat(ctx, &llmpb.SimpleChatRequest{
+			ApiKey:       c.apiKey,
+			Messages:     messages,
+			SystemPrompt: req.SystemPrompt,
+			Model:        plan.model,
+			MaxTokens:    req.MaxTokens,
+			Temperature:  req.Temperature,
+			RequestId:    req.RequestID,
+			Provider:     provider,
+			Routing:      routing,
+			Params:       params,
+		})
+		if err == nil {
+			break
+		}
+		attempts = append(attempts, ChatAttempt{Model: plan.model, Err: err})
+		audit.Model = plan.model
+		c.audit(ctx, audit.withResult(nil, err))
+		if !isRetryableLLMError(err) || !plan.next(ctx) {
+			return nil, fmt.Errorf("chat request failed: %w", err)
+		}
 	}
 
-	return &ChatResponse{
+	result := &ChatResponse{
 		Content:      resp.Content,
 		Model:        resp.Model,
 		InputTokens:  resp.InputTokens,
@@ -237,93 +439,266 @@ func (c *LLMClient) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, e
 		CostUSD:      resp.CostUsd,
 		LatencyMs:    resp.LatencyMs,
 		StopReason:   resp.StopReason,
-	}, nil
+		Provider:     resp.Provider,
+		QueueWaitMs:  wait.Milliseconds(),
+		Fingerprint:  resp.SystemFingerprint,
+	}
+	result.Attempts = append(attempts, result.attempt(plan.model))
+	c.recordUsage(result, time.Since(start))
+	c.audit(ctx, audit.withResult(result, nil))
+	if result.Content, err = c.guardOutput(result.Content); err != nil {
+		return nil, err
+	}
+	if result.Content, err = c.filterOutput(ctx, result.Content); err != nil {
+		return nil, err
+	}
+	if cacheKey != "" {
+		c.cache.Set(ctx, cacheKey, req, result, c.cacheTTL)
+	}
+	if n := len(req.Messages); n > 0 && req.Messages[n-1].Role == "user" {
+		// The reply is returned even if it couldn't be stored.
+		return result, c.saveTurn(ctx, req.SessionID, req.Messages[n-1], result.Content)
+	}
+	return result, nil
 }
 
 // StreamChunk represents a chunk of streamed content.
 type StreamChunk struct {
 	Content string
 	Index   int32
+	// InputTokens and OutputTokens are the running token counts of the turn,
+	// when the gateway reports them.
+	InputTokens  int64
+	OutputTokens int64
 }
 
 // StreamCallback is called for each chunk during streaming.
 type StreamCallback func(chunk StreamChunk) error
 
 // ChatSession represents an active chat session for bidirectional streaming.
+//
+// If a turn fails with a retryable error before any output arrives (e.g. the
+// gateway restarted or the provider is rate limited), Send re-opens the stream
+// with the conversation so far and resends the message, retrying and falling
+// back to other models as configured.
 type ChatSession struct {
-	stream llmpb.LLMService_ChatClient
-	apiKey string
-	done   bool
-	mu     sync.Mutex
+	llm       *LLMClient
+	ctx       context.Context
+	sessionID string
+	start     *llmpb.StartChatRequest
+	history   []*llmpb.Message // completed turns, replayed on reconnect
+	stream    llmpb.LLMService_ChatClient
+	apiKey    string
+	done      bool
+	mu        sync.Mutex
+
+	historyStrategy HistoryStrategy
+
+	provider string // provider and model the gateway routed the session to
+	model    string
+
+	gatewayID  string // gateway session ID, for ResumeSession
+	lastChunk  int32  // index of the last chunk received this turn
+	generating bool   // resumed with a turn in flight, read by Receive
+
+	// sendMu serializes stream sends during a turn with its abort on cancel.
+	sendMu sync.Mutex
+
+	transcript []TranscriptMessage  // every message, for Export
+	toolCalls  []TranscriptToolCall // tools run for the reply in progress
+
+	examples []ExamplePair // pinned at the start of start.Messages
 }
 
 // NewChatSession starts a new bidirectional chat session.
 func (c *LLMClient) NewChatSession(ctx context.Context, req ChatRequest) (*ChatSession, error) {
-	if err := c.connect(); err != nil {
-		return nil, err
-	}
-
-	stream, err := c.client.Chat(ctx)
+	stored, err := c.loadHistory(ctx, req.SessionID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to start chat session: %w", err)
+		return nil, err
 	}
 
 	// Convert initial messages
-	messages := make([]*llmpb.Message, 0, len(req.Messages))
-	for _, msg := range req.Messages {
-		messages = append(messages, &llmpb.Message{
-			Role:    msg.Role,
-			Content: msg.Content,
-		})
-	}
+	initial := append(stored, req.Messages...)
+	messages := withExamples(req.Examples, initial)
 
-	// Send start request
-	err = stream.Send(&llmpb.ChatRequest{
-		Request: &llmpb.ChatRequest_Start{
-			Start: &llmpb.StartChatRequest{
-				ApiKey:       c.apiKey,
-				SystemPrompt: req.SystemPrompt,
-				Model:        req.Model,
-				MaxTokens:    req.MaxTokens,
-				Temperature:  req.Temperature,
-				Messages:     messages,
-			},
-		},
-	})
+	start := &llmpb.StartChatRequest{
+		ApiKey:       c.apiKey,
+		SystemPrompt: req.SystemPrompt,
+		Model:        req.Model,
+		MaxTokens:    req.MaxTokens,
+		Temperature:  req.Temperature,
+		Messages:     messages,
+		Tools:        c.toolDefinitions(),
+		Params:       c.params(req),
+
+		ParallelToolCalls: true,
+	}
+	provider, routing := c.route(req)
+	start.Provider, start.Routing = provider, routing
+	stream, err := c.openChat(ctx, start)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send start request: %w", err)
+		return nil, err
 	}
 
 	return &ChatSession{
-		stream: stream,
-		apiKey: c.apiKey,
+		llm:       c,
+		ctx:       ctx,
+		sessionID: req.SessionID,
+		start:     start,
+		stream:    stream,
+		apiKey:    c.apiKey,
+
+		historyStrategy: req.History,
+
+		examples:   req.Examples,
+		transcript: transcriptFromPB(messagesToPB(initial)),
 	}, nil
 }
 
-// Send sends a user message and streams the response.
+// Send sends a user message and streams the response. Cancelling ctx aborts
+// the generation and returns ctx.Err(); the session stays usable.
 func (s *ChatSession) Send(ctx context.Context, content string, callback StreamCallback) (*ChatResponse, error) {
+	return s.send(ctx, ChatMessage{Role: "user", Content: content}, callback, false)
+}
+
+// SendMessage sends a user message with images or audio and streams the
+// response.
+func (s *ChatSession) SendMessage(ctx context.Context, msg ChatMessage, callback StreamCallback) (*ChatResponse, error) {
+	msg.Role = "user"
+	return s.send(ctx, msg, callback, false)
+}
+
+// send runs one turn, re-opening a broken stream once if nothing was streamed yet.
+func (s *ChatSession) send(ctx context.Context, msg ChatMessage, callback StreamCallback, runTools bool) (*ChatResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	if s.done {
 		return nil, fmt.Errorf("session is closed")
 	}
+	if s.generating {
+		return nil, fmt.Errorf("a resumed turn is still in flight; call Receive first")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var err error
+	if msg.Content, err = s.llm.filterInput(ctx, msg.Content); err != nil {
+		return nil, err
+	}
+	if s.historyStrategy != nil {
+		if err := s.trimHistory(ctx, msg.Content); err != nil {
+			return nil, err
+		}
+	}
 
+	// Replies are screened once complete, so output filters hold back chunks.
+	filterOutput := len(s.llm.outputFilters) > 0
+	turnCallback := callback
+	if filterOutput {
+		turnCallback = nil
+	}
+
+	release, wait, err := s.llm.acquireGeneration(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	// Retry retryable errors, then fall back to the next model. The session
+	// stays on the fallback model for later turns.
+	plan := s.llm.newRetryPlan(s.start.Model, s.start.Params.GetDeterministic())
+	var attempts []ChatAttempt
+	for {
+		resp, streamed, err := s.sendTurn(ctx, msg, turnCallback, runTools)
+		s.llm.audit(ctx, s.auditRecord(msg).withResult(resp, err))
+		if err == nil {
+			resp.Attempts = append(attempts, resp.attempt(s.start.Model))
+			resp.QueueWaitMs = wait.Milliseconds()
+			s.history = append(s.history,
+				messagesToPB([]ChatMessage{msg})[0],
+				&llmpb.Message{Role: "assistant", Content: resp.Content},
+			)
+			if filterOutput {
+				if resp.Content, err = s.llm.filterOutput(ctx, resp.Content); err != nil {
+					return nil, err
+				}
+				if callback != nil && resp.Content != "" {
+					if err := callback(StreamChunk{Content: resp.Content}); err != nil {
+						return nil, err
+					}
+				}
+			}
+			s.recordTurn(&msg, resp)
+			// The reply is returned even if it couldn't be stored.
+			return resp, s.llm.saveTurn(ctx, s.sessionID, msg, resp.Content)
+		}
+		attempts = append(attempts, ChatAttempt{Model: s.start.Model, Err: err})
+
+		// Output already passed to callback, or tools already run, can't be replayed.
+		if streamed || !isRetryableLLMError(err) || !plan.next(ctx) {
+			return nil, err
+		}
+
+		s.start.Model = plan.model
+		stream, rerr := s.llm.reopenChat(s.ctx, s.start, s.history)
+		if rerr != nil {
+			return nil, fmt.Errorf("%w (%v)", err, rerr)
+		}
+		drainStream(s.stream)
+		s.stream = stream
+	}
+}
+
+// sendTurn sends one user message and reads the response. With runTools, tool
+// calls are executed and answered until the model completes. streamed reports
+// whether any chunk reached callback or any tool ran.
+func (s *ChatSession) sendTurn(ctx context.Context, msg ChatMessage, callback StreamCallback, runTools bool) (resp *ChatResponse, streamed bool, err error) {
 	// Send user message
-	err := s.stream.Send(&llmpb.ChatRequest{
+	err = s.stream.Send(&llmpb.ChatRequest{
 		Request: &llmpb.ChatRequest_Message{
 			Message: &llmpb.UserMessage{
-				Content: content,
+				Content: msg.Content,
+				Images:  imagesToPB(msg.Images),
+				Audio:   audioPartsToPB(msg.Audio),
 			},
 		},
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to send message: %w", err)
+		// io.EOF means the stream ended; its status comes from Recv.
+		if errors.Is(err, io.EOF) {
+			if _, rerr := s.stream.Recv(); rerr != nil && rerr != io.EOF {
+				err = rerr
+			}
+		}
+		return nil, false, fmt.Errorf("failed to send message: %w", err)
 	}
+	s.lastChunk = -1
+	return s.receive(ctx, callback, runTools)
+}
 
+// receive reads the response of the turn in flight until completion.
+func (s *ChatSession) receive(ctx context.Context, callback StreamCallback, runTools bool) (resp *ChatResponse, streamed bool, err error) {
 	// Stream responses until completion
 	var fullContent string
 	var completion *llmpb.CompletionResponse
+	var toolCalls int
+	start := time.Now()
+	timer := streamTimer{start: start}
+	guard := s.llm.newGuardRun()
+	var violation error
+	s.toolCalls = nil
+
+	// Abort the generation when the caller gives up, so the gateway stops
+	// producing tokens nobody will read. The stream then ends the turn with
+	// an Aborted response.
+	stream := s.stream
+	stop := context.AfterFunc(ctx, func() {
+		s.sendMu.Lock()
+		defer s.sendMu.Unlock()
+		stream.Send(abortRequest(ctx.Err().Error()))
+	})
+	defer stop()
 
 	for {
 		resp, err := s.stream.Recv()
@@ -331,26 +706,91 @@ func (s *ChatSession) Send(ctx context.Context, content string, callback StreamC
 			break
 		}
 		if err != nil {
-			return nil, fmt.Errorf("stream receive error: %w", err)
+			return nil, streamed, fmt.Errorf("stream receive error: %w", err)
 		}
 
 		switch r := resp.Response.(type) {
 		case *llmpb.ChatResponse_SessionStarted:
-			// Session started, continue
+			s.gatewayID = r.SessionStarted.SessionId
+			s.provider = r.SessionStarted.Provider
+			s.model = r.SessionStarted.Model
 		case *llmpb.ChatResponse_Chunk:
 			fullContent += r.Chunk.Content
-			if callback != nil {
-				if err := callback(StreamChunk{Content: r.Chunk.Content, Index: r.Chunk.Index}); err != nil {
-					return nil, err
+			s.lastChunk = r.Chunk.Index
+			streamed = true
+			s.llm.recordChunk(&timer, s.model, s.provider)
+			if violation != nil {
+				continue
+			}
+			text, err := guard.chunk(r.Chunk.Content)
+			if err != nil {
+				// Stop the generation and read on to its end, so the
+				// stream is ready for the next turn.
+				violation = err
+				s.sendMu.Lock()
+				s.stream.Send(abortRequest(err.Error()))
+				s.sendMu.Unlock()
+				continue
+			}
+			if callback != nil && ctx.Err() == nil && text != "" {
+				chunk := StreamChunk{
+					Content:      text,
+					Index:        r.Chunk.Index,
+					InputTokens:  r.Chunk.InputTokens,
+					OutputTokens: r.Chunk.OutputTokens,
+				}
+				if err := callback(chunk); err != nil {
+					return nil, streamed, err
 				}
 			}
+		case *llmpb.ChatResponse_ToolCall:
+			if !runTools {
+				continue
+			}
+			if toolCalls++; toolCalls > maxToolCalls {
+				return nil, true, fmt.Errorf("too many tool calls (limit %d)", maxToolCalls)
+			}
+			streamed = true
+			result := s.llm.executeTool(ctx, r.ToolCall)
+			s.recordToolCall(r.ToolCall, result.GetToolResult())
+			s.sendMu.Lock()
+			err := s.stream.Send(result)
+			s.sendMu.Unlock()
+			if err != nil {
+				return nil, streamed, fmt.Errorf("failed to send tool result: %w", err)
+			}
+		case *llmpb.ChatResponse_ToolCalls:
+			if !runTools {
+				continue
+			}
+			calls := r.ToolCalls.Calls
+			if toolCalls += len(calls); toolCalls > maxToolCalls {
+				return nil, true, fmt.Errorf("too many tool calls (limit %d)", maxToolCalls)
+			}
+			streamed = true
+			result := s.llm.executeTools(ctx, calls)
+			for i, call := range calls {
+				s.recordToolCall(call, result.GetToolResults().Results[i])
+			}
+			s.sendMu.Lock()
+			err := s.stream.Send(result)
+			s.sendMu.Unlock()
+			if err != nil {
+				return nil, streamed, fmt.Errorf("failed to send tool results: %w", err)
+			}
 		case *llmpb.ChatResponse_Completion:
 			completion = r.Completion
 			// Don't break - there might be more responses
 		case *llmpb.ChatResponse_Error:
-			return nil, fmt.Errorf("LLM error: %s", r.Error.Message)
+			return nil, streamed, &LLMError{Code: r.Error.Code, Message: r.Error.Message, Retryable: r.Error.Retryable}
 		case *llmpb.ChatResponse_Aborted:
-			return nil, fmt.Errorf("generation aborted: %s", r.Aborted.Reason)
+			if violation != nil {
+				return nil, streamed, violation
+			}
+			if err := ctx.Err(); err != nil {
+				return nil, streamed, err
+			}
+			return nil, streamed, fmt.Errorf("generation aborted: %s", r.Aborted.Reason)
 		}
 
 		if completion != nil {
@@ -358,18 +798,43 @@ func (s *ChatSession) Send(ctx context.Context, content string, callback StreamC
 		}
 	}
 
+	if violation != nil {
+		return nil, streamed, violation
+	}
+	content := fullContent
+	if completion != nil {
+		content = completion.FullContent
+	}
+	content, rest, err := guard.final(content)
+	if err != nil {
+		return nil, streamed, err
+	}
+	if rest != "" && callback != nil && ctx.Err() == nil {
+		if err := callback(StreamChunk{Content: rest, Index: s.lastChunk}); err != nil {
+			return nil, streamed, err
+		}
+	}
+
 	if completion == nil {
-		return &ChatResponse{Content: fullContent}, nil
+		resp = &ChatResponse{Content: content}
+		timer.apply(resp)
+		return resp, streamed, nil
 	}
+	s.llm.recordCompletion(completion, time.Since(start))
 
-	return &ChatResponse{
-		Content:      completion.FullContent,
+	resp = &ChatResponse{
+		Content:      content,
+		Model:        cmp.Or(completion.Model, s.model),
+		Provider:     cmp.Or(completion.Provider, s.provider),
 		StopReason:   completion.StopReason,
 		InputTokens:  completion.InputTokens,
 		OutputTokens: completion.OutputTokens,
 		CostUSD:      completion.CostUsd,
 		LatencyMs:    completion.LatencyMs,
-	}, nil
+		Fingerprint:  completion.SystemFingerprint,
+	}
+	timer.apply(resp)
+	return resp, streamed, nil
 }
 
 // Abort aborts the current generation.
@@ -377,13 +842,18 @@ func (s *ChatSession) Abort(reason string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	return s.stream.Send(&llmpb.ChatRequest{
+	return s.stream.Send(abortRequest(reason))
+}
+
+// abortRequest builds the request that stops the generation in flight.
+func abortRequest(reason string) *llmpb.ChatRequest {
+	return &llmpb.ChatRequest{
 		Request: &llmpb.ChatRequest_Abort{
 			Abort: &llmpb.AbortRequest{
 				Reason: reason,
 			},
 		},
-	})
+	}
 }
 
 // Close closes the chat session.
@@ -392,33 +862,30 @@ func (s *ChatSession) Close() error {
 	defer s.mu.Unlock()
 
 	s.done = true
-	return s.stream.CloseSend()
+	// Read the stream to its end so gRPC releases it and interceptors see
+	// the final status.
+	return drainStream(s.stream)
 }
 
 // ChatStream sends a message and streams the response via callback.
 // This is a convenience method for simple streaming use cases.
 func (c *LLMClient) ChatStream(ctx context.Context, req ChatRequest, callback StreamCallback) (*ChatResponse, error) {
-	session, err := c.NewChatSession(ctx, ChatRequest{
-		SystemPrompt: req.SystemPrompt,
-		Model:        req.Model,
-		MaxTokens:    req.MaxTokens,
-		Temperature:  req.Temperature,
-	})
-	if err != nil {
-		return nil, err
-	}
-	defer session.Close()
-
-	// If there are existing messages, we need to send them as the initial context
-	// and then send the last user message
 	if len(req.Messages) == 0 {
 		return nil, fmt.Errorf("at least one message is required")
 	}
-
 	lastMsg := req.Messages[len(req.Messages)-1]
 	if lastMsg.Role != "user" {
 		return nil, fmt.Errorf("last message must be from user")
 	}
 
-	return session.Send(ctx, lastMsg.Content, callback)
+	// Earlier messages open the session as context; the last one is the turn.
+	start := req
+	start.Messages = req.Messages[:len(req.Messages)-1]
+	session, err := c.NewChatSession(ctx, start)
+	if err != nil {
+		return nil, err
+	}
+	defer session.Close()
+
+	return session.SendMessage(ctx, lastMsg, callback)
 }
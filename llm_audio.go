@@ -0,0 +1,150 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/almatuck/levee-go/llmpb"
+)
+
+// AudioPart is a speech input of a chat message. The gateway transcribes it
+// before the model sees the message. Set URL, or Data with its MediaType.
+type AudioPart struct {
+	URL       string `json:"url,omitempty"`
+	Data      []byte `json:"data,omitempty"` // base64 in JSON
+	MediaType string `json:"media_type,omitempty"`
+}
+
+// AudioBytes returns an inline speech input. An empty mediaType is detected
+// from the data.
+func AudioBytes(data []byte, mediaType string) AudioPart {
+	if mediaType == "" {
+		mediaType = http.DetectContentType(data)
+	}
+	return AudioPart{Data: data, MediaType: mediaType}
+}
+
+// AudioFile reads an inline speech input from a file.
+func AudioFile(path string) (AudioPart, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return AudioPart{}, fmt.Errorf("failed to read audio: %w", err)
+	}
+	return AudioBytes(data, mime.TypeByExtension(filepath.Ext(path))), nil
+}
+
+// Transcription is the text of a speech recording.
+type Transcription struct {
+	Text       string
+	Language   string // detected ISO 639-1 code
+	DurationMs int64
+	CostUSD    float64
+}
+
+// Transcribe converts speech to text. language is an optional ISO 639-1 hint.
+func (c *LLMClient) Transcribe(ctx context.Context, audio AudioPart, language string) (*Transcription, error) {
+	if err := c.connect(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Transcribe(ctx, &llmpb.TranscribeRequest{
+		ApiKey:   c.apiKey,
+		Audio:    audioToPB(audio),
+		Language: language,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("transcribe request failed: %w", err)
+	}
+	return &Transcription{
+		Text:       resp.Text,
+		Language:   resp.Language,
+		DurationMs: resp.DurationMs,
+		CostUSD:    resp.CostUsd,
+	}, nil
+}
+
+// SpeechRequest is a text-to-speech request.
+type SpeechRequest struct {
+	Text   string
+	Voice  string // provider voice name; empty uses the default
+	Format string // "mp3", "opus", "wav", "pcm" (default "mp3")
+	Model  string
+}
+
+// AudioChunk is a piece of synthesized speech.
+type AudioChunk struct {
+	Data      []byte
+	MediaType string
+	Index     int32
+}
+
+// Synthesize converts text to speech, calling callback with each audio chunk
+// as it is generated so playback can start early.
+//
+//	err := llm.Synthesize(ctx, levee.SpeechRequest{Text: resp.Content, Format: "mp3"},
+//		func(chunk levee.AudioChunk) error {
+//			_, err := w.Write(chunk.Data)
+//			return err
+//		})
+func (c *LLMClient) Synthesize(ctx context.Context, req SpeechRequest, callback func(AudioChunk) error) error {
+	if err := c.connect(); err != nil {
+		return err
+	}
+
+	stream, err := c.client.Synthesize(ctx, &llmpb.SynthesizeRequest{
+		ApiKey: c.apiKey,
+		Text:   req.Text,
+		Voice:  req.Voice,
+		Format: req.Format,
+		Model:  req.Model,
+	})
+	if err != nil {
+		return fmt.Errorf("synthesize request failed: %w", err)
+	}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("synthesize stream error: %w", err)
+		}
+		if err := callback(AudioChunk{Data: chunk.Data, MediaType: chunk.MediaType, Index: chunk.Index}); err != nil {
+			return err
+		}
+	}
+}
+
+// audioToPB converts a speech input to its gRPC form.
+func audioToPB(a AudioPart) *llmpb.Audio {
+	return &llmpb.Audio{Url: a.URL, Data: a.Data, MediaType: a.MediaType}
+}
+
+// audioPartsToPB converts speech inputs to their gRPC form.
+func audioPartsToPB(parts []AudioPart) []*llmpb.Audio {
+	if len(parts) == 0 {
+		return nil
+	}
+	out := make([]*llmpb.Audio, 0, len(parts))
+	for _, a := range parts {
+		out = append(out, audioToPB(a))
+	}
+	return out
+}
+
+// audioPartsFromPB converts gRPC speech inputs back.
+func audioPartsFromPB(parts []*llmpb.Audio) []AudioPart {
+	if len(parts) == 0 {
+		return nil
+	}
+	out := make([]AudioPart, 0, len(parts))
+	for _, a := range parts {
+		out = append(out, AudioPart{URL: a.Url, Data: a.Data, MediaType: a.MediaType})
+	}
+	return out
+}
@@ -0,0 +1,168 @@
+package levee
+
+import (
+	"context"
+	"regexp"
+	"time"
+)
+
+// AuditSource is the API an audited call was made through.
+type AuditSource string
+
+const (
+	AuditChat      AuditSource = "chat"      // LLMClient.Chat
+	AuditSession   AuditSource = "session"   // ChatSession turns
+	AuditWebSocket AuditSource = "websocket" // HandleChatWebSocket replies
+)
+
+// AuditRecord is a prompt and its completion, for compliance retention. Text
+// fields have the client's redaction rules applied.
+type AuditRecord struct {
+	Time   time.Time
+	Source AuditSource
+	// SessionID is the ConversationStore session ID, if any. GatewaySessionID
+	// is the gateway's ID of a chat session or WebSocket chat.
+	SessionID        string
+	GatewaySessionID string
+	RequestID        string
+
+	Model        string
+	Provider     string
+	SystemPrompt string
+	// Messages are the prompt messages of the call. Stored and earlier
+	// session history is not repeated.
+	Messages   []ChatMessage
+	Completion string
+	StopReason string
+	Cached     bool
+
+	InputTokens  int64
+	OutputTokens int64
+	CostUSD      float64
+
+	// Error is set if the call failed.
+	Error string
+}
+
+// AuditSink receives every prompt and completion of an LLMClient. Audit is
+// called synchronously after each call, so slow sinks should buffer; sinks
+// handle their own delivery errors. Implementations must be safe for
+// concurrent use.
+type AuditSink interface {
+	Audit(ctx context.Context, rec AuditRecord)
+}
+
+// AuditSinkFunc adapts a function to AuditSink.
+type AuditSinkFunc func(ctx context.Context, rec AuditRecord)
+
+// Audit calls f(ctx, rec).
+func (f AuditSinkFunc) Audit(ctx context.Context, rec AuditRecord) {
+	f(ctx, rec)
+}
+
+// RedactionRule replaces text matching Pattern with Replacement, which may
+// use regexp expansion ($1).
+type RedactionRule struct {
+	Name        string
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// Built-in redaction rules for common PII.
+var (
+	RedactEmails = RedactionRule{
+		Name:        "email",
+		Pattern:     regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+		Replacement: "[email]",
+	}
+	RedactPhoneNumbers = RedactionRule{
+		Name:        "phone",
+		Pattern:     regexp.MustCompile(`(?:\+\d{1,3}[\s.-]?)?\(?\d{3}\)?[\s.-]?\d{3}[\s.-]?\d{4}\b`),
+		Replacement: "[phone]",
+	}
+	RedactCardNumbers = RedactionRule{
+		Name:        "card",
+		Pattern:     regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`),
+		Replacement: "[card]",
+	}
+	RedactSSNs = RedactionRule{
+		Name:        "ssn",
+		Pattern:     regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`),
+		Replacement: "[ssn]",
+	}
+)
+
+// DefaultRedactionRules redact emails, card numbers, SSNs, and phone numbers.
+var DefaultRedactionRules = []RedactionRule{RedactEmails, RedactCardNumbers, RedactSSNs, RedactPhoneNumbers}
+
+// Redact applies rules to text in order.
+func Redact(text string, rules ...RedactionRule) string {
+	for _, rule := range rules {
+		text = rule.Pattern.ReplaceAllString(text, rule.Replacement)
+	}
+	return text
+}
+
+// WithAuditSink sends every prompt and completion to sink, redacted with
+// rules. With no rules, DefaultRedactionRules are used; pass
+// []levee.RedactionRule{}... to audit unredacted text.
+//
+//	llm := levee.NewLLMClient(apiKey, baseURL,
+//		levee.WithAuditSink(sink, levee.RedactEmails, levee.RedactCardNumbers),
+//	)
+func WithAuditSink(sink AuditSink, rules ...RedactionRule) LLMOption {
+	return func(c *LLMClient) {
+		c.auditSink = sink
+		c.redactionRules = rules
+		if rules == nil {
+			c.redactionRules = DefaultRedactionRules
+		}
+	}
+}
+
+// audit redacts rec and sends it to the audit sink, if any.
+func (c *LLMClient) audit(ctx context.Context, rec AuditRecord) {
+	if c.auditSink == nil {
+		return
+	}
+	rec.Time = time.Now()
+	rec.SystemPrompt = Redact(rec.SystemPrompt, c.redactionRules...)
+	rec.Completion = Redact(rec.Completion, c.redactionRules...)
+	rec.Error = Redact(rec.Error, c.redactionRules...)
+	messages := make([]ChatMessage, len(rec.Messages))
+	for i, m := range rec.Messages {
+		m.Content = Redact(m.Content, c.redactionRules...)
+		messages[i] = m
+	}
+	rec.Messages = messages
+	c.auditSink.Audit(ctx, rec)
+}
+
+// auditRecord starts the audit record of a session turn sending prompt.
+func (s *ChatSession) auditRecord(prompt ...ChatMessage) AuditRecord {
+	return AuditRecord{
+		Source:           AuditSession,
+		SessionID:        s.sessionID,
+		GatewaySessionID: s.gatewayID,
+		Model:            s.start.Model,
+		SystemPrompt:     s.start.SystemPrompt,
+		Messages:         prompt,
+	}
+}
+
+// withResult fills in the outcome of a call: resp on success, or err.
+func (rec AuditRecord) withResult(resp *ChatResponse, err error) AuditRecord {
+	if err != nil {
+		rec.Error = err.Error()
+		return rec
+	}
+	rec.Model = resp.Model
+	rec.Provider = resp.Provider
+	rec.Completion = resp.Content
+	rec.StopReason = resp.StopReason
+	rec.Cached = resp.Cached
+	rec.InputTokens = resp.InputTokens
+	rec.OutputTokens = resp.OutputTokens
+	rec.CostUSD = resp.CostUSD
+	return rec
+}
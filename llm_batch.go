@@ -0,0 +1,231 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"time"
+
+	"github.com/almatuck/levee-go/llmpb"
+)
+
+// BatchStatus is the state of a batch job.
+type BatchStatus string
+
+// Batch job states.
+const (
+	BatchQueued    BatchStatus = "queued"
+	BatchRunning   BatchStatus = "running"
+	BatchCompleted BatchStatus = "completed"
+	BatchFailed    BatchStatus = "failed"
+	BatchCancelled BatchStatus = "cancelled"
+	BatchExpired   BatchStatus = "expired"
+)
+
+// BatchJob tracks a batch of chat requests processed offline.
+type BatchJob struct {
+	ID        string
+	Status    BatchStatus
+	Total     int
+	Completed int
+	Failed    int
+	CostUSD   float64
+	CreatedAt time.Time
+	EndedAt   time.Time // zero while the job runs
+	Error     string
+}
+
+// Done reports whether the job has stopped processing requests.
+func (j *BatchJob) Done() bool {
+	switch j.Status {
+	case BatchCompleted, BatchFailed, BatchCancelled, BatchExpired:
+		return true
+	}
+	return false
+}
+
+// BatchResult is the outcome of one request of a batch. Index is the
+// request's position in SubmitBatch, and RequestID its ChatRequest.RequestID.
+type BatchResult struct {
+	Index     int
+	RequestID string
+	Response  *ChatResponse
+	Err       error
+}
+
+// SubmitBatch queues chat requests for offline processing at batch pricing,
+// for large jobs such as summarizing every support ticket. Results usually
+// arrive within hours; follow the job with GetBatch, WaitForBatch, or
+// BatchResults. Requests can't use a SessionID.
+//
+//	job, err := llm.SubmitBatch(ctx, requests)
+//	for result, err := range llm.BatchResults(ctx, job.ID) {
+//		if err != nil {
+//			return err
+//		}
+//		if result.Err != nil {
+//			log.Printf("ticket %s: %v", result.RequestID, result.Err)
+//			continue
+//		}
+//		save(result.RequestID, result.Response.Content)
+//	}
+func (c *LLMClient) SubmitBatch(ctx context.Context, reqs []ChatRequest) (*BatchJob, error) {
+	if len(reqs) == 0 {
+		return nil, fmt.Errorf("at least one request is required")
+	}
+	if err := c.connect(); err != nil {
+		return nil, err
+	}
+
+	pbReqs := make([]*llmpb.SimpleChatRequest, 0, len(reqs))
+	for i, req := range reqs {
+		if req.SessionID != "" {
+			return nil, fmt.Errorf("batch request %d: session IDs are not supported", i)
+		}
+		pbReqs = append(pbReqs, &llmpb.SimpleChatRequest{
+			Messages:     withExamples(req.Examples, req.Messages),
+			SystemPrompt: req.SystemPrompt,
+			Model:        req.Model,
+			MaxTokens:    req.MaxTokens,
+			Temperature:  req.Temperature,
+			RequestId:    req.RequestID,
+			Params:       c.params(req),
+		})
+		pbReqs[i].Provider, pbReqs[i].Routing = c.route(req)
+	}
+
+	job, err := c.client.SubmitBatch(ctx, &llmpb.SubmitBatchRequest{
+		ApiKey:   c.apiKey,
+		Requests: pbReqs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("submit batch failed: %w", err)
+	}
+	return batchJobFromPB(job), nil
+}
+
+// GetBatch returns the status of a batch job.
+func (c *LLMClient) GetBatch(ctx context.Context, batchID string) (*BatchJob, error) {
+	if err := c.connect(); err != nil {
+		return nil, err
+	}
+	job, err := c.client.GetBatch(ctx, &llmpb.GetBatchRequest{ApiKey: c.apiKey, BatchId: batchID})
+	if err != nil {
+		return nil, fmt.Errorf("get batch failed: %w", err)
+	}
+	return batchJobFromPB(job), nil
+}
+
+// CancelBatch stops a batch job. Results already finished remain available.
+func (c *LLMClient) CancelBatch(ctx context.Context, batchID string) (*BatchJob, error) {
+	if err := c.connect(); err != nil {
+		return nil, err
+	}
+	job, err := c.client.CancelBatch(ctx, &llmpb.GetBatchRequest{ApiKey: c.apiKey, BatchId: batchID})
+	if err != nil {
+		return nil, fmt.Errorf("cancel batch failed: %w", err)
+	}
+	return batchJobFromPB(job), nil
+}
+
+// WaitForBatch polls a batch job every interval (default 10s) until it ends or
+// ctx is done.
+// A failed or expired job is returned with an error.
+func (c *LLMClient) WaitForBatch(ctx context.Context, batchID string, interval time.Duration) (*BatchJob, error) {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		job, err := c.GetBatch(ctx, batchID)
+		if err != nil {
+			return nil, err
+		}
+		switch job.Status {
+		case BatchCompleted, BatchCancelled:
+			return job, nil
+		case BatchFailed, BatchExpired:
+			return job, fmt.Errorf("batch %s %s: %s", job.ID, job.Status, job.Error)
+		}
+
+		select {
+		case <-ctx.Done():
+			return job, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// BatchResults streams the results of a batch job as they finish, in
+// completion order, ending when the job does. Failed requests are reported in
+// BatchResult.Err; the iterator's error is for the stream itself.
+func (c *LLMClient) BatchResults(ctx context.Context, batchID string) iter.Seq2[BatchResult, error] {
+	return func(yield func(BatchResult, error) bool) {
+		if err := c.connect(); err != nil {
+			yield(BatchResult{}, err)
+			return
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		stream, err := c.client.StreamBatchResults(ctx, &llmpb.GetBatchRequest{ApiKey: c.apiKey, BatchId: batchID})
+		if err != nil {
+			yield(BatchResult{}, fmt.Errorf("batch results request failed: %w", err))
+			return
+		}
+		for {
+			r, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(BatchResult{}, fmt.Errorf("batch results stream error: %w", err))
+				return
+			}
+
+			result := BatchResult{Index: int(r.Index), RequestID: r.RequestId}
+			if r.Error != nil {
+				result.Err = &LLMError{Code: r.Error.Code, Message: r.Error.Message, Retryable: r.Error.Retryable}
+			} else if resp := r.Response; resp != nil {
+				result.Response = &ChatResponse{
+					Content:      resp.Content,
+					Model:        resp.Model,
+					InputTokens:  resp.InputTokens,
+					OutputTokens: resp.OutputTokens,
+					CostUSD:      resp.CostUsd,
+					LatencyMs:    resp.LatencyMs,
+					StopReason:   resp.StopReason,
+					Provider:     resp.Provider,
+					Fingerprint:  resp.SystemFingerprint,
+				}
+			}
+			if !yield(result, nil) {
+				return
+			}
+		}
+	}
+}
+
+// batchJobFromPB converts a gRPC batch job.
+func batchJobFromPB(job *llmpb.BatchJob) *BatchJob {
+	out := &BatchJob{
+		ID:        job.Id,
+		Status:    BatchStatus(job.Status),
+		Total:     int(job.Total),
+		Completed: int(job.Completed),
+		Failed:    int(job.Failed),
+		CostUSD:   job.CostUsd,
+		Error:     job.Error,
+	}
+	if job.CreatedAt > 0 {
+		out.CreatedAt = time.Unix(job.CreatedAt, 0)
+	}
+	if job.EndedAt > 0 {
+		out.EndedAt = time.Unix(job.EndedAt, 0)
+	}
+	return out
+}
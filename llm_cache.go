@@ -0,0 +1,201 @@
+package levee
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/almatuck/levee-go/llmpb"
+)
+
+// ResponseCache stores Chat responses so repeated identical requests (e.g.
+// classifying the same text) aren't sent and billed again. key is
+// ChatCacheKey(req); exact-match caches use it alone, while semantic caches
+// can match similar requests from req instead. Implementations must be safe
+// for concurrent use.
+type ResponseCache interface {
+	// Get returns the cached response for a request, if any.
+	Get(ctx context.Context, key string, req ChatRequest) (*ChatResponse, bool, error)
+	// Set caches a response for ttl (0 means no expiry).
+	Set(ctx context.Context, key string, req ChatRequest, resp *ChatResponse, ttl time.Duration) error
+}
+
+// WithResponseCache caches Chat responses for ttl. Requests with a SessionID
+// or SkipCache are not cached. Cache errors are treated as misses, so a failing
+// cache never fails a chat.
+func WithResponseCache(cache ResponseCache, ttl time.Duration) LLMOption {
+	return func(c *LLMClient) {
+		c.cache = cache
+		c.cacheTTL = ttl
+	}
+}
+
+// ChatCacheKey returns the exact-match cache key of a request: a hash of its
+// model, provider, system prompt, messages, and sampling parameters.
+func ChatCacheKey(req ChatRequest) string {
+	b, _ := json.Marshal(struct {
+		Model        string                  `json:"model"`
+		SystemPrompt string                  `json:"system_prompt"`
+		Messages     []ChatMessage           `json:"messages"`
+		MaxTokens    int32                   `json:"max_tokens"`
+		Temperature  float32                 `json:"temperature"`
+		Provider     Provider                `json:"provider,omitempty"`
+		Routing      RoutingPolicy           `json:"routing,omitempty"`
+		Params       *llmpb.GenerationParams `json:"params,omitempty"`
+		Examples     []ExamplePair           `json:"examples,omitempty"`
+	}{req.Model, req.SystemPrompt, req.Messages, req.MaxTokens, req.Temperature, req.Provider, req.Routing, req.paramsToPB(), req.Examples})
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// cacheable reports whether a request's response may be cached.
+func (c *LLMClient) cacheable(req ChatRequest) bool {
+	return c.cache != nil && req.SessionID == "" && !req.SkipCache
+}
+
+// cachedResponse returns a cache hit for req. Nothing is billed for a hit, so
+// its tokens, cost, and attempts are zero.
+func (c *LLMClient) cachedResponse(ctx context.Context, key string, req ChatRequest) (*ChatResponse, bool) {
+	resp, ok, err := c.cache.Get(ctx, key, req)
+	if err != nil || !ok || resp == nil {
+		return nil, false
+	}
+	return &ChatResponse{
+		Content:     resp.Content,
+		Model:       resp.Model,
+		StopReason:  resp.StopReason,
+		Provider:    resp.Provider,
+		Cached:      true,
+		Fingerprint: resp.Fingerprint,
+	}, true
+}
+
+// MemoryResponseCache is an in-process exact-match ResponseCache.
+type MemoryResponseCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]cachedResponse
+}
+
+type cachedResponse struct {
+	resp    ChatResponse
+	expires time.Time // zero never expires
+}
+
+// NewMemoryResponseCache returns a cache holding up to maxEntries responses
+// (0 means unbounded). When full, expired entries are pruned first, then
+// arbitrary ones.
+func NewMemoryResponseCache(maxEntries int) *MemoryResponseCache {
+	return &MemoryResponseCache{maxEntries: maxEntries, entries: make(map[string]cachedResponse)}
+}
+
+func (m *MemoryResponseCache) Get(ctx context.Context, key string, req ChatRequest) (*ChatResponse, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if !e.expires.IsZero() && time.Now().After(e.expires) {
+		delete(m.entries, key)
+		return nil, false, nil
+	}
+	resp := e.resp
+	return &resp, true, nil
+}
+
+func (m *MemoryResponseCache) Set(ctx context.Context, key string, req ChatRequest, resp *ChatResponse, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if _, exists := m.entries[key]; !exists && m.maxEntries > 0 && len(m.entries) >= m.maxEntries {
+		for k, e := range m.entries {
+			if !e.expires.IsZero() && now.After(e.expires) {
+				delete(m.entries, k)
+			}
+		}
+		for k := range m.entries {
+			if len(m.entries) < m.maxEntries {
+				break
+			}
+			delete(m.entries, k)
+		}
+	}
+
+	e := cachedResponse{resp: *resp}
+	e.resp.Attempts = nil
+	if ttl > 0 {
+		e.expires = now.Add(ttl)
+	}
+	m.entries[key] = e
+	return nil
+}
+
+// Clear removes all cached responses.
+func (m *MemoryResponseCache) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clear(m.entries)
+}
+
+// RedisResponseCache is an exact-match ResponseCache storing JSON responses
+// under KeyPrefix+key, shared by all app servers.
+type RedisResponseCache struct {
+	do        RedisDo
+	KeyPrefix string
+}
+
+// NewRedisResponseCache returns a Redis cache with the key prefix "levee:llmcache:".
+func NewRedisResponseCache(do RedisDo) *RedisResponseCache {
+	return &RedisResponseCache{do: do, KeyPrefix: "levee:llmcache:"}
+}
+
+// redisCachedResponse is the stored form of a cached response.
+type redisCachedResponse struct {
+	Content    string `json:"content"`
+	Model      string `json:"model"`
+	StopReason string `json:"stop_reason"`
+	Provider   string `json:"provider,omitempty"`
+}
+
+func (r *RedisResponseCache) Get(ctx context.Context, key string, req ChatRequest) (*ChatResponse, bool, error) {
+	reply, err := r.do(ctx, "GET", r.KeyPrefix+key)
+	if err != nil || reply == nil {
+		// go-redis reports a missing key as an error; treat any error as a miss
+		return nil, false, nil
+	}
+
+	var raw []byte
+	switch v := reply.(type) {
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return nil, false, fmt.Errorf("unexpected GET reply %T", reply)
+	}
+	var stored redisCachedResponse
+	if err := json.Unmarshal(raw, &stored); err != nil {
+		return nil, false, fmt.Errorf("invalid cached response: %w", err)
+	}
+	return &ChatResponse{Content: stored.Content, Model: stored.Model, StopReason: stored.StopReason, Provider: stored.Provider}, true, nil
+}
+
+func (r *RedisResponseCache) Set(ctx context.Context, key string, req ChatRequest, resp *ChatResponse, ttl time.Duration) error {
+	b, err := json.Marshal(redisCachedResponse{Content: resp.Content, Model: resp.Model, StopReason: resp.StopReason, Provider: resp.Provider})
+	if err != nil {
+		return err
+	}
+	args := []any{"SET", r.KeyPrefix + key, string(b)}
+	if ttl > 0 {
+		args = append(args, "PX", ttl.Milliseconds())
+	}
+	_, err = r.do(ctx, args...)
+	return err
+}
@@ -0,0 +1,67 @@
+package levee
+
+import "testing"
+
+func TestChatCacheKey(t *testing.T) {
+	base := func() ChatRequest {
+		return ChatRequest{
+			Model:        "haiku",
+			SystemPrompt: "Classify the sentiment.",
+			Messages:     []ChatMessage{{Role: "user", Content: "I love it"}},
+			MaxTokens:    100,
+			Temperature:  0.2,
+		}
+	}
+	seed := int64(7)
+
+	tests := []struct {
+		name   string
+		change func(*ChatRequest)
+		same   bool
+	}{
+		{"identical", func(*ChatRequest) {}, true},
+		{"session ID", func(r *ChatRequest) { r.SessionID = "s1" }, true},
+		{"request ID", func(r *ChatRequest) { r.RequestID = "r1" }, true},
+		{"skip cache", func(r *ChatRequest) { r.SkipCache = true }, true},
+		{"model", func(r *ChatRequest) { r.Model = "sonnet" }, false},
+		{"system prompt", func(r *ChatRequest) { r.SystemPrompt = "Summarize." }, false},
+		{"message content", func(r *ChatRequest) { r.Messages[0].Content = "I hate it" }, false},
+		{"message role", func(r *ChatRequest) { r.Messages[0].Role = "assistant" }, false},
+		{"extra message", func(r *ChatRequest) {
+			r.Messages = append(r.Messages, ChatMessage{Role: "user", Content: "really"})
+		}, false},
+		{"max tokens", func(r *ChatRequest) { r.MaxTokens = 200 }, false},
+		{"temperature", func(r *ChatRequest) { r.Temperature = 0.9 }, false},
+		{"provider", func(r *ChatRequest) { r.Provider = ProviderAnthropic }, false},
+		{"stop sequences", func(r *ChatRequest) { r.StopSequences = []string{"\n"} }, false},
+		{"top p", func(r *ChatRequest) { r.TopP = 0.5 }, false},
+		{"seed", func(r *ChatRequest) { r.Seed = &seed }, false},
+		{"deterministic", func(r *ChatRequest) { r.Deterministic = true }, false},
+		{"examples", func(r *ChatRequest) {
+			r.Examples = []ExamplePair{{Input: "meh", Output: "neutral"}}
+		}, false},
+	}
+
+	want := ChatCacheKey(base())
+	if len(want) != 64 {
+		t.Fatalf("ChatCacheKey() = %q, want a hex SHA-256", want)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := base()
+			tt.change(&req)
+			if got := ChatCacheKey(req); (got == want) != tt.same {
+				t.Errorf("key equal to base = %v, want %v", got == want, tt.same)
+			}
+		})
+	}
+}
+
+func TestChatCacheKeyMessageBoundaries(t *testing.T) {
+	// Content moved between messages must not produce the same key.
+	a := ChatRequest{Messages: []ChatMessage{{Role: "user", Content: "ab"}, {Role: "user", Content: "c"}}}
+	b := ChatRequest{Messages: []ChatMessage{{Role: "user", Content: "a"}, {Role: "user", Content: "bc"}}}
+	if ChatCacheKey(a) == ChatCacheKey(b) {
+		t.Error("requests with different message boundaries share a cache key")
+	}
+}
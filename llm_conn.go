@@ -0,0 +1,179 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/almatuck/levee-go/llmpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Stream reconnect defaults.
+const (
+	maxStreamReconnects       = 3
+	defaultReconnectBaseDelay = 500 * time.Millisecond
+	defaultReconnectMaxDelay  = 10 * time.Second
+)
+
+// WithKeepalive pings the gateway every interval while the connection is idle
+// and drops it if no ack arrives within timeout, so dead connections behind
+// load balancers are detected before the next request. The server's keepalive
+// enforcement policy must allow the interval (gRPC servers default to 5 minutes).
+func WithKeepalive(interval, timeout time.Duration) LLMOption {
+	return func(c *LLMClient) {
+		c.keepalive = &keepalive.ClientParameters{
+			Time:                interval,
+			Timeout:             timeout,
+			PermitWithoutStream: true,
+		}
+	}
+}
+
+// WithWaitForReady makes calls wait for the connection to become ready (e.g.
+// while the gateway restarts) instead of failing fast. Calls still fail when
+// their context expires.
+func WithWaitForReady() LLMOption {
+	return func(c *LLMClient) {
+		c.waitForReady = true
+	}
+}
+
+// WithReconnectBackoff sets the exponential backoff between reconnect attempts,
+// both for the gRPC channel and for re-opening chat streams.
+func WithReconnectBackoff(base, max time.Duration) LLMOption {
+	return func(c *LLMClient) {
+		c.reconnectBase = base
+		c.reconnectMax = max
+	}
+}
+
+// Ping connects to the LLM gateway, waits for the channel to become ready, and
+// runs a gRPC health check. Gateways without the health service count as healthy
+// once the channel is ready. Over the HTTPS transport, it checks the API answers.
+func (c *LLMClient) Ping(ctx context.Context) error {
+	if err := c.checkConnectivity(ctx); err != nil {
+		return err
+	}
+	if c.usingHTTP() {
+		return nil
+	}
+
+	c.mu.Lock()
+	pool := c.pool
+	c.mu.Unlock()
+	if pool == nil {
+		return fmt.Errorf("LLM client is closed")
+	}
+
+	resp, err := healthpb.NewHealthClient(pool).Check(ctx, &healthpb.HealthCheckRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("LLM health check failed: %w", err)
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("LLM gateway not serving: %s", resp.Status)
+	}
+	return nil
+}
+
+// connDialOptions returns the dial options for keepalive, reconnect backoff, and
+// wait-for-ready.
+func (c *LLMClient) connDialOptions() []grpc.DialOption {
+	var opts []grpc.DialOption
+	if c.keepalive != nil {
+		opts = append(opts, grpc.WithKeepaliveParams(*c.keepalive))
+	}
+	if c.reconnectBase > 0 {
+		cfg := backoff.DefaultConfig
+		cfg.BaseDelay = c.reconnectBase
+		if c.reconnectMax > 0 {
+			cfg.MaxDelay = c.reconnectMax
+		}
+		opts = append(opts, grpc.WithConnectParams(grpc.ConnectParams{Backoff: cfg}))
+	}
+	if c.waitForReady {
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.WaitForReady(true)))
+	}
+	return opts
+}
+
+// openChat opens a Chat stream and sends the start request.
+func (c *LLMClient) openChat(ctx context.Context, start *llmpb.StartChatRequest) (llmpb.LLMService_ChatClient, error) {
+	if err := c.connect(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	client := c.client
+	c.mu.Unlock()
+	if client == nil {
+		return nil, fmt.Errorf("LLM client is closed")
+	}
+
+	stream, err := client.Chat(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start chat session: %w", err)
+	}
+
+	err = stream.Send(&llmpb.ChatRequest{
+		Request: &llmpb.ChatRequest_Start{Start: start},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send start request: %w", err)
+	}
+	return stream, nil
+}
+
+// reopenChat re-opens a broken chat stream with history replayed after the
+// start request's messages, retrying with backoff.
+func (c *LLMClient) reopenChat(ctx context.Context, start *llmpb.StartChatRequest, history []*llmpb.Message) (llmpb.LLMService_ChatClient, error) {
+	replay := proto.Clone(start).(*llmpb.StartChatRequest)
+	replay.Messages = append(replay.Messages, history...)
+
+	var lastErr error
+	for attempt := 0; attempt < maxStreamReconnects; attempt++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(c.reconnectDelay(attempt)):
+		}
+
+		stream, err := c.openChat(ctx, replay)
+		if err == nil {
+			return stream, nil
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("failed to reconnect chat stream: %w", lastErr)
+}
+
+// reconnectDelay returns the exponential backoff before reconnect attempt n.
+func (c *LLMClient) reconnectDelay(n int) time.Duration {
+	base, max := c.reconnectBase, c.reconnectMax
+	if base <= 0 {
+		base = defaultReconnectBaseDelay
+	}
+	if max <= 0 {
+		max = defaultReconnectMaxDelay
+	}
+	delay := base << n
+	if delay <= 0 || delay > max {
+		delay = max
+	}
+	return delay
+}
+
+// isStreamBroken reports whether err means the transport broke (e.g. the
+// gateway restarted) rather than the request failing.
+func isStreamBroken(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
@@ -0,0 +1,99 @@
+package levee
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// WithTLS connects to the LLM gateway over TLS with config, e.g. a private CA
+// in RootCAs, a ServerName override, or client certificates for mutual TLS.
+// It applies even when baseURL is http://.
+func WithTLS(config *tls.Config) LLMOption {
+	return func(c *LLMClient) {
+		c.tlsConfig = config
+		c.useTLS = true
+	}
+}
+
+// WithSystemCertPool connects to the LLM gateway over TLS, verifying its
+// certificate against the operating system's trusted roots.
+func WithSystemCertPool() LLMOption {
+	return func(c *LLMClient) {
+		// On error pool is nil, which makes crypto/tls use the platform verifier.
+		pool, _ := x509.SystemCertPool()
+		c.tlsConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+		c.useTLS = true
+	}
+}
+
+// WithInsecure uses a plaintext gRPC connection even when baseURL is
+// https://, e.g. for a gateway sidecar on localhost.
+func WithInsecure() LLMOption {
+	return func(c *LLMClient) {
+		c.tlsConfig = nil
+		c.useTLS = false
+	}
+}
+
+// WithPerRPCToken sends token as an "authorization: Bearer" header on every
+// gRPC call, for gateways that authenticate in front of the LLM service.
+// The token is only sent over TLS.
+func WithPerRPCToken(token string) LLMOption {
+	return WithPerRPCTokenSource(func(context.Context) (string, error) {
+		return token, nil
+	})
+}
+
+// WithPerRPCTokenSource is like WithPerRPCToken for tokens that expire; fn is
+// called before every gRPC call and should cache tokens itself.
+func WithPerRPCTokenSource(fn func(ctx context.Context) (string, error)) LLMOption {
+	return WithPerRPCCredentials(tokenCredentials(fn))
+}
+
+// WithPerRPCCredentials attaches arbitrary credentials to every gRPC call,
+// e.g. from google.golang.org/grpc/credentials/oauth.
+func WithPerRPCCredentials(creds credentials.PerRPCCredentials) LLMOption {
+	return func(c *LLMClient) {
+		c.perRPC = append(c.perRPC, creds)
+	}
+}
+
+// tokenCredentials adapts a token function to credentials.PerRPCCredentials.
+type tokenCredentials func(ctx context.Context) (string, error)
+
+func (fn tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := fn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"authorization": "Bearer " + token}, nil
+}
+
+func (fn tokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// dialOptions returns the gRPC dial options for the client's transport and
+// per-RPC credentials.
+func (c *LLMClient) dialOptions() []grpc.DialOption {
+	var opts []grpc.DialOption
+	if c.useTLS {
+		config := c.tlsConfig
+		if config == nil {
+			config = &tls.Config{}
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(config)))
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	for _, creds := range c.perRPC {
+		opts = append(opts, grpc.WithPerRPCCredentials(creds))
+	}
+	opts = append(opts, c.connDialOptions()...)
+	return append(opts, c.interceptorDialOptions()...)
+}
@@ -0,0 +1,284 @@
+package levee
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Email content generation defaults.
+const (
+	defaultEmailVariants     = 3
+	defaultSubjectMaxLength  = 60
+	defaultPreviewMaxLength  = 110
+	defaultEmailContentModel = "sonnet"
+)
+
+// EmailBrief describes an email for GenerateEmailContent.
+type EmailBrief struct {
+	// Brief is what the email is for: the offer or news, key points, and the
+	// action readers should take. Required.
+	Brief    string
+	Audience string // who receives it, e.g. "trial users who haven't invited a teammate"
+	Tone     string // e.g. "friendly", "urgent", "formal"
+
+	// Template constrains the body to its content slots, the unescaped
+	// {{{variables}}} of its HTML and text (see TemplateSlots). Slots, if set,
+	// are used instead; with neither, the body is one "body" slot.
+	Template *Template
+	Slots    []EmailSlot
+
+	Variants         int    // candidates of each kind (default: 3)
+	SubjectMaxLength int    // characters (default: 60)
+	PreviewMaxLength int    // characters (default: 110)
+	Model            string // default: "sonnet"
+}
+
+// EmailSlot is a piece of the email body to write.
+type EmailSlot struct {
+	Name        string
+	Description string // what goes in it, e.g. "one-line headline"
+	MaxLength   int    // characters; 0 for no limit
+}
+
+// EmailCandidate is a generated subject line or preview text. Score is the
+// model's 0-1 estimate of how well it will perform.
+type EmailCandidate struct {
+	Text      string
+	Score     float64
+	Rationale string
+}
+
+// EmailBodyVariant is a generated body: content for each slot, by name.
+type EmailBodyVariant struct {
+	Slots     map[string]string
+	Score     float64
+	Rationale string
+}
+
+// EmailContent holds generated candidates, best first.
+type EmailContent struct {
+	Subjects     []EmailCandidate
+	PreviewTexts []EmailCandidate
+	Bodies       []EmailBodyVariant
+	Response     *ChatResponse // tokens and cost of the generation
+}
+
+// emailContentOutput is the structured reply of the model.
+type emailContentOutput struct {
+	Subjects     []emailCandidateOutput `json:"subjects"`
+	PreviewTexts []emailCandidateOutput `json:"preview_texts"`
+	Bodies       []struct {
+		Slots []struct {
+			Name    string `json:"name"`
+			Content string `json:"content"`
+		} `json:"slots"`
+		Score     float64 `json:"score" jsonschema:"minimum=0,maximum=1"`
+		Rationale string  `json:"rationale"`
+	} `json:"bodies"`
+}
+
+type emailCandidateOutput struct {
+	Text      string  `json:"text"`
+	Score     float64 `json:"score" jsonschema:"minimum=0,maximum=1"`
+	Rationale string  `json:"rationale"`
+}
+
+// GenerateEmailContent asks the model for subject lines, preview texts, and
+// body variants for a brief, with the body written into a template's slots.
+// Candidates over their length limits or missing a slot are dropped; the rest
+// are returned best first by the model's score.
+//
+//	tmpl, _ := client.GetTemplate(ctx, "product-update")
+//	content, err := llm.GenerateEmailContent(ctx, levee.EmailBrief{
+//		Brief:    "Announce dark mode, available today on all plans",
+//		Audience: "active users",
+//		Template: tmpl,
+//	})
+//	subject := content.Subjects[0].Text
+func (c *LLMClient) GenerateEmailContent(ctx context.Context, brief EmailBrief) (*EmailContent, error) {
+	if strings.TrimSpace(brief.Brief) == "" {
+		return nil, fmt.Errorf("brief is required")
+	}
+	slots := brief.Slots
+	if len(slots) == 0 && brief.Template != nil {
+		names, err := TemplateSlots(brief.Template)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			slots = append(slots, EmailSlot{Name: name})
+		}
+	}
+	if len(slots) == 0 {
+		slots = []EmailSlot{{Name: "body", Description: "the email body as HTML"}}
+	}
+	variants := cmp.Or(brief.Variants, defaultEmailVariants)
+	subjectMax := cmp.Or(brief.SubjectMaxLength, defaultSubjectMaxLength)
+	previewMax := cmp.Or(brief.PreviewMaxLength, defaultPreviewMaxLength)
+
+	prompt := briefPrompt(brief)
+	fmt.Fprintf(prompt, "\nWrite %d distinct subject lines of at most %d characters, %d preview texts of at most %d characters, "+
+		"and %d body variants. Each body fills exactly these slots:\n", variants, subjectMax, variants, previewMax, variants)
+	for _, s := range slots {
+		fmt.Fprintf(prompt, "- %s", s.Name)
+		if s.Description != "" {
+			fmt.Fprintf(prompt, ": %s", s.Description)
+		}
+		if s.MaxLength > 0 {
+			fmt.Fprintf(prompt, " (at most %d characters)", s.MaxLength)
+		}
+		prompt.WriteString("\n")
+	}
+
+	out, resp, err := ChatStructured[emailContentOutput](ctx, c, ChatRequest{
+		Model:        cmp.Or(brief.Model, defaultEmailContentModel),
+		SystemPrompt: copywriterPrompt,
+		Messages:     []ChatMessage{{Role: "user", Content: prompt.String()}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate email content: %w", err)
+	}
+
+	content := &EmailContent{
+		Subjects:     emailCandidates(out.Subjects, subjectMax),
+		PreviewTexts: emailCandidates(out.PreviewTexts, previewMax),
+		Response:     resp,
+	}
+	for _, b := range out.Bodies {
+		body := EmailBodyVariant{Slots: make(map[string]string, len(slots)), Score: b.Score, Rationale: b.Rationale}
+		for _, s := range b.Slots {
+			body.Slots[s.Name] = strings.TrimSpace(s.Content)
+		}
+		if fitsSlots(body.Slots, slots) {
+			content.Bodies = append(content.Bodies, body)
+		}
+	}
+	slices.SortStableFunc(content.Bodies, func(a, b EmailBodyVariant) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+
+	switch {
+	case len(content.Subjects) == 0:
+		return nil, fmt.Errorf("no generated subject line fits %d characters", subjectMax)
+	case len(content.PreviewTexts) == 0:
+		return nil, fmt.Errorf("no generated preview text fits %d characters", previewMax)
+	case len(content.Bodies) == 0:
+		return nil, fmt.Errorf("no generated body fills the template slots")
+	}
+	return content, nil
+}
+
+// GenerateSubjectLines asks the model for brief.Variants subject lines only,
+// returned best first like the subjects of GenerateEmailContent.
+func (c *LLMClient) GenerateSubjectLines(ctx context.Context, brief EmailBrief) ([]EmailCandidate, *ChatResponse, error) {
+	if strings.TrimSpace(brief.Brief) == "" {
+		return nil, nil, fmt.Errorf("brief is required")
+	}
+	variants := cmp.Or(brief.Variants, defaultEmailVariants)
+	subjectMax := cmp.Or(brief.SubjectMaxLength, defaultSubjectMaxLength)
+
+	prompt := briefPrompt(brief)
+	fmt.Fprintf(prompt, "\nWrite %d distinct subject lines of at most %d characters. "+
+		"Vary the angle (benefit, curiosity, urgency, specificity) so they are worth testing against each other.\n", variants, subjectMax)
+
+	out, resp, err := ChatStructured[struct {
+		Subjects []emailCandidateOutput `json:"subjects"`
+	}](ctx, c, ChatRequest{
+		Model:        cmp.Or(brief.Model, defaultEmailContentModel),
+		SystemPrompt: copywriterPrompt,
+		Messages:     []ChatMessage{{Role: "user", Content: prompt.String()}},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate subject lines: %w", err)
+	}
+	subjects := emailCandidates(out.Subjects, subjectMax)
+	if len(subjects) == 0 {
+		return nil, resp, fmt.Errorf("no generated subject line fits %d characters", subjectMax)
+	}
+	return subjects, resp, nil
+}
+
+// copywriterPrompt is the system prompt of email content generation.
+const copywriterPrompt = "You are an expert email copywriter. Write clear, specific copy without spammy phrasing or " +
+	"excessive punctuation. Score each candidate from 0 to 1 by how well you expect it to perform " +
+	"(opens for subject lines and preview texts, clicks for bodies) and give a one-sentence rationale."
+
+// briefPrompt starts the user prompt with the brief, audience, and tone.
+func briefPrompt(brief EmailBrief) *strings.Builder {
+	var prompt strings.Builder
+	fmt.Fprintf(&prompt, "Brief: %s\n", brief.Brief)
+	if brief.Audience != "" {
+		fmt.Fprintf(&prompt, "Audience: %s\n", brief.Audience)
+	}
+	if brief.Tone != "" {
+		fmt.Fprintf(&prompt, "Tone: %s\n", brief.Tone)
+	}
+	return &prompt
+}
+
+// emailCandidates keeps the candidates within maxLength, best first.
+func emailCandidates(out []emailCandidateOutput, maxLength int) []EmailCandidate {
+	var candidates []EmailCandidate
+	for _, o := range out {
+		text := strings.TrimSpace(o.Text)
+		if text == "" || utf8.RuneCountInString(text) > maxLength {
+			continue
+		}
+		candidates = append(candidates, EmailCandidate{Text: text, Score: o.Score, Rationale: o.Rationale})
+	}
+	slices.SortStableFunc(candidates, func(a, b EmailCandidate) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+	return candidates
+}
+
+// fitsSlots reports whether content fills every slot within its limit, and
+// drops content for unknown slots.
+func fitsSlots(content map[string]string, slots []EmailSlot) bool {
+	for name := range content {
+		if !slices.ContainsFunc(slots, func(s EmailSlot) bool { return s.Name == name }) {
+			delete(content, name)
+		}
+	}
+	for _, s := range slots {
+		text := content[s.Name]
+		if text == "" || (s.MaxLength > 0 && utf8.RuneCountInString(text) > s.MaxLength) {
+			return false
+		}
+	}
+	return true
+}
+
+// TemplateSlots returns the content slots of a template: the names of the
+// unescaped {{{variables}}} in its HTML and text, in order of appearance.
+// Escaped {{variables}} are left for personalization at send time.
+func TemplateSlots(tmpl *Template) ([]string, error) {
+	var slots []string
+	for _, src := range []string{tmpl.HTML, tmpl.Text} {
+		p := &mergeParser{src: src}
+		nodes, _, err := p.parse()
+		if err != nil {
+			return nil, err
+		}
+		collectSlots(nodes, &slots)
+	}
+	return slots, nil
+}
+
+// collectSlots appends the unescaped variables in nodes to slots.
+func collectSlots(nodes []mergeNode, slots *[]string) {
+	for _, n := range nodes {
+		if n.kind == "var" && n.raw {
+			name, _, _ := strings.Cut(n.path, "|")
+			if name = strings.TrimSpace(name); !slices.Contains(*slots, name) {
+				*slots = append(*slots, name)
+			}
+		}
+		collectSlots(n.body, slots)
+		collectSlots(n.elseBody, slots)
+	}
+}
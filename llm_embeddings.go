@@ -0,0 +1,75 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/almatuck/levee-go/llmpb"
+)
+
+// maxEmbedBatch is the number of inputs sent per Embed RPC.
+const maxEmbedBatch = 256
+
+// EmbedResponse holds one vector per input, in input order.
+type EmbedResponse struct {
+	Vectors     [][]float32
+	Model       string
+	Dimensions  int
+	InputTokens int64
+	CostUSD     float64
+	LatencyMs   int64
+}
+
+// Embed returns embedding vectors for inputs over the gateway connection.
+// model is an embedding model ID; empty uses the organization's default.
+// Large batches are split into several requests, with tokens, cost, and
+// latency totaled.
+//
+//	resp, err := llm.Embed(ctx, []string{"refund policy", "shipping times"}, "")
+//	for i, v := range resp.Vectors {
+//		index.Add(docs[i].ID, v)
+//	}
+func (c *LLMClient) Embed(ctx context.Context, inputs []string, model string) (*EmbedResponse, error) {
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("at least one input is required")
+	}
+	if err := c.connect(); err != nil {
+		return nil, err
+	}
+
+	result := &EmbedResponse{Vectors: make([][]float32, len(inputs))}
+	for start := 0; start < len(inputs); start += maxEmbedBatch {
+		batch := inputs[start:min(start+maxEmbedBatch, len(inputs))]
+
+		resp, err := c.client.Embed(ctx, &llmpb.EmbedRequest{
+			ApiKey: c.apiKey,
+			Inputs: batch,
+			Model:  model,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("embed request failed: %w", err)
+		}
+
+		for _, e := range resp.Embeddings {
+			if e.Index < 0 || int(e.Index) >= len(batch) {
+				return nil, fmt.Errorf("embed response index %d out of range", e.Index)
+			}
+			result.Vectors[start+int(e.Index)] = e.Values
+		}
+		result.Model = resp.Model
+		result.Dimensions = int(resp.Dimensions)
+		result.InputTokens += resp.InputTokens
+		result.CostUSD += resp.CostUsd
+		result.LatencyMs += resp.LatencyMs
+	}
+
+	for i, v := range result.Vectors {
+		if v == nil {
+			return nil, fmt.Errorf("embed response missing vector for input %d", i)
+		}
+	}
+	if result.Dimensions == 0 {
+		result.Dimensions = len(result.Vectors[0])
+	}
+	return result, nil
+}
@@ -0,0 +1,36 @@
+package levee
+
+import "github.com/almatuck/levee-go/llmpb"
+
+// ExamplePair is a few-shot example: an input and the reply the model should
+// give to it.
+type ExamplePair struct {
+	Input  string `json:"input"`
+	Output string `json:"output"`
+}
+
+// examplesToMessages converts examples to user and assistant message pairs.
+func examplesToMessages(examples []ExamplePair) []ChatMessage {
+	messages := make([]ChatMessage, 0, 2*len(examples))
+	for _, e := range examples {
+		messages = append(messages,
+			ChatMessage{Role: "user", Content: e.Input},
+			ChatMessage{Role: "assistant", Content: e.Output},
+		)
+	}
+	return messages
+}
+
+// withExamples returns the gRPC messages of a conversation preceded by its
+// examples.
+func withExamples(examples []ExamplePair, messages []ChatMessage) []*llmpb.Message {
+	return messagesToPB(append(examplesToMessages(examples), messages...))
+}
+
+// exampleTokens estimates the prompt tokens taken by examples.
+func exampleTokens(examples []ExamplePair) int {
+	if len(examples) == 0 {
+		return 0
+	}
+	return estimateMessageTokens(examplesToMessages(examples)) - conversationTokenOverhead
+}
@@ -0,0 +1,109 @@
+package levee
+
+import (
+	"context"
+	"io"
+	"net"
+	"slices"
+	"testing"
+
+	"github.com/almatuck/levee-go/llmpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startRecorder answers each message with an empty completion and records
+// the conversation each chat session starts with.
+type startRecorder struct {
+	llmpb.UnimplementedLLMServiceServer
+	starts chan *llmpb.StartChatRequest
+}
+
+func (s startRecorder) Chat(stream grpc.BidiStreamingServer[llmpb.ChatRequest, llmpb.ChatResponse]) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case req.GetStart() != nil:
+			s.starts <- req.GetStart()
+		case req.GetMessage() != nil:
+			err = stream.Send(&llmpb.ChatResponse{Response: &llmpb.ChatResponse_Completion{
+				Completion: &llmpb.CompletionResponse{FullContent: "ok", StopReason: "end_turn"},
+			}})
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func TestChatStreamStartMessages(t *testing.T) {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	rec := startRecorder{starts: make(chan *llmpb.StartChatRequest, 1)}
+	llmpb.RegisterLLMServiceServer(srv, rec)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	llm := NewLLMClient("key", "",
+		WithGRPCAddress("passthrough:///bufnet"),
+		WithTransport(TransportGRPC),
+		WithInsecure(),
+		WithDialOptions(grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		})),
+	)
+	defer llm.Close()
+
+	examples := []ExamplePair{{Input: "2+2", Output: "4"}}
+	history := []ChatMessage{{Role: "user", Content: "hi"}, {Role: "assistant", Content: "hello"}}
+	last := ChatMessage{Role: "user", Content: "3+3"}
+
+	tests := []struct {
+		name string
+		call func(ChatRequest) error
+	}{
+		{"ChatStream", func(req ChatRequest) error {
+			_, err := llm.ChatStream(context.Background(), req, nil)
+			return err
+		}},
+		{"ChatStreamChan", func(req ChatRequest) error {
+			chunks, errc := llm.ChatStreamChan(context.Background(), req)
+			for range chunks {
+			}
+			return <-errc
+		}},
+		{"ChatStreamSeq", func(req ChatRequest) error {
+			for _, err := range llm.ChatStreamSeq(context.Background(), req) {
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+	}
+
+	want := []string{"user:2+2", "assistant:4", "user:hi", "assistant:hello"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := ChatRequest{Messages: append(slices.Clone(history), last), Examples: examples}
+			if err := tt.call(req); err != nil {
+				t.Fatalf("error = %v", err)
+			}
+
+			var got []string
+			for _, m := range (<-rec.starts).Messages {
+				got = append(got, m.Role+":"+m.Content)
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("session started with %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package levee
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// OutputGuard checks a model reply as it streams. It is called with the reply
+// so far each time a chunk arrives, and once more with final set when the
+// reply is complete. It returns the reply to deliver, possibly rewritten (e.g.
+// with URLs stripped), or a *PolicyViolationError to stop the generation.
+//
+// Unlike output filters, guards don't hold back the stream: chunks reach the
+// callback or WebSocket as soon as the guards pass them, less the last partial
+// word, which a guard may still rewrite.
+type OutputGuard func(reply string, final bool) (string, error)
+
+// WithOutputGuards applies guards, in order, to replies of Chat, chat
+// sessions, and the WebSocket handler. A violation aborts the generation and
+// is returned as a *PolicyViolationError ("policy_violation" over WebSocket).
+func WithOutputGuards(guards ...OutputGuard) LLMOption {
+	return func(c *LLMClient) {
+		c.outputGuards = append(c.outputGuards, guards...)
+	}
+}
+
+// PolicyViolationError is returned when an output guard rejects a reply.
+type PolicyViolationError struct {
+	Guard  string // "max_length", "banned_term", "pattern", or "json_only"
+	Reason string
+}
+
+func (e *PolicyViolationError) Error() string {
+	return fmt.Sprintf("policy violation (%s): %s", e.Guard, e.Reason)
+}
+
+// MaxLength rejects replies longer than n characters.
+func MaxLength(n int) OutputGuard {
+	return func(reply string, final bool) (string, error) {
+		if utf8.RuneCountInString(reply) > n {
+			return "", &PolicyViolationError{Guard: "max_length", Reason: fmt.Sprintf("reply exceeds %d characters", n)}
+		}
+		return reply, nil
+	}
+}
+
+// BannedTerms rejects replies containing any of terms as whole words,
+// ignoring case.
+func BannedTerms(terms ...string) OutputGuard {
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = regexp.QuoteMeta(term)
+	}
+	re := regexp.MustCompile(`(?i)\b(?:` + strings.Join(quoted, "|") + `)\b`)
+	return func(reply string, final bool) (string, error) {
+		if term := re.FindString(reply); term != "" {
+			return "", &PolicyViolationError{Guard: "banned_term", Reason: fmt.Sprintf("reply contains %q", term)}
+		}
+		return reply, nil
+	}
+}
+
+// BlockPattern rejects replies matching re. name describes the pattern in
+// the violation.
+func BlockPattern(name string, re *regexp.Regexp) OutputGuard {
+	return func(reply string, final bool) (string, error) {
+		if re.MatchString(reply) {
+			return "", &PolicyViolationError{Guard: "pattern", Reason: "reply matches " + name}
+		}
+		return reply, nil
+	}
+}
+
+// urlPattern matches http(s) and www URLs.
+var urlPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"'()]+`)
+
+// StripURLs removes links from replies, replacing each with replacement.
+func StripURLs(replacement string) OutputGuard {
+	return func(reply string, final bool) (string, error) {
+		return urlPattern.ReplaceAllLiteralString(reply, replacement), nil
+	}
+}
+
+// JSONOnly rejects replies that aren't a JSON object or array: as soon as
+// the reply starts with anything else, and when it completes invalid.
+func JSONOnly() OutputGuard {
+	return func(reply string, final bool) (string, error) {
+		trimmed := strings.TrimSpace(reply)
+		if trimmed != "" && trimmed[0] != '{' && trimmed[0] != '[' {
+			return "", &PolicyViolationError{Guard: "json_only", Reason: "reply is not JSON"}
+		}
+		if final && !json.Valid([]byte(trimmed)) {
+			return "", &PolicyViolationError{Guard: "json_only", Reason: "reply is not valid JSON"}
+		}
+		return reply, nil
+	}
+}
+
+// guardRun applies the output guards to one streamed reply. A nil guardRun
+// passes everything through.
+type guardRun struct {
+	guards []OutputGuard
+	reply  string // raw reply so far
+	sent   string // guarded text delivered so far
+}
+
+// newGuardRun starts guarding a reply, or returns nil without guards.
+func (c *LLMClient) newGuardRun() *guardRun {
+	if len(c.outputGuards) == 0 {
+		return nil
+	}
+	return &guardRun{guards: c.outputGuards}
+}
+
+// check runs the guards over reply.
+func (g *guardRun) check(reply string, final bool) (string, error) {
+	for _, guard := range g.guards {
+		out, err := guard(reply, final)
+		if err != nil {
+			return "", err
+		}
+		reply = out
+	}
+	return reply, nil
+}
+
+// chunk adds a chunk to the reply and returns the guarded text to deliver
+// for it.
+func (g *guardRun) chunk(text string) (string, error) {
+	if g == nil {
+		return text, nil
+	}
+	g.reply += text
+	out, err := g.check(g.reply, false)
+	if err != nil {
+		return "", err
+	}
+	// Hold back the last partial word, which a guard may still rewrite.
+	out = out[:strings.LastIndexFunc(out, unicode.IsSpace)+1]
+	return g.deliver(out), nil
+}
+
+// final checks the complete reply and returns it guarded, with the text not
+// yet delivered.
+func (g *guardRun) final(reply string) (out, rest string, err error) {
+	if g == nil {
+		return reply, "", nil
+	}
+	out, err = g.check(reply, true)
+	if err != nil {
+		return "", "", err
+	}
+	return out, g.deliver(out), nil
+}
+
+// deliver returns the part of out not yet sent. Text a guard rewrote after
+// it was sent can't be recalled, so it is left as sent.
+func (g *guardRun) deliver(out string) string {
+	if len(out) <= len(g.sent) || !strings.HasPrefix(out, g.sent) {
+		return ""
+	}
+	rest := out[len(g.sent):]
+	g.sent = out
+	return rest
+}
+
+// guardOutput checks a complete, unstreamed reply.
+func (c *LLMClient) guardOutput(reply string) (string, error) {
+	out, _, err := c.newGuardRun().final(reply)
+	return out, err
+}
@@ -0,0 +1,165 @@
+package levee
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// HistoryContext is the conversation a HistoryStrategy trims before a send.
+type HistoryContext struct {
+	LLM          *LLMClient
+	Model        string
+	SystemPrompt string
+	MaxTokens    int32         // reply budget of the session
+	Messages     []ChatMessage // conversation so far, oldest first
+	Next         string        // user message about to be sent
+	Examples     []ExamplePair // few-shot examples, always kept
+}
+
+// HistoryStrategy keeps a chat session's history within the model's context
+// window. Trim returns the messages to keep; returning them unchanged keeps the
+// session's stream, otherwise the session restarts it with the result.
+type HistoryStrategy interface {
+	Trim(ctx context.Context, h HistoryContext) ([]ChatMessage, error)
+}
+
+// HistoryStrategyFunc adapts a function to HistoryStrategy.
+type HistoryStrategyFunc func(ctx context.Context, h HistoryContext) ([]ChatMessage, error)
+
+func (f HistoryStrategyFunc) Trim(ctx context.Context, h HistoryContext) ([]ChatMessage, error) {
+	return f(ctx, h)
+}
+
+// SlidingWindow keeps the last n messages, starting at a user message.
+func SlidingWindow(n int) HistoryStrategy {
+	return HistoryStrategyFunc(func(ctx context.Context, h HistoryContext) ([]ChatMessage, error) {
+		if len(h.Messages) <= n {
+			return h.Messages, nil
+		}
+		return startAtUser(h.Messages[len(h.Messages)-n:]), nil
+	})
+}
+
+// TokenBudget drops the oldest messages until the estimated prompt (system
+// prompt, examples, history, next message, and reply budget) fits in maxTokens. With
+// maxTokens 0 the budget is the model's context window (see MaxContextFor).
+func TokenBudget(maxTokens int) HistoryStrategy {
+	return HistoryStrategyFunc(func(ctx context.Context, h HistoryContext) ([]ChatMessage, error) {
+		budget := historyBudget(h, maxTokens)
+		if budget <= 0 {
+			return h.Messages, nil
+		}
+		messages := h.Messages
+		for len(messages) > 0 && estimateMessageTokens(messages) > budget {
+			messages = startAtUser(messages[1:])
+		}
+		return messages, nil
+	})
+}
+
+// SummarizeHistory compresses the conversation when it exceeds the token
+// budget (as in TokenBudget): all but the last keepRecent messages are
+// summarized by the model into one exchange at the start of the history.
+// model is the model used for summaries; empty uses the session's model.
+func SummarizeHistory(maxTokens, keepRecent int, model string) HistoryStrategy {
+	return HistoryStrategyFunc(func(ctx context.Context, h HistoryContext) ([]ChatMessage, error) {
+		budget := historyBudget(h, maxTokens)
+		if budget <= 0 || estimateMessageTokens(h.Messages) <= budget || len(h.Messages) <= keepRecent {
+			return h.Messages, nil
+		}
+
+		recent := startAtUser(h.Messages[len(h.Messages)-keepRecent:])
+		older := h.Messages[:len(h.Messages)-len(recent)]
+
+		var transcript strings.Builder
+		for _, msg := range older {
+			fmt.Fprintf(&transcript, "%s: %s\n\n", msg.Role, msg.Content)
+		}
+		resp, err := h.LLM.Chat(ctx, ChatRequest{
+			Model: cmp.Or(model, h.Model),
+			SystemPrompt: "Summarize the conversation below for the assistant continuing it. " +
+				"Keep facts, decisions, names, numbers, and open questions. Be concise.",
+			Messages: []ChatMessage{{Role: "user", Content: transcript.String()}},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to summarize history: %w", err)
+		}
+
+		summary := []ChatMessage{
+			{Role: "user", Content: "Summary of our conversation so far:\n" + resp.Content},
+			{Role: "assistant", Content: "Understood, I'll continue from there."},
+		}
+		return append(summary, recent...), nil
+	})
+}
+
+// historyBudget returns the tokens available for the history.
+func historyBudget(h HistoryContext, maxTokens int) int {
+	if maxTokens == 0 {
+		maxTokens = MaxContextFor(h.Model)
+	}
+	if maxTokens == 0 {
+		return 0
+	}
+	reserved := int(h.MaxTokens) + EstimateTokens(h.SystemPrompt) + EstimateTokens(h.Next) + messageTokenOverhead
+	return maxTokens - reserved - exampleTokens(h.Examples)
+}
+
+// startAtUser drops leading messages until the history starts with a user
+// message, as providers require.
+func startAtUser(messages []ChatMessage) []ChatMessage {
+	for len(messages) > 0 && messages[0].Role != "user" {
+		messages = messages[1:]
+	}
+	return messages
+}
+
+// sameMessage reports whether two messages have the same role, text, and media.
+func sameMessage(a, b ChatMessage) bool {
+	return a.Role == b.Role && a.Content == b.Content &&
+		slices.EqualFunc(a.Images, b.Images, func(x, y ImagePart) bool {
+			return x.URL == y.URL && x.MediaType == y.MediaType && bytes.Equal(x.Data, y.Data)
+		}) &&
+		slices.EqualFunc(a.Audio, b.Audio, func(x, y AudioPart) bool {
+			return x.URL == y.URL && x.MediaType == y.MediaType && bytes.Equal(x.Data, y.Data)
+		})
+}
+
+// trimHistory applies the session's history strategy before a send, restarting
+// the stream with the trimmed conversation if it changed. Examples are kept at
+// the start. The caller holds s.mu.
+func (s *ChatSession) trimHistory(ctx context.Context, next string) error {
+	pinned := 2 * len(s.examples)
+	messages := messagesFromPB(append(slices.Clip(s.start.Messages[pinned:]), s.history...))
+
+	trimmed, err := s.historyStrategy.Trim(ctx, HistoryContext{
+		LLM:          s.llm,
+		Model:        s.start.Model,
+		SystemPrompt: s.start.SystemPrompt,
+		MaxTokens:    s.start.MaxTokens,
+		Messages:     messages,
+		Next:         next,
+		Examples:     s.examples,
+	})
+	if err != nil {
+		return err
+	}
+	if slices.EqualFunc(trimmed, messages, sameMessage) {
+		return nil
+	}
+
+	s.start.Messages = withExamples(s.examples, trimmed)
+	s.history = nil
+
+	stream, err := s.llm.openChat(s.ctx, s.start)
+	if err != nil {
+		return err
+	}
+	drainStream(s.stream)
+	s.stream = stream
+	return nil
+}
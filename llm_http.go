@@ -0,0 +1,517 @@
+package levee
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/almatuck/levee-go/llmpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// LLMTransport selects how the LLM client reaches the gateway.
+type LLMTransport int
+
+const (
+	// TransportAuto uses gRPC, falling back to HTTPS when the gRPC port is
+	// unreachable (common on restrictive PaaS networks).
+	TransportAuto LLMTransport = iota
+	// TransportGRPC always uses gRPC.
+	TransportGRPC
+	// TransportHTTP always uses HTTPS with server-sent events for streaming.
+	TransportHTTP
+)
+
+// grpcProbeTimeout bounds the TCP probe of the gRPC port in TransportAuto.
+const grpcProbeTimeout = 3 * time.Second
+
+// llmSessionHeader carries the gateway chat session of HTTP chat requests.
+const llmSessionHeader = "X-LLM-Session"
+
+// WithTransport selects the gateway transport (default TransportAuto). Over
+// HTTPS, every feature works the same, but gRPC interceptors and dial options
+// don't apply.
+func WithTransport(t LLMTransport) LLMOption {
+	return func(c *LLMClient) {
+		c.transport = t
+	}
+}
+
+// grpcReachable reports whether a TCP connection to the gRPC port succeeds.
+func grpcReachable(addr string) bool {
+	conn, err := net.DialTimeout("tcp", addr, grpcProbeTimeout)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// usingHTTP reports whether the client fell back to (or was set to) HTTPS.
+func (c *LLMClient) usingHTTP() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.client.(*httpLLMService)
+	return ok
+}
+
+// httpLLMService implements llmpb.LLMServiceClient over HTTPS. Each RPC is a
+// POST of its protojson request to /sdk/v1/llm/rpc/{method}; streaming
+// responses are server-sent events with one protojson message per event.
+type httpLLMService struct {
+	c *LLMClient
+}
+
+// post sends an RPC request and returns the response, mapping HTTP failures to
+// gRPC status errors so retries and fallbacks work as over gRPC.
+func (h *httpLLMService) post(ctx context.Context, method string, in proto.Message, header http.Header) (*http.Response, error) {
+	body, err := protojson.Marshal(in)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode %s request: %v", method, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.c.baseURL+"/sdk/v1/llm/rpc/"+method, bytes.NewReader(body))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create %s request: %v", method, err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", h.c.apiKey)
+	for _, creds := range h.c.perRPC {
+		md, err := creds.GetRequestMetadata(ctx, req.URL.String())
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "failed to get request credentials: %v", err)
+		}
+		for k, v := range md {
+			req.Header.Set(k, v)
+		}
+	}
+
+	resp, err := h.c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, httpStatusError(resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return resp, nil
+}
+
+// httpStatusError converts an HTTP status to the equivalent gRPC status error.
+func httpStatusError(code int, msg string) error {
+	c := codes.Unknown
+	switch code {
+	case http.StatusBadRequest:
+		c = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		c = codes.Unauthenticated
+	case http.StatusForbidden:
+		c = codes.PermissionDenied
+	case http.StatusNotFound:
+		c = codes.NotFound
+	case http.StatusConflict:
+		c = codes.Aborted
+	case http.StatusTooManyRequests:
+		c = codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		c = codes.Unimplemented
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		c = codes.Unavailable
+	case http.StatusInternalServerError:
+		c = codes.Internal
+	}
+	if msg == "" {
+		msg = http.StatusText(code)
+	}
+	return status.Error(c, msg)
+}
+
+// unary runs a unary RPC over HTTPS.
+func (h *httpLLMService) unary(ctx context.Context, method string, in, out proto.Message) error {
+	resp, err := h.post(ctx, method, in, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, out); err != nil {
+		return status.Errorf(codes.Internal, "failed to decode %s response: %v", method, err)
+	}
+	return nil
+}
+
+// sseReader reads the data of server-sent events. An "error" event carries
+// {"status": <http status>, "message": "..."}.
+type sseReader struct {
+	r *bufio.Reader
+}
+
+// next returns the data of the next message event, io.EOF at the end of the
+// stream, or the error of an error event.
+func (s *sseReader) next() ([]byte, error) {
+	var event string
+	var data []byte
+	for {
+		line, err := s.r.ReadBytes('\n')
+		if err != nil && (len(line) == 0 || err != io.EOF) {
+			if err == io.EOF && data != nil {
+				break
+			}
+			return nil, err
+		}
+		line = bytes.TrimRight(line, "\r\n")
+		if len(line) == 0 {
+			if data != nil {
+				break
+			}
+			event = ""
+			continue
+		}
+		field, value, _ := bytes.Cut(line, []byte(":"))
+		value = bytes.TrimPrefix(value, []byte(" "))
+		switch string(field) {
+		case "event":
+			event = string(value)
+		case "data":
+			if data != nil {
+				data = append(data, '\n')
+			}
+			data = append(data, value...)
+		}
+	}
+
+	if event == "error" {
+		var e struct {
+			Status  int    `json:"status"`
+			Message string `json:"message"`
+		}
+		json.Unmarshal(data, &e)
+		return nil, httpStatusError(e.Status, e.Message)
+	}
+	return data, nil
+}
+
+// httpServerStream is a server-streaming RPC over server-sent events.
+type httpServerStream[T any, PT interface {
+	*T
+	proto.Message
+}] struct {
+	ctx  context.Context
+	body io.ReadCloser
+	sse  *sseReader
+}
+
+func (s *httpServerStream[T, PT]) Recv() (*T, error) {
+	msg := PT(new(T))
+	if err := s.RecvMsg(msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func (s *httpServerStream[T, PT]) RecvMsg(m any) error {
+	data, err := s.sse.next()
+	if err == io.EOF {
+		s.body.Close()
+		return io.EOF
+	}
+	if err != nil {
+		s.body.Close()
+		if s.ctx.Err() != nil {
+			return status.FromContextError(s.ctx.Err()).Err()
+		}
+		if _, ok := status.FromError(err); !ok {
+			err = status.Error(codes.Unavailable, err.Error())
+		}
+		return err
+	}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, m.(proto.Message)); err != nil {
+		return status.Errorf(codes.Internal, "failed to decode stream message: %v", err)
+	}
+	return nil
+}
+
+func (s *httpServerStream[T, PT]) Header() (metadata.MD, error) { return nil, nil }
+func (s *httpServerStream[T, PT]) Trailer() metadata.MD         { return nil }
+func (s *httpServerStream[T, PT]) CloseSend() error             { return nil }
+func (s *httpServerStream[T, PT]) Context() context.Context     { return s.ctx }
+func (s *httpServerStream[T, PT]) SendMsg(m any) error {
+	return status.Error(codes.Unimplemented, "send on a server stream")
+}
+
+// serverStream runs a server-streaming RPC over HTTPS.
+func serverStream[T any, PT interface {
+	*T
+	proto.Message
+}](ctx context.Context, h *httpLLMService, method string, in proto.Message) (*httpServerStream[T, PT], error) {
+	resp, err := h.post(ctx, method, in, http.Header{"Accept": {"text/event-stream"}})
+	if err != nil {
+		return nil, err
+	}
+	return &httpServerStream[T, PT]{ctx: ctx, body: resp.Body, sse: &sseReader{r: bufio.NewReader(resp.Body)}}, nil
+}
+
+func (h *httpLLMService) SimpleChat(ctx context.Context, in *llmpb.SimpleChatRequest, opts ...grpc.CallOption) (*llmpb.SimpleChatResponse, error) {
+	out := new(llmpb.SimpleChatResponse)
+	return out, h.unary(ctx, "SimpleChat", in, out)
+}
+
+func (h *httpLLMService) Embed(ctx context.Context, in *llmpb.EmbedRequest, opts ...grpc.CallOption) (*llmpb.EmbedResponse, error) {
+	out := new(llmpb.EmbedResponse)
+	return out, h.unary(ctx, "Embed", in, out)
+}
+
+func (h *httpLLMService) CountTokens(ctx context.Context, in *llmpb.CountTokensRequest, opts ...grpc.CallOption) (*llmpb.CountTokensResponse, error) {
+	out := new(llmpb.CountTokensResponse)
+	return out, h.unary(ctx, "CountTokens", in, out)
+}
+
+func (h *httpLLMService) Moderate(ctx context.Context, in *llmpb.ModerateRequest, opts ...grpc.CallOption) (*llmpb.ModerateResponse, error) {
+	out := new(llmpb.ModerateResponse)
+	return out, h.unary(ctx, "Moderate", in, out)
+}
+
+func (h *httpLLMService) Transcribe(ctx context.Context, in *llmpb.TranscribeRequest, opts ...grpc.CallOption) (*llmpb.TranscribeResponse, error) {
+	out := new(llmpb.TranscribeResponse)
+	return out, h.unary(ctx, "Transcribe", in, out)
+}
+
+func (h *httpLLMService) SubmitBatch(ctx context.Context, in *llmpb.SubmitBatchRequest, opts ...grpc.CallOption) (*llmpb.BatchJob, error) {
+	out := new(llmpb.BatchJob)
+	return out, h.unary(ctx, "SubmitBatch", in, out)
+}
+
+func (h *httpLLMService) GetBatch(ctx context.Context, in *llmpb.GetBatchRequest, opts ...grpc.CallOption) (*llmpb.BatchJob, error) {
+	out := new(llmpb.BatchJob)
+	return out, h.unary(ctx, "GetBatch", in, out)
+}
+
+func (h *httpLLMService) CancelBatch(ctx context.Context, in *llmpb.GetBatchRequest, opts ...grpc.CallOption) (*llmpb.BatchJob, error) {
+	out := new(llmpb.BatchJob)
+	return out, h.unary(ctx, "CancelBatch", in, out)
+}
+
+func (h *httpLLMService) Synthesize(ctx context.Context, in *llmpb.SynthesizeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[llmpb.AudioChunk], error) {
+	return serverStream[llmpb.AudioChunk](ctx, h, "Synthesize", in)
+}
+
+func (h *httpLLMService) StreamBatchResults(ctx context.Context, in *llmpb.GetBatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[llmpb.BatchResult], error) {
+	return serverStream[llmpb.BatchResult](ctx, h, "StreamBatchResults", in)
+}
+
+// Chat emulates the bidirectional chat stream: each Send is a POST whose
+// server-sent events are delivered by Recv. The gateway keeps the session
+// between requests, identified by the session ID of its SessionStarted event.
+func (h *httpLLMService) Chat(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[llmpb.ChatRequest, llmpb.ChatResponse], error) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &httpChatStream{
+		h:       h,
+		ctx:     ctx,
+		cancel:  cancel,
+		events:  make(chan *llmpb.ChatResponse, 64),
+		errs:    make(chan error, 1),
+		started: make(chan struct{}),
+	}, nil
+}
+
+// httpChatStream is a chat stream over HTTPS.
+type httpChatStream struct {
+	h      *httpLLMService
+	ctx    context.Context
+	cancel context.CancelFunc
+	events chan *llmpb.ChatResponse
+	errs   chan error
+
+	mu        sync.Mutex
+	sessionID string
+	sentStart bool
+	closed    bool
+	started   chan struct{} // closed once the session ID is known or the start failed
+}
+
+func (s *httpChatStream) Send(req *llmpb.ChatRequest) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return io.EOF
+	}
+	isStart := req.GetStart() != nil
+	if isStart {
+		if s.sentStart {
+			s.mu.Unlock()
+			return status.Error(codes.FailedPrecondition, "chat already started")
+		}
+		s.sentStart = true
+	}
+	s.mu.Unlock()
+
+	header := http.Header{"Accept": {"text/event-stream"}}
+	if !isStart {
+		// Later requests need the session the start request opened.
+		select {
+		case <-s.started:
+		case <-s.ctx.Done():
+			return io.EOF
+		}
+		s.mu.Lock()
+		id := s.sessionID
+		s.mu.Unlock()
+		if id == "" {
+			return io.EOF
+		}
+		header.Set(llmSessionHeader, id)
+	}
+
+	resp, err := s.h.post(s.ctx, "Chat", req, header)
+	if err != nil {
+		if isStart {
+			close(s.started)
+		}
+		s.fail(err)
+		return io.EOF // the error is reported by Recv, as over gRPC
+	}
+	go s.read(resp.Body, isStart)
+	return nil
+}
+
+// read forwards the events of one response to Recv.
+func (s *httpChatStream) read(body io.ReadCloser, isStart bool) {
+	defer body.Close()
+	if isStart {
+		defer s.markStarted()
+	}
+
+	sse := &sseReader{r: bufio.NewReader(body)}
+	for {
+		data, err := sse.next()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			if _, ok := status.FromError(err); !ok {
+				err = status.Error(codes.Unavailable, err.Error())
+			}
+			s.fail(err)
+			return
+		}
+
+		msg := new(llmpb.ChatResponse)
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, msg); err != nil {
+			s.fail(status.Errorf(codes.Internal, "failed to decode chat event: %v", err))
+			return
+		}
+		if started := msg.GetSessionStarted(); started != nil && isStart {
+			s.mu.Lock()
+			s.sessionID = started.SessionId
+			s.mu.Unlock()
+			s.markStarted()
+		}
+		select {
+		case s.events <- msg:
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// markStarted unblocks requests waiting for the session, once.
+func (s *httpChatStream) markStarted() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-s.started:
+	default:
+		close(s.started)
+	}
+}
+
+// fail records the first stream error for Recv.
+func (s *httpChatStream) fail(err error) {
+	if s.ctx.Err() != nil {
+		return
+	}
+	select {
+	case s.errs <- err:
+	default:
+	}
+}
+
+func (s *httpChatStream) Recv() (*llmpb.ChatResponse, error) {
+	select {
+	case msg := <-s.events:
+		return msg, nil
+	default:
+	}
+	select {
+	case msg := <-s.events:
+		return msg, nil
+	case err := <-s.errs:
+		s.errs <- err // keep failing, like a broken gRPC stream
+		return nil, err
+	case <-s.ctx.Done():
+		s.mu.Lock()
+		closed := s.closed
+		s.mu.Unlock()
+		if closed {
+			return nil, io.EOF
+		}
+		return nil, status.FromContextError(s.ctx.Err()).Err()
+	}
+}
+
+// CloseSend ends the stream. Unlike gRPC, responses still in flight are
+// discarded; callers only close after their last turn.
+func (s *httpChatStream) CloseSend() error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	s.cancel()
+	return nil
+}
+
+func (s *httpChatStream) Header() (metadata.MD, error) { return nil, nil }
+func (s *httpChatStream) Trailer() metadata.MD         { return nil }
+func (s *httpChatStream) Context() context.Context     { return s.ctx }
+
+func (s *httpChatStream) SendMsg(m any) error {
+	req, ok := m.(*llmpb.ChatRequest)
+	if !ok {
+		return status.Errorf(codes.Internal, "unexpected message %T", m)
+	}
+	return s.Send(req)
+}
+
+func (s *httpChatStream) RecvMsg(m any) error {
+	msg, err := s.Recv()
+	if err != nil {
+		return err
+	}
+	out, ok := m.(*llmpb.ChatResponse)
+	if !ok {
+		return status.Errorf(codes.Internal, "unexpected message %T", m)
+	}
+	proto.Merge(out, msg)
+	return nil
+}
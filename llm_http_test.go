@@ -0,0 +1,109 @@
+package levee
+
+import (
+	"bufio"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSSEReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		stream  string
+		want    []string
+		wantErr codes.Code // of the error ending the stream; codes.OK for io.EOF
+	}{
+		{
+			name:   "single event",
+			stream: "data: {\"a\":1}\n\n",
+			want:   []string{`{"a":1}`},
+		},
+		{
+			name:   "several events",
+			stream: "data: one\n\ndata: two\n\n",
+			want:   []string{"one", "two"},
+		},
+		{
+			name:   "multi-line data is joined with newlines",
+			stream: "data: one\ndata: two\n\n",
+			want:   []string{"one\ntwo"},
+		},
+		{
+			name:   "CRLF line endings",
+			stream: "data: one\r\n\r\ndata: two\r\n\r\n",
+			want:   []string{"one", "two"},
+		},
+		{
+			name:   "comments and unknown fields are ignored",
+			stream: ": keep-alive\n\nid: 7\nretry: 1000\ndata: one\n\n",
+			want:   []string{"one"},
+		},
+		{
+			name:   "value without a space after the colon",
+			stream: "data:one\n\n",
+			want:   []string{"one"},
+		},
+		{
+			name:   "last event without a blank line",
+			stream: "data: one\n\ndata: two",
+			want:   []string{"one", "two"},
+		},
+		{
+			name:   "named events carry data",
+			stream: "event: chunk\ndata: one\n\n",
+			want:   []string{"one"},
+		},
+		{
+			name:    "error event",
+			stream:  "data: one\n\nevent: error\ndata: {\"status\": 429, \"message\": \"slow down\"}\n\n",
+			want:    []string{"one"},
+			wantErr: codes.ResourceExhausted,
+		},
+		{
+			name:    "error event without a status",
+			stream:  "event: error\ndata: {\"message\": \"boom\"}\n\n",
+			wantErr: codes.Unknown,
+		},
+		{
+			name:   "event name does not leak into the next event",
+			stream: "event: error\n\ndata: one\n\n",
+			want:   []string{"one"},
+		},
+		{
+			name:   "empty stream",
+			stream: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sse := &sseReader{r: bufio.NewReader(strings.NewReader(tt.stream))}
+
+			var got []string
+			var err error
+			for {
+				var data []byte
+				if data, err = sse.next(); err != nil {
+					break
+				}
+				got = append(got, string(data))
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("data = %q, want %q", got, tt.want)
+			}
+			if tt.wantErr == codes.OK {
+				if err != io.EOF {
+					t.Errorf("stream ended with %v, want io.EOF", err)
+				}
+			} else if code := status.Code(err); code != tt.wantErr {
+				t.Errorf("stream ended with %v (%s), want %s", err, code, tt.wantErr)
+			}
+		})
+	}
+}
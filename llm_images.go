@@ -0,0 +1,106 @@
+package levee
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/almatuck/levee-go/llmpb"
+)
+
+// imageTokenEstimate is the approximate input tokens of one image, used when
+// estimating locally (a ~1.1 megapixel image at about 750 pixels per token).
+const imageTokenEstimate = 1600
+
+// ImagePart is an image input of a chat message, for vision-capable models.
+// Set URL for a publicly reachable image, or Data with its MediaType.
+type ImagePart struct {
+	URL       string `json:"url,omitempty"`
+	Data      []byte `json:"data,omitempty"` // base64 in JSON
+	MediaType string `json:"media_type,omitempty"`
+}
+
+// ImageURL returns an image input the provider fetches from url.
+func ImageURL(url string) ImagePart {
+	return ImagePart{URL: url}
+}
+
+// ImageBytes returns an inline image input. An empty mediaType is detected
+// from the data.
+func ImageBytes(data []byte, mediaType string) ImagePart {
+	if mediaType == "" {
+		mediaType = http.DetectContentType(data)
+	}
+	return ImagePart{Data: data, MediaType: mediaType}
+}
+
+// ImageFile reads an inline image input from a file, e.g. a screenshot.
+//
+//	img, err := levee.ImageFile("screenshot.png")
+//	resp, err := llm.Chat(ctx, levee.ChatRequest{
+//		Model: "sonnet",
+//		Messages: []levee.ChatMessage{
+//			{Role: "user", Content: "Describe this screenshot", Images: []levee.ImagePart{img}},
+//		},
+//	})
+func ImageFile(path string) (ImagePart, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ImagePart{}, fmt.Errorf("failed to read image: %w", err)
+	}
+	return ImageBytes(data, mime.TypeByExtension(filepath.Ext(path))), nil
+}
+
+// imagesToPB converts image inputs to their gRPC form.
+func imagesToPB(images []ImagePart) []*llmpb.Image {
+	if len(images) == 0 {
+		return nil
+	}
+	out := make([]*llmpb.Image, 0, len(images))
+	for _, img := range images {
+		out = append(out, &llmpb.Image{Url: img.URL, Data: img.Data, MediaType: img.MediaType})
+	}
+	return out
+}
+
+// imagesFromPB converts gRPC image inputs back.
+func imagesFromPB(images []*llmpb.Image) []ImagePart {
+	if len(images) == 0 {
+		return nil
+	}
+	out := make([]ImagePart, 0, len(images))
+	for _, img := range images {
+		out = append(out, ImagePart{URL: img.Url, Data: img.Data, MediaType: img.MediaType})
+	}
+	return out
+}
+
+// messagesToPB converts chat messages to their gRPC form.
+func messagesToPB(messages []ChatMessage) []*llmpb.Message {
+	out := make([]*llmpb.Message, 0, len(messages))
+	for _, msg := range messages {
+		out = append(out, &llmpb.Message{
+			Role:    msg.Role,
+			Content: msg.Content,
+			Images:  imagesToPB(msg.Images),
+			Audio:   audioPartsToPB(msg.Audio),
+		})
+	}
+	return out
+}
+
+// messagesFromPB converts gRPC messages back.
+func messagesFromPB(messages []*llmpb.Message) []ChatMessage {
+	out := make([]ChatMessage, 0, len(messages))
+	for _, msg := range messages {
+		out = append(out, ChatMessage{
+			Role:    msg.Role,
+			Content: msg.Content,
+			Images:  imagesFromPB(msg.Images),
+			Audio:   audioPartsFromPB(msg.Audio),
+		})
+	}
+	return out
+}
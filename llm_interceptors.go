@@ -0,0 +1,205 @@
+package levee
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Metric names emitted by MetricsUnaryInterceptor and MetricsStreamInterceptor.
+const (
+	// MetricLLMRequests counts LLM gRPC calls. Labels: method, code.
+	MetricLLMRequests = "levee_llm_requests_total"
+	// MetricLLMDuration times LLM gRPC calls; streams are timed until they end.
+	// Labels: method.
+	MetricLLMDuration = "levee_llm_duration_seconds"
+)
+
+// SpanStarter starts a trace span for an LLM gRPC call and returns the context
+// carrying it and a function that ends it. It lets TracingUnaryInterceptor and
+// TracingStreamInterceptor feed any tracer without the SDK depending on one:
+//
+//	tracer := otel.Tracer("levee-llm")
+//	start := func(ctx context.Context, method string) (context.Context, func(error)) {
+//		ctx, span := tracer.Start(ctx, method)
+//		return ctx, func(err error) {
+//			if err != nil {
+//				span.RecordError(err)
+//			}
+//			span.End()
+//		}
+//	}
+type SpanStarter func(ctx context.Context, method string) (context.Context, func(err error))
+
+// WithUnaryInterceptor adds interceptors to unary LLM calls (Chat). Interceptors
+// run in the order they are added, across calls to WithUnaryInterceptor.
+func WithUnaryInterceptor(interceptors ...grpc.UnaryClientInterceptor) LLMOption {
+	return func(c *LLMClient) {
+		c.unaryInterceptors = append(c.unaryInterceptors, interceptors...)
+	}
+}
+
+// WithStreamInterceptor adds interceptors to streaming LLM calls (chat sessions).
+// Interceptors run in the order they are added.
+func WithStreamInterceptor(interceptors ...grpc.StreamClientInterceptor) LLMOption {
+	return func(c *LLMClient) {
+		c.streamInterceptors = append(c.streamInterceptors, interceptors...)
+	}
+}
+
+// WithDialOptions passes extra options to grpc.NewClient, e.g. a stats handler
+// such as otelgrpc.NewClientHandler().
+func WithDialOptions(opts ...grpc.DialOption) LLMOption {
+	return func(c *LLMClient) {
+		c.extraDialOpts = append(c.extraDialOpts, opts...)
+	}
+}
+
+// LoggingUnaryInterceptor logs every unary LLM call with its method, status
+// code, and duration. Failed calls are logged at warn level.
+func LoggingUnaryInterceptor(logger *slog.Logger) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		logLLMCall(ctx, logger, method, time.Since(start), err)
+		return err
+	}
+}
+
+// LoggingStreamInterceptor logs every LLM stream when it ends.
+func LoggingStreamInterceptor(logger *slog.Logger) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+		return observeStream(ctx, desc, cc, method, streamer, opts, func(err error) {
+			logLLMCall(ctx, logger, method, time.Since(start), err)
+		})
+	}
+}
+
+// MetricsUnaryInterceptor records MetricLLMRequests and MetricLLMDuration for
+// every unary LLM call.
+func MetricsUnaryInterceptor(metrics Metrics) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		recordLLMCall(metrics, method, time.Since(start), err)
+		return err
+	}
+}
+
+// MetricsStreamInterceptor records MetricLLMRequests and MetricLLMDuration for
+// every LLM stream when it ends.
+func MetricsStreamInterceptor(metrics Metrics) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+		return observeStream(ctx, desc, cc, method, streamer, opts, func(err error) {
+			recordLLMCall(metrics, method, time.Since(start), err)
+		})
+	}
+}
+
+// TracingUnaryInterceptor wraps every unary LLM call in a span from start.
+func TracingUnaryInterceptor(start SpanStarter) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, end := start(ctx, method)
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		end(err)
+		return err
+	}
+}
+
+// TracingStreamInterceptor wraps every LLM stream in a span from start, ended
+// when the stream ends.
+func TracingStreamInterceptor(start SpanStarter) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, end := start(ctx, method)
+		return observeStream(ctx, desc, cc, method, streamer, opts, end)
+	}
+}
+
+// interceptorDialOptions returns the dial options installing the configured
+// interceptors and extra dial options.
+func (c *LLMClient) interceptorDialOptions() []grpc.DialOption {
+	var opts []grpc.DialOption
+	if len(c.unaryInterceptors) > 0 {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(c.unaryInterceptors...))
+	}
+	if len(c.streamInterceptors) > 0 {
+		opts = append(opts, grpc.WithChainStreamInterceptor(c.streamInterceptors...))
+	}
+	return append(opts, c.extraDialOpts...)
+}
+
+// observeStream opens a stream and calls done exactly once when it ends: when
+// a receive returns an error (nil for io.EOF), a send fails, or opening fails.
+func observeStream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts []grpc.CallOption, done func(error)) (grpc.ClientStream, error) {
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		done(err)
+		return nil, err
+	}
+	return &observedStream{ClientStream: stream, done: done}, nil
+}
+
+// observedStream reports the end of a client stream.
+type observedStream struct {
+	grpc.ClientStream
+	once sync.Once
+	done func(error)
+}
+
+func (s *observedStream) SendMsg(m any) error {
+	err := s.ClientStream.SendMsg(m)
+	// io.EOF means the stream ended; RecvMsg returns its status.
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.finish(err)
+	}
+	return err
+}
+
+func (s *observedStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if errors.Is(err, io.EOF) {
+		s.finish(nil)
+	} else if err != nil {
+		s.finish(err)
+	}
+	return err
+}
+
+func (s *observedStream) finish(err error) {
+	s.once.Do(func() { s.done(err) })
+}
+
+// logLLMCall writes one access log entry for an LLM call.
+func logLLMCall(ctx context.Context, logger *slog.Logger, method string, d time.Duration, err error) {
+	level := slog.LevelInfo
+	if err != nil && status.Code(err) != codes.Canceled {
+		level = slog.LevelWarn
+	}
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("code", status.Code(err).String()),
+		slog.Duration("duration", d),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	logger.LogAttrs(ctx, level, "levee llm call", attrs...)
+}
+
+// recordLLMCall emits the counter and timing for an LLM call.
+func recordLLMCall(metrics Metrics, method string, d time.Duration, err error) {
+	metrics.IncCounter(MetricLLMRequests, map[string]string{
+		"method": method,
+		"code":   status.Code(err).String(),
+	})
+	metrics.ObserveDuration(MetricLLMDuration, map[string]string{"method": method}, d)
+}
@@ -0,0 +1,224 @@
+package levee
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrGenerationQueueTimeout is returned when a request waited longer than the
+// WithGenerationQueueTimeout limit for a generation slot.
+var ErrGenerationQueueTimeout = errors.New("levee: timed out waiting for a generation slot")
+
+// MetricLLMQueueWait times how long calls waited for a generation slot.
+// Labels: none.
+const MetricLLMQueueWait = "levee_llm_queue_wait_seconds"
+
+// GenerationLimiter caps concurrent LLM generations. Waiting requests are
+// served fairly: API keys take turns, and each key's requests run in order.
+// Share one limiter between the LLMClients of several API keys with
+// WithGenerationLimiter.
+type GenerationLimiter struct {
+	mu     sync.Mutex
+	max    int
+	perKey int
+	active int
+	byKey  map[string]int          // active generations per key
+	queues map[string][]*genWaiter // waiting requests per key, FIFO
+	order  []string                // keys with waiting requests, round-robin
+}
+
+// genWaiter is a request waiting for a generation slot.
+type genWaiter struct {
+	ready   chan struct{}
+	granted bool
+}
+
+// NewGenerationLimiter creates a limiter allowing max concurrent generations
+// in total and perKey per API key (0 for no per-key limit).
+func NewGenerationLimiter(max, perKey int) *GenerationLimiter {
+	return &GenerationLimiter{
+		max:    max,
+		perKey: perKey,
+		byKey:  make(map[string]int),
+		queues: make(map[string][]*genWaiter),
+	}
+}
+
+// WithMaxConcurrentGenerations limits the client to n concurrent generations
+// (Chat calls, session turns, and WebSocket replies); further requests queue.
+// A burst of chat users then can't exhaust the gateway or rate limits. Zero
+// or less removes the limit.
+func WithMaxConcurrentGenerations(n int) LLMOption {
+	return func(c *LLMClient) {
+		c.limiter = nil
+		if n > 0 {
+			c.limiter = NewGenerationLimiter(n, 0)
+		}
+	}
+}
+
+// WithGenerationLimiter shares limiter with other clients, limiting them
+// together and per API key.
+//
+//	limiter := levee.NewGenerationLimiter(50, 10)
+//	for _, key := range tenantKeys {
+//		clients[key] = levee.NewLLMClient(key, baseURL, levee.WithGenerationLimiter(limiter))
+//	}
+func WithGenerationLimiter(limiter *GenerationLimiter) LLMOption {
+	return func(c *LLMClient) {
+		c.limiter = limiter
+	}
+}
+
+// WithGenerationQueueTimeout fails requests with ErrGenerationQueueTimeout
+// after waiting d for a generation slot (default: wait until the context
+// ends).
+func WithGenerationQueueTimeout(d time.Duration) LLMOption {
+	return func(c *LLMClient) {
+		c.queueTimeout = d
+	}
+}
+
+// Queued returns the number of requests waiting for a generation slot.
+func (l *GenerationLimiter) Queued() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	n := 0
+	for _, q := range l.queues {
+		n += len(q)
+	}
+	return n
+}
+
+// acquire waits for a generation slot for key. release must be called when
+// the generation ends; it is safe to call more than once.
+func (l *GenerationLimiter) acquire(ctx context.Context, key string) (release func(), err error) {
+	release = sync.OnceFunc(func() { l.release(key) })
+
+	l.mu.Lock()
+	if len(l.queues[key]) == 0 && l.available(key) {
+		l.start(key)
+		l.mu.Unlock()
+		return release, nil
+	}
+	w := &genWaiter{ready: make(chan struct{})}
+	if len(l.queues[key]) == 0 {
+		l.order = append(l.order, key)
+	}
+	l.queues[key] = append(l.queues[key], w)
+	l.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return release, nil
+	case <-ctx.Done():
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if w.granted {
+		// The slot arrived as the context ended; pass it on.
+		l.finish(key)
+		l.dispatch()
+	} else {
+		l.remove(key, w)
+	}
+	return nil, context.Cause(ctx)
+}
+
+// available reports whether key may start a generation now.
+func (l *GenerationLimiter) available(key string) bool {
+	return l.active < l.max && (l.perKey <= 0 || l.byKey[key] < l.perKey)
+}
+
+func (l *GenerationLimiter) start(key string) {
+	l.active++
+	l.byKey[key]++
+}
+
+func (l *GenerationLimiter) finish(key string) {
+	l.active--
+	if l.byKey[key]--; l.byKey[key] <= 0 {
+		delete(l.byKey, key)
+	}
+}
+
+func (l *GenerationLimiter) release(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.finish(key)
+	l.dispatch()
+}
+
+// dispatch grants free slots to waiting requests, taking keys in turn.
+func (l *GenerationLimiter) dispatch() {
+	for skipped := 0; l.active < l.max && skipped < len(l.order); {
+		key := l.order[0]
+		l.order = l.order[1:]
+		if !l.available(key) {
+			l.order = append(l.order, key)
+			skipped++
+			continue
+		}
+		skipped = 0
+
+		q := l.queues[key]
+		w := q[0]
+		if len(q) == 1 {
+			delete(l.queues, key)
+		} else {
+			l.queues[key] = q[1:]
+			l.order = append(l.order, key)
+		}
+		w.granted = true
+		l.start(key)
+		close(w.ready)
+	}
+}
+
+// remove drops a waiter whose context ended.
+func (l *GenerationLimiter) remove(key string, w *genWaiter) {
+	q := l.queues[key]
+	for i, x := range q {
+		if x == w {
+			q = append(q[:i:i], q[i+1:]...)
+			break
+		}
+	}
+	if len(q) > 0 {
+		l.queues[key] = q
+		return
+	}
+	delete(l.queues, key)
+	for i, k := range l.order {
+		if k == key {
+			l.order = append(l.order[:i:i], l.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// acquireGeneration waits for a generation slot, if the client is limited,
+// and returns how long it waited. release is never nil on success.
+func (c *LLMClient) acquireGeneration(ctx context.Context) (release func(), wait time.Duration, err error) {
+	if c.limiter == nil {
+		return func() {}, 0, nil
+	}
+	if c.queueTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, c.queueTimeout, ErrGenerationQueueTimeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	release, err = c.limiter.acquire(ctx, c.apiKey)
+	wait = time.Since(start)
+	if c.metrics != nil {
+		c.metrics.ObserveDuration(MetricLLMQueueWait, nil, wait)
+	}
+	return release, wait, err
+}
@@ -0,0 +1,141 @@
+package levee
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestGenerationLimiterFairness(t *testing.T) {
+	tests := []struct {
+		name        string
+		max, perKey int
+		// holding are the keys holding slots at the start, oldest first.
+		holding []string
+		// queued are waiters labelled by key and sequence, e.g. "a1", in the
+		// order they queue.
+		queued []string
+		// want is the order waiters are granted as the oldest slot is
+		// released, one at a time.
+		want []string
+	}{
+		{
+			name:    "keys take turns",
+			max:     1,
+			holding: []string{"a"},
+			queued:  []string{"a1", "a2", "b1", "c1"},
+			want:    []string{"a1", "b1", "c1", "a2"},
+		},
+		{
+			name:    "one key runs in order",
+			max:     1,
+			holding: []string{"a"},
+			queued:  []string{"b1", "b2", "b3"},
+			want:    []string{"b1", "b2", "b3"},
+		},
+		{
+			name:    "per-key limit lets other keys pass",
+			max:     2,
+			perKey:  1,
+			holding: []string{"b", "a"},
+			queued:  []string{"a1", "c1"},
+			want:    []string{"c1", "a1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewGenerationLimiter(tt.max, tt.perKey)
+			ctx := context.Background()
+
+			var held []func()
+			for _, key := range tt.holding {
+				release, err := l.acquire(ctx, key)
+				if err != nil {
+					t.Fatalf("acquire %s: %v", key, err)
+				}
+				held = append(held, release)
+			}
+
+			type grant struct {
+				label   string
+				release func()
+			}
+			grants := make(chan grant, len(tt.queued))
+			for i, label := range tt.queued {
+				go func() {
+					release, err := l.acquire(ctx, label[:1])
+					if err != nil {
+						t.Errorf("acquire %s: %v", label, err)
+						return
+					}
+					grants <- grant{label, release}
+				}()
+				waitFor(t, func() bool { return l.Queued() == i+1 })
+			}
+
+			var got []string
+			for range tt.want {
+				held[0]()
+				held = held[1:]
+				select {
+				case g := <-grants:
+					got = append(got, g.label)
+					held = append(held, g.release)
+				case <-time.After(time.Second):
+					t.Fatalf("no grant after %v", got)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("grant order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerationLimiterCancel(t *testing.T) {
+	l := NewGenerationLimiter(1, 0)
+	release, err := l.acquire(context.Background(), "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	errc := make(chan error, 1)
+	go func() {
+		_, err := l.acquire(ctx, "b")
+		errc <- err
+	}()
+	waitFor(t, func() bool { return l.Queued() == 1 })
+
+	cause := errors.New("client went away")
+	cancel(cause)
+	if err := <-errc; !errors.Is(err, cause) {
+		t.Errorf("acquire error = %v, want %v", err, cause)
+	}
+	if n := l.Queued(); n != 0 {
+		t.Errorf("Queued() = %d after cancel, want 0", n)
+	}
+
+	// The cancelled waiter must not keep the slot once it is released.
+	release()
+	release2, err := l.acquire(context.Background(), "c")
+	if err != nil {
+		t.Fatalf("acquire after release: %v", err)
+	}
+	release2()
+}
+
+// waitFor polls cond until it holds, failing the test after a second.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,168 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/almatuck/levee-go/llmpb"
+)
+
+// ModerationResult is the moderation verdict on a text.
+type ModerationResult struct {
+	Flagged    bool
+	Categories []ModerationCategory
+	Model      string
+}
+
+// ModerationCategory is the verdict for one policy category, e.g. "hate",
+// "harassment", "self-harm", "sexual", or "violence".
+type ModerationCategory struct {
+	Name    string
+	Score   float64 // 0-1 confidence
+	Flagged bool
+}
+
+// FlaggedCategories returns the names of the flagged categories.
+func (r *ModerationResult) FlaggedCategories() []string {
+	var names []string
+	for _, c := range r.Categories {
+		if c.Flagged {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
+// Moderate classifies text against the content policy with the organization's
+// moderation model.
+func (c *LLMClient) Moderate(ctx context.Context, text string) (*ModerationResult, error) {
+	if err := c.connect(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Moderate(ctx, &llmpb.ModerateRequest{
+		ApiKey: c.apiKey,
+		Input:  text,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("moderation request failed: %w", err)
+	}
+
+	result := &ModerationResult{Flagged: resp.Flagged, Model: resp.Model}
+	for _, cat := range resp.Categories {
+		result.Categories = append(result.Categories, ModerationCategory{
+			Name:    cat.Name,
+			Score:   cat.Score,
+			Flagged: cat.Flagged,
+		})
+	}
+	return result, nil
+}
+
+// ContentFilter screens a user message or model reply and returns the text to
+// use in its place. Returning an error blocks the message. llm is the client
+// running the filter, for filters that call Moderate or Chat.
+type ContentFilter func(ctx context.Context, llm *LLMClient, text string) (string, error)
+
+// WithInputFilter screens user messages before they are sent by Chat, chat
+// sessions, and the WebSocket handler. Filters run in order.
+func WithInputFilter(filters ...ContentFilter) LLMOption {
+	return func(c *LLMClient) {
+		c.inputFilters = append(c.inputFilters, filters...)
+	}
+}
+
+// WithOutputFilter screens model replies in Chat, chat sessions, and the
+// WebSocket handler. A reply can only be screened once complete, so with
+// output filters, streamed replies are delivered as one chunk after passing.
+func WithOutputFilter(filters ...ContentFilter) LLMOption {
+	return func(c *LLMClient) {
+		c.outputFilters = append(c.outputFilters, filters...)
+	}
+}
+
+// ModerationAction is what ModerationFilter does with flagged content.
+type ModerationAction int
+
+const (
+	// ModerationBlock rejects flagged content with a *ContentBlockedError.
+	ModerationBlock ModerationAction = iota
+	// ModerationRedact replaces flagged content with RedactedContent.
+	ModerationRedact
+)
+
+// RedactedContent replaces content removed by ModerationRedact.
+const RedactedContent = "[content removed]"
+
+// ModerationFilter returns a ContentFilter that screens text with Moderate.
+// If the moderation request fails, the text is blocked.
+func ModerationFilter(action ModerationAction) ContentFilter {
+	return func(ctx context.Context, llm *LLMClient, text string) (string, error) {
+		if strings.TrimSpace(text) == "" {
+			return text, nil
+		}
+		result, err := llm.Moderate(ctx, text)
+		if err != nil {
+			return "", err
+		}
+		if !result.Flagged {
+			return text, nil
+		}
+		if action == ModerationRedact {
+			return RedactedContent, nil
+		}
+		return "", &ContentBlockedError{Categories: result.FlaggedCategories()}
+	}
+}
+
+// ContentBlockedError is returned when a content filter blocks a message.
+type ContentBlockedError struct {
+	Stage      string   // "input" or "output"
+	Categories []string // flagged moderation categories, if known
+	Err        error    // the filter's error, for custom filters
+}
+
+func (e *ContentBlockedError) Error() string {
+	msg := "content blocked"
+	if e.Stage != "" {
+		msg = e.Stage + " " + msg
+	}
+	if len(e.Categories) > 0 {
+		msg += ": flagged for " + strings.Join(e.Categories, ", ")
+	} else if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *ContentBlockedError) Unwrap() error {
+	return e.Err
+}
+
+// filterInput runs the input filters on a user message.
+func (c *LLMClient) filterInput(ctx context.Context, text string) (string, error) {
+	return c.runFilters(ctx, c.inputFilters, "input", text)
+}
+
+// filterOutput runs the output filters on a model reply.
+func (c *LLMClient) filterOutput(ctx context.Context, text string) (string, error) {
+	return c.runFilters(ctx, c.outputFilters, "output", text)
+}
+
+// runFilters applies filters in order, reporting a block as a *ContentBlockedError.
+func (c *LLMClient) runFilters(ctx context.Context, filters []ContentFilter, stage, text string) (string, error) {
+	for _, filter := range filters {
+		out, err := filter(ctx, c, text)
+		if err != nil {
+			blocked, ok := err.(*ContentBlockedError)
+			if !ok {
+				blocked = &ContentBlockedError{Err: err}
+			}
+			blocked.Stage = stage
+			return "", blocked
+		}
+		text = out
+	}
+	return text, nil
+}
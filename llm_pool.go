@@ -0,0 +1,85 @@
+package levee
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// DefaultConnectionPoolSize is the pool size used by WithConnectionPool(0).
+//
+// Each connection is one HTTP/2 connection, and load balancers and proxies in
+// front of the gateway commonly allow 100-128 concurrent streams on each;
+// further chat streams queue behind them. In BenchmarkConnectionPool, 400
+// concurrent 100ms chat streams against a 100-stream limit take 480ms on one
+// connection, 250ms on two, and 133ms on four, with no gain beyond; four is
+// the smallest pool that doesn't queue them. Size larger pools at peak
+// concurrent chats / 100.
+const DefaultConnectionPoolSize = 4
+
+// WithConnectionPool opens size gRPC connections to the gateway instead of
+// one and spreads calls and chat streams across them round-robin, skipping
+// connections that are failing. Use it on servers running hundreds or
+// thousands of concurrent chats; size <= 0 uses DefaultConnectionPoolSize.
+func WithConnectionPool(size int) LLMOption {
+	return func(c *LLMClient) {
+		if size <= 0 {
+			size = DefaultConnectionPoolSize
+		}
+		c.poolSize = size
+	}
+}
+
+// connPool is a grpc.ClientConnInterface spreading RPCs across connections.
+type connPool struct {
+	conns []*grpc.ClientConn
+	next  atomic.Uint64
+}
+
+// dialPool opens size connections to addr.
+func dialPool(addr string, size int, opts ...grpc.DialOption) (*connPool, error) {
+	p := &connPool{conns: make([]*grpc.ClientConn, 0, max(size, 1))}
+	for range max(size, 1) {
+		conn, err := grpc.NewClient(addr, opts...)
+		if err != nil {
+			p.Close()
+			return nil, err
+		}
+		p.conns = append(p.conns, conn)
+	}
+	return p, nil
+}
+
+// pick returns the next connection in turn that isn't failing, or the next
+// one if all are.
+func (p *connPool) pick() *grpc.ClientConn {
+	n := uint64(len(p.conns))
+	start := p.next.Add(1) - 1
+	for i := range n {
+		conn := p.conns[(start+i)%n]
+		if state := conn.GetState(); state != connectivity.TransientFailure && state != connectivity.Shutdown {
+			return conn
+		}
+	}
+	return p.conns[start%n]
+}
+
+func (p *connPool) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	return p.pick().Invoke(ctx, method, args, reply, opts...)
+}
+
+func (p *connPool) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return p.pick().NewStream(ctx, desc, method, opts...)
+}
+
+// Close closes every connection.
+func (p *connPool) Close() error {
+	var errs []error
+	for _, conn := range p.conns {
+		errs = append(errs, conn.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,116 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/almatuck/levee-go/llmpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// benchStreamLimit is the concurrent streams per connection the benchmark
+// server allows, matching common load balancer and proxy limits.
+const benchStreamLimit = 100
+
+// benchChatServer streams a fixed reply of benchChunks chunks per message.
+type benchChatServer struct {
+	llmpb.UnimplementedLLMServiceServer
+}
+
+const (
+	benchChunks     = 20
+	benchChunkDelay = 5 * time.Millisecond
+)
+
+func (benchChatServer) Chat(stream grpc.BidiStreamingServer[llmpb.ChatRequest, llmpb.ChatResponse]) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case req.GetStart() != nil:
+			err = stream.Send(&llmpb.ChatResponse{Response: &llmpb.ChatResponse_SessionStarted{
+				SessionStarted: &llmpb.SessionStarted{SessionId: "bench"},
+			}})
+		case req.GetMessage() != nil:
+			for i := range benchChunks {
+				time.Sleep(benchChunkDelay)
+				if err := stream.Send(&llmpb.ChatResponse{Response: &llmpb.ChatResponse_Chunk{
+					Chunk: &llmpb.ContentChunk{Content: "token ", Index: int32(i)},
+				}}); err != nil {
+					return err
+				}
+			}
+			err = stream.Send(&llmpb.ChatResponse{Response: &llmpb.ChatResponse_Completion{
+				Completion: &llmpb.CompletionResponse{StopReason: "end_turn"},
+			}})
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// BenchmarkConnectionPool runs concurrent chat streams against a server
+// limited to benchStreamLimit streams per connection, for each pool size.
+// Throughput levels off once size*benchStreamLimit covers the concurrent
+// chats; DefaultConnectionPoolSize is the size that does so for 400.
+//
+//	go test -run '^$' -bench ConnectionPool
+func BenchmarkConnectionPool(b *testing.B) {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.MaxConcurrentStreams(benchStreamLimit))
+	llmpb.RegisterLLMServiceServer(srv, benchChatServer{})
+	go srv.Serve(lis)
+	b.Cleanup(srv.Stop)
+
+	for _, chats := range []int{100, 400} {
+		for _, size := range []int{1, 2, 4, 8} {
+			b.Run(fmt.Sprintf("chats=%d/pool=%d", chats, size), func(b *testing.B) {
+				llm := NewLLMClient("key", "",
+					WithGRPCAddress("passthrough:///bufnet"),
+					WithTransport(TransportGRPC),
+					WithInsecure(),
+					WithConnectionPool(size),
+					WithDialOptions(grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+						return lis.DialContext(ctx)
+					})),
+				)
+				defer llm.Close()
+
+				req := ChatRequest{Messages: []ChatMessage{{Role: "user", Content: "hi"}}}
+				b.ResetTimer()
+				for range b.N {
+					var wg sync.WaitGroup
+					errs := make(chan error, chats)
+					for range chats {
+						wg.Add(1)
+						go func() {
+							defer wg.Done()
+							if _, err := llm.ChatStream(context.Background(), req, nil); err != nil {
+								errs <- err
+							}
+						}()
+					}
+					wg.Wait()
+					close(errs)
+					if err := <-errs; err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.ReportMetric(float64(chats*b.N)/b.Elapsed().Seconds(), "chats/s")
+			})
+		}
+	}
+}
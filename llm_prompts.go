@@ -0,0 +1,213 @@
+package levee
+
+import (
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// maxPartialDepth bounds nested {{> partial}} includes.
+const maxPartialDepth = 10
+
+// partialTag matches {{> name}} and {{> name@version}} includes.
+var partialTag = regexp.MustCompile(`{{>\s*([\w.-]+)(?:@(\d+))?\s*}}`)
+
+// PromptTemplate is a named, versioned prompt registered on an LLMClient.
+// Text uses merge tag syntax (see RenderMergeTags) without HTML escaping, plus
+// {{> name}} to include another registered prompt as a partial ({{> name@2}}
+// pins a version).
+type PromptTemplate struct {
+	Name        string
+	Version     int // 0 registers the next version
+	Description string
+	Text        string
+	// Defaults are used for variables not passed to RenderPrompt.
+	Defaults map[string]any
+}
+
+// promptRegistry holds every version of each prompt, oldest first.
+type promptRegistry struct {
+	mu      sync.RWMutex
+	prompts map[string][]*PromptTemplate
+}
+
+// RegisterPrompt adds a prompt template version. Registering an existing
+// name and version replaces it. The template syntax is checked here so
+// mistakes surface at startup.
+//
+//	llm.RegisterPrompt(levee.PromptTemplate{
+//		Name: "support_agent",
+//		Text: "You are {{company}}'s support agent.\n{{> tone}}\n" +
+//			"{{#if plan}}The customer is on the {{plan}} plan.{{/if}}",
+//		Defaults: map[string]any{"company": "Acme"},
+//	})
+func (c *LLMClient) RegisterPrompt(t PromptTemplate) error {
+	if t.Name == "" {
+		return fmt.Errorf("prompt name is required")
+	}
+	if strings.ContainsAny(t.Name, "@ ") {
+		return fmt.Errorf("prompt name %q must not contain spaces or @", t.Name)
+	}
+	if _, _, err := (&mergeParser{src: partialTag.ReplaceAllString(t.Text, "")}).parse(); err != nil {
+		return fmt.Errorf("prompt %s: %w", t.Name, err)
+	}
+
+	r := &c.prompts
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.prompts == nil {
+		r.prompts = make(map[string][]*PromptTemplate)
+	}
+
+	versions := r.prompts[t.Name]
+	if t.Version == 0 {
+		t.Version = 1
+		if n := len(versions); n > 0 {
+			t.Version = versions[n-1].Version + 1
+		}
+	}
+	t.Defaults = maps.Clone(t.Defaults)
+
+	i, found := slices.BinarySearchFunc(versions, t.Version, func(p *PromptTemplate, v int) int { return p.Version - v })
+	if found {
+		versions[i] = &t
+	} else {
+		versions = slices.Insert(versions, i, &t)
+	}
+	r.prompts[t.Name] = versions
+	return nil
+}
+
+// LoadPrompts registers every file in fsys matching pattern (see fs.Glob), e.g.
+// from an embed.FS. The prompt name is the file name without extension; a
+// name@N suffix sets the version, as in support_agent@2.txt.
+func (c *LLMClient) LoadPrompts(fsys fs.FS, pattern string) error {
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		text, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return fmt.Errorf("failed to read prompt %s: %w", file, err)
+		}
+
+		name := strings.TrimSuffix(path.Base(file), path.Ext(file))
+		t := PromptTemplate{Name: name, Text: string(text)}
+		if base, v, ok := strings.Cut(name, "@"); ok {
+			if t.Version, err = strconv.Atoi(v); err != nil || t.Version < 1 {
+				return fmt.Errorf("prompt %s: invalid version %q", file, v)
+			}
+			t.Name = base
+		}
+		if err := c.RegisterPrompt(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Prompt returns a prompt template; version 0 returns the latest.
+func (c *LLMClient) Prompt(name string, version int) (*PromptTemplate, bool) {
+	c.prompts.mu.RLock()
+	defer c.prompts.mu.RUnlock()
+	return c.prompts.lookup(name, version)
+}
+
+// PromptVersions returns the registered versions of a prompt, oldest first.
+func (c *LLMClient) PromptVersions(name string) []int {
+	c.prompts.mu.RLock()
+	defer c.prompts.mu.RUnlock()
+
+	var versions []int
+	for _, t := range c.prompts.prompts[name] {
+		versions = append(versions, t.Version)
+	}
+	return versions
+}
+
+// RenderPrompt renders the latest version of a prompt with vars over its
+// Defaults. Missing variables without a default are reported as a
+// *MissingVariableError.
+//
+//	system, err := llm.RenderPrompt("support_agent", map[string]any{"plan": "pro"})
+//	resp, err := llm.Chat(ctx, levee.ChatRequest{SystemPrompt: system, Messages: msgs})
+func (c *LLMClient) RenderPrompt(name string, vars map[string]any) (string, error) {
+	return c.RenderPromptVersion(name, 0, vars)
+}
+
+// RenderPromptVersion renders a specific prompt version; 0 is the latest.
+func (c *LLMClient) RenderPromptVersion(name string, version int, vars map[string]any) (string, error) {
+	c.prompts.mu.RLock()
+	t, ok := c.prompts.lookup(name, version)
+	if !ok {
+		c.prompts.mu.RUnlock()
+		return "", promptNotFound(name, version)
+	}
+	text, err := c.prompts.expandPartials(t.Text, 0)
+	c.prompts.mu.RUnlock()
+	if err != nil {
+		return "", fmt.Errorf("prompt %s: %w", name, err)
+	}
+
+	data := maps.Clone(t.Defaults)
+	if data == nil {
+		data = map[string]any{}
+	}
+	maps.Copy(data, vars)
+	return renderMergeText(text, data, false)
+}
+
+// lookup returns a prompt version; the caller holds r.mu.
+func (r *promptRegistry) lookup(name string, version int) (*PromptTemplate, bool) {
+	versions := r.prompts[name]
+	if len(versions) == 0 {
+		return nil, false
+	}
+	if version == 0 {
+		return versions[len(versions)-1], true
+	}
+	i, found := slices.BinarySearchFunc(versions, version, func(p *PromptTemplate, v int) int { return p.Version - v })
+	if !found {
+		return nil, false
+	}
+	return versions[i], true
+}
+
+// expandPartials replaces {{> name}} includes with the partials' text; the
+// caller holds r.mu.
+func (r *promptRegistry) expandPartials(text string, depth int) (string, error) {
+	if depth > maxPartialDepth {
+		return "", fmt.Errorf("partials nested more than %d deep (cycle?)", maxPartialDepth)
+	}
+
+	var expandErr error
+	out := partialTag.ReplaceAllStringFunc(text, func(tag string) string {
+		m := partialTag.FindStringSubmatch(tag)
+		version, _ := strconv.Atoi(m[2])
+		partial, ok := r.lookup(m[1], version)
+		if !ok {
+			expandErr = promptNotFound(m[1], version)
+			return ""
+		}
+		expanded, err := r.expandPartials(partial.Text, depth+1)
+		if err != nil {
+			expandErr = err
+		}
+		return expanded
+	})
+	return out, expandErr
+}
+
+func promptNotFound(name string, version int) error {
+	if version == 0 {
+		return fmt.Errorf("prompt %q not registered", name)
+	}
+	return fmt.Errorf("prompt %q version %d not registered", name, version)
+}
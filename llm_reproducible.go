@@ -0,0 +1,24 @@
+package levee
+
+import "github.com/almatuck/levee-go/llmpb"
+
+// WithReproducible makes generations reproducible where the provider supports
+// it, for tests and evaluations: requests without their own Seed use seed and
+// are Deterministic, with greedy decoding and no model or provider fallback.
+// Compare ChatResponse.Fingerprint across runs to detect backend changes that
+// can alter output for the same seed.
+func WithReproducible(seed int64) LLMOption {
+	return func(c *LLMClient) {
+		c.seed = &seed
+	}
+}
+
+// params returns the sampling parameters sent for req, with the client's
+// reproducibility defaults applied.
+func (c *LLMClient) params(req ChatRequest) *llmpb.GenerationParams {
+	if c.seed != nil && req.Seed == nil {
+		req.Seed = c.seed
+		req.Deterministic = true
+	}
+	return req.paramsToPB()
+}
@@ -0,0 +1,177 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/almatuck/levee-go/llmpb"
+)
+
+// ResumeOption configures ResumeSession.
+type ResumeOption func(*resumeOptions)
+
+type resumeOptions struct {
+	lastChunk int32
+}
+
+// ResumeAfterChunk replays only the chunks after index, the last one the
+// client received (see ChatSession.LastChunkIndex). By default the whole
+// turn in flight is replayed.
+func ResumeAfterChunk(index int32) ResumeOption {
+	return func(o *resumeOptions) {
+		o.lastChunk = index
+	}
+}
+
+// ResumeSession reattaches to a gateway chat session after a dropped
+// connection, e.g. when a mobile client reconnects. If a turn was in flight,
+// read it with Receive: missed chunks are replayed, then streaming continues.
+//
+//	session, err := llm.ResumeSession(ctx, savedID, levee.ResumeAfterChunk(lastIndex))
+//	if err != nil {
+//		return err
+//	}
+//	resp, err := session.Receive(ctx, callback) // nil if no turn was in flight
+func (c *LLMClient) ResumeSession(ctx context.Context, sessionID string, opts ...ResumeOption) (*ChatSession, error) {
+	o := resumeOptions{lastChunk: -1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	stream, started, err := c.resumeChat(ctx, sessionID, o.lastChunk)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChatSession{
+		llm: c,
+		ctx: ctx,
+		start: &llmpb.StartChatRequest{
+			ApiKey: c.apiKey,
+			Model:  started.Model,
+			Tools:  c.toolDefinitions(),
+
+			ParallelToolCalls: true,
+		},
+		history: started.Messages,
+		stream:  stream,
+		apiKey:  c.apiKey,
+
+		provider:   started.Provider,
+		model:      started.Model,
+		gatewayID:  started.SessionId,
+		lastChunk:  o.lastChunk,
+		generating: started.Generating,
+
+		transcript: transcriptFromPB(started.Messages),
+	}, nil
+}
+
+// resumeChat opens a Chat stream reattached to a session and reads its
+// SessionStarted reply.
+func (c *LLMClient) resumeChat(ctx context.Context, sessionID string, lastChunk int32) (llmpb.LLMService_ChatClient, *llmpb.SessionStarted, error) {
+	if sessionID == "" {
+		return nil, nil, fmt.Errorf("session ID is required")
+	}
+	if err := c.connect(); err != nil {
+		return nil, nil, err
+	}
+
+	c.mu.Lock()
+	client := c.client
+	c.mu.Unlock()
+	if client == nil {
+		return nil, nil, fmt.Errorf("LLM client is closed")
+	}
+
+	stream, err := client.Chat(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resume chat session: %w", err)
+	}
+	err = stream.Send(&llmpb.ChatRequest{
+		Request: &llmpb.ChatRequest_Resume{Resume: &llmpb.ResumeRequest{
+			ApiKey:         c.apiKey,
+			SessionId:      sessionID,
+			LastChunkIndex: lastChunk,
+		}},
+	})
+	if err != nil {
+		drainStream(stream)
+		return nil, nil, fmt.Errorf("failed to send resume request: %w", err)
+	}
+
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resume chat session: %w", err)
+	}
+	switch r := resp.Response.(type) {
+	case *llmpb.ChatResponse_SessionStarted:
+		return stream, r.SessionStarted, nil
+	case *llmpb.ChatResponse_Error:
+		drainStream(stream)
+		return nil, nil, &LLMError{Code: r.Error.Code, Message: r.Error.Message, Retryable: r.Error.Retryable}
+	}
+	drainStream(stream)
+	return nil, nil, fmt.Errorf("unexpected resume response %T", resp.Response)
+}
+
+// ID returns the gateway's ID of the session, for ResumeSession. It is known
+// once the first Send has started streaming.
+func (s *ChatSession) ID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.gatewayID
+}
+
+// LastChunkIndex returns the index of the last chunk received in the current
+// or last turn, or -1 if none, for ResumeAfterChunk.
+func (s *ChatSession) LastChunkIndex() int32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastChunk
+}
+
+// Receive reads the turn that was in flight when the session was resumed:
+// missed chunks are replayed to callback, then streaming continues until the
+// turn completes. It returns nil if no turn was in flight.
+func (s *ChatSession) Receive(ctx context.Context, callback StreamCallback) (*ChatResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.done {
+		return nil, fmt.Errorf("session is closed")
+	}
+	if !s.generating {
+		return nil, nil
+	}
+	s.generating = false
+
+	filterOutput := len(s.llm.outputFilters) > 0
+	turnCallback := callback
+	if filterOutput {
+		turnCallback = nil
+	}
+	var prompt []ChatMessage
+	if n := len(s.history); n > 0 && s.history[n-1].Role == "user" {
+		prompt = messagesFromPB(s.history[n-1:])
+	}
+	resp, _, err := s.receive(ctx, turnCallback, false)
+	s.llm.audit(ctx, s.auditRecord(prompt...).withResult(resp, err))
+	if err != nil {
+		return nil, err
+	}
+	s.history = append(s.history, &llmpb.Message{Role: "assistant", Content: resp.Content})
+
+	if filterOutput {
+		if resp.Content, err = s.llm.filterOutput(ctx, resp.Content); err != nil {
+			return nil, err
+		}
+		if callback != nil && resp.Content != "" {
+			if err := callback(StreamChunk{Content: resp.Content}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	s.recordTurn(nil, resp)
+	return resp, nil
+}
@@ -0,0 +1,130 @@
+package levee
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/almatuck/levee-go/llmpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultLLMRetries is the number of retries per model on retryable errors.
+const defaultLLMRetries = 2
+
+// LLMError is an error reported by the gateway or provider during generation.
+type LLMError struct {
+	Code      string
+	Message   string
+	Retryable bool // e.g. rate limits and overloaded providers
+}
+
+func (e *LLMError) Error() string {
+	return "LLM error: " + e.Message
+}
+
+// ChatAttempt records one request made for a chat turn, so the cost of
+// retries and fallbacks can be accounted for.
+type ChatAttempt struct {
+	Model        string
+	Err          error // nil for the successful attempt
+	InputTokens  int64
+	OutputTokens int64
+	CostUSD      float64
+	LatencyMs    int64
+}
+
+// WithFallbackModels sets models to try in order, after the requested model
+// keeps failing with retryable errors (rate limits, provider outages).
+// Non-retryable errors, such as invalid requests, are returned immediately.
+//
+//	llm := levee.NewLLMClient(apiKey, baseURL, levee.WithFallbackModels("sonnet", "haiku"))
+func WithFallbackModels(models ...string) LLMOption {
+	return func(c *LLMClient) {
+		c.fallbackModels = models
+	}
+}
+
+// WithLLMRetries sets how many times a request is retried on the same model
+// after a retryable error, with exponential backoff (see WithReconnectBackoff).
+// The default is 2; 0 disables retries.
+func WithLLMRetries(n int) LLMOption {
+	return func(c *LLMClient) {
+		c.retries = max(n, 0)
+	}
+}
+
+// isRetryableLLMError reports whether a failed request may succeed if repeated.
+func isRetryableLLMError(err error) bool {
+	var llmErr *LLMError
+	if errors.As(err, &llmErr) {
+		return llmErr.Retryable
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
+}
+
+// retryPlan walks the retries of each model in the fallback chain.
+type retryPlan struct {
+	llm    *LLMClient
+	models []string
+	model  string // model of the current attempt
+	index  int    // position of model in models
+	try    int    // retries made on model
+}
+
+// newRetryPlan starts a plan with model followed by the fallback models.
+// Pinned plans only retry model, so deterministic requests stay reproducible.
+func (c *LLMClient) newRetryPlan(model string, pinned bool) *retryPlan {
+	models := []string{model}
+	for _, m := range c.fallbackModels {
+		if pinned {
+			break
+		}
+		if !slices.Contains(models, m) {
+			models = append(models, m)
+		}
+	}
+	return &retryPlan{llm: c, models: models, model: model}
+}
+
+// next moves to the next attempt after a retryable failure, waiting out the
+// backoff for a retry of the same model. It returns false when the plan is
+// exhausted or ctx is done.
+func (p *retryPlan) next(ctx context.Context) bool {
+	if p.try < p.llm.retries {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(p.llm.reconnectDelay(p.try)):
+		}
+		p.try++
+		return true
+	}
+	if p.index+1 >= len(p.models) {
+		return false
+	}
+	p.index++
+	p.model = p.models[p.index]
+	p.try = 0
+	return ctx.Err() == nil
+}
+
+// drainStream closes the sending side of a stream and reads it to its end in
+// the background, so gRPC releases it.
+func drainStream(stream llmpb.LLMService_ChatClient) error {
+	err := stream.CloseSend()
+	go func() {
+		for {
+			if _, err := stream.Recv(); err != nil {
+				return
+			}
+		}
+	}()
+	return err
+}
@@ -0,0 +1,54 @@
+package levee
+
+// Provider is an LLM provider configured for the organization.
+type Provider string
+
+// Providers the gateway can route to.
+const (
+	ProviderAnthropic Provider = "anthropic"
+	ProviderOpenAI    Provider = "openai"
+	ProviderLocal     Provider = "local" // self-hosted models
+)
+
+// RoutingPolicy picks a provider and model when a request leaves them open,
+// e.g. a tier alias like "sonnet" served by several providers.
+type RoutingPolicy string
+
+// Routing policies.
+const (
+	// RouteCheapest picks the lowest-cost provider for the model tier.
+	RouteCheapest RoutingPolicy = "cheapest"
+	// RouteFastest picks the provider with the lowest recent latency.
+	RouteFastest RoutingPolicy = "fastest"
+	// RouteSticky keeps a session or API key on the provider it was first
+	// routed to, for consistent output and prompt caching.
+	RouteSticky RoutingPolicy = "sticky"
+)
+
+// WithProvider sets the default provider of requests that don't set one.
+func WithProvider(provider Provider) LLMOption {
+	return func(c *LLMClient) {
+		c.provider = provider
+	}
+}
+
+// WithRoutingPolicy sets the default routing policy of requests that don't set
+// one. Without a policy, the organization's gateway setting applies.
+func WithRoutingPolicy(policy RoutingPolicy) LLMOption {
+	return func(c *LLMClient) {
+		c.routing = policy
+	}
+}
+
+// route returns the provider and routing policy of a request, falling back to
+// the client defaults.
+func (c *LLMClient) route(req ChatRequest) (provider, routing string) {
+	p, r := req.Provider, req.Routing
+	if p == "" {
+		p = c.provider
+	}
+	if r == "" {
+		r = c.routing
+	}
+	return string(p), string(r)
+}
@@ -0,0 +1,355 @@
+package levee
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// jsonSchema is the subset of JSON Schema generated from Go types.
+type jsonSchema struct {
+	Type                 string                 `json:"type,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties any                    `json:"additionalProperties,omitempty"` // false or *jsonSchema
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Enum                 []any                  `json:"enum,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	Maximum              *float64               `json:"maximum,omitempty"`
+	MinLength            *int                   `json:"minLength,omitempty"`
+	MaxLength            *int                   `json:"maxLength,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+
+	pattern  *regexp.Regexp
+	nullable bool // pointer fields accept null
+}
+
+var (
+	timeType       = reflect.TypeFor[time.Time]()
+	rawMessageType = reflect.TypeFor[json.RawMessage]()
+)
+
+// JSONSchemaFor returns the JSON Schema of T, which must be a struct, for tool
+// parameters and structured output. Properties are named by their json tags;
+// fields without omitempty and not pointers are required. Constraints come
+// from struct tags:
+//
+//	type WeatherArgs struct {
+//		City  string `json:"city" description:"City name, e.g. Paris"`
+//		Units string `json:"units,omitempty" jsonschema:"enum=celsius|fahrenheit"`
+//		Days  int    `json:"days,omitempty" jsonschema:"minimum=1,maximum=14"`
+//	}
+//
+// The jsonschema tag accepts enum (values separated by |), minimum, maximum,
+// minLength, maxLength, pattern, format, and the flags required and optional.
+func JSONSchemaFor[T any]() (string, error) {
+	schema, err := schemaForType(reflect.TypeFor[T]())
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// schemaForType builds the schema of a struct type.
+func schemaForType(t reflect.Type) (*jsonSchema, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("schema type must be a struct, got %s", t)
+	}
+	return schemaOf(t, map[reflect.Type]bool{})
+}
+
+// schemaOf maps a Go type to a schema. seen guards against recursive types,
+// which are emitted as unconstrained schemas.
+func schemaOf(t reflect.Type, seen map[reflect.Type]bool) (*jsonSchema, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &jsonSchema{Type: "string", Format: "date-time"}, nil
+	case t == rawMessageType:
+		return &jsonSchema{}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &jsonSchema{Type: "string"}, nil
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &jsonSchema{Type: "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		zero := 0.0
+		return &jsonSchema{Type: "integer", Minimum: &zero}, nil
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}, nil
+	case reflect.Interface:
+		return &jsonSchema{}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &jsonSchema{Type: "string", Format: "byte"}, nil
+		}
+		items, err := schemaOf(t.Elem(), seen)
+		if err != nil {
+			return nil, err
+		}
+		return &jsonSchema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type %s", t.Key())
+		}
+		values, err := schemaOf(t.Elem(), seen)
+		if err != nil {
+			return nil, err
+		}
+		return &jsonSchema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		if seen[t] {
+			return &jsonSchema{Type: "object"}, nil
+		}
+		seen[t] = true
+		defer delete(seen, t)
+
+		schema := &jsonSchema{Type: "object", Properties: map[string]*jsonSchema{}, AdditionalProperties: false}
+		if err := addFields(schema, t, seen); err != nil {
+			return nil, err
+		}
+		return schema, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+// addFields adds the exported fields of t to schema, flattening embedded
+// structs the way encoding/json does.
+func addFields(schema *jsonSchema, t reflect.Type, seen map[reflect.Type]bool) error {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		ft := field.Type
+		if field.Anonymous && name == "" {
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if err := addFields(schema, ft, seen); err != nil {
+					return err
+				}
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		prop, err := schemaOf(ft, seen)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		prop.Description = field.Tag.Get("description")
+		prop.nullable = ft.Kind() == reflect.Pointer
+
+		required := !slices.Contains(strings.Split(opts, ","), "omitempty") && ft.Kind() != reflect.Pointer
+		if err := applySchemaTag(prop, field.Tag.Get("jsonschema"), &required); err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		schema.Properties[name] = prop
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return nil
+}
+
+// applySchemaTag applies the constraints of a jsonschema struct tag.
+func applySchemaTag(s *jsonSchema, tag string, required *bool) error {
+	if tag == "" {
+		return nil
+	}
+	for part := range strings.SplitSeq(tag, ",") {
+		key, val, _ := strings.Cut(part, "=")
+		var err error
+		switch key {
+		case "required":
+			*required = true
+		case "optional":
+			*required = false
+		case "enum":
+			for v := range strings.SplitSeq(val, "|") {
+				if s.Type == "integer" || s.Type == "number" {
+					n, perr := strconv.ParseFloat(v, 64)
+					if perr != nil {
+						return fmt.Errorf("invalid enum value %q", v)
+					}
+					s.Enum = append(s.Enum, n)
+				} else {
+					s.Enum = append(s.Enum, v)
+				}
+			}
+		case "minimum":
+			s.Minimum, err = parseFloatPtr(val)
+		case "maximum":
+			s.Maximum, err = parseFloatPtr(val)
+		case "minLength":
+			s.MinLength, err = parseIntPtr(val)
+		case "maxLength":
+			s.MaxLength, err = parseIntPtr(val)
+		case "pattern":
+			s.Pattern = val
+			s.pattern, err = regexp.Compile(val)
+		case "format":
+			s.Format = val
+		default:
+			return fmt.Errorf("unknown jsonschema tag option %q", key)
+		}
+		if err != nil {
+			return fmt.Errorf("jsonschema %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func parseFloatPtr(s string) (*float64, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	return &f, err
+}
+
+func parseIntPtr(s string) (*int, error) {
+	n, err := strconv.Atoi(s)
+	return &n, err
+}
+
+// validate checks a decoded JSON value against the schema. Errors name the
+// offending path so the model can correct its arguments.
+func (s *jsonSchema) validate(v any, path string) error {
+	if v == nil {
+		if s.Type == "" || s.nullable {
+			return nil
+		}
+		return fmt.Errorf("%s: must not be null", path)
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: must be an object", path)
+		}
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				return fmt.Errorf("%s: missing required property %q", path, name)
+			}
+		}
+		for name, val := range obj {
+			prop, ok := s.Properties[name]
+			if !ok {
+				if extra, ok := s.AdditionalProperties.(*jsonSchema); ok {
+					prop = extra
+				} else if s.AdditionalProperties == false {
+					return fmt.Errorf("%s: unknown property %q", path, name)
+				} else {
+					continue
+				}
+			}
+			if err := prop.validate(val, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case "array":
+		arr, ok := v.([]any)
+		if !ok {
+			return fmt.Errorf("%s: must be an array", path)
+		}
+		if s.Items != nil {
+			for i, item := range arr {
+				if err := s.Items.validate(item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%s: must be a string", path)
+		}
+		n := utf8.RuneCountInString(str)
+		if s.MinLength != nil && n < *s.MinLength {
+			return fmt.Errorf("%s: must be at least %d characters", path, *s.MinLength)
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			return fmt.Errorf("%s: must be at most %d characters", path, *s.MaxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(str) {
+			return fmt.Errorf("%s: must match %s", path, s.Pattern)
+		}
+	case "integer", "number":
+		num, ok := v.(float64)
+		if !ok {
+			return fmt.Errorf("%s: must be a number", path)
+		}
+		if s.Type == "integer" && num != math.Trunc(num) {
+			return fmt.Errorf("%s: must be an integer", path)
+		}
+		if s.Minimum != nil && num < *s.Minimum {
+			return fmt.Errorf("%s: must be >= %v", path, *s.Minimum)
+		}
+		if s.Maximum != nil && num > *s.Maximum {
+			return fmt.Errorf("%s: must be <= %v", path, *s.Maximum)
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("%s: must be a boolean", path)
+		}
+	}
+
+	if len(s.Enum) > 0 && !slices.Contains(s.Enum, v) {
+		return fmt.Errorf("%s: must be one of %v", path, s.Enum)
+	}
+	return nil
+}
+
+// decodeValidated validates JSON data against schema and decodes it into T.
+func decodeValidated[T any](schema *jsonSchema, data []byte) (T, error) {
+	var out T
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return out, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if err := schema.validate(raw, "$"); err != nil {
+		return out, err
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return out, err
+	}
+	return out, nil
+}
@@ -0,0 +1,221 @@
+package levee
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxContentItemText bounds the text embedded for a content item.
+const maxContentItemText = 8000
+
+// ContentItem is content to recommend, such as a post or product. Text is
+// what its embedding represents, e.g. title and summary.
+type ContentItem struct {
+	ID       string
+	Text     string
+	Metadata map[string]string // e.g. URL and image, for rendering in an email
+	// Vector is the item's embedding. Items without one are embedded on each
+	// call; fill it with EmbedContent to reuse it across calls.
+	Vector []float32
+}
+
+// ContentMatch is a recommended content item and its similarity, from -1 to 1.
+type ContentMatch struct {
+	ContentItem
+	Score float64
+}
+
+// ContactMatch is a similar contact and its similarity, from -1 to 1.
+type ContactMatch struct {
+	Contact Contact
+	Score   float64
+}
+
+// PostContentItem returns a content item for a CMS post, with its slug,
+// title, image, and category as metadata.
+func PostContentItem(post *SDKContentPostInfo) ContentItem {
+	text := post.Title + "\n" + cmp.Or(post.Excerpt, post.MetaDescription) + "\n" + post.Content
+	return ContentItem{
+		ID:   post.ID,
+		Text: text,
+		Metadata: map[string]string{
+			"slug":           post.Slug,
+			"title":          post.Title,
+			"featured_image": post.FeaturedImage,
+			"category":       post.CategoryName,
+		},
+	}
+}
+
+// EmbedContent fills the Vector of items that have none, in one batch.
+func (c *LLMClient) EmbedContent(ctx context.Context, items []ContentItem) error {
+	var texts []string
+	var missing []int
+	for i, item := range items {
+		if item.Vector == nil {
+			texts = append(texts, truncateRunes(item.Text, maxContentItemText))
+			missing = append(missing, i)
+		}
+	}
+	if len(texts) == 0 {
+		return nil
+	}
+	resp, err := c.Embed(ctx, texts, "")
+	if err != nil {
+		return err
+	}
+	for j, i := range missing {
+		items[i].Vector = resp.Vectors[j]
+	}
+	return nil
+}
+
+// SimilarContent recommends up to k items of catalog most similar to the items
+// a contact has read, for "readers of this also liked" sections in emails.
+// Items in seen are not recommended.
+//
+//	post, _ := client.Content.GetContentPost(ctx, "dark-mode-launch")
+//	recs, err := llm.SimilarContent(ctx, []levee.ContentItem{levee.PostContentItem(post)}, catalog, 3)
+//	for _, r := range recs {
+//		data["recommended"] = append(data["recommended"], r.Metadata)
+//	}
+func (c *LLMClient) SimilarContent(ctx context.Context, seen, catalog []ContentItem, k int) ([]ContentMatch, error) {
+	if len(seen) == 0 {
+		return nil, fmt.Errorf("at least one seen item is required")
+	}
+	items := slices.Concat(seen, catalog)
+	if err := c.EmbedContent(ctx, items); err != nil {
+		return nil, err
+	}
+	seen, catalog = items[:len(seen)], items[len(seen):]
+
+	vectors := make([][]float32, len(seen))
+	exclude := make(map[string]bool, len(seen))
+	for i, item := range seen {
+		vectors[i] = item.Vector
+		exclude[item.ID] = true
+	}
+	query := centroid(vectors)
+
+	var matches []ContentMatch
+	for _, item := range catalog {
+		if item.ID != "" && exclude[item.ID] {
+			continue
+		}
+		matches = append(matches, ContentMatch{ContentItem: item, Score: CosineSimilarity(query, item.Vector)})
+	}
+	return topMatches(matches, k, func(m ContentMatch) float64 { return m.Score }), nil
+}
+
+// SimilarContacts returns up to k candidates most similar to contact, by the
+// embeddings of their company, tags, lists, and attributes (never their name
+// or email), for lookalike audiences and "people like you" recommendations.
+// contact itself is skipped if among the candidates.
+func (c *LLMClient) SimilarContacts(ctx context.Context, contact *Contact, candidates []Contact, k int) ([]ContactMatch, error) {
+	if contact == nil {
+		return nil, fmt.Errorf("contact is required")
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	texts := make([]string, 0, len(candidates)+1)
+	texts = append(texts, contactProfile(contact))
+	for i := range candidates {
+		texts = append(texts, contactProfile(&candidates[i]))
+	}
+	resp, err := c.Embed(ctx, texts, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []ContactMatch
+	for i, candidate := range candidates {
+		if (contact.ID != "" && candidate.ID == contact.ID) || (contact.Email != "" && strings.EqualFold(candidate.Email, contact.Email)) {
+			continue
+		}
+		matches = append(matches, ContactMatch{Contact: candidate, Score: CosineSimilarity(resp.Vectors[0], resp.Vectors[i+1])})
+	}
+	return topMatches(matches, k, func(m ContactMatch) float64 { return m.Score }), nil
+}
+
+// contactProfile describes a contact's interests for embedding, without
+// personal identifiers.
+func contactProfile(contact *Contact) string {
+	var b strings.Builder
+	if contact.Company != "" {
+		fmt.Fprintf(&b, "Company: %s\n", contact.Company)
+	}
+	if len(contact.Tags) > 0 {
+		fmt.Fprintf(&b, "Tags: %s\n", strings.Join(contact.Tags, ", "))
+	}
+	if len(contact.Lists) > 0 {
+		fmt.Fprintf(&b, "Lists: %s\n", strings.Join(contact.Lists, ", "))
+	}
+	for _, name := range slices.Sorted(maps.Keys(contact.Attributes)) {
+		fmt.Fprintf(&b, "%s: %v\n", name, contact.Attributes[name])
+	}
+	if b.Len() == 0 {
+		return "(no profile)"
+	}
+	return b.String()
+}
+
+// CosineSimilarity returns the cosine similarity of two embeddings, from -1
+// to 1, or 0 if their lengths differ or either is zero.
+func CosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(na*nb)
+}
+
+// centroid returns the mean direction of vectors, each normalized first so
+// long and short texts weigh the same.
+func centroid(vectors [][]float32) []float32 {
+	sum := make([]float32, len(vectors[0]))
+	for _, v := range vectors {
+		var norm float64
+		for _, x := range v {
+			norm += float64(x) * float64(x)
+		}
+		if norm == 0 || len(v) != len(sum) {
+			continue
+		}
+		scale := float32(1 / math.Sqrt(norm))
+		for i, x := range v {
+			sum[i] += x * scale
+		}
+	}
+	return sum
+}
+
+// topMatches returns the k highest-scoring matches, best first.
+func topMatches[M any](matches []M, k int, score func(M) float64) []M {
+	slices.SortStableFunc(matches, func(a, b M) int {
+		return cmp.Compare(score(b), score(a))
+	})
+	return matches[:min(max(k, 0), len(matches))]
+}
+
+// truncateRunes returns the first n runes of s.
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
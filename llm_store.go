@@ -0,0 +1,287 @@
+package levee
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ConversationStore persists chat history by session ID, so any app server
+// can resume a conversation. Implementations must be safe for concurrent use.
+type ConversationStore interface {
+	// Load returns the messages of a session in order, or none if it doesn't exist.
+	Load(ctx context.Context, sessionID string) ([]ChatMessage, error)
+	// Append adds messages to the end of a session's history.
+	Append(ctx context.Context, sessionID string, messages ...ChatMessage) error
+	// Delete removes a session's history.
+	Delete(ctx context.Context, sessionID string) error
+}
+
+// WithConversationStore persists the history of chat sessions and WebSocket
+// chats started with a session ID, and reloads it when a session resumes.
+func WithConversationStore(store ConversationStore) LLMOption {
+	return func(c *LLMClient) {
+		c.store = store
+	}
+}
+
+// loadHistory returns the stored history of a session, if a store is configured.
+func (c *LLMClient) loadHistory(ctx context.Context, sessionID string) ([]ChatMessage, error) {
+	if c.store == nil || sessionID == "" {
+		return nil, nil
+	}
+	messages, err := c.store.Load(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load conversation %s: %w", sessionID, err)
+	}
+	return messages, nil
+}
+
+// saveTurn appends a completed exchange to the store, if one is configured.
+func (c *LLMClient) saveTurn(ctx context.Context, sessionID string, user ChatMessage, assistant string) error {
+	if c.store == nil || sessionID == "" {
+		return nil
+	}
+	err := c.store.Append(ctx, sessionID,
+		user,
+		ChatMessage{Role: "assistant", Content: assistant},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save conversation %s: %w", sessionID, err)
+	}
+	return nil
+}
+
+// MemoryConversationStore keeps conversations in process memory, for tests and
+// single-instance deployments.
+type MemoryConversationStore struct {
+	mu       sync.RWMutex
+	sessions map[string][]ChatMessage
+}
+
+// NewMemoryConversationStore returns an empty in-memory store.
+func NewMemoryConversationStore() *MemoryConversationStore {
+	return &MemoryConversationStore{sessions: make(map[string][]ChatMessage)}
+}
+
+func (s *MemoryConversationStore) Load(ctx context.Context, sessionID string) ([]ChatMessage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]ChatMessage(nil), s.sessions[sessionID]...), nil
+}
+
+func (s *MemoryConversationStore) Append(ctx context.Context, sessionID string, messages ...ChatMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[sessionID] = append(s.sessions[sessionID], messages...)
+	return nil
+}
+
+func (s *MemoryConversationStore) Delete(ctx context.Context, sessionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, sessionID)
+	return nil
+}
+
+// RedisDo runs one Redis command and returns its reply, adapting any Redis
+// client without the SDK depending on one. With go-redis:
+//
+//	do := func(ctx context.Context, args ...any) (any, error) {
+//		return rdb.Do(ctx, args...).Result()
+//	}
+type RedisDo func(ctx context.Context, args ...any) (any, error)
+
+// RedisConversationStore keeps each conversation in a Redis list of JSON
+// messages under KeyPrefix+sessionID, expiring TTL after the last append.
+type RedisConversationStore struct {
+	do        RedisDo
+	KeyPrefix string
+	TTL       time.Duration // 0 keeps conversations forever
+}
+
+// NewRedisConversationStore returns a Redis store with the key prefix
+// "levee:conversation:" and a 30 day TTL.
+func NewRedisConversationStore(do RedisDo) *RedisConversationStore {
+	return &RedisConversationStore{
+		do:        do,
+		KeyPrefix: "levee:conversation:",
+		TTL:       30 * 24 * time.Hour,
+	}
+}
+
+func (s *RedisConversationStore) Load(ctx context.Context, sessionID string) ([]ChatMessage, error) {
+	reply, err := s.do(ctx, "LRANGE", s.KeyPrefix+sessionID, 0, -1)
+	if err != nil {
+		return nil, err
+	}
+	items, ok := reply.([]any)
+	if !ok && reply != nil {
+		return nil, fmt.Errorf("unexpected LRANGE reply %T", reply)
+	}
+
+	messages := make([]ChatMessage, 0, len(items))
+	for _, item := range items {
+		var raw []byte
+		switch v := item.(type) {
+		case string:
+			raw = []byte(v)
+		case []byte:
+			raw = v
+		default:
+			return nil, fmt.Errorf("unexpected LRANGE item %T", item)
+		}
+		var msg ChatMessage
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			return nil, fmt.Errorf("invalid stored message: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+	return messages, nil
+}
+
+func (s *RedisConversationStore) Append(ctx context.Context, sessionID string, messages ...ChatMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	key := s.KeyPrefix + sessionID
+	args := []any{"RPUSH", key}
+	for _, msg := range messages {
+		b, err := json.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		args = append(args, string(b))
+	}
+	if _, err := s.do(ctx, args...); err != nil {
+		return err
+	}
+	if s.TTL > 0 {
+		if _, err := s.do(ctx, "PEXPIRE", key, s.TTL.Milliseconds()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *RedisConversationStore) Delete(ctx context.Context, sessionID string) error {
+	_, err := s.do(ctx, "DEL", s.KeyPrefix+sessionID)
+	return err
+}
+
+// SQLDialect selects the placeholder and DDL syntax of SQLConversationStore.
+type SQLDialect string
+
+// Supported SQL dialects.
+const (
+	SQLDialectPostgres SQLDialect = "postgres"
+	SQLDialectMySQL    SQLDialect = "mysql"
+	SQLDialectSQLite   SQLDialect = "sqlite"
+)
+
+// SQLConversationStore keeps conversations in a SQL table with one row per
+// message. Create the table with CreateTable or an equivalent migration.
+type SQLConversationStore struct {
+	db      *sql.DB
+	dialect SQLDialect
+	table   string
+}
+
+// NewSQLConversationStore returns a store using table (e.g. "llm_messages").
+// table is inserted into queries as is and must be a trusted identifier.
+func NewSQLConversationStore(db *sql.DB, dialect SQLDialect, table string) *SQLConversationStore {
+	return &SQLConversationStore{db: db, dialect: dialect, table: table}
+}
+
+// CreateTable creates the message table and its index if they don't exist.
+func (s *SQLConversationStore) CreateTable(ctx context.Context) error {
+	id := "BIGSERIAL PRIMARY KEY"
+	switch s.dialect {
+	case SQLDialectMySQL:
+		id = "BIGINT AUTO_INCREMENT PRIMARY KEY"
+	case SQLDialectSQLite:
+		id = "INTEGER PRIMARY KEY AUTOINCREMENT"
+	}
+	content := "TEXT"
+	if s.dialect == SQLDialectMySQL {
+		content = "MEDIUMTEXT"
+	}
+
+	var index string
+	if s.dialect == SQLDialectMySQL {
+		// MySQL has no CREATE INDEX IF NOT EXISTS; define it inline
+		index = ",\n\tINDEX session_idx (session_id, id)"
+	}
+	stmts := []string{fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	id %s,
+	session_id VARCHAR(255) NOT NULL,
+	role VARCHAR(32) NOT NULL,
+	content %s NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP%s
+)`, s.table, id, content, index)}
+	if s.dialect != SQLDialectMySQL {
+		stmts = append(stmts, fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_session_idx ON %s (session_id, id)", s.table, s.table))
+	}
+	for _, stmt := range stmts {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to create %s: %w", s.table, err)
+		}
+	}
+	return nil
+}
+
+// placeholder returns the nth (1-based) bind parameter.
+func (s *SQLConversationStore) placeholder(n int) string {
+	if s.dialect == SQLDialectPostgres {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+func (s *SQLConversationStore) Load(ctx context.Context, sessionID string) ([]ChatMessage, error) {
+	rows, err := s.db.QueryContext(ctx,
+		fmt.Sprintf("SELECT role, content FROM %s WHERE session_id = %s ORDER BY id", s.table, s.placeholder(1)),
+		sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []ChatMessage
+	for rows.Next() {
+		var msg ChatMessage
+		if err := rows.Scan(&msg.Role, &msg.Content); err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+	return messages, rows.Err()
+}
+
+func (s *SQLConversationStore) Append(ctx context.Context, sessionID string, messages ...ChatMessage) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf("INSERT INTO %s (session_id, role, content) VALUES (%s, %s, %s)",
+		s.table, s.placeholder(1), s.placeholder(2), s.placeholder(3))
+	for _, msg := range messages {
+		if _, err := tx.ExecContext(ctx, query, sessionID, msg.Role, msg.Content); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (s *SQLConversationStore) Delete(ctx context.Context, sessionID string) error {
+	_, err := s.db.ExecContext(ctx,
+		fmt.Sprintf("DELETE FROM %s WHERE session_id = %s", s.table, s.placeholder(1)),
+		sessionID)
+	return err
+}
@@ -0,0 +1,66 @@
+package levee
+
+import (
+	"context"
+	"errors"
+	"iter"
+)
+
+// errStopStream ends a stream when a range loop over ChatStreamSeq breaks.
+var errStopStream = errors.New("stream stopped")
+
+// ChatStreamChan is ChatStream for select loops. Chunks arrive on the first
+// channel, which is closed when the response ends; the second channel then
+// receives the final error (nil on success) and is closed. Canceling ctx stops
+// the stream.
+//
+//	chunks, errc := llm.ChatStreamChan(ctx, req)
+//	for chunk := range chunks {
+//		fmt.Print(chunk.Content)
+//	}
+//	if err := <-errc; err != nil {
+//		log.Fatal(err)
+//	}
+func (c *LLMClient) ChatStreamChan(ctx context.Context, req ChatRequest) (<-chan StreamChunk, <-chan error) {
+	chunks := make(chan StreamChunk)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(errc)
+		_, err := c.ChatStream(ctx, req, func(chunk StreamChunk) error {
+			select {
+			case chunks <- chunk:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		close(chunks)
+		errc <- err
+	}()
+	return chunks, errc
+}
+
+// ChatStreamSeq is ChatStream for range-over-func loops. Each chunk is yielded
+// with a nil error; a failure is yielded once as a zero chunk and the error.
+// Breaking out of the loop ends the stream.
+//
+//	for chunk, err := range llm.ChatStreamSeq(ctx, req) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Print(chunk.Content)
+//	}
+func (c *LLMClient) ChatStreamSeq(ctx context.Context, req ChatRequest) iter.Seq2[StreamChunk, error] {
+	return func(yield func(StreamChunk, error) bool) {
+		_, err := c.ChatStream(ctx, req, func(chunk StreamChunk) error {
+			if !yield(chunk, nil) {
+				return errStopStream
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errStopStream) {
+			yield(StreamChunk{}, err)
+		}
+	}
+}
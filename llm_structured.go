@@ -0,0 +1,129 @@
+package levee
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// maxStructuredAttempts bounds the requests ChatStructured makes for one value.
+const maxStructuredAttempts = 3
+
+// ChatStructured sends req and decodes the answer into a T. The model is told
+// to answer with JSON conforming to the schema of T (see JSONSchemaFor); the
+// output is repaired where possible (Markdown fences, surrounding prose,
+// trailing commas), validated, and on failure the model is shown the error
+// and asked again. The returned response totals tokens and cost over attempts.
+//
+//	type Sentiment struct {
+//		Label      string  `json:"label" jsonschema:"enum=positive|neutral|negative"`
+//		Confidence float64 `json:"confidence" jsonschema:"minimum=0,maximum=1"`
+//	}
+//
+//	s, _, err := levee.ChatStructured[Sentiment](ctx, llm, levee.ChatRequest{
+//		Model:    "haiku",
+//		Messages: []levee.ChatMessage{{Role: "user", Content: review}},
+//	})
+func ChatStructured[T any](ctx context.Context, llm *LLMClient, req ChatRequest) (T, *ChatResponse, error) {
+	var zero T
+	schema, err := schemaForType(reflect.TypeFor[T]())
+	if err != nil {
+		return zero, nil, err
+	}
+	schemaJSON, err := json.Marshal(schema)
+	if err != nil {
+		return zero, nil, err
+	}
+
+	instructions := "Respond only with a JSON object that conforms to this JSON Schema:\n" +
+		string(schemaJSON) + "\nDo not wrap it in Markdown or add any other text."
+	if req.SystemPrompt != "" {
+		req.SystemPrompt += "\n\n" + instructions
+	} else {
+		req.SystemPrompt = instructions
+	}
+	req.Messages = append([]ChatMessage(nil), req.Messages...)
+
+	total := &ChatResponse{}
+	for attempt := 1; ; attempt++ {
+		resp, err := llm.Chat(ctx, req)
+		if err != nil {
+			return zero, nil, err
+		}
+		total.Content = resp.Content
+		total.Model = resp.Model
+		total.StopReason = resp.StopReason
+		total.InputTokens += resp.InputTokens
+		total.OutputTokens += resp.OutputTokens
+		total.CostUSD += resp.CostUSD
+		total.LatencyMs += resp.LatencyMs
+
+		value, err := decodeValidated[T](schema, []byte(repairJSON(resp.Content)))
+		if err == nil {
+			return value, total, nil
+		}
+		if attempt == maxStructuredAttempts {
+			return zero, total, fmt.Errorf("structured output invalid after %d attempts: %w", attempt, err)
+		}
+
+		req.Messages = append(req.Messages,
+			ChatMessage{Role: "assistant", Content: resp.Content},
+			ChatMessage{Role: "user", Content: "That response was invalid: " + err.Error() +
+				". Reply with only the corrected JSON object."},
+		)
+	}
+}
+
+// repairJSON extracts the JSON object from model output and fixes common
+// mistakes: Markdown code fences, text around the object, and trailing commas.
+func repairJSON(s string) string {
+	s = strings.TrimSpace(s)
+	if start := strings.Index(s, "```"); start >= 0 {
+		body := s[start+3:]
+		if nl := strings.IndexByte(body, '\n'); nl >= 0 {
+			body = body[nl+1:] // skip the language tag
+		}
+		if end := strings.Index(body, "```"); end >= 0 {
+			s = strings.TrimSpace(body[:end])
+		}
+	}
+	if start, end := strings.IndexByte(s, '{'), strings.LastIndexByte(s, '}'); start >= 0 && end > start {
+		s = s[start : end+1]
+	}
+	return stripTrailingCommas(s)
+}
+
+// stripTrailingCommas removes commas directly before a closing } or ],
+// ignoring string contents.
+func stripTrailingCommas(s string) string {
+	var b strings.Builder
+	inString, escaped := false, false
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case ch == '\\':
+				escaped = true
+			case ch == '"':
+				inString = false
+			}
+			b.WriteByte(ch)
+			continue
+		}
+		if ch == '"' {
+			inString = true
+		}
+		if ch == ',' {
+			rest := strings.TrimLeft(s[i+1:], " \t\r\n")
+			if rest != "" && (rest[0] == '}' || rest[0] == ']') {
+				continue
+			}
+		}
+		b.WriteByte(ch)
+	}
+	return b.String()
+}
@@ -0,0 +1,144 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/almatuck/levee-go/llmpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Per-message token overhead of chat formatting (role markers, separators).
+const (
+	messageTokenOverhead      = 4
+	conversationTokenOverhead = 3
+)
+
+// contextWindows maps model IDs (by prefix) and tiers to their context window
+// in tokens. Longer prefixes win.
+var contextWindows = map[string]int{
+	"haiku":          200_000,
+	"sonnet":         200_000,
+	"opus":           200_000,
+	"claude-":        200_000,
+	"gpt-4.1":        1_047_576,
+	"gpt-4o":         128_000,
+	"gpt-4-turbo":    128_000,
+	"gpt-4":          8_192,
+	"gpt-3.5-turbo":  16_385,
+	"o1":             200_000,
+	"o3":             200_000,
+	"o4-mini":        200_000,
+	"gemini-1.5-pro": 2_097_152,
+	"gemini-":        1_048_576,
+}
+
+// MaxContextFor returns the context window in tokens of a model tier ("haiku",
+// "sonnet", "opus") or model ID, or 0 if the model is unknown.
+func MaxContextFor(model string) int {
+	model = strings.ToLower(model)
+	best, window := 0, 0
+	for prefix, n := range contextWindows {
+		if strings.HasPrefix(model, prefix) && len(prefix) > best {
+			best, window = len(prefix), n
+		}
+	}
+	return window
+}
+
+// CountTokens returns the input tokens of messages for model. Known models
+// (those MaxContextFor knows) are counted locally with EstimateTokens, without
+// a network call; other models are counted by the gateway with the provider's
+// tokenizer, falling back to the local estimate if the gateway can't count them.
+//
+//	n, err := llm.CountTokens(ctx, "sonnet", history)
+//	if n > levee.MaxContextFor("sonnet")-int(maxTokens) {
+//		history = history[2:] // drop the oldest exchange
+//	}
+func (c *LLMClient) CountTokens(ctx context.Context, model string, messages []ChatMessage) (int, error) {
+	if MaxContextFor(model) > 0 {
+		return estimateMessageTokens(messages), nil
+	}
+
+	if err := c.connect(); err != nil {
+		return 0, err
+	}
+	resp, err := c.client.CountTokens(ctx, &llmpb.CountTokensRequest{
+		ApiKey:   c.apiKey,
+		Model:    model,
+		Messages: messagesToPB(messages),
+	})
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.Unimplemented, codes.NotFound, codes.InvalidArgument:
+		// Gateway or provider has no tokenizer for this model
+		return estimateMessageTokens(messages), nil
+	default:
+		return 0, fmt.Errorf("count tokens request failed: %w", err)
+	}
+	return int(resp.InputTokens), nil
+}
+
+// estimateMessageTokens estimates the tokens of a conversation, including
+// per-message formatting overhead.
+func estimateMessageTokens(messages []ChatMessage) int {
+	total := conversationTokenOverhead
+	for _, msg := range messages {
+		total += messageTokenOverhead + EstimateTokens(msg.Content) + len(msg.Images)*imageTokenEstimate
+	}
+	return total
+}
+
+// EstimateTokens approximates the BPE token count of text without a model
+// tokenizer: words of up to six letters are one token, longer words about
+// one per five letters, digits about one per three, punctuation one each, and CJK and
+// other non-Latin scripts about one per character. Estimates are typically
+// within 10-15% for English prose.
+func EstimateTokens(text string) int {
+	tokens := 0
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r < utf8.RuneSelf && (unicode.IsLetter(r) || r == '\''):
+			n := 0
+			for i < len(text) && text[i] < utf8.RuneSelf && (unicode.IsLetter(rune(text[i])) || text[i] == '\'') {
+				i++
+				n++
+			}
+			if n <= 6 {
+				tokens++
+			} else {
+				tokens += (n + 4) / 5
+			}
+		case unicode.IsDigit(r) && r < utf8.RuneSelf:
+			n := 0
+			for i < len(text) && text[i] >= '0' && text[i] <= '9' {
+				i++
+				n++
+			}
+			tokens += (n + 2) / 3
+		case r >= utf8.RuneSelf && unicode.IsLetter(r) && !unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			// Accented Latin, Cyrillic, etc.: about two characters per token
+			n := 0
+			for i < len(text) {
+				r, size := utf8.DecodeRuneInString(text[i:])
+				if r < utf8.RuneSelf || !unicode.IsLetter(r) || unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+					break
+				}
+				i += size
+				n++
+			}
+			tokens += (n + 1) / 2
+		default:
+			tokens++
+			i += size
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,300 @@
+package levee
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/almatuck/levee-go/llmpb"
+)
+
+// maxToolCalls bounds the tool calls RunWithTools executes in one turn, so a
+// model stuck calling tools can't loop forever.
+const maxToolCalls = 25
+
+// ToolFunc executes a tool call. args is the JSON arguments object generated
+// by the model; the returned string is sent back as the tool result. Errors are
+// reported to the model as failed tool results rather than ending the turn.
+type ToolFunc func(ctx context.Context, args json.RawMessage) (string, error)
+
+// registeredTool is a tool offered to chat sessions.
+type registeredTool struct {
+	def     *llmpb.ToolDefinition
+	fn      ToolFunc
+	timeout time.Duration
+}
+
+// WithToolTimeout bounds the execution of every tool without its own
+// timeout (see Tool.WithTimeout). A tool that runs longer is reported to the
+// model as a failed tool result; its context is cancelled.
+func WithToolTimeout(d time.Duration) LLMOption {
+	return func(c *LLMClient) {
+		c.toolTimeout = d
+	}
+}
+
+// RegisterTool makes a tool available to chat sessions started afterwards.
+// jsonSchema is the JSON Schema of the arguments object; its top-level
+// "description", if any, is sent as the tool description. Registering a name
+// again replaces the tool. ToolFor derives the schema from a struct instead.
+//
+//	err := llm.RegisterTool("get_weather", `{
+//		"type": "object",
+//		"description": "Current weather for a city",
+//		"properties": {"city": {"type": "string"}},
+//		"required": ["city"]
+//	}`, func(ctx context.Context, args json.RawMessage) (string, error) {
+//		var in struct{ City string }
+//		if err := json.Unmarshal(args, &in); err != nil {
+//			return "", err
+//		}
+//		return weather.Lookup(ctx, in.City)
+//	})
+func (c *LLMClient) RegisterTool(name string, jsonSchema string, fn ToolFunc) error {
+	var schema struct {
+		Description string `json:"description"`
+	}
+	if err := json.Unmarshal([]byte(jsonSchema), &schema); err != nil {
+		return fmt.Errorf("tool %s: invalid JSON schema: %w", name, err)
+	}
+	return c.RegisterTools(Tool{Name: name, Description: schema.Description, Schema: jsonSchema, Func: fn})
+}
+
+// Tool is a function the model can call, for RegisterTools.
+type Tool struct {
+	Name        string
+	Description string
+	Schema      string // JSON Schema of the arguments object
+	Func        ToolFunc
+	Timeout     time.Duration // zero uses WithToolTimeout
+
+	err error // schema generation error from ToolFor
+}
+
+// ToolFor builds a tool whose parameter schema is derived from the struct T
+// (see JSONSchemaFor). Arguments are validated against the schema and decoded
+// into T before fn runs; invalid arguments are returned to the model as a
+// failed tool result so it can retry.
+//
+//	type WeatherArgs struct {
+//		City  string `json:"city" description:"City name"`
+//		Units string `json:"units,omitempty" jsonschema:"enum=celsius|fahrenheit"`
+//	}
+//
+//	err := llm.RegisterTools(
+//		levee.ToolFor("get_weather", func(ctx context.Context, args WeatherArgs) (string, error) {
+//			return weather.Lookup(ctx, args.City, args.Units)
+//		}).WithDescription("Current weather for a city"),
+//	)
+func ToolFor[T any](name string, fn func(ctx context.Context, args T) (string, error)) Tool {
+	schema, err := schemaForType(reflect.TypeFor[T]())
+	if err != nil {
+		return Tool{Name: name, err: err}
+	}
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return Tool{Name: name, err: err}
+	}
+
+	return Tool{
+		Name:   name,
+		Schema: string(b),
+		Func: func(ctx context.Context, raw json.RawMessage) (string, error) {
+			args, err := decodeValidated[T](schema, raw)
+			if err != nil {
+				return "", fmt.Errorf("invalid arguments: %w", err)
+			}
+			return fn(ctx, args)
+		},
+	}
+}
+
+// WithDescription returns a copy of the tool with the description set.
+func (t Tool) WithDescription(description string) Tool {
+	t.Description = description
+	return t
+}
+
+// WithTimeout returns a copy of the tool with its execution timeout set.
+func (t Tool) WithTimeout(d time.Duration) Tool {
+	t.Timeout = d
+	return t
+}
+
+// RegisterTools makes tools available to chat sessions started afterwards,
+// replacing tools with the same name.
+func (c *LLMClient) RegisterTools(tools ...Tool) error {
+	for _, t := range tools {
+		if t.err != nil {
+			return fmt.Errorf("tool %s: %w", t.Name, t.err)
+		}
+		if t.Name == "" {
+			return fmt.Errorf("tool name is required")
+		}
+		if t.Func == nil {
+			return fmt.Errorf("tool %s: function is required", t.Name)
+		}
+		if !json.Valid([]byte(t.Schema)) {
+			return fmt.Errorf("tool %s: invalid JSON schema", t.Name)
+		}
+	}
+
+	c.toolsMu.Lock()
+	defer c.toolsMu.Unlock()
+	if c.tools == nil {
+		c.tools = make(map[string]*registeredTool)
+	}
+	for _, t := range tools {
+		c.tools[t.Name] = &registeredTool{
+			def: &llmpb.ToolDefinition{
+				Name:           t.Name,
+				Description:    t.Description,
+				ParametersJson: t.Schema,
+			},
+			fn:      t.Func,
+			timeout: t.Timeout,
+		}
+	}
+	return nil
+}
+
+// UnregisterTool removes a tool from sessions started afterwards.
+func (c *LLMClient) UnregisterTool(name string) {
+	c.toolsMu.Lock()
+	defer c.toolsMu.Unlock()
+	delete(c.tools, name)
+}
+
+// RunWithTools sends a user message like Send, but executes the model's tool
+// calls with the registered tools and sends their results back, looping until
+// the model completes its answer. Tool calls the model makes together run
+// concurrently. Content streamed between tool calls reaches callback; the
+// response holds the final answer.
+func (s *ChatSession) RunWithTools(ctx context.Context, content string, callback StreamCallback) (*ChatResponse, error) {
+	return s.send(ctx, ChatMessage{Role: "user", Content: content}, callback, true)
+}
+
+// hasTool reports whether a tool is registered.
+func (c *LLMClient) hasTool(name string) bool {
+	c.toolsMu.RLock()
+	defer c.toolsMu.RUnlock()
+	return c.tools[name] != nil
+}
+
+// toolDefinitions returns the registered tools sorted by name.
+func (c *LLMClient) toolDefinitions() []*llmpb.ToolDefinition {
+	c.toolsMu.RLock()
+	defer c.toolsMu.RUnlock()
+
+	defs := make([]*llmpb.ToolDefinition, 0, len(c.tools))
+	for _, t := range c.tools {
+		defs = append(defs, t.def)
+	}
+	slices.SortFunc(defs, func(a, b *llmpb.ToolDefinition) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return defs
+}
+
+// executeTool runs a tool call and returns the tool result message.
+func (c *LLMClient) executeTool(ctx context.Context, call *llmpb.ToolCallRequest) *llmpb.ChatRequest {
+	return &llmpb.ChatRequest{
+		Request: &llmpb.ChatRequest_ToolResult{ToolResult: c.runTool(ctx, call)},
+	}
+}
+
+// executeTools runs a batch of tool calls concurrently and returns their
+// results in one message, in call order.
+func (c *LLMClient) executeTools(ctx context.Context, calls []*llmpb.ToolCallRequest) *llmpb.ChatRequest {
+	results := make([]*llmpb.ToolResult, len(calls))
+	var wg sync.WaitGroup
+	for i, call := range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.runTool(ctx, call)
+		}()
+	}
+	wg.Wait()
+
+	return &llmpb.ChatRequest{
+		Request: &llmpb.ChatRequest_ToolResults{ToolResults: &llmpb.ToolResultBatch{Results: results}},
+	}
+}
+
+// runTool runs a tool call within its timeout. Unknown tools, errors,
+// timeouts, and panics become error results so the model can recover.
+func (c *LLMClient) runTool(ctx context.Context, call *llmpb.ToolCallRequest) *llmpb.ToolResult {
+	result := &llmpb.ToolResult{ToolCallId: call.ToolCallId}
+
+	c.toolsMu.RLock()
+	tool := c.tools[call.Name]
+	c.toolsMu.RUnlock()
+
+	if tool == nil {
+		result.Result = fmt.Sprintf("unknown tool %q", call.Name)
+		result.IsError = true
+		return result
+	}
+
+	out, err := callToolTimeout(ctx, tool.fn, call.ArgumentsJson, cmp.Or(tool.timeout, c.toolTimeout))
+	if err != nil {
+		result.Result = err.Error()
+		result.IsError = true
+	} else {
+		result.Result = out
+	}
+	return result
+}
+
+// callToolTimeout calls fn, giving up after timeout (if set) even if fn
+// ignores its cancelled context.
+func callToolTimeout(ctx context.Context, fn ToolFunc, args string, timeout time.Duration) (string, error) {
+	if timeout <= 0 {
+		return callTool(ctx, fn, args)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type outcome struct {
+		out string
+		err error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		out, err := callTool(ctx, fn, args)
+		done <- outcome{out, err}
+	}()
+	select {
+	case o := <-done:
+		if errors.Is(o.err, context.DeadlineExceeded) && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("tool timed out after %s", timeout)
+		}
+		return o.out, o.err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("tool timed out after %s", timeout)
+		}
+		return "", ctx.Err()
+	}
+}
+
+// callTool calls fn, converting a panic into an error.
+func callTool(ctx context.Context, fn ToolFunc, args string) (out string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("tool panicked: %v", r)
+		}
+	}()
+	if args == "" {
+		args = "{}"
+	}
+	return fn(ctx, json.RawMessage(args))
+}
@@ -0,0 +1,173 @@
+package levee
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/almatuck/levee-go/llmpb"
+)
+
+// TranscriptVersion is the version of the transcript format written by
+// Export. ImportConversation reads this and earlier versions.
+const TranscriptVersion = 1
+
+// Transcript is an exported chat session: every message, the tools called
+// for each reply, and token usage. Messages trimmed from the model's context
+// by a HistoryStrategy are kept.
+type Transcript struct {
+	Version      int                 `json:"version"`
+	ExportedAt   time.Time           `json:"exported_at"`
+	SessionID    string              `json:"session_id,omitempty"` // ConversationStore key
+	Model        string              `json:"model,omitempty"`
+	Provider     string              `json:"provider,omitempty"`
+	SystemPrompt string              `json:"system_prompt,omitempty"`
+	Messages     []TranscriptMessage `json:"messages"`
+	Usage        TranscriptUsage     `json:"usage"` // totals of the replies
+}
+
+// TranscriptMessage is a message of a transcript. Replies carry the tools the
+// model called while writing them and their usage.
+type TranscriptMessage struct {
+	ChatMessage
+	ToolCalls []TranscriptToolCall `json:"tool_calls,omitempty"`
+	Usage     *TranscriptUsage     `json:"usage,omitempty"`
+}
+
+// TranscriptToolCall is a registered tool the model called, and its result.
+type TranscriptToolCall struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+	Result    string          `json:"result"`
+	IsError   bool            `json:"is_error,omitempty"`
+}
+
+// TranscriptUsage is the token usage and cost of one reply or a transcript.
+type TranscriptUsage struct {
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+}
+
+// Export returns the session as a versioned JSON transcript, to store, audit,
+// or replay into a new session with ImportConversation.
+func (s *ChatSession) Export() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := Transcript{
+		Version:      TranscriptVersion,
+		ExportedAt:   time.Now().UTC(),
+		SessionID:    s.sessionID,
+		Model:        s.model,
+		Provider:     s.provider,
+		SystemPrompt: s.start.SystemPrompt,
+		Messages:     s.transcript,
+	}
+	if t.Model == "" {
+		t.Model = s.start.Model
+	}
+	if t.Messages == nil {
+		t.Messages = []TranscriptMessage{}
+	}
+	for _, m := range s.transcript {
+		if m.Usage != nil {
+			t.Usage.InputTokens += m.Usage.InputTokens
+			t.Usage.OutputTokens += m.Usage.OutputTokens
+			t.Usage.CostUSD += m.Usage.CostUSD
+		}
+	}
+	return json.MarshalIndent(t, "", "  ")
+}
+
+// ParseTranscript decodes a transcript written by Export.
+func ParseTranscript(data []byte) (*Transcript, error) {
+	var t Transcript
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("invalid transcript: %w", err)
+	}
+	if t.Version < 1 || t.Version > TranscriptVersion {
+		return nil, fmt.Errorf("unsupported transcript version %d", t.Version)
+	}
+	return &t, nil
+}
+
+// ImportConversation starts a new chat session replaying a transcript written
+// by Export. The transcript's system prompt and model are used unless set in
+// req; req.Messages follow the transcript's messages.
+//
+//	data, _ := session.Export()
+//	// ...later, or on another server
+//	session, err := llm.ImportConversation(ctx, data, levee.ChatRequest{})
+func (c *LLMClient) ImportConversation(ctx context.Context, data []byte, req ChatRequest) (*ChatSession, error) {
+	t, err := ParseTranscript(data)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]ChatMessage, 0, len(t.Messages)+len(req.Messages))
+	for _, m := range t.Messages {
+		messages = append(messages, m.ChatMessage)
+	}
+	req.Messages = append(messages, req.Messages...)
+	if req.SystemPrompt == "" {
+		req.SystemPrompt = t.SystemPrompt
+	}
+	if req.Model == "" {
+		req.Model = t.Model
+	}
+
+	s, err := c.NewChatSession(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	// Keep the tool calls and usage of the imported replies for re-export.
+	offset := len(s.transcript) - len(req.Messages)
+	for i, m := range t.Messages {
+		s.transcript[offset+i] = m
+	}
+	return s, nil
+}
+
+// transcriptFromPB starts a transcript from the messages a session opened
+// with.
+func transcriptFromPB(messages []*llmpb.Message) []TranscriptMessage {
+	transcript := make([]TranscriptMessage, 0, len(messages))
+	for _, m := range messagesFromPB(messages) {
+		transcript = append(transcript, TranscriptMessage{ChatMessage: m})
+	}
+	return transcript
+}
+
+// recordTurn adds a completed turn to the session transcript. user is nil for
+// a resumed turn, whose message is already in it.
+func (s *ChatSession) recordTurn(user *ChatMessage, resp *ChatResponse) {
+	if user != nil {
+		s.transcript = append(s.transcript, TranscriptMessage{ChatMessage: *user})
+	}
+	s.transcript = append(s.transcript, TranscriptMessage{
+		ChatMessage: ChatMessage{Role: "assistant", Content: resp.Content},
+		ToolCalls:   s.toolCalls,
+		Usage: &TranscriptUsage{
+			InputTokens:  resp.InputTokens,
+			OutputTokens: resp.OutputTokens,
+			CostUSD:      resp.CostUSD,
+		},
+	})
+	s.toolCalls = nil
+}
+
+// recordToolCall notes a tool run for the reply in progress.
+func (s *ChatSession) recordToolCall(call *llmpb.ToolCallRequest, result *llmpb.ToolResult) {
+	tc := TranscriptToolCall{
+		ID:   call.ToolCallId,
+		Name: call.Name,
+	}
+	if json.Valid([]byte(call.ArgumentsJson)) {
+		tc.Arguments = json.RawMessage(call.ArgumentsJson)
+	}
+	tc.Result, tc.IsError = result.GetResult(), result.GetIsError()
+	s.toolCalls = append(s.toolCalls, tc)
+}
@@ -0,0 +1,180 @@
+package levee
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/almatuck/levee-go/llmpb"
+)
+
+// Metric names emitted per completed LLM call by an LLMClient with
+// WithLLMMetrics. All are labelled with model, provider, and stop_reason.
+const (
+	// MetricLLMCompletions counts completed chat calls.
+	MetricLLMCompletions = "levee_llm_completions_total"
+	// MetricLLMLatency times completed chat calls as reported by the gateway.
+	MetricLLMLatency = "levee_llm_latency_seconds"
+	// MetricLLMInputTokens, MetricLLMOutputTokens, and MetricLLMCost sum token
+	// counts and spend. They are only emitted to sinks implementing ValueMetrics.
+	MetricLLMInputTokens  = "levee_llm_input_tokens_total"
+	MetricLLMOutputTokens = "levee_llm_output_tokens_total"
+	MetricLLMCost         = "levee_llm_cost_usd_total"
+	// MetricLLMTTFT times streamed turns to their first chunk, and
+	// MetricLLMChunkGap the time between chunks. Labels: model, provider.
+	MetricLLMTTFT     = "levee_llm_ttft_seconds"
+	MetricLLMChunkGap = "levee_llm_chunk_gap_seconds"
+)
+
+// WithLLMMetrics emits per-call usage metrics (tokens, cost, latency, model,
+// stop reason) for every completed Chat, session turn, and WebSocket reply.
+// Cached responses are not counted. Pair it with MetricsUnaryInterceptor and
+// MetricsStreamInterceptor to also count failed calls.
+func WithLLMMetrics(metrics Metrics) LLMOption {
+	return func(c *LLMClient) {
+		c.metrics = metrics
+	}
+}
+
+// recordUsage emits the usage metrics of a completed call. elapsed is used
+// when the gateway didn't report a latency.
+func (c *LLMClient) recordUsage(resp *ChatResponse, elapsed time.Duration) {
+	if c.metrics == nil {
+		return
+	}
+	labels := map[string]string{
+		"model":       resp.Model,
+		"provider":    resp.Provider,
+		"stop_reason": resp.StopReason,
+	}
+	latency := elapsed
+	if resp.LatencyMs > 0 {
+		latency = time.Duration(resp.LatencyMs) * time.Millisecond
+	}
+
+	c.metrics.IncCounter(MetricLLMCompletions, labels)
+	c.metrics.ObserveDuration(MetricLLMLatency, labels, latency)
+	if vm, ok := c.metrics.(ValueMetrics); ok {
+		vm.AddCounter(MetricLLMInputTokens, labels, float64(resp.InputTokens))
+		vm.AddCounter(MetricLLMOutputTokens, labels, float64(resp.OutputTokens))
+		vm.AddCounter(MetricLLMCost, labels, resp.CostUSD)
+	}
+}
+
+// recordCompletion emits the usage metrics of a streamed completion.
+func (c *LLMClient) recordCompletion(completion *llmpb.CompletionResponse, elapsed time.Duration) {
+	c.recordUsage(&ChatResponse{
+		Model:        completion.Model,
+		Provider:     completion.Provider,
+		StopReason:   completion.StopReason,
+		InputTokens:  completion.InputTokens,
+		OutputTokens: completion.OutputTokens,
+		CostUSD:      completion.CostUsd,
+		LatencyMs:    completion.LatencyMs,
+	}, elapsed)
+}
+
+// streamTimer tracks the time to first chunk and between chunks of a turn.
+type streamTimer struct {
+	start    time.Time
+	last     time.Time
+	ttft     time.Duration
+	maxGap   time.Duration
+	totalGap time.Duration
+	chunks   int
+}
+
+// chunk records a chunk arriving now. It returns the time since the previous
+// chunk, or the time to first chunk if first.
+func (t *streamTimer) chunk() (d time.Duration, first bool) {
+	now := time.Now()
+	first = t.chunks == 0
+	if first {
+		d = now.Sub(t.start)
+		t.ttft = d
+	} else {
+		d = now.Sub(t.last)
+		t.totalGap += d
+		t.maxGap = max(t.maxGap, d)
+	}
+	t.last = now
+	t.chunks++
+	return d, first
+}
+
+// apply sets the streaming latency fields of resp.
+func (t *streamTimer) apply(resp *ChatResponse) {
+	if t.chunks == 0 {
+		return
+	}
+	resp.TTFTMs = t.ttft.Milliseconds()
+	resp.MaxChunkGapMs = t.maxGap.Milliseconds()
+	if t.chunks > 1 {
+		resp.ChunkGapMs = (t.totalGap / time.Duration(t.chunks-1)).Milliseconds()
+	}
+}
+
+// recordChunk times a chunk with t and emits MetricLLMTTFT or
+// MetricLLMChunkGap.
+func (c *LLMClient) recordChunk(t *streamTimer, model, provider string) {
+	d, first := t.chunk()
+	if c.metrics == nil {
+		return
+	}
+	name := MetricLLMChunkGap
+	if first {
+		name = MetricLLMTTFT
+	}
+	c.metrics.ObserveDuration(name, map[string]string{"model": model, "provider": provider}, d)
+}
+
+// LLMUsageTotals are the aggregated LLM calls, tokens, and spend of a period.
+type LLMUsageTotals struct {
+	Requests     int64   `json:"requests"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+}
+
+// LLMModelUsage is the usage of one model.
+type LLMModelUsage struct {
+	Model    string `json:"model"`
+	Provider string `json:"provider"`
+	LLMUsageTotals
+}
+
+// LLMUsagePoint is one day of an LLM usage trend.
+type LLMUsagePoint struct {
+	Date time.Time `json:"date"`
+	LLMUsageTotals
+}
+
+// LLMUsageReport summarizes LLM spend over a time range, as metered by the
+// gateway.
+type LLMUsageReport struct {
+	LLMUsageTotals
+	Models []LLMModelUsage `json:"models"`
+	Trend  []LLMUsagePoint `json:"trend"`
+}
+
+// GetLLMUsage returns aggregated LLM calls, tokens, and spend for rng, with a
+// per-model breakdown and a daily trend.
+//
+//	usage, err := client.GetLLMUsage(ctx, levee.LastDays(30))
+//	fmt.Printf("$%.2f over %d calls\n", usage.CostUSD, usage.Requests)
+func (c *Client) GetLLMUsage(ctx context.Context, rng TimeRange) (*LLMUsageReport, error) {
+	query := url.Values{}
+	if !rng.Start.IsZero() {
+		query.Set("start_date", rng.Start.UTC().Format(time.RFC3339))
+	}
+	if !rng.End.IsZero() {
+		query.Set("end_date", rng.End.UTC().Format(time.RFC3339))
+	}
+
+	var result LLMUsageReport
+	if err := c.do(ctx, http.MethodGet, "/sdk/v1/stats/llm-usage", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -30,6 +30,8 @@ type ChatRequest struct {
 	//	*ChatRequest_Message
 	//	*ChatRequest_Abort
 	//	*ChatRequest_ToolResult
+	//	*ChatRequest_Resume
+	//	*ChatRequest_ToolResults
 	Request       isChatRequest_Request `protobuf_oneof:"request"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -108,6 +110,24 @@ func (x *ChatRequest) GetToolResult() *ToolResult {
 	return nil
 }
 
+func (x *ChatRequest) GetResume() *ResumeRequest {
+	if x != nil {
+		if x, ok := x.Request.(*ChatRequest_Resume); ok {
+			return x.Resume
+		}
+	}
+	return nil
+}
+
+func (x *ChatRequest) GetToolResults() *ToolResultBatch {
+	if x != nil {
+		if x, ok := x.Request.(*ChatRequest_ToolResults); ok {
+			return x.ToolResults
+		}
+	}
+	return nil
+}
+
 type isChatRequest_Request interface {
 	isChatRequest_Request()
 }
@@ -132,6 +152,16 @@ type ChatRequest_ToolResult struct {
 	ToolResult *ToolResult `protobuf:"bytes,4,opt,name=tool_result,json=toolResult,proto3,oneof"`
 }
 
+type ChatRequest_Resume struct {
+	// Reattach to an existing session instead of starting one
+	Resume *ResumeRequest `protobuf:"bytes,5,opt,name=resume,proto3,oneof"`
+}
+
+type ChatRequest_ToolResults struct {
+	// Provide results of several tool calls at once
+	ToolResults *ToolResultBatch `protobuf:"bytes,6,opt,name=tool_results,json=toolResults,proto3,oneof"`
+}
+
 func (*ChatRequest_Start) isChatRequest_Request() {}
 
 func (*ChatRequest_Message) isChatRequest_Request() {}
@@ -140,6 +170,10 @@ func (*ChatRequest_Abort) isChatRequest_Request() {}
 
 func (*ChatRequest_ToolResult) isChatRequest_Request() {}
 
+func (*ChatRequest_Resume) isChatRequest_Request() {}
+
+func (*ChatRequest_ToolResults) isChatRequest_Request() {}
+
 // StartChatRequest initializes a chat session.
 type StartChatRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -158,9 +192,17 @@ type StartChatRequest struct {
 	// Tools available for function calling (optional)
 	Tools []*ToolDefinition `protobuf:"bytes,7,rep,name=tools,proto3" json:"tools,omitempty"`
 	// Unique request ID for tracking
-	RequestId     string `protobuf:"bytes,8,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	RequestId string `protobuf:"bytes,8,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// Provider to use: "anthropic", "openai", "local" (optional, routed if empty)
+	Provider string `protobuf:"bytes,9,opt,name=provider,proto3" json:"provider,omitempty"`
+	// Routing policy when provider or model is open: "cheapest", "fastest", "sticky"
+	Routing string `protobuf:"bytes,10,opt,name=routing,proto3" json:"routing,omitempty"`
+	// Sampling parameters beyond max_tokens and temperature (optional)
+	Params *GenerationParams `protobuf:"bytes,11,opt,name=params,proto3" json:"params,omitempty"`
+	// Deliver tool calls the model makes together as one ToolCallBatch
+	ParallelToolCalls bool `protobuf:"varint,12,opt,name=parallel_tool_calls,json=parallelToolCalls,proto3" json:"parallel_tool_calls,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *StartChatRequest) Reset() {
@@ -249,10 +291,40 @@ func (x *StartChatRequest) GetRequestId() string {
 	return ""
 }
 
+func (x *StartChatRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *StartChatRequest) GetRouting() string {
+	if x != nil {
+		return x.Routing
+	}
+	return ""
+}
+
+func (x *StartChatRequest) GetParams() *GenerationParams {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *StartChatRequest) GetParallelToolCalls() bool {
+	if x != nil {
+		return x.ParallelToolCalls
+	}
+	return false
+}
+
 // UserMessage sends a message from the user.
 type UserMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	Images        []*Image               `protobuf:"bytes,2,rep,name=images,proto3" json:"images,omitempty"` // Image inputs for vision-capable models
+	Audio         []*Audio               `protobuf:"bytes,3,rep,name=audio,proto3" json:"audio,omitempty"`   // Speech inputs, transcribed by the gateway
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -294,6 +366,20 @@ func (x *UserMessage) GetContent() string {
 	return ""
 }
 
+func (x *UserMessage) GetImages() []*Image {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *UserMessage) GetAudio() []*Audio {
+	if x != nil {
+		return x.Audio
+	}
+	return nil
+}
+
 // AbortRequest aborts the current generation.
 type AbortRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -339,30 +425,33 @@ func (x *AbortRequest) GetReason() string {
 	return ""
 }
 
-// ToolResult provides the result of a tool call.
-type ToolResult struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ToolCallId    string                 `protobuf:"bytes,1,opt,name=tool_call_id,json=toolCallId,proto3" json:"tool_call_id,omitempty"`
-	Result        string                 `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
-	IsError       bool                   `protobuf:"varint,3,opt,name=is_error,json=isError,proto3" json:"is_error,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// ResumeRequest reattaches to a session after a dropped connection. The
+// server replies with SessionStarted, then replays the missed chunks of a
+// turn still in flight (or just finished) and continues streaming it.
+type ResumeRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey    string                 `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	SessionId string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Replay chunks after this index; -1 replays the whole turn
+	LastChunkIndex int32 `protobuf:"varint,3,opt,name=last_chunk_index,json=lastChunkIndex,proto3" json:"last_chunk_index,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *ToolResult) Reset() {
-	*x = ToolResult{}
+func (x *ResumeRequest) Reset() {
+	*x = ResumeRequest{}
 	mi := &file_llm_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ToolResult) String() string {
+func (x *ResumeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ToolResult) ProtoMessage() {}
+func (*ResumeRequest) ProtoMessage() {}
 
-func (x *ToolResult) ProtoReflect() protoreflect.Message {
+func (x *ResumeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_llm_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -374,56 +463,56 @@ func (x *ToolResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ToolResult.ProtoReflect.Descriptor instead.
-func (*ToolResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use ResumeRequest.ProtoReflect.Descriptor instead.
+func (*ResumeRequest) Descriptor() ([]byte, []int) {
 	return file_llm_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *ToolResult) GetToolCallId() string {
+func (x *ResumeRequest) GetApiKey() string {
 	if x != nil {
-		return x.ToolCallId
+		return x.ApiKey
 	}
 	return ""
 }
 
-func (x *ToolResult) GetResult() string {
+func (x *ResumeRequest) GetSessionId() string {
 	if x != nil {
-		return x.Result
+		return x.SessionId
 	}
 	return ""
 }
 
-func (x *ToolResult) GetIsError() bool {
+func (x *ResumeRequest) GetLastChunkIndex() int32 {
 	if x != nil {
-		return x.IsError
+		return x.LastChunkIndex
 	}
-	return false
+	return 0
 }
 
-// Message represents a conversation message.
-type Message struct {
+// ToolResult provides the result of a tool call.
+type ToolResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"` // "user", "assistant", "system"
-	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
-	ToolCalls     []*ToolCall            `protobuf:"bytes,3,rep,name=tool_calls,json=toolCalls,proto3" json:"tool_calls,omitempty"`
+	ToolCallId    string                 `protobuf:"bytes,1,opt,name=tool_call_id,json=toolCallId,proto3" json:"tool_call_id,omitempty"`
+	Result        string                 `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	IsError       bool                   `protobuf:"varint,3,opt,name=is_error,json=isError,proto3" json:"is_error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Message) Reset() {
-	*x = Message{}
+func (x *ToolResult) Reset() {
+	*x = ToolResult{}
 	mi := &file_llm_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Message) String() string {
+func (x *ToolResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Message) ProtoMessage() {}
+func (*ToolResult) ProtoMessage() {}
 
-func (x *Message) ProtoReflect() protoreflect.Message {
+func (x *ToolResult) ProtoReflect() protoreflect.Message {
 	mi := &file_llm_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -435,56 +524,54 @@ func (x *Message) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Message.ProtoReflect.Descriptor instead.
-func (*Message) Descriptor() ([]byte, []int) {
+// Deprecated: Use ToolResult.ProtoReflect.Descriptor instead.
+func (*ToolResult) Descriptor() ([]byte, []int) {
 	return file_llm_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Message) GetRole() string {
+func (x *ToolResult) GetToolCallId() string {
 	if x != nil {
-		return x.Role
+		return x.ToolCallId
 	}
 	return ""
 }
 
-func (x *Message) GetContent() string {
+func (x *ToolResult) GetResult() string {
 	if x != nil {
-		return x.Content
+		return x.Result
 	}
 	return ""
 }
 
-func (x *Message) GetToolCalls() []*ToolCall {
+func (x *ToolResult) GetIsError() bool {
 	if x != nil {
-		return x.ToolCalls
+		return x.IsError
 	}
-	return nil
+	return false
 }
 
-// ToolDefinition defines a tool for function calling.
-type ToolDefinition struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description    string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	ParametersJson string                 `protobuf:"bytes,3,opt,name=parameters_json,json=parametersJson,proto3" json:"parameters_json,omitempty"` // JSON schema for parameters
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+// ToolResultBatch provides results keyed by tool_call_id, in any order.
+type ToolResultBatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*ToolResult          `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ToolDefinition) Reset() {
-	*x = ToolDefinition{}
+func (x *ToolResultBatch) Reset() {
+	*x = ToolResultBatch{}
 	mi := &file_llm_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ToolDefinition) String() string {
+func (x *ToolResultBatch) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ToolDefinition) ProtoMessage() {}
+func (*ToolResultBatch) ProtoMessage() {}
 
-func (x *ToolDefinition) ProtoReflect() protoreflect.Message {
+func (x *ToolResultBatch) ProtoReflect() protoreflect.Message {
 	mi := &file_llm_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -496,56 +583,47 @@ func (x *ToolDefinition) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ToolDefinition.ProtoReflect.Descriptor instead.
-func (*ToolDefinition) Descriptor() ([]byte, []int) {
+// Deprecated: Use ToolResultBatch.ProtoReflect.Descriptor instead.
+func (*ToolResultBatch) Descriptor() ([]byte, []int) {
 	return file_llm_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ToolDefinition) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *ToolDefinition) GetDescription() string {
-	if x != nil {
-		return x.Description
-	}
-	return ""
-}
-
-func (x *ToolDefinition) GetParametersJson() string {
+func (x *ToolResultBatch) GetResults() []*ToolResult {
 	if x != nil {
-		return x.ParametersJson
+		return x.Results
 	}
-	return ""
+	return nil
 }
 
-// ToolCall represents an LLM-generated tool call.
-type ToolCall struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	ArgumentsJson string                 `protobuf:"bytes,3,opt,name=arguments_json,json=argumentsJson,proto3" json:"arguments_json,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// GenerationParams are sampling parameters. Zero values use the model
+// defaults; providers ignore parameters they don't support.
+type GenerationParams struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	StopSequences    []string               `protobuf:"bytes,1,rep,name=stop_sequences,json=stopSequences,proto3" json:"stop_sequences,omitempty"`
+	TopP             float32                `protobuf:"fixed32,2,opt,name=top_p,json=topP,proto3" json:"top_p,omitempty"`
+	TopK             int32                  `protobuf:"varint,3,opt,name=top_k,json=topK,proto3" json:"top_k,omitempty"`
+	FrequencyPenalty float32                `protobuf:"fixed32,4,opt,name=frequency_penalty,json=frequencyPenalty,proto3" json:"frequency_penalty,omitempty"`
+	PresencePenalty  float32                `protobuf:"fixed32,5,opt,name=presence_penalty,json=presencePenalty,proto3" json:"presence_penalty,omitempty"`
+	Seed             *int64                 `protobuf:"varint,6,opt,name=seed,proto3,oneof" json:"seed,omitempty"`             // deterministic sampling where supported
+	Deterministic    bool                   `protobuf:"varint,7,opt,name=deterministic,proto3" json:"deterministic,omitempty"` // greedy decoding and no provider failover
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *ToolCall) Reset() {
-	*x = ToolCall{}
+func (x *GenerationParams) Reset() {
+	*x = GenerationParams{}
 	mi := &file_llm_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ToolCall) String() string {
+func (x *GenerationParams) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ToolCall) ProtoMessage() {}
+func (*GenerationParams) ProtoMessage() {}
 
-func (x *ToolCall) ProtoReflect() protoreflect.Message {
+func (x *GenerationParams) ProtoReflect() protoreflect.Message {
 	mi := &file_llm_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -557,62 +635,86 @@ func (x *ToolCall) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ToolCall.ProtoReflect.Descriptor instead.
-func (*ToolCall) Descriptor() ([]byte, []int) {
+// Deprecated: Use GenerationParams.ProtoReflect.Descriptor instead.
+func (*GenerationParams) Descriptor() ([]byte, []int) {
 	return file_llm_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ToolCall) GetId() string {
+func (x *GenerationParams) GetStopSequences() []string {
 	if x != nil {
-		return x.Id
+		return x.StopSequences
 	}
-	return ""
+	return nil
 }
 
-func (x *ToolCall) GetName() string {
+func (x *GenerationParams) GetTopP() float32 {
 	if x != nil {
-		return x.Name
+		return x.TopP
 	}
-	return ""
+	return 0
 }
 
-func (x *ToolCall) GetArgumentsJson() string {
+func (x *GenerationParams) GetTopK() int32 {
 	if x != nil {
-		return x.ArgumentsJson
+		return x.TopK
 	}
-	return ""
+	return 0
 }
 
-// ChatResponse is streamed from server to client.
-type ChatResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Response:
-	//
-	//	*ChatResponse_SessionStarted
-	//	*ChatResponse_Chunk
-	//	*ChatResponse_ToolCall
-	//	*ChatResponse_Completion
-	//	*ChatResponse_Error
-	//	*ChatResponse_Aborted
-	Response      isChatResponse_Response `protobuf_oneof:"response"`
+func (x *GenerationParams) GetFrequencyPenalty() float32 {
+	if x != nil {
+		return x.FrequencyPenalty
+	}
+	return 0
+}
+
+func (x *GenerationParams) GetPresencePenalty() float32 {
+	if x != nil {
+		return x.PresencePenalty
+	}
+	return 0
+}
+
+func (x *GenerationParams) GetSeed() int64 {
+	if x != nil && x.Seed != nil {
+		return *x.Seed
+	}
+	return 0
+}
+
+func (x *GenerationParams) GetDeterministic() bool {
+	if x != nil {
+		return x.Deterministic
+	}
+	return false
+}
+
+// Message represents a conversation message.
+type Message struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"` // "user", "assistant", "system"
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	ToolCalls     []*ToolCall            `protobuf:"bytes,3,rep,name=tool_calls,json=toolCalls,proto3" json:"tool_calls,omitempty"`
+	Images        []*Image               `protobuf:"bytes,4,rep,name=images,proto3" json:"images,omitempty"` // Image inputs for vision-capable models
+	Audio         []*Audio               `protobuf:"bytes,5,rep,name=audio,proto3" json:"audio,omitempty"`   // Speech inputs, transcribed by the gateway
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChatResponse) Reset() {
-	*x = ChatResponse{}
+func (x *Message) Reset() {
+	*x = Message{}
 	mi := &file_llm_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChatResponse) String() string {
+func (x *Message) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChatResponse) ProtoMessage() {}
+func (*Message) ProtoMessage() {}
 
-func (x *ChatResponse) ProtoReflect() protoreflect.Message {
+func (x *Message) ProtoReflect() protoreflect.Message {
 	mi := &file_llm_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -624,143 +726,1639 @@ func (x *ChatResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChatResponse.ProtoReflect.Descriptor instead.
-func (*ChatResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Message.ProtoReflect.Descriptor instead.
+func (*Message) Descriptor() ([]byte, []int) {
 	return file_llm_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ChatResponse) GetResponse() isChatResponse_Response {
+func (x *Message) GetRole() string {
 	if x != nil {
-		return x.Response
+		return x.Role
 	}
-	return nil
+	return ""
 }
 
-func (x *ChatResponse) GetSessionStarted() *SessionStarted {
+func (x *Message) GetContent() string {
 	if x != nil {
-		if x, ok := x.Response.(*ChatResponse_SessionStarted); ok {
-			return x.SessionStarted
-		}
+		return x.Content
 	}
-	return nil
+	return ""
 }
 
-func (x *ChatResponse) GetChunk() *ContentChunk {
+func (x *Message) GetToolCalls() []*ToolCall {
 	if x != nil {
-		if x, ok := x.Response.(*ChatResponse_Chunk); ok {
-			return x.Chunk
-		}
+		return x.ToolCalls
 	}
 	return nil
 }
 
-func (x *ChatResponse) GetToolCall() *ToolCallRequest {
+func (x *Message) GetImages() []*Image {
 	if x != nil {
-		if x, ok := x.Response.(*ChatResponse_ToolCall); ok {
-			return x.ToolCall
-		}
+		return x.Images
 	}
 	return nil
 }
 
-func (x *ChatResponse) GetCompletion() *CompletionResponse {
+func (x *Message) GetAudio() []*Audio {
 	if x != nil {
-		if x, ok := x.Response.(*ChatResponse_Completion); ok {
+		return x.Audio
+	}
+	return nil
+}
+
+// Image is an image input, by URL or inline bytes.
+type Image struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	MediaType     string                 `protobuf:"bytes,3,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"` // "image/png", "image/jpeg", "image/gif", "image/webp"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Image) Reset() {
+	*x = Image{}
+	mi := &file_llm_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Image) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Image) ProtoMessage() {}
+
+func (x *Image) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Image.ProtoReflect.Descriptor instead.
+func (*Image) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Image) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Image) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Image) GetMediaType() string {
+	if x != nil {
+		return x.MediaType
+	}
+	return ""
+}
+
+// Audio is a speech input, by URL or inline bytes.
+type Audio struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	MediaType     string                 `protobuf:"bytes,3,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"` // "audio/wav", "audio/mpeg", "audio/webm", "audio/ogg"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Audio) Reset() {
+	*x = Audio{}
+	mi := &file_llm_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Audio) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Audio) ProtoMessage() {}
+
+func (x *Audio) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Audio.ProtoReflect.Descriptor instead.
+func (*Audio) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Audio) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Audio) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Audio) GetMediaType() string {
+	if x != nil {
+		return x.MediaType
+	}
+	return ""
+}
+
+// ToolDefinition defines a tool for function calling.
+type ToolDefinition struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description    string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	ParametersJson string                 `protobuf:"bytes,3,opt,name=parameters_json,json=parametersJson,proto3" json:"parameters_json,omitempty"` // JSON schema for parameters
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ToolDefinition) Reset() {
+	*x = ToolDefinition{}
+	mi := &file_llm_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolDefinition) ProtoMessage() {}
+
+func (x *ToolDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolDefinition.ProtoReflect.Descriptor instead.
+func (*ToolDefinition) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ToolDefinition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ToolDefinition) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ToolDefinition) GetParametersJson() string {
+	if x != nil {
+		return x.ParametersJson
+	}
+	return ""
+}
+
+// ToolCall represents an LLM-generated tool call.
+type ToolCall struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ArgumentsJson string                 `protobuf:"bytes,3,opt,name=arguments_json,json=argumentsJson,proto3" json:"arguments_json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToolCall) Reset() {
+	*x = ToolCall{}
+	mi := &file_llm_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolCall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolCall) ProtoMessage() {}
+
+func (x *ToolCall) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolCall.ProtoReflect.Descriptor instead.
+func (*ToolCall) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ToolCall) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ToolCall) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ToolCall) GetArgumentsJson() string {
+	if x != nil {
+		return x.ArgumentsJson
+	}
+	return ""
+}
+
+// ChatResponse is streamed from server to client.
+type ChatResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*ChatResponse_SessionStarted
+	//	*ChatResponse_Chunk
+	//	*ChatResponse_ToolCall
+	//	*ChatResponse_Completion
+	//	*ChatResponse_Error
+	//	*ChatResponse_Aborted
+	//	*ChatResponse_ToolCalls
+	Response      isChatResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatResponse) Reset() {
+	*x = ChatResponse{}
+	mi := &file_llm_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatResponse) ProtoMessage() {}
+
+func (x *ChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatResponse.ProtoReflect.Descriptor instead.
+func (*ChatResponse) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ChatResponse) GetResponse() isChatResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ChatResponse) GetSessionStarted() *SessionStarted {
+	if x != nil {
+		if x, ok := x.Response.(*ChatResponse_SessionStarted); ok {
+			return x.SessionStarted
+		}
+	}
+	return nil
+}
+
+func (x *ChatResponse) GetChunk() *ContentChunk {
+	if x != nil {
+		if x, ok := x.Response.(*ChatResponse_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+func (x *ChatResponse) GetToolCall() *ToolCallRequest {
+	if x != nil {
+		if x, ok := x.Response.(*ChatResponse_ToolCall); ok {
+			return x.ToolCall
+		}
+	}
+	return nil
+}
+
+func (x *ChatResponse) GetCompletion() *CompletionResponse {
+	if x != nil {
+		if x, ok := x.Response.(*ChatResponse_Completion); ok {
 			return x.Completion
 		}
 	}
 	return nil
 }
 
-func (x *ChatResponse) GetError() *ErrorResponse {
+func (x *ChatResponse) GetError() *ErrorResponse {
+	if x != nil {
+		if x, ok := x.Response.(*ChatResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+func (x *ChatResponse) GetAborted() *AbortedResponse {
+	if x != nil {
+		if x, ok := x.Response.(*ChatResponse_Aborted); ok {
+			return x.Aborted
+		}
+	}
+	return nil
+}
+
+func (x *ChatResponse) GetToolCalls() *ToolCallBatch {
+	if x != nil {
+		if x, ok := x.Response.(*ChatResponse_ToolCalls); ok {
+			return x.ToolCalls
+		}
+	}
+	return nil
+}
+
+type isChatResponse_Response interface {
+	isChatResponse_Response()
+}
+
+type ChatResponse_SessionStarted struct {
+	// Session started successfully
+	SessionStarted *SessionStarted `protobuf:"bytes,1,opt,name=session_started,json=sessionStarted,proto3,oneof"`
+}
+
+type ChatResponse_Chunk struct {
+	// Content chunk (streamed tokens)
+	Chunk *ContentChunk `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+type ChatResponse_ToolCall struct {
+	// Tool call requested by the LLM
+	ToolCall *ToolCallRequest `protobuf:"bytes,3,opt,name=tool_call,json=toolCall,proto3,oneof"`
+}
+
+type ChatResponse_Completion struct {
+	// Generation complete
+	Completion *CompletionResponse `protobuf:"bytes,4,opt,name=completion,proto3,oneof"`
+}
+
+type ChatResponse_Error struct {
+	// Error occurred
+	Error *ErrorResponse `protobuf:"bytes,5,opt,name=error,proto3,oneof"`
+}
+
+type ChatResponse_Aborted struct {
+	// Generation aborted (ack of AbortRequest)
+	Aborted *AbortedResponse `protobuf:"bytes,6,opt,name=aborted,proto3,oneof"`
+}
+
+type ChatResponse_ToolCalls struct {
+	// Tool calls requested together by the LLM (parallel_tool_calls)
+	ToolCalls *ToolCallBatch `protobuf:"bytes,7,opt,name=tool_calls,json=toolCalls,proto3,oneof"`
+}
+
+func (*ChatResponse_SessionStarted) isChatResponse_Response() {}
+
+func (*ChatResponse_Chunk) isChatResponse_Response() {}
+
+func (*ChatResponse_ToolCall) isChatResponse_Response() {}
+
+func (*ChatResponse_Completion) isChatResponse_Response() {}
+
+func (*ChatResponse_Error) isChatResponse_Response() {}
+
+func (*ChatResponse_Aborted) isChatResponse_Response() {}
+
+func (*ChatResponse_ToolCalls) isChatResponse_Response() {}
+
+// SessionStarted confirms the session was initialized.
+type SessionStarted struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Provider  string                 `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	Model     string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	// Set when the session was resumed
+	Resumed       bool       `protobuf:"varint,4,opt,name=resumed,proto3" json:"resumed,omitempty"`
+	Generating    bool       `protobuf:"varint,5,opt,name=generating,proto3" json:"generating,omitempty"` // A turn is in flight; its output follows
+	Messages      []*Message `protobuf:"bytes,6,rep,name=messages,proto3" json:"messages,omitempty"`      // Conversation so far, with the user message of a turn in flight
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionStarted) Reset() {
+	*x = SessionStarted{}
+	mi := &file_llm_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionStarted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionStarted) ProtoMessage() {}
+
+func (x *SessionStarted) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionStarted.ProtoReflect.Descriptor instead.
+func (*SessionStarted) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SessionStarted) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SessionStarted) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *SessionStarted) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *SessionStarted) GetResumed() bool {
+	if x != nil {
+		return x.Resumed
+	}
+	return false
+}
+
+func (x *SessionStarted) GetGenerating() bool {
+	if x != nil {
+		return x.Generating
+	}
+	return false
+}
+
+func (x *SessionStarted) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+// ContentChunk streams generated content.
+type ContentChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	Index         int32                  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`                                // Chunk index for ordering
+	InputTokens   int64                  `protobuf:"varint,3,opt,name=input_tokens,json=inputTokens,proto3" json:"input_tokens,omitempty"` // Running token counts of the turn (optional)
+	OutputTokens  int64                  `protobuf:"varint,4,opt,name=output_tokens,json=outputTokens,proto3" json:"output_tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContentChunk) Reset() {
+	*x = ContentChunk{}
+	mi := &file_llm_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContentChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContentChunk) ProtoMessage() {}
+
+func (x *ContentChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContentChunk.ProtoReflect.Descriptor instead.
+func (*ContentChunk) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ContentChunk) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *ContentChunk) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ContentChunk) GetInputTokens() int64 {
+	if x != nil {
+		return x.InputTokens
+	}
+	return 0
+}
+
+func (x *ContentChunk) GetOutputTokens() int64 {
+	if x != nil {
+		return x.OutputTokens
+	}
+	return 0
+}
+
+// ToolCallRequest indicates the LLM wants to call a tool.
+type ToolCallRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ToolCallId    string                 `protobuf:"bytes,1,opt,name=tool_call_id,json=toolCallId,proto3" json:"tool_call_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ArgumentsJson string                 `protobuf:"bytes,3,opt,name=arguments_json,json=argumentsJson,proto3" json:"arguments_json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToolCallRequest) Reset() {
+	*x = ToolCallRequest{}
+	mi := &file_llm_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolCallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolCallRequest) ProtoMessage() {}
+
+func (x *ToolCallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolCallRequest.ProtoReflect.Descriptor instead.
+func (*ToolCallRequest) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ToolCallRequest) GetToolCallId() string {
+	if x != nil {
+		return x.ToolCallId
+	}
+	return ""
+}
+
+func (x *ToolCallRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ToolCallRequest) GetArgumentsJson() string {
+	if x != nil {
+		return x.ArgumentsJson
+	}
+	return ""
+}
+
+// ToolCallBatch holds tool calls the LLM made in one step. Results may be
+// sent together or one by one.
+type ToolCallBatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Calls         []*ToolCallRequest     `protobuf:"bytes,1,rep,name=calls,proto3" json:"calls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToolCallBatch) Reset() {
+	*x = ToolCallBatch{}
+	mi := &file_llm_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolCallBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolCallBatch) ProtoMessage() {}
+
+func (x *ToolCallBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolCallBatch.ProtoReflect.Descriptor instead.
+func (*ToolCallBatch) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ToolCallBatch) GetCalls() []*ToolCallRequest {
+	if x != nil {
+		return x.Calls
+	}
+	return nil
+}
+
+// CompletionResponse indicates generation is complete.
+type CompletionResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	FullContent       string                 `protobuf:"bytes,1,opt,name=full_content,json=fullContent,proto3" json:"full_content,omitempty"`
+	StopReason        string                 `protobuf:"bytes,2,opt,name=stop_reason,json=stopReason,proto3" json:"stop_reason,omitempty"`
+	InputTokens       int64                  `protobuf:"varint,3,opt,name=input_tokens,json=inputTokens,proto3" json:"input_tokens,omitempty"`
+	OutputTokens      int64                  `protobuf:"varint,4,opt,name=output_tokens,json=outputTokens,proto3" json:"output_tokens,omitempty"`
+	CostUsd           float64                `protobuf:"fixed64,5,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
+	LatencyMs         int64                  `protobuf:"varint,6,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	Provider          string                 `protobuf:"bytes,7,opt,name=provider,proto3" json:"provider,omitempty"`                                            // Provider that served the turn
+	Model             string                 `protobuf:"bytes,8,opt,name=model,proto3" json:"model,omitempty"`                                                  // Resolved model ID
+	SystemFingerprint string                 `protobuf:"bytes,9,opt,name=system_fingerprint,json=systemFingerprint,proto3" json:"system_fingerprint,omitempty"` // Backend configuration, for reproducibility
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CompletionResponse) Reset() {
+	*x = CompletionResponse{}
+	mi := &file_llm_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompletionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompletionResponse) ProtoMessage() {}
+
+func (x *CompletionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompletionResponse.ProtoReflect.Descriptor instead.
+func (*CompletionResponse) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CompletionResponse) GetFullContent() string {
+	if x != nil {
+		return x.FullContent
+	}
+	return ""
+}
+
+func (x *CompletionResponse) GetStopReason() string {
+	if x != nil {
+		return x.StopReason
+	}
+	return ""
+}
+
+func (x *CompletionResponse) GetInputTokens() int64 {
+	if x != nil {
+		return x.InputTokens
+	}
+	return 0
+}
+
+func (x *CompletionResponse) GetOutputTokens() int64 {
+	if x != nil {
+		return x.OutputTokens
+	}
+	return 0
+}
+
+func (x *CompletionResponse) GetCostUsd() float64 {
+	if x != nil {
+		return x.CostUsd
+	}
+	return 0
+}
+
+func (x *CompletionResponse) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *CompletionResponse) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *CompletionResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *CompletionResponse) GetSystemFingerprint() string {
+	if x != nil {
+		return x.SystemFingerprint
+	}
+	return ""
+}
+
+// ErrorResponse indicates an error occurred.
+type ErrorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Retryable     bool                   `protobuf:"varint,3,opt,name=retryable,proto3" json:"retryable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorResponse) Reset() {
+	*x = ErrorResponse{}
+	mi := &file_llm_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorResponse) ProtoMessage() {}
+
+func (x *ErrorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorResponse.ProtoReflect.Descriptor instead.
+func (*ErrorResponse) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ErrorResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ErrorResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ErrorResponse) GetRetryable() bool {
+	if x != nil {
+		return x.Retryable
+	}
+	return false
+}
+
+// AbortedResponse confirms the generation was aborted.
+type AbortedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reason        string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbortedResponse) Reset() {
+	*x = AbortedResponse{}
+	mi := &file_llm_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortedResponse) ProtoMessage() {}
+
+func (x *AbortedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortedResponse.ProtoReflect.Descriptor instead.
+func (*AbortedResponse) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AbortedResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// SimpleChatRequest for unary RPC (non-streaming).
+type SimpleChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey        string                 `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	Messages      []*Message             `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"`
+	SystemPrompt  string                 `protobuf:"bytes,3,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
+	Model         string                 `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"`
+	MaxTokens     int32                  `protobuf:"varint,5,opt,name=max_tokens,json=maxTokens,proto3" json:"max_tokens,omitempty"`
+	Temperature   float32                `protobuf:"fixed32,6,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	RequestId     string                 `protobuf:"bytes,7,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Provider      string                 `protobuf:"bytes,8,opt,name=provider,proto3" json:"provider,omitempty"` // "anthropic", "openai", "local" (optional)
+	Routing       string                 `protobuf:"bytes,9,opt,name=routing,proto3" json:"routing,omitempty"`   // "cheapest", "fastest", "sticky" (optional)
+	Params        *GenerationParams      `protobuf:"bytes,10,opt,name=params,proto3" json:"params,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimpleChatRequest) Reset() {
+	*x = SimpleChatRequest{}
+	mi := &file_llm_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimpleChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimpleChatRequest) ProtoMessage() {}
+
+func (x *SimpleChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimpleChatRequest.ProtoReflect.Descriptor instead.
+func (*SimpleChatRequest) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SimpleChatRequest) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
+	}
+	return ""
+}
+
+func (x *SimpleChatRequest) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *SimpleChatRequest) GetSystemPrompt() string {
+	if x != nil {
+		return x.SystemPrompt
+	}
+	return ""
+}
+
+func (x *SimpleChatRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *SimpleChatRequest) GetMaxTokens() int32 {
+	if x != nil {
+		return x.MaxTokens
+	}
+	return 0
+}
+
+func (x *SimpleChatRequest) GetTemperature() float32 {
+	if x != nil {
+		return x.Temperature
+	}
+	return 0
+}
+
+func (x *SimpleChatRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *SimpleChatRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *SimpleChatRequest) GetRouting() string {
+	if x != nil {
+		return x.Routing
+	}
+	return ""
+}
+
+func (x *SimpleChatRequest) GetParams() *GenerationParams {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+// SimpleChatResponse for unary RPC.
+type SimpleChatResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Content           string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	Model             string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	InputTokens       int64                  `protobuf:"varint,3,opt,name=input_tokens,json=inputTokens,proto3" json:"input_tokens,omitempty"`
+	OutputTokens      int64                  `protobuf:"varint,4,opt,name=output_tokens,json=outputTokens,proto3" json:"output_tokens,omitempty"`
+	CostUsd           float64                `protobuf:"fixed64,5,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
+	LatencyMs         int64                  `protobuf:"varint,6,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	StopReason        string                 `protobuf:"bytes,7,opt,name=stop_reason,json=stopReason,proto3" json:"stop_reason,omitempty"`
+	Provider          string                 `protobuf:"bytes,8,opt,name=provider,proto3" json:"provider,omitempty"`                                            // Provider that served the request
+	SystemFingerprint string                 `protobuf:"bytes,9,opt,name=system_fingerprint,json=systemFingerprint,proto3" json:"system_fingerprint,omitempty"` // Backend configuration, for reproducibility
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SimpleChatResponse) Reset() {
+	*x = SimpleChatResponse{}
+	mi := &file_llm_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimpleChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimpleChatResponse) ProtoMessage() {}
+
+func (x *SimpleChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimpleChatResponse.ProtoReflect.Descriptor instead.
+func (*SimpleChatResponse) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SimpleChatResponse) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *SimpleChatResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *SimpleChatResponse) GetInputTokens() int64 {
+	if x != nil {
+		return x.InputTokens
+	}
+	return 0
+}
+
+func (x *SimpleChatResponse) GetOutputTokens() int64 {
+	if x != nil {
+		return x.OutputTokens
+	}
+	return 0
+}
+
+func (x *SimpleChatResponse) GetCostUsd() float64 {
+	if x != nil {
+		return x.CostUsd
+	}
+	return 0
+}
+
+func (x *SimpleChatResponse) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *SimpleChatResponse) GetStopReason() string {
+	if x != nil {
+		return x.StopReason
+	}
+	return ""
+}
+
+func (x *SimpleChatResponse) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *SimpleChatResponse) GetSystemFingerprint() string {
+	if x != nil {
+		return x.SystemFingerprint
+	}
+	return ""
+}
+
+// EmbedRequest asks for embeddings of a batch of texts.
+type EmbedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey        string                 `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	Inputs        []string               `protobuf:"bytes,2,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	Model         string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"` // Embedding model; empty uses the org default
+	RequestId     string                 `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmbedRequest) Reset() {
+	*x = EmbedRequest{}
+	mi := &file_llm_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbedRequest) ProtoMessage() {}
+
+func (x *EmbedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbedRequest.ProtoReflect.Descriptor instead.
+func (*EmbedRequest) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *EmbedRequest) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
+	}
+	return ""
+}
+
+func (x *EmbedRequest) GetInputs() []string {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *EmbedRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *EmbedRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// Embedding is the vector of one input.
+type Embedding struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // Position of the input in the request
+	Values        []float32              `protobuf:"fixed32,2,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Embedding) Reset() {
+	*x = Embedding{}
+	mi := &file_llm_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Embedding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Embedding) ProtoMessage() {}
+
+func (x *Embedding) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Embedding.ProtoReflect.Descriptor instead.
+func (*Embedding) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Embedding) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Embedding) GetValues() []float32 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// EmbedResponse returns one embedding per input.
+type EmbedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Embeddings    []*Embedding           `protobuf:"bytes,1,rep,name=embeddings,proto3" json:"embeddings,omitempty"`
+	Model         string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	Dimensions    int32                  `protobuf:"varint,3,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
+	InputTokens   int64                  `protobuf:"varint,4,opt,name=input_tokens,json=inputTokens,proto3" json:"input_tokens,omitempty"`
+	CostUsd       float64                `protobuf:"fixed64,5,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
+	LatencyMs     int64                  `protobuf:"varint,6,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmbedResponse) Reset() {
+	*x = EmbedResponse{}
+	mi := &file_llm_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbedResponse) ProtoMessage() {}
+
+func (x *EmbedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbedResponse.ProtoReflect.Descriptor instead.
+func (*EmbedResponse) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *EmbedResponse) GetEmbeddings() []*Embedding {
+	if x != nil {
+		return x.Embeddings
+	}
+	return nil
+}
+
+func (x *EmbedResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *EmbedResponse) GetDimensions() int32 {
+	if x != nil {
+		return x.Dimensions
+	}
+	return 0
+}
+
+func (x *EmbedResponse) GetInputTokens() int64 {
+	if x != nil {
+		return x.InputTokens
+	}
+	return 0
+}
+
+func (x *EmbedResponse) GetCostUsd() float64 {
+	if x != nil {
+		return x.CostUsd
+	}
+	return 0
+}
+
+func (x *EmbedResponse) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+// CountTokensRequest asks for the input token count of a conversation.
+type CountTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey        string                 `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	Model         string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	SystemPrompt  string                 `protobuf:"bytes,3,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
+	Messages      []*Message             `protobuf:"bytes,4,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountTokensRequest) Reset() {
+	*x = CountTokensRequest{}
+	mi := &file_llm_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountTokensRequest) ProtoMessage() {}
+
+func (x *CountTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountTokensRequest.ProtoReflect.Descriptor instead.
+func (*CountTokensRequest) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CountTokensRequest) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
+	}
+	return ""
+}
+
+func (x *CountTokensRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *CountTokensRequest) GetSystemPrompt() string {
+	if x != nil {
+		return x.SystemPrompt
+	}
+	return ""
+}
+
+func (x *CountTokensRequest) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+// CountTokensResponse returns the input token count.
+type CountTokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InputTokens   int64                  `protobuf:"varint,1,opt,name=input_tokens,json=inputTokens,proto3" json:"input_tokens,omitempty"`
+	Model         string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"` // Resolved model ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountTokensResponse) Reset() {
+	*x = CountTokensResponse{}
+	mi := &file_llm_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountTokensResponse) ProtoMessage() {}
+
+func (x *CountTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[27]
 	if x != nil {
-		if x, ok := x.Response.(*ChatResponse_Error); ok {
-			return x.Error
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *ChatResponse) GetAborted() *AbortedResponse {
+// Deprecated: Use CountTokensResponse.ProtoReflect.Descriptor instead.
+func (*CountTokensResponse) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CountTokensResponse) GetInputTokens() int64 {
 	if x != nil {
-		if x, ok := x.Response.(*ChatResponse_Aborted); ok {
-			return x.Aborted
+		return x.InputTokens
+	}
+	return 0
+}
+
+func (x *CountTokensResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+// ModerateRequest asks for a moderation verdict on a text.
+type ModerateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey        string                 `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	Input         string                 `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	Model         string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"` // Moderation model (optional, org default if empty)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModerateRequest) Reset() {
+	*x = ModerateRequest{}
+	mi := &file_llm_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerateRequest) ProtoMessage() {}
+
+func (x *ModerateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-type isChatResponse_Response interface {
-	isChatResponse_Response()
+// Deprecated: Use ModerateRequest.ProtoReflect.Descriptor instead.
+func (*ModerateRequest) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{28}
 }
 
-type ChatResponse_SessionStarted struct {
-	// Session started successfully
-	SessionStarted *SessionStarted `protobuf:"bytes,1,opt,name=session_started,json=sessionStarted,proto3,oneof"`
+func (x *ModerateRequest) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
+	}
+	return ""
 }
 
-type ChatResponse_Chunk struct {
-	// Content chunk (streamed tokens)
-	Chunk *ContentChunk `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+func (x *ModerateRequest) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
 }
 
-type ChatResponse_ToolCall struct {
-	// Tool call requested by the LLM
-	ToolCall *ToolCallRequest `protobuf:"bytes,3,opt,name=tool_call,json=toolCall,proto3,oneof"`
+func (x *ModerateRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
 }
 
-type ChatResponse_Completion struct {
-	// Generation complete
-	Completion *CompletionResponse `protobuf:"bytes,4,opt,name=completion,proto3,oneof"`
+// ModerationCategory is the verdict for one policy category.
+type ModerationCategory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`     // e.g. "hate", "harassment", "self-harm", "sexual", "violence"
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"` // 0-1 confidence
+	Flagged       bool                   `protobuf:"varint,3,opt,name=flagged,proto3" json:"flagged,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type ChatResponse_Error struct {
-	// Error occurred
-	Error *ErrorResponse `protobuf:"bytes,5,opt,name=error,proto3,oneof"`
+func (x *ModerationCategory) Reset() {
+	*x = ModerationCategory{}
+	mi := &file_llm_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type ChatResponse_Aborted struct {
-	// Generation aborted (ack of AbortRequest)
-	Aborted *AbortedResponse `protobuf:"bytes,6,opt,name=aborted,proto3,oneof"`
+func (x *ModerationCategory) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChatResponse_SessionStarted) isChatResponse_Response() {}
+func (*ModerationCategory) ProtoMessage() {}
 
-func (*ChatResponse_Chunk) isChatResponse_Response() {}
+func (x *ModerationCategory) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-func (*ChatResponse_ToolCall) isChatResponse_Response() {}
+// Deprecated: Use ModerationCategory.ProtoReflect.Descriptor instead.
+func (*ModerationCategory) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{29}
+}
 
-func (*ChatResponse_Completion) isChatResponse_Response() {}
+func (x *ModerationCategory) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
 
-func (*ChatResponse_Error) isChatResponse_Response() {}
+func (x *ModerationCategory) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
 
-func (*ChatResponse_Aborted) isChatResponse_Response() {}
+func (x *ModerationCategory) GetFlagged() bool {
+	if x != nil {
+		return x.Flagged
+	}
+	return false
+}
 
-// SessionStarted confirms the session was initialized.
-type SessionStarted struct {
+// ModerateResponse returns the moderation verdict.
+type ModerateResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	Provider      string                 `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	Flagged       bool                   `protobuf:"varint,1,opt,name=flagged,proto3" json:"flagged,omitempty"`
+	Categories    []*ModerationCategory  `protobuf:"bytes,2,rep,name=categories,proto3" json:"categories,omitempty"`
 	Model         string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SessionStarted) Reset() {
-	*x = SessionStarted{}
-	mi := &file_llm_proto_msgTypes[9]
+func (x *ModerateResponse) Reset() {
+	*x = ModerateResponse{}
+	mi := &file_llm_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SessionStarted) String() string {
+func (x *ModerateResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SessionStarted) ProtoMessage() {}
+func (*ModerateResponse) ProtoMessage() {}
 
-func (x *SessionStarted) ProtoReflect() protoreflect.Message {
-	mi := &file_llm_proto_msgTypes[9]
+func (x *ModerateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -771,56 +2369,127 @@ func (x *SessionStarted) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SessionStarted.ProtoReflect.Descriptor instead.
-func (*SessionStarted) Descriptor() ([]byte, []int) {
-	return file_llm_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ModerateResponse.ProtoReflect.Descriptor instead.
+func (*ModerateResponse) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *SessionStarted) GetSessionId() string {
+func (x *ModerateResponse) GetFlagged() bool {
 	if x != nil {
-		return x.SessionId
+		return x.Flagged
+	}
+	return false
+}
+
+func (x *ModerateResponse) GetCategories() []*ModerationCategory {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *ModerateResponse) GetModel() string {
+	if x != nil {
+		return x.Model
 	}
 	return ""
 }
 
-func (x *SessionStarted) GetProvider() string {
+// TranscribeRequest asks for the text of a speech recording.
+type TranscribeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey        string                 `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	Audio         *Audio                 `protobuf:"bytes,2,opt,name=audio,proto3" json:"audio,omitempty"`
+	Model         string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`       // Speech-to-text model (optional)
+	Language      string                 `protobuf:"bytes,4,opt,name=language,proto3" json:"language,omitempty"` // ISO 639-1 hint (optional)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TranscribeRequest) Reset() {
+	*x = TranscribeRequest{}
+	mi := &file_llm_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TranscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranscribeRequest) ProtoMessage() {}
+
+func (x *TranscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[31]
 	if x != nil {
-		return x.Provider
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranscribeRequest.ProtoReflect.Descriptor instead.
+func (*TranscribeRequest) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *TranscribeRequest) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
 	}
 	return ""
 }
 
-func (x *SessionStarted) GetModel() string {
+func (x *TranscribeRequest) GetAudio() *Audio {
+	if x != nil {
+		return x.Audio
+	}
+	return nil
+}
+
+func (x *TranscribeRequest) GetModel() string {
 	if x != nil {
 		return x.Model
 	}
 	return ""
 }
 
-// ContentChunk streams generated content.
-type ContentChunk struct {
+func (x *TranscribeRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+// TranscribeResponse returns the transcript.
+type TranscribeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
-	Index         int32                  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"` // Chunk index for ordering
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Language      string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"` // Detected language
+	DurationMs    int64                  `protobuf:"varint,3,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	CostUsd       float64                `protobuf:"fixed64,4,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ContentChunk) Reset() {
-	*x = ContentChunk{}
-	mi := &file_llm_proto_msgTypes[10]
+func (x *TranscribeResponse) Reset() {
+	*x = TranscribeResponse{}
+	mi := &file_llm_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ContentChunk) String() string {
+func (x *TranscribeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ContentChunk) ProtoMessage() {}
+func (*TranscribeResponse) ProtoMessage() {}
 
-func (x *ContentChunk) ProtoReflect() protoreflect.Message {
-	mi := &file_llm_proto_msgTypes[10]
+func (x *TranscribeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -831,50 +2500,66 @@ func (x *ContentChunk) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ContentChunk.ProtoReflect.Descriptor instead.
-func (*ContentChunk) Descriptor() ([]byte, []int) {
-	return file_llm_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use TranscribeResponse.ProtoReflect.Descriptor instead.
+func (*TranscribeResponse) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *ContentChunk) GetContent() string {
+func (x *TranscribeResponse) GetText() string {
 	if x != nil {
-		return x.Content
+		return x.Text
 	}
 	return ""
 }
 
-func (x *ContentChunk) GetIndex() int32 {
+func (x *TranscribeResponse) GetLanguage() string {
 	if x != nil {
-		return x.Index
+		return x.Language
+	}
+	return ""
+}
+
+func (x *TranscribeResponse) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
 	}
 	return 0
 }
 
-// ToolCallRequest indicates the LLM wants to call a tool.
-type ToolCallRequest struct {
+func (x *TranscribeResponse) GetCostUsd() float64 {
+	if x != nil {
+		return x.CostUsd
+	}
+	return 0
+}
+
+// SynthesizeRequest asks for speech of a text.
+type SynthesizeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ToolCallId    string                 `protobuf:"bytes,1,opt,name=tool_call_id,json=toolCallId,proto3" json:"tool_call_id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	ArgumentsJson string                 `protobuf:"bytes,3,opt,name=arguments_json,json=argumentsJson,proto3" json:"arguments_json,omitempty"`
+	ApiKey        string                 `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Voice         string                 `protobuf:"bytes,3,opt,name=voice,proto3" json:"voice,omitempty"`   // Provider voice name (optional)
+	Format        string                 `protobuf:"bytes,4,opt,name=format,proto3" json:"format,omitempty"` // "mp3", "opus", "wav", "pcm" (default "mp3")
+	Model         string                 `protobuf:"bytes,5,opt,name=model,proto3" json:"model,omitempty"`   // Text-to-speech model (optional)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ToolCallRequest) Reset() {
-	*x = ToolCallRequest{}
-	mi := &file_llm_proto_msgTypes[11]
+func (x *SynthesizeRequest) Reset() {
+	*x = SynthesizeRequest{}
+	mi := &file_llm_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ToolCallRequest) String() string {
+func (x *SynthesizeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ToolCallRequest) ProtoMessage() {}
+func (*SynthesizeRequest) ProtoMessage() {}
 
-func (x *ToolCallRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_llm_proto_msgTypes[11]
+func (x *SynthesizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -885,60 +2570,71 @@ func (x *ToolCallRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ToolCallRequest.ProtoReflect.Descriptor instead.
-func (*ToolCallRequest) Descriptor() ([]byte, []int) {
-	return file_llm_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use SynthesizeRequest.ProtoReflect.Descriptor instead.
+func (*SynthesizeRequest) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *ToolCallRequest) GetToolCallId() string {
+func (x *SynthesizeRequest) GetApiKey() string {
 	if x != nil {
-		return x.ToolCallId
+		return x.ApiKey
 	}
 	return ""
 }
 
-func (x *ToolCallRequest) GetName() string {
+func (x *SynthesizeRequest) GetText() string {
 	if x != nil {
-		return x.Name
+		return x.Text
 	}
 	return ""
 }
 
-func (x *ToolCallRequest) GetArgumentsJson() string {
+func (x *SynthesizeRequest) GetVoice() string {
 	if x != nil {
-		return x.ArgumentsJson
+		return x.Voice
 	}
 	return ""
 }
 
-// CompletionResponse indicates generation is complete.
-type CompletionResponse struct {
+func (x *SynthesizeRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *SynthesizeRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+// AudioChunk is a piece of synthesized speech.
+type AudioChunk struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FullContent   string                 `protobuf:"bytes,1,opt,name=full_content,json=fullContent,proto3" json:"full_content,omitempty"`
-	StopReason    string                 `protobuf:"bytes,2,opt,name=stop_reason,json=stopReason,proto3" json:"stop_reason,omitempty"`
-	InputTokens   int64                  `protobuf:"varint,3,opt,name=input_tokens,json=inputTokens,proto3" json:"input_tokens,omitempty"`
-	OutputTokens  int64                  `protobuf:"varint,4,opt,name=output_tokens,json=outputTokens,proto3" json:"output_tokens,omitempty"`
-	CostUsd       float64                `protobuf:"fixed64,5,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
-	LatencyMs     int64                  `protobuf:"varint,6,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	MediaType     string                 `protobuf:"bytes,2,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"`
+	Index         int32                  `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CompletionResponse) Reset() {
-	*x = CompletionResponse{}
-	mi := &file_llm_proto_msgTypes[12]
+func (x *AudioChunk) Reset() {
+	*x = AudioChunk{}
+	mi := &file_llm_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CompletionResponse) String() string {
+func (x *AudioChunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CompletionResponse) ProtoMessage() {}
+func (*AudioChunk) ProtoMessage() {}
 
-func (x *CompletionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_llm_proto_msgTypes[12]
+func (x *AudioChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -949,78 +2645,56 @@ func (x *CompletionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CompletionResponse.ProtoReflect.Descriptor instead.
-func (*CompletionResponse) Descriptor() ([]byte, []int) {
-	return file_llm_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use AudioChunk.ProtoReflect.Descriptor instead.
+func (*AudioChunk) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *CompletionResponse) GetFullContent() string {
+func (x *AudioChunk) GetData() []byte {
 	if x != nil {
-		return x.FullContent
+		return x.Data
 	}
-	return ""
+	return nil
 }
 
-func (x *CompletionResponse) GetStopReason() string {
+func (x *AudioChunk) GetMediaType() string {
 	if x != nil {
-		return x.StopReason
+		return x.MediaType
 	}
 	return ""
 }
 
-func (x *CompletionResponse) GetInputTokens() int64 {
-	if x != nil {
-		return x.InputTokens
-	}
-	return 0
-}
-
-func (x *CompletionResponse) GetOutputTokens() int64 {
-	if x != nil {
-		return x.OutputTokens
-	}
-	return 0
-}
-
-func (x *CompletionResponse) GetCostUsd() float64 {
-	if x != nil {
-		return x.CostUsd
-	}
-	return 0
-}
-
-func (x *CompletionResponse) GetLatencyMs() int64 {
+func (x *AudioChunk) GetIndex() int32 {
 	if x != nil {
-		return x.LatencyMs
+		return x.Index
 	}
 	return 0
 }
 
-// ErrorResponse indicates an error occurred.
-type ErrorResponse struct {
+// SubmitBatchRequest queues chat requests as one job.
+type SubmitBatchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Retryable     bool                   `protobuf:"varint,3,opt,name=retryable,proto3" json:"retryable,omitempty"`
+	ApiKey        string                 `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	Requests      []*SimpleChatRequest   `protobuf:"bytes,2,rep,name=requests,proto3" json:"requests,omitempty"` // request_id is echoed in results
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ErrorResponse) Reset() {
-	*x = ErrorResponse{}
-	mi := &file_llm_proto_msgTypes[13]
+func (x *SubmitBatchRequest) Reset() {
+	*x = SubmitBatchRequest{}
+	mi := &file_llm_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ErrorResponse) String() string {
+func (x *SubmitBatchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ErrorResponse) ProtoMessage() {}
+func (*SubmitBatchRequest) ProtoMessage() {}
 
-func (x *ErrorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_llm_proto_msgTypes[13]
+func (x *SubmitBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1031,55 +2705,49 @@ func (x *ErrorResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ErrorResponse.ProtoReflect.Descriptor instead.
-func (*ErrorResponse) Descriptor() ([]byte, []int) {
-	return file_llm_proto_rawDescGZIP(), []int{13}
-}
-
-func (x *ErrorResponse) GetCode() string {
-	if x != nil {
-		return x.Code
-	}
-	return ""
+// Deprecated: Use SubmitBatchRequest.ProtoReflect.Descriptor instead.
+func (*SubmitBatchRequest) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *ErrorResponse) GetMessage() string {
+func (x *SubmitBatchRequest) GetApiKey() string {
 	if x != nil {
-		return x.Message
+		return x.ApiKey
 	}
 	return ""
 }
 
-func (x *ErrorResponse) GetRetryable() bool {
+func (x *SubmitBatchRequest) GetRequests() []*SimpleChatRequest {
 	if x != nil {
-		return x.Retryable
+		return x.Requests
 	}
-	return false
+	return nil
 }
 
-// AbortedResponse confirms the generation was aborted.
-type AbortedResponse struct {
+// GetBatchRequest identifies a batch job.
+type GetBatchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Reason        string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	ApiKey        string                 `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	BatchId       string                 `protobuf:"bytes,2,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AbortedResponse) Reset() {
-	*x = AbortedResponse{}
-	mi := &file_llm_proto_msgTypes[14]
+func (x *GetBatchRequest) Reset() {
+	*x = GetBatchRequest{}
+	mi := &file_llm_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AbortedResponse) String() string {
+func (x *GetBatchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AbortedResponse) ProtoMessage() {}
+func (*GetBatchRequest) ProtoMessage() {}
 
-func (x *AbortedResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_llm_proto_msgTypes[14]
+func (x *GetBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1090,47 +2758,56 @@ func (x *AbortedResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AbortedResponse.ProtoReflect.Descriptor instead.
-func (*AbortedResponse) Descriptor() ([]byte, []int) {
-	return file_llm_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use GetBatchRequest.ProtoReflect.Descriptor instead.
+func (*GetBatchRequest) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *AbortedResponse) GetReason() string {
+func (x *GetBatchRequest) GetApiKey() string {
 	if x != nil {
-		return x.Reason
+		return x.ApiKey
 	}
 	return ""
 }
 
-// SimpleChatRequest for unary RPC (non-streaming).
-type SimpleChatRequest struct {
+func (x *GetBatchRequest) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+// BatchJob is the status of a batch job.
+type BatchJob struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ApiKey        string                 `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
-	Messages      []*Message             `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"`
-	SystemPrompt  string                 `protobuf:"bytes,3,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
-	Model         string                 `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"`
-	MaxTokens     int32                  `protobuf:"varint,5,opt,name=max_tokens,json=maxTokens,proto3" json:"max_tokens,omitempty"`
-	Temperature   float32                `protobuf:"fixed32,6,opt,name=temperature,proto3" json:"temperature,omitempty"`
-	RequestId     string                 `protobuf:"bytes,7,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // "queued", "running", "completed", "failed", "cancelled", "expired"
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Completed     int32                  `protobuf:"varint,4,opt,name=completed,proto3" json:"completed,omitempty"`
+	Failed        int32                  `protobuf:"varint,5,opt,name=failed,proto3" json:"failed,omitempty"`
+	CostUsd       float64                `protobuf:"fixed64,6,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix seconds
+	EndedAt       int64                  `protobuf:"varint,8,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`       // Unix seconds, 0 while running
+	Error         string                 `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SimpleChatRequest) Reset() {
-	*x = SimpleChatRequest{}
-	mi := &file_llm_proto_msgTypes[15]
+func (x *BatchJob) Reset() {
+	*x = BatchJob{}
+	mi := &file_llm_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SimpleChatRequest) String() string {
+func (x *BatchJob) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SimpleChatRequest) ProtoMessage() {}
+func (*BatchJob) ProtoMessage() {}
 
-func (x *SimpleChatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_llm_proto_msgTypes[15]
+func (x *BatchJob) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1141,89 +2818,100 @@ func (x *SimpleChatRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SimpleChatRequest.ProtoReflect.Descriptor instead.
-func (*SimpleChatRequest) Descriptor() ([]byte, []int) {
-	return file_llm_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use BatchJob.ProtoReflect.Descriptor instead.
+func (*BatchJob) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *SimpleChatRequest) GetApiKey() string {
+func (x *BatchJob) GetId() string {
 	if x != nil {
-		return x.ApiKey
+		return x.Id
 	}
 	return ""
 }
 
-func (x *SimpleChatRequest) GetMessages() []*Message {
+func (x *BatchJob) GetStatus() string {
 	if x != nil {
-		return x.Messages
+		return x.Status
 	}
-	return nil
+	return ""
 }
 
-func (x *SimpleChatRequest) GetSystemPrompt() string {
+func (x *BatchJob) GetTotal() int32 {
 	if x != nil {
-		return x.SystemPrompt
+		return x.Total
 	}
-	return ""
+	return 0
 }
 
-func (x *SimpleChatRequest) GetModel() string {
+func (x *BatchJob) GetCompleted() int32 {
 	if x != nil {
-		return x.Model
+		return x.Completed
 	}
-	return ""
+	return 0
 }
 
-func (x *SimpleChatRequest) GetMaxTokens() int32 {
+func (x *BatchJob) GetFailed() int32 {
 	if x != nil {
-		return x.MaxTokens
+		return x.Failed
 	}
 	return 0
 }
 
-func (x *SimpleChatRequest) GetTemperature() float32 {
+func (x *BatchJob) GetCostUsd() float64 {
 	if x != nil {
-		return x.Temperature
+		return x.CostUsd
 	}
 	return 0
 }
 
-func (x *SimpleChatRequest) GetRequestId() string {
+func (x *BatchJob) GetCreatedAt() int64 {
 	if x != nil {
-		return x.RequestId
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *BatchJob) GetEndedAt() int64 {
+	if x != nil {
+		return x.EndedAt
+	}
+	return 0
+}
+
+func (x *BatchJob) GetError() string {
+	if x != nil {
+		return x.Error
 	}
 	return ""
 }
 
-// SimpleChatResponse for unary RPC.
-type SimpleChatResponse struct {
+// BatchResult is the outcome of one request in a batch.
+type BatchResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
-	Model         string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
-	InputTokens   int64                  `protobuf:"varint,3,opt,name=input_tokens,json=inputTokens,proto3" json:"input_tokens,omitempty"`
-	OutputTokens  int64                  `protobuf:"varint,4,opt,name=output_tokens,json=outputTokens,proto3" json:"output_tokens,omitempty"`
-	CostUsd       float64                `protobuf:"fixed64,5,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
-	LatencyMs     int64                  `protobuf:"varint,6,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
-	StopReason    string                 `protobuf:"bytes,7,opt,name=stop_reason,json=stopReason,proto3" json:"stop_reason,omitempty"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // Position in SubmitBatchRequest.requests
+	RequestId     string                 `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Response      *SimpleChatResponse    `protobuf:"bytes,3,opt,name=response,proto3" json:"response,omitempty"`
+	Error         *ErrorResponse         `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SimpleChatResponse) Reset() {
-	*x = SimpleChatResponse{}
-	mi := &file_llm_proto_msgTypes[16]
+func (x *BatchResult) Reset() {
+	*x = BatchResult{}
+	mi := &file_llm_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SimpleChatResponse) String() string {
+func (x *BatchResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SimpleChatResponse) ProtoMessage() {}
+func (*BatchResult) ProtoMessage() {}
 
-func (x *SimpleChatResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_llm_proto_msgTypes[16]
+func (x *BatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_llm_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1234,72 +2922,53 @@ func (x *SimpleChatResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SimpleChatResponse.ProtoReflect.Descriptor instead.
-func (*SimpleChatResponse) Descriptor() ([]byte, []int) {
-	return file_llm_proto_rawDescGZIP(), []int{16}
-}
-
-func (x *SimpleChatResponse) GetContent() string {
-	if x != nil {
-		return x.Content
-	}
-	return ""
-}
-
-func (x *SimpleChatResponse) GetModel() string {
-	if x != nil {
-		return x.Model
-	}
-	return ""
-}
-
-func (x *SimpleChatResponse) GetInputTokens() int64 {
-	if x != nil {
-		return x.InputTokens
-	}
-	return 0
+// Deprecated: Use BatchResult.ProtoReflect.Descriptor instead.
+func (*BatchResult) Descriptor() ([]byte, []int) {
+	return file_llm_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *SimpleChatResponse) GetOutputTokens() int64 {
+func (x *BatchResult) GetIndex() int32 {
 	if x != nil {
-		return x.OutputTokens
+		return x.Index
 	}
 	return 0
 }
 
-func (x *SimpleChatResponse) GetCostUsd() float64 {
+func (x *BatchResult) GetRequestId() string {
 	if x != nil {
-		return x.CostUsd
+		return x.RequestId
 	}
-	return 0
+	return ""
 }
 
-func (x *SimpleChatResponse) GetLatencyMs() int64 {
+func (x *BatchResult) GetResponse() *SimpleChatResponse {
 	if x != nil {
-		return x.LatencyMs
+		return x.Response
 	}
-	return 0
+	return nil
 }
 
-func (x *SimpleChatResponse) GetStopReason() string {
+func (x *BatchResult) GetError() *ErrorResponse {
 	if x != nil {
-		return x.StopReason
+		return x.Error
 	}
-	return ""
+	return nil
 }
 
 var File_llm_proto protoreflect.FileDescriptor
 
 const file_llm_proto_rawDesc = "" +
 	"\n" +
-	"\tllm.proto\x12\x03llm\"\xd4\x01\n" +
+	"\tllm.proto\x12\x03llm\"\xbd\x02\n" +
 	"\vChatRequest\x12-\n" +
 	"\x05start\x18\x01 \x01(\v2\x15.llm.StartChatRequestH\x00R\x05start\x12,\n" +
 	"\amessage\x18\x02 \x01(\v2\x10.llm.UserMessageH\x00R\amessage\x12)\n" +
 	"\x05abort\x18\x03 \x01(\v2\x11.llm.AbortRequestH\x00R\x05abort\x122\n" +
 	"\vtool_result\x18\x04 \x01(\v2\x0f.llm.ToolResultH\x00R\n" +
-	"toolResultB\t\n" +
-	"\arequest\"\x9b\x02\n" +
+	"toolResult\x12,\n" +
+	"\x06resume\x18\x05 \x01(\v2\x12.llm.ResumeRequestH\x00R\x06resume\x129\n" +
+	"\ftool_results\x18\x06 \x01(\v2\x14.llm.ToolResultBatchH\x00R\vtoolResultsB\t\n" +
+	"\arequest\"\xb0\x03\n" +
 	"\x10StartChatRequest\x12\x17\n" +
 	"\aapi_key\x18\x01 \x01(\tR\x06apiKey\x12#\n" +
 	"\rsystem_prompt\x18\x02 \x01(\tR\fsystemPrompt\x12\x14\n" +
@@ -1310,22 +2979,61 @@ const file_llm_proto_rawDesc = "" +
 	"\bmessages\x18\x06 \x03(\v2\f.llm.MessageR\bmessages\x12)\n" +
 	"\x05tools\x18\a \x03(\v2\x13.llm.ToolDefinitionR\x05tools\x12\x1d\n" +
 	"\n" +
-	"request_id\x18\b \x01(\tR\trequestId\"'\n" +
+	"request_id\x18\b \x01(\tR\trequestId\x12\x1a\n" +
+	"\bprovider\x18\t \x01(\tR\bprovider\x12\x18\n" +
+	"\arouting\x18\n" +
+	" \x01(\tR\arouting\x12-\n" +
+	"\x06params\x18\v \x01(\v2\x15.llm.GenerationParamsR\x06params\x12.\n" +
+	"\x13parallel_tool_calls\x18\f \x01(\bR\x11parallelToolCalls\"m\n" +
 	"\vUserMessage\x12\x18\n" +
-	"\acontent\x18\x01 \x01(\tR\acontent\"&\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\x12\"\n" +
+	"\x06images\x18\x02 \x03(\v2\n" +
+	".llm.ImageR\x06images\x12 \n" +
+	"\x05audio\x18\x03 \x03(\v2\n" +
+	".llm.AudioR\x05audio\"&\n" +
 	"\fAbortRequest\x12\x16\n" +
-	"\x06reason\x18\x01 \x01(\tR\x06reason\"a\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\"q\n" +
+	"\rResumeRequest\x12\x17\n" +
+	"\aapi_key\x18\x01 \x01(\tR\x06apiKey\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12(\n" +
+	"\x10last_chunk_index\x18\x03 \x01(\x05R\x0elastChunkIndex\"a\n" +
 	"\n" +
 	"ToolResult\x12 \n" +
 	"\ftool_call_id\x18\x01 \x01(\tR\n" +
 	"toolCallId\x12\x16\n" +
 	"\x06result\x18\x02 \x01(\tR\x06result\x12\x19\n" +
-	"\bis_error\x18\x03 \x01(\bR\aisError\"e\n" +
+	"\bis_error\x18\x03 \x01(\bR\aisError\"<\n" +
+	"\x0fToolResultBatch\x12)\n" +
+	"\aresults\x18\x01 \x03(\v2\x0f.llm.ToolResultR\aresults\"\x83\x02\n" +
+	"\x10GenerationParams\x12%\n" +
+	"\x0estop_sequences\x18\x01 \x03(\tR\rstopSequences\x12\x13\n" +
+	"\x05top_p\x18\x02 \x01(\x02R\x04topP\x12\x13\n" +
+	"\x05top_k\x18\x03 \x01(\x05R\x04topK\x12+\n" +
+	"\x11frequency_penalty\x18\x04 \x01(\x02R\x10frequencyPenalty\x12)\n" +
+	"\x10presence_penalty\x18\x05 \x01(\x02R\x0fpresencePenalty\x12\x17\n" +
+	"\x04seed\x18\x06 \x01(\x03H\x00R\x04seed\x88\x01\x01\x12$\n" +
+	"\rdeterministic\x18\a \x01(\bR\rdeterministicB\a\n" +
+	"\x05_seed\"\xab\x01\n" +
 	"\aMessage\x12\x12\n" +
 	"\x04role\x18\x01 \x01(\tR\x04role\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\x12,\n" +
 	"\n" +
-	"tool_calls\x18\x03 \x03(\v2\r.llm.ToolCallR\ttoolCalls\"o\n" +
+	"tool_calls\x18\x03 \x03(\v2\r.llm.ToolCallR\ttoolCalls\x12\"\n" +
+	"\x06images\x18\x04 \x03(\v2\n" +
+	".llm.ImageR\x06images\x12 \n" +
+	"\x05audio\x18\x05 \x03(\v2\n" +
+	".llm.AudioR\x05audio\"L\n" +
+	"\x05Image\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x1d\n" +
+	"\n" +
+	"media_type\x18\x03 \x01(\tR\tmediaType\"L\n" +
+	"\x05Audio\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x1d\n" +
+	"\n" +
+	"media_type\x18\x03 \x01(\tR\tmediaType\"o\n" +
 	"\x0eToolDefinition\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12'\n" +
@@ -1333,7 +3041,7 @@ const file_llm_proto_rawDesc = "" +
 	"\bToolCall\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
-	"\x0earguments_json\x18\x03 \x01(\tR\rargumentsJson\"\xd3\x02\n" +
+	"\x0earguments_json\x18\x03 \x01(\tR\rargumentsJson\"\x88\x03\n" +
 	"\fChatResponse\x12>\n" +
 	"\x0fsession_started\x18\x01 \x01(\v2\x13.llm.SessionStartedH\x00R\x0esessionStarted\x12)\n" +
 	"\x05chunk\x18\x02 \x01(\v2\x11.llm.ContentChunkH\x00R\x05chunk\x123\n" +
@@ -1342,22 +3050,33 @@ const file_llm_proto_rawDesc = "" +
 	"completion\x18\x04 \x01(\v2\x17.llm.CompletionResponseH\x00R\n" +
 	"completion\x12*\n" +
 	"\x05error\x18\x05 \x01(\v2\x12.llm.ErrorResponseH\x00R\x05error\x120\n" +
-	"\aaborted\x18\x06 \x01(\v2\x14.llm.AbortedResponseH\x00R\aabortedB\n" +
+	"\aaborted\x18\x06 \x01(\v2\x14.llm.AbortedResponseH\x00R\aaborted\x123\n" +
 	"\n" +
-	"\bresponse\"a\n" +
+	"tool_calls\x18\a \x01(\v2\x12.llm.ToolCallBatchH\x00R\ttoolCallsB\n" +
+	"\n" +
+	"\bresponse\"\xc5\x01\n" +
 	"\x0eSessionStarted\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1a\n" +
 	"\bprovider\x18\x02 \x01(\tR\bprovider\x12\x14\n" +
-	"\x05model\x18\x03 \x01(\tR\x05model\">\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x12\x18\n" +
+	"\aresumed\x18\x04 \x01(\bR\aresumed\x12\x1e\n" +
+	"\n" +
+	"generating\x18\x05 \x01(\bR\n" +
+	"generating\x12(\n" +
+	"\bmessages\x18\x06 \x03(\v2\f.llm.MessageR\bmessages\"\x86\x01\n" +
 	"\fContentChunk\x12\x18\n" +
 	"\acontent\x18\x01 \x01(\tR\acontent\x12\x14\n" +
-	"\x05index\x18\x02 \x01(\x05R\x05index\"n\n" +
+	"\x05index\x18\x02 \x01(\x05R\x05index\x12!\n" +
+	"\finput_tokens\x18\x03 \x01(\x03R\vinputTokens\x12#\n" +
+	"\routput_tokens\x18\x04 \x01(\x03R\foutputTokens\"n\n" +
 	"\x0fToolCallRequest\x12 \n" +
 	"\ftool_call_id\x18\x01 \x01(\tR\n" +
 	"toolCallId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
-	"\x0earguments_json\x18\x03 \x01(\tR\rargumentsJson\"\xda\x01\n" +
+	"\x0earguments_json\x18\x03 \x01(\tR\rargumentsJson\";\n" +
+	"\rToolCallBatch\x12*\n" +
+	"\x05calls\x18\x01 \x03(\v2\x14.llm.ToolCallRequestR\x05calls\"\xbb\x02\n" +
 	"\x12CompletionResponse\x12!\n" +
 	"\ffull_content\x18\x01 \x01(\tR\vfullContent\x12\x1f\n" +
 	"\vstop_reason\x18\x02 \x01(\tR\n" +
@@ -1366,13 +3085,16 @@ const file_llm_proto_rawDesc = "" +
 	"\routput_tokens\x18\x04 \x01(\x03R\foutputTokens\x12\x19\n" +
 	"\bcost_usd\x18\x05 \x01(\x01R\acostUsd\x12\x1d\n" +
 	"\n" +
-	"latency_ms\x18\x06 \x01(\x03R\tlatencyMs\"[\n" +
+	"latency_ms\x18\x06 \x01(\x03R\tlatencyMs\x12\x1a\n" +
+	"\bprovider\x18\a \x01(\tR\bprovider\x12\x14\n" +
+	"\x05model\x18\b \x01(\tR\x05model\x12-\n" +
+	"\x12system_fingerprint\x18\t \x01(\tR\x11systemFingerprint\"[\n" +
 	"\rErrorResponse\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1c\n" +
 	"\tretryable\x18\x03 \x01(\bR\tretryable\")\n" +
 	"\x0fAbortedResponse\x12\x16\n" +
-	"\x06reason\x18\x01 \x01(\tR\x06reason\"\xf1\x01\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\"\xd6\x02\n" +
 	"\x11SimpleChatRequest\x12\x17\n" +
 	"\aapi_key\x18\x01 \x01(\tR\x06apiKey\x12(\n" +
 	"\bmessages\x18\x02 \x03(\v2\f.llm.MessageR\bmessages\x12#\n" +
@@ -1382,7 +3104,11 @@ const file_llm_proto_rawDesc = "" +
 	"max_tokens\x18\x05 \x01(\x05R\tmaxTokens\x12 \n" +
 	"\vtemperature\x18\x06 \x01(\x02R\vtemperature\x12\x1d\n" +
 	"\n" +
-	"request_id\x18\a \x01(\tR\trequestId\"\xe7\x01\n" +
+	"request_id\x18\a \x01(\tR\trequestId\x12\x1a\n" +
+	"\bprovider\x18\b \x01(\tR\bprovider\x12\x18\n" +
+	"\arouting\x18\t \x01(\tR\arouting\x12-\n" +
+	"\x06params\x18\n" +
+	" \x01(\v2\x15.llm.GenerationParamsR\x06params\"\xb2\x02\n" +
 	"\x12SimpleChatResponse\x12\x18\n" +
 	"\acontent\x18\x01 \x01(\tR\acontent\x12\x14\n" +
 	"\x05model\x18\x02 \x01(\tR\x05model\x12!\n" +
@@ -1392,12 +3118,115 @@ const file_llm_proto_rawDesc = "" +
 	"\n" +
 	"latency_ms\x18\x06 \x01(\x03R\tlatencyMs\x12\x1f\n" +
 	"\vstop_reason\x18\a \x01(\tR\n" +
-	"stopReason2|\n" +
+	"stopReason\x12\x1a\n" +
+	"\bprovider\x18\b \x01(\tR\bprovider\x12-\n" +
+	"\x12system_fingerprint\x18\t \x01(\tR\x11systemFingerprint\"t\n" +
+	"\fEmbedRequest\x12\x17\n" +
+	"\aapi_key\x18\x01 \x01(\tR\x06apiKey\x12\x16\n" +
+	"\x06inputs\x18\x02 \x03(\tR\x06inputs\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x04 \x01(\tR\trequestId\"9\n" +
+	"\tEmbedding\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\x02R\x06values\"\xd2\x01\n" +
+	"\rEmbedResponse\x12.\n" +
+	"\n" +
+	"embeddings\x18\x01 \x03(\v2\x0e.llm.EmbeddingR\n" +
+	"embeddings\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x12\x1e\n" +
+	"\n" +
+	"dimensions\x18\x03 \x01(\x05R\n" +
+	"dimensions\x12!\n" +
+	"\finput_tokens\x18\x04 \x01(\x03R\vinputTokens\x12\x19\n" +
+	"\bcost_usd\x18\x05 \x01(\x01R\acostUsd\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x06 \x01(\x03R\tlatencyMs\"\x92\x01\n" +
+	"\x12CountTokensRequest\x12\x17\n" +
+	"\aapi_key\x18\x01 \x01(\tR\x06apiKey\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x12#\n" +
+	"\rsystem_prompt\x18\x03 \x01(\tR\fsystemPrompt\x12(\n" +
+	"\bmessages\x18\x04 \x03(\v2\f.llm.MessageR\bmessages\"N\n" +
+	"\x13CountTokensResponse\x12!\n" +
+	"\finput_tokens\x18\x01 \x01(\x03R\vinputTokens\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\"V\n" +
+	"\x0fModerateRequest\x12\x17\n" +
+	"\aapi_key\x18\x01 \x01(\tR\x06apiKey\x12\x14\n" +
+	"\x05input\x18\x02 \x01(\tR\x05input\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\"X\n" +
+	"\x12ModerationCategory\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x18\n" +
+	"\aflagged\x18\x03 \x01(\bR\aflagged\"{\n" +
+	"\x10ModerateResponse\x12\x18\n" +
+	"\aflagged\x18\x01 \x01(\bR\aflagged\x127\n" +
+	"\n" +
+	"categories\x18\x02 \x03(\v2\x17.llm.ModerationCategoryR\n" +
+	"categories\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\"\x80\x01\n" +
+	"\x11TranscribeRequest\x12\x17\n" +
+	"\aapi_key\x18\x01 \x01(\tR\x06apiKey\x12 \n" +
+	"\x05audio\x18\x02 \x01(\v2\n" +
+	".llm.AudioR\x05audio\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x12\x1a\n" +
+	"\blanguage\x18\x04 \x01(\tR\blanguage\"\x80\x01\n" +
+	"\x12TranscribeResponse\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x1f\n" +
+	"\vduration_ms\x18\x03 \x01(\x03R\n" +
+	"durationMs\x12\x19\n" +
+	"\bcost_usd\x18\x04 \x01(\x01R\acostUsd\"\x84\x01\n" +
+	"\x11SynthesizeRequest\x12\x17\n" +
+	"\aapi_key\x18\x01 \x01(\tR\x06apiKey\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x14\n" +
+	"\x05voice\x18\x03 \x01(\tR\x05voice\x12\x16\n" +
+	"\x06format\x18\x04 \x01(\tR\x06format\x12\x14\n" +
+	"\x05model\x18\x05 \x01(\tR\x05model\"U\n" +
+	"\n" +
+	"AudioChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x1d\n" +
+	"\n" +
+	"media_type\x18\x02 \x01(\tR\tmediaType\x12\x14\n" +
+	"\x05index\x18\x03 \x01(\x05R\x05index\"a\n" +
+	"\x12SubmitBatchRequest\x12\x17\n" +
+	"\aapi_key\x18\x01 \x01(\tR\x06apiKey\x122\n" +
+	"\brequests\x18\x02 \x03(\v2\x16.llm.SimpleChatRequestR\brequests\"E\n" +
+	"\x0fGetBatchRequest\x12\x17\n" +
+	"\aapi_key\x18\x01 \x01(\tR\x06apiKey\x12\x19\n" +
+	"\bbatch_id\x18\x02 \x01(\tR\abatchId\"\xe9\x01\n" +
+	"\bBatchJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x1c\n" +
+	"\tcompleted\x18\x04 \x01(\x05R\tcompleted\x12\x16\n" +
+	"\x06failed\x18\x05 \x01(\x05R\x06failed\x12\x19\n" +
+	"\bcost_usd\x18\x06 \x01(\x01R\acostUsd\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x03R\tcreatedAt\x12\x19\n" +
+	"\bended_at\x18\b \x01(\x03R\aendedAt\x12\x14\n" +
+	"\x05error\x18\t \x01(\tR\x05error\"\xa1\x01\n" +
+	"\vBatchResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\x123\n" +
+	"\bresponse\x18\x03 \x01(\v2\x17.llm.SimpleChatResponseR\bresponse\x12(\n" +
+	"\x05error\x18\x04 \x01(\v2\x12.llm.ErrorResponseR\x05error2\xfb\x04\n" +
 	"\n" +
 	"LLMService\x12/\n" +
 	"\x04Chat\x12\x10.llm.ChatRequest\x1a\x11.llm.ChatResponse(\x010\x01\x12=\n" +
 	"\n" +
-	"SimpleChat\x12\x16.llm.SimpleChatRequest\x1a\x17.llm.SimpleChatResponseB%Z#github.com/almatuck/levee-sdk/llmpbb\x06proto3"
+	"SimpleChat\x12\x16.llm.SimpleChatRequest\x1a\x17.llm.SimpleChatResponse\x12.\n" +
+	"\x05Embed\x12\x11.llm.EmbedRequest\x1a\x12.llm.EmbedResponse\x12@\n" +
+	"\vCountTokens\x12\x17.llm.CountTokensRequest\x1a\x18.llm.CountTokensResponse\x127\n" +
+	"\bModerate\x12\x14.llm.ModerateRequest\x1a\x15.llm.ModerateResponse\x12=\n" +
+	"\n" +
+	"Transcribe\x12\x16.llm.TranscribeRequest\x1a\x17.llm.TranscribeResponse\x127\n" +
+	"\n" +
+	"Synthesize\x12\x16.llm.SynthesizeRequest\x1a\x0f.llm.AudioChunk0\x01\x125\n" +
+	"\vSubmitBatch\x12\x17.llm.SubmitBatchRequest\x1a\r.llm.BatchJob\x12/\n" +
+	"\bGetBatch\x12\x14.llm.GetBatchRequest\x1a\r.llm.BatchJob\x122\n" +
+	"\vCancelBatch\x12\x14.llm.GetBatchRequest\x1a\r.llm.BatchJob\x12>\n" +
+	"\x12StreamBatchResults\x12\x14.llm.GetBatchRequest\x1a\x10.llm.BatchResult0\x01B$Z\"github.com/almatuck/levee-go/llmpbb\x06proto3"
 
 var (
 	file_llm_proto_rawDescOnce sync.Once
@@ -1411,50 +3240,109 @@ func file_llm_proto_rawDescGZIP() []byte {
 	return file_llm_proto_rawDescData
 }
 
-var file_llm_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_llm_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
 var file_llm_proto_goTypes = []any{
-	(*ChatRequest)(nil),        // 0: llm.ChatRequest
-	(*StartChatRequest)(nil),   // 1: llm.StartChatRequest
-	(*UserMessage)(nil),        // 2: llm.UserMessage
-	(*AbortRequest)(nil),       // 3: llm.AbortRequest
-	(*ToolResult)(nil),         // 4: llm.ToolResult
-	(*Message)(nil),            // 5: llm.Message
-	(*ToolDefinition)(nil),     // 6: llm.ToolDefinition
-	(*ToolCall)(nil),           // 7: llm.ToolCall
-	(*ChatResponse)(nil),       // 8: llm.ChatResponse
-	(*SessionStarted)(nil),     // 9: llm.SessionStarted
-	(*ContentChunk)(nil),       // 10: llm.ContentChunk
-	(*ToolCallRequest)(nil),    // 11: llm.ToolCallRequest
-	(*CompletionResponse)(nil), // 12: llm.CompletionResponse
-	(*ErrorResponse)(nil),      // 13: llm.ErrorResponse
-	(*AbortedResponse)(nil),    // 14: llm.AbortedResponse
-	(*SimpleChatRequest)(nil),  // 15: llm.SimpleChatRequest
-	(*SimpleChatResponse)(nil), // 16: llm.SimpleChatResponse
+	(*ChatRequest)(nil),         // 0: llm.ChatRequest
+	(*StartChatRequest)(nil),    // 1: llm.StartChatRequest
+	(*UserMessage)(nil),         // 2: llm.UserMessage
+	(*AbortRequest)(nil),        // 3: llm.AbortRequest
+	(*ResumeRequest)(nil),       // 4: llm.ResumeRequest
+	(*ToolResult)(nil),          // 5: llm.ToolResult
+	(*ToolResultBatch)(nil),     // 6: llm.ToolResultBatch
+	(*GenerationParams)(nil),    // 7: llm.GenerationParams
+	(*Message)(nil),             // 8: llm.Message
+	(*Image)(nil),               // 9: llm.Image
+	(*Audio)(nil),               // 10: llm.Audio
+	(*ToolDefinition)(nil),      // 11: llm.ToolDefinition
+	(*ToolCall)(nil),            // 12: llm.ToolCall
+	(*ChatResponse)(nil),        // 13: llm.ChatResponse
+	(*SessionStarted)(nil),      // 14: llm.SessionStarted
+	(*ContentChunk)(nil),        // 15: llm.ContentChunk
+	(*ToolCallRequest)(nil),     // 16: llm.ToolCallRequest
+	(*ToolCallBatch)(nil),       // 17: llm.ToolCallBatch
+	(*CompletionResponse)(nil),  // 18: llm.CompletionResponse
+	(*ErrorResponse)(nil),       // 19: llm.ErrorResponse
+	(*AbortedResponse)(nil),     // 20: llm.AbortedResponse
+	(*SimpleChatRequest)(nil),   // 21: llm.SimpleChatRequest
+	(*SimpleChatResponse)(nil),  // 22: llm.SimpleChatResponse
+	(*EmbedRequest)(nil),        // 23: llm.EmbedRequest
+	(*Embedding)(nil),           // 24: llm.Embedding
+	(*EmbedResponse)(nil),       // 25: llm.EmbedResponse
+	(*CountTokensRequest)(nil),  // 26: llm.CountTokensRequest
+	(*CountTokensResponse)(nil), // 27: llm.CountTokensResponse
+	(*ModerateRequest)(nil),     // 28: llm.ModerateRequest
+	(*ModerationCategory)(nil),  // 29: llm.ModerationCategory
+	(*ModerateResponse)(nil),    // 30: llm.ModerateResponse
+	(*TranscribeRequest)(nil),   // 31: llm.TranscribeRequest
+	(*TranscribeResponse)(nil),  // 32: llm.TranscribeResponse
+	(*SynthesizeRequest)(nil),   // 33: llm.SynthesizeRequest
+	(*AudioChunk)(nil),          // 34: llm.AudioChunk
+	(*SubmitBatchRequest)(nil),  // 35: llm.SubmitBatchRequest
+	(*GetBatchRequest)(nil),     // 36: llm.GetBatchRequest
+	(*BatchJob)(nil),            // 37: llm.BatchJob
+	(*BatchResult)(nil),         // 38: llm.BatchResult
 }
 var file_llm_proto_depIdxs = []int32{
 	1,  // 0: llm.ChatRequest.start:type_name -> llm.StartChatRequest
 	2,  // 1: llm.ChatRequest.message:type_name -> llm.UserMessage
 	3,  // 2: llm.ChatRequest.abort:type_name -> llm.AbortRequest
-	4,  // 3: llm.ChatRequest.tool_result:type_name -> llm.ToolResult
-	5,  // 4: llm.StartChatRequest.messages:type_name -> llm.Message
-	6,  // 5: llm.StartChatRequest.tools:type_name -> llm.ToolDefinition
-	7,  // 6: llm.Message.tool_calls:type_name -> llm.ToolCall
-	9,  // 7: llm.ChatResponse.session_started:type_name -> llm.SessionStarted
-	10, // 8: llm.ChatResponse.chunk:type_name -> llm.ContentChunk
-	11, // 9: llm.ChatResponse.tool_call:type_name -> llm.ToolCallRequest
-	12, // 10: llm.ChatResponse.completion:type_name -> llm.CompletionResponse
-	13, // 11: llm.ChatResponse.error:type_name -> llm.ErrorResponse
-	14, // 12: llm.ChatResponse.aborted:type_name -> llm.AbortedResponse
-	5,  // 13: llm.SimpleChatRequest.messages:type_name -> llm.Message
-	0,  // 14: llm.LLMService.Chat:input_type -> llm.ChatRequest
-	15, // 15: llm.LLMService.SimpleChat:input_type -> llm.SimpleChatRequest
-	8,  // 16: llm.LLMService.Chat:output_type -> llm.ChatResponse
-	16, // 17: llm.LLMService.SimpleChat:output_type -> llm.SimpleChatResponse
-	16, // [16:18] is the sub-list for method output_type
-	14, // [14:16] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	5,  // 3: llm.ChatRequest.tool_result:type_name -> llm.ToolResult
+	4,  // 4: llm.ChatRequest.resume:type_name -> llm.ResumeRequest
+	6,  // 5: llm.ChatRequest.tool_results:type_name -> llm.ToolResultBatch
+	8,  // 6: llm.StartChatRequest.messages:type_name -> llm.Message
+	11, // 7: llm.StartChatRequest.tools:type_name -> llm.ToolDefinition
+	7,  // 8: llm.StartChatRequest.params:type_name -> llm.GenerationParams
+	9,  // 9: llm.UserMessage.images:type_name -> llm.Image
+	10, // 10: llm.UserMessage.audio:type_name -> llm.Audio
+	5,  // 11: llm.ToolResultBatch.results:type_name -> llm.ToolResult
+	12, // 12: llm.Message.tool_calls:type_name -> llm.ToolCall
+	9,  // 13: llm.Message.images:type_name -> llm.Image
+	10, // 14: llm.Message.audio:type_name -> llm.Audio
+	14, // 15: llm.ChatResponse.session_started:type_name -> llm.SessionStarted
+	15, // 16: llm.ChatResponse.chunk:type_name -> llm.ContentChunk
+	16, // 17: llm.ChatResponse.tool_call:type_name -> llm.ToolCallRequest
+	18, // 18: llm.ChatResponse.completion:type_name -> llm.CompletionResponse
+	19, // 19: llm.ChatResponse.error:type_name -> llm.ErrorResponse
+	20, // 20: llm.ChatResponse.aborted:type_name -> llm.AbortedResponse
+	17, // 21: llm.ChatResponse.tool_calls:type_name -> llm.ToolCallBatch
+	8,  // 22: llm.SessionStarted.messages:type_name -> llm.Message
+	16, // 23: llm.ToolCallBatch.calls:type_name -> llm.ToolCallRequest
+	8,  // 24: llm.SimpleChatRequest.messages:type_name -> llm.Message
+	7,  // 25: llm.SimpleChatRequest.params:type_name -> llm.GenerationParams
+	24, // 26: llm.EmbedResponse.embeddings:type_name -> llm.Embedding
+	8,  // 27: llm.CountTokensRequest.messages:type_name -> llm.Message
+	29, // 28: llm.ModerateResponse.categories:type_name -> llm.ModerationCategory
+	10, // 29: llm.TranscribeRequest.audio:type_name -> llm.Audio
+	21, // 30: llm.SubmitBatchRequest.requests:type_name -> llm.SimpleChatRequest
+	22, // 31: llm.BatchResult.response:type_name -> llm.SimpleChatResponse
+	19, // 32: llm.BatchResult.error:type_name -> llm.ErrorResponse
+	0,  // 33: llm.LLMService.Chat:input_type -> llm.ChatRequest
+	21, // 34: llm.LLMService.SimpleChat:input_type -> llm.SimpleChatRequest
+	23, // 35: llm.LLMService.Embed:input_type -> llm.EmbedRequest
+	26, // 36: llm.LLMService.CountTokens:input_type -> llm.CountTokensRequest
+	28, // 37: llm.LLMService.Moderate:input_type -> llm.ModerateRequest
+	31, // 38: llm.LLMService.Transcribe:input_type -> llm.TranscribeRequest
+	33, // 39: llm.LLMService.Synthesize:input_type -> llm.SynthesizeRequest
+	35, // 40: llm.LLMService.SubmitBatch:input_type -> llm.SubmitBatchRequest
+	36, // 41: llm.LLMService.GetBatch:input_type -> llm.GetBatchRequest
+	36, // 42: llm.LLMService.CancelBatch:input_type -> llm.GetBatchRequest
+	36, // 43: llm.LLMService.StreamBatchResults:input_type -> llm.GetBatchRequest
+	13, // 44: llm.LLMService.Chat:output_type -> llm.ChatResponse
+	22, // 45: llm.LLMService.SimpleChat:output_type -> llm.SimpleChatResponse
+	25, // 46: llm.LLMService.Embed:output_type -> llm.EmbedResponse
+	27, // 47: llm.LLMService.CountTokens:output_type -> llm.CountTokensResponse
+	30, // 48: llm.LLMService.Moderate:output_type -> llm.ModerateResponse
+	32, // 49: llm.LLMService.Transcribe:output_type -> llm.TranscribeResponse
+	34, // 50: llm.LLMService.Synthesize:output_type -> llm.AudioChunk
+	37, // 51: llm.LLMService.SubmitBatch:output_type -> llm.BatchJob
+	37, // 52: llm.LLMService.GetBatch:output_type -> llm.BatchJob
+	37, // 53: llm.LLMService.CancelBatch:output_type -> llm.BatchJob
+	38, // 54: llm.LLMService.StreamBatchResults:output_type -> llm.BatchResult
+	44, // [44:55] is the sub-list for method output_type
+	33, // [33:44] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_llm_proto_init() }
@@ -1467,14 +3355,18 @@ func file_llm_proto_init() {
 		(*ChatRequest_Message)(nil),
 		(*ChatRequest_Abort)(nil),
 		(*ChatRequest_ToolResult)(nil),
+		(*ChatRequest_Resume)(nil),
+		(*ChatRequest_ToolResults)(nil),
 	}
-	file_llm_proto_msgTypes[8].OneofWrappers = []any{
+	file_llm_proto_msgTypes[7].OneofWrappers = []any{}
+	file_llm_proto_msgTypes[13].OneofWrappers = []any{
 		(*ChatResponse_SessionStarted)(nil),
 		(*ChatResponse_Chunk)(nil),
 		(*ChatResponse_ToolCall)(nil),
 		(*ChatResponse_Completion)(nil),
 		(*ChatResponse_Error)(nil),
 		(*ChatResponse_Aborted)(nil),
+		(*ChatResponse_ToolCalls)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1482,7 +3374,7 @@ func file_llm_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_llm_proto_rawDesc), len(file_llm_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   39,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	LLMService_Chat_FullMethodName       = "/llm.LLMService/Chat"
-	LLMService_SimpleChat_FullMethodName = "/llm.LLMService/SimpleChat"
+	LLMService_Chat_FullMethodName               = "/llm.LLMService/Chat"
+	LLMService_SimpleChat_FullMethodName         = "/llm.LLMService/SimpleChat"
+	LLMService_Embed_FullMethodName              = "/llm.LLMService/Embed"
+	LLMService_CountTokens_FullMethodName        = "/llm.LLMService/CountTokens"
+	LLMService_Moderate_FullMethodName           = "/llm.LLMService/Moderate"
+	LLMService_Transcribe_FullMethodName         = "/llm.LLMService/Transcribe"
+	LLMService_Synthesize_FullMethodName         = "/llm.LLMService/Synthesize"
+	LLMService_SubmitBatch_FullMethodName        = "/llm.LLMService/SubmitBatch"
+	LLMService_GetBatch_FullMethodName           = "/llm.LLMService/GetBatch"
+	LLMService_CancelBatch_FullMethodName        = "/llm.LLMService/CancelBatch"
+	LLMService_StreamBatchResults_FullMethodName = "/llm.LLMService/StreamBatchResults"
 )
 
 // LLMServiceClient is the client API for LLMService service.
@@ -35,6 +44,25 @@ type LLMServiceClient interface {
 	Chat(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ChatRequest, ChatResponse], error)
 	// SimpleChat is a unary RPC for simple request/response without streaming.
 	SimpleChat(ctx context.Context, in *SimpleChatRequest, opts ...grpc.CallOption) (*SimpleChatResponse, error)
+	// Embed returns embedding vectors for a batch of texts.
+	Embed(ctx context.Context, in *EmbedRequest, opts ...grpc.CallOption) (*EmbedResponse, error)
+	// CountTokens counts the input tokens of a conversation with the model's tokenizer.
+	CountTokens(ctx context.Context, in *CountTokensRequest, opts ...grpc.CallOption) (*CountTokensResponse, error)
+	// Moderate classifies text against the content policy categories.
+	Moderate(ctx context.Context, in *ModerateRequest, opts ...grpc.CallOption) (*ModerateResponse, error)
+	// Transcribe converts speech to text.
+	Transcribe(ctx context.Context, in *TranscribeRequest, opts ...grpc.CallOption) (*TranscribeResponse, error)
+	// Synthesize converts text to speech, streaming audio as it is generated.
+	Synthesize(ctx context.Context, in *SynthesizeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AudioChunk], error)
+	// SubmitBatch queues chat requests for offline processing at batch pricing.
+	SubmitBatch(ctx context.Context, in *SubmitBatchRequest, opts ...grpc.CallOption) (*BatchJob, error)
+	// GetBatch returns the status of a batch job.
+	GetBatch(ctx context.Context, in *GetBatchRequest, opts ...grpc.CallOption) (*BatchJob, error)
+	// CancelBatch stops a batch job; finished results are kept.
+	CancelBatch(ctx context.Context, in *GetBatchRequest, opts ...grpc.CallOption) (*BatchJob, error)
+	// StreamBatchResults streams the finished results of a batch job, waiting
+	// for the rest until the job ends.
+	StreamBatchResults(ctx context.Context, in *GetBatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BatchResult], error)
 }
 
 type lLMServiceClient struct {
@@ -68,6 +96,114 @@ func (c *lLMServiceClient) SimpleChat(ctx context.Context, in *SimpleChatRequest
 	return out, nil
 }
 
+func (c *lLMServiceClient) Embed(ctx context.Context, in *EmbedRequest, opts ...grpc.CallOption) (*EmbedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmbedResponse)
+	err := c.cc.Invoke(ctx, LLMService_Embed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lLMServiceClient) CountTokens(ctx context.Context, in *CountTokensRequest, opts ...grpc.CallOption) (*CountTokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountTokensResponse)
+	err := c.cc.Invoke(ctx, LLMService_CountTokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lLMServiceClient) Moderate(ctx context.Context, in *ModerateRequest, opts ...grpc.CallOption) (*ModerateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ModerateResponse)
+	err := c.cc.Invoke(ctx, LLMService_Moderate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lLMServiceClient) Transcribe(ctx context.Context, in *TranscribeRequest, opts ...grpc.CallOption) (*TranscribeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TranscribeResponse)
+	err := c.cc.Invoke(ctx, LLMService_Transcribe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lLMServiceClient) Synthesize(ctx context.Context, in *SynthesizeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AudioChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LLMService_ServiceDesc.Streams[1], LLMService_Synthesize_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SynthesizeRequest, AudioChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LLMService_SynthesizeClient = grpc.ServerStreamingClient[AudioChunk]
+
+func (c *lLMServiceClient) SubmitBatch(ctx context.Context, in *SubmitBatchRequest, opts ...grpc.CallOption) (*BatchJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchJob)
+	err := c.cc.Invoke(ctx, LLMService_SubmitBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lLMServiceClient) GetBatch(ctx context.Context, in *GetBatchRequest, opts ...grpc.CallOption) (*BatchJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchJob)
+	err := c.cc.Invoke(ctx, LLMService_GetBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lLMServiceClient) CancelBatch(ctx context.Context, in *GetBatchRequest, opts ...grpc.CallOption) (*BatchJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchJob)
+	err := c.cc.Invoke(ctx, LLMService_CancelBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lLMServiceClient) StreamBatchResults(ctx context.Context, in *GetBatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BatchResult], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LLMService_ServiceDesc.Streams[2], LLMService_StreamBatchResults_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetBatchRequest, BatchResult]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LLMService_StreamBatchResultsClient = grpc.ServerStreamingClient[BatchResult]
+
 // LLMServiceServer is the server API for LLMService service.
 // All implementations must embed UnimplementedLLMServiceServer
 // for forward compatibility.
@@ -80,6 +216,25 @@ type LLMServiceServer interface {
 	Chat(grpc.BidiStreamingServer[ChatRequest, ChatResponse]) error
 	// SimpleChat is a unary RPC for simple request/response without streaming.
 	SimpleChat(context.Context, *SimpleChatRequest) (*SimpleChatResponse, error)
+	// Embed returns embedding vectors for a batch of texts.
+	Embed(context.Context, *EmbedRequest) (*EmbedResponse, error)
+	// CountTokens counts the input tokens of a conversation with the model's tokenizer.
+	CountTokens(context.Context, *CountTokensRequest) (*CountTokensResponse, error)
+	// Moderate classifies text against the content policy categories.
+	Moderate(context.Context, *ModerateRequest) (*ModerateResponse, error)
+	// Transcribe converts speech to text.
+	Transcribe(context.Context, *TranscribeRequest) (*TranscribeResponse, error)
+	// Synthesize converts text to speech, streaming audio as it is generated.
+	Synthesize(*SynthesizeRequest, grpc.ServerStreamingServer[AudioChunk]) error
+	// SubmitBatch queues chat requests for offline processing at batch pricing.
+	SubmitBatch(context.Context, *SubmitBatchRequest) (*BatchJob, error)
+	// GetBatch returns the status of a batch job.
+	GetBatch(context.Context, *GetBatchRequest) (*BatchJob, error)
+	// CancelBatch stops a batch job; finished results are kept.
+	CancelBatch(context.Context, *GetBatchRequest) (*BatchJob, error)
+	// StreamBatchResults streams the finished results of a batch job, waiting
+	// for the rest until the job ends.
+	StreamBatchResults(*GetBatchRequest, grpc.ServerStreamingServer[BatchResult]) error
 	mustEmbedUnimplementedLLMServiceServer()
 }
 
@@ -96,6 +251,33 @@ func (UnimplementedLLMServiceServer) Chat(grpc.BidiStreamingServer[ChatRequest,
 func (UnimplementedLLMServiceServer) SimpleChat(context.Context, *SimpleChatRequest) (*SimpleChatResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SimpleChat not implemented")
 }
+func (UnimplementedLLMServiceServer) Embed(context.Context, *EmbedRequest) (*EmbedResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Embed not implemented")
+}
+func (UnimplementedLLMServiceServer) CountTokens(context.Context, *CountTokensRequest) (*CountTokensResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CountTokens not implemented")
+}
+func (UnimplementedLLMServiceServer) Moderate(context.Context, *ModerateRequest) (*ModerateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Moderate not implemented")
+}
+func (UnimplementedLLMServiceServer) Transcribe(context.Context, *TranscribeRequest) (*TranscribeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Transcribe not implemented")
+}
+func (UnimplementedLLMServiceServer) Synthesize(*SynthesizeRequest, grpc.ServerStreamingServer[AudioChunk]) error {
+	return status.Error(codes.Unimplemented, "method Synthesize not implemented")
+}
+func (UnimplementedLLMServiceServer) SubmitBatch(context.Context, *SubmitBatchRequest) (*BatchJob, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubmitBatch not implemented")
+}
+func (UnimplementedLLMServiceServer) GetBatch(context.Context, *GetBatchRequest) (*BatchJob, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBatch not implemented")
+}
+func (UnimplementedLLMServiceServer) CancelBatch(context.Context, *GetBatchRequest) (*BatchJob, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelBatch not implemented")
+}
+func (UnimplementedLLMServiceServer) StreamBatchResults(*GetBatchRequest, grpc.ServerStreamingServer[BatchResult]) error {
+	return status.Error(codes.Unimplemented, "method StreamBatchResults not implemented")
+}
 func (UnimplementedLLMServiceServer) mustEmbedUnimplementedLLMServiceServer() {}
 func (UnimplementedLLMServiceServer) testEmbeddedByValue()                    {}
 
@@ -142,6 +324,154 @@ func _LLMService_SimpleChat_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LLMService_Embed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmbedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LLMServiceServer).Embed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LLMService_Embed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LLMServiceServer).Embed(ctx, req.(*EmbedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LLMService_CountTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LLMServiceServer).CountTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LLMService_CountTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LLMServiceServer).CountTokens(ctx, req.(*CountTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LLMService_Moderate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LLMServiceServer).Moderate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LLMService_Moderate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LLMServiceServer).Moderate(ctx, req.(*ModerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LLMService_Transcribe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TranscribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LLMServiceServer).Transcribe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LLMService_Transcribe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LLMServiceServer).Transcribe(ctx, req.(*TranscribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LLMService_Synthesize_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SynthesizeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LLMServiceServer).Synthesize(m, &grpc.GenericServerStream[SynthesizeRequest, AudioChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LLMService_SynthesizeServer = grpc.ServerStreamingServer[AudioChunk]
+
+func _LLMService_SubmitBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LLMServiceServer).SubmitBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LLMService_SubmitBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LLMServiceServer).SubmitBatch(ctx, req.(*SubmitBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LLMService_GetBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LLMServiceServer).GetBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LLMService_GetBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LLMServiceServer).GetBatch(ctx, req.(*GetBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LLMService_CancelBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LLMServiceServer).CancelBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LLMService_CancelBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LLMServiceServer).CancelBatch(ctx, req.(*GetBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LLMService_StreamBatchResults_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetBatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LLMServiceServer).StreamBatchResults(m, &grpc.GenericServerStream[GetBatchRequest, BatchResult]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LLMService_StreamBatchResultsServer = grpc.ServerStreamingServer[BatchResult]
+
 // LLMService_ServiceDesc is the grpc.ServiceDesc for LLMService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +483,34 @@ var LLMService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SimpleChat",
 			Handler:    _LLMService_SimpleChat_Handler,
 		},
+		{
+			MethodName: "Embed",
+			Handler:    _LLMService_Embed_Handler,
+		},
+		{
+			MethodName: "CountTokens",
+			Handler:    _LLMService_CountTokens_Handler,
+		},
+		{
+			MethodName: "Moderate",
+			Handler:    _LLMService_Moderate_Handler,
+		},
+		{
+			MethodName: "Transcribe",
+			Handler:    _LLMService_Transcribe_Handler,
+		},
+		{
+			MethodName: "SubmitBatch",
+			Handler:    _LLMService_SubmitBatch_Handler,
+		},
+		{
+			MethodName: "GetBatch",
+			Handler:    _LLMService_GetBatch_Handler,
+		},
+		{
+			MethodName: "CancelBatch",
+			Handler:    _LLMService_CancelBatch_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -161,6 +519,16 @@ var LLMService_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Synthesize",
+			Handler:       _LLMService_Synthesize_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamBatchResults",
+			Handler:       _LLMService_StreamBatchResults_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "llm.proto",
 }
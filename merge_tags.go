@@ -0,0 +1,362 @@
+package levee
+
+import (
+	"fmt"
+	"html"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Merge tag syntax supported by RenderMergeTags, matching Levee's templates:
+//
+//	{{first_name}}                      variable, HTML-escaped
+//	{{{html_block}}}                    variable, not escaped
+//	{{first_name | default: "there"}}   fallback when the variable is missing or empty
+//	{{address.city}}, {{items.0.title}} nested fields and list elements
+//	{{#if plan}}...{{else}}...{{/if}}   conditional block ({{#unless}} inverts it)
+//	{{#each items}}{{title}}{{/each}}   loop; {{this}} is the item, {{@index}} its position
+//	{{! comment }}                      removed from the output
+
+// RenderMergeTags renders the merge tags in text with a contact's fields, locally
+// and without calling the API, so personalization can be unit tested. The
+// variables are those of ContactMergeData. Variables that are missing and have
+// no fallback are reported as a *MissingVariableError, like a real send.
+//
+//	out, err := levee.RenderMergeTags(`Hi {{first_name | default: "there"}}!`, &levee.Contact{Name: "Ada Lovelace"})
+//	// out == "Hi Ada!"
+func RenderMergeTags(text string, contact *Contact) (string, error) {
+	return RenderMergeTagsData(text, ContactMergeData(contact))
+}
+
+// RenderMergeTagsData renders merge tags with arbitrary variables, such as
+// those passed to SendTemplate.
+func RenderMergeTagsData(text string, data map[string]interface{}) (string, error) {
+	return renderMergeText(text, data, true)
+}
+
+// renderMergeText renders merge tags, HTML-escaping {{variables}} if escape is set.
+func renderMergeText(text string, data map[string]interface{}, escape bool) (string, error) {
+	p := &mergeParser{src: text}
+	nodes, end, err := p.parse()
+	if err != nil {
+		return "", err
+	}
+	if end != "" {
+		return "", fmt.Errorf("merge tags: unexpected {{%s}}", end)
+	}
+
+	r := &mergeRenderer{scopes: []mergeScope{{value: reflect.ValueOf(data)}}, escape: escape}
+	r.render(nodes)
+	if len(r.missing) > 0 {
+		return "", &MissingVariableError{Variables: r.missing}
+	}
+	return r.out.String(), nil
+}
+
+// ContactMergeData returns the merge variables Levee provides for a contact:
+// email, name, first_name, last_name, phone, company, tags, lists, and each
+// custom attribute, both at the top level and under "attributes".
+func ContactMergeData(contact *Contact) map[string]interface{} {
+	data := map[string]interface{}{}
+	if contact == nil {
+		return data
+	}
+
+	for name, value := range contact.Attributes {
+		data[name] = value
+	}
+	first, last, _ := strings.Cut(strings.TrimSpace(contact.Name), " ")
+	for name, value := range map[string]string{
+		"email":      contact.Email,
+		"name":       contact.Name,
+		"first_name": first,
+		"last_name":  strings.TrimSpace(last),
+		"phone":      contact.Phone,
+		"company":    contact.Company,
+	} {
+		if value != "" {
+			data[name] = value
+		}
+	}
+	data["tags"] = contact.Tags
+	data["lists"] = contact.Lists
+	data["attributes"] = contact.Attributes
+	return data
+}
+
+// mergeNode is a parsed piece of a template.
+type mergeNode struct {
+	text     string // literal text when kind is ""
+	kind     string // "", "var", "if", "unless", "each"
+	path     string
+	fallback *string
+	raw      bool
+	body     []mergeNode
+	elseBody []mergeNode
+}
+
+// mergeParser parses merge tag templates.
+type mergeParser struct {
+	src string
+	pos int
+}
+
+// parse parses nodes until the end of input or a closing/else tag, which is returned.
+func (p *mergeParser) parse() ([]mergeNode, string, error) {
+	var nodes []mergeNode
+	for p.pos < len(p.src) {
+		start := strings.Index(p.src[p.pos:], "{{")
+		if start < 0 {
+			nodes = append(nodes, mergeNode{text: p.src[p.pos:]})
+			p.pos = len(p.src)
+			break
+		}
+		if start > 0 {
+			nodes = append(nodes, mergeNode{text: p.src[p.pos : p.pos+start]})
+		}
+		p.pos += start
+
+		raw := strings.HasPrefix(p.src[p.pos:], "{{{")
+		open, closer := "{{", "}}"
+		if raw {
+			open, closer = "{{{", "}}}"
+		}
+		end := strings.Index(p.src[p.pos+len(open):], closer)
+		if end < 0 {
+			return nil, "", fmt.Errorf("merge tags: unclosed %s at offset %d", open, p.pos)
+		}
+		tag := strings.TrimSpace(p.src[p.pos+len(open) : p.pos+len(open)+end])
+		p.pos += len(open) + end + len(closer)
+
+		switch {
+		case raw:
+			node, err := parseVarTag(tag)
+			if err != nil {
+				return nil, "", err
+			}
+			node.raw = true
+			nodes = append(nodes, node)
+		case strings.HasPrefix(tag, "!"):
+			// comment
+		case tag == "else" || strings.HasPrefix(tag, "/"):
+			return nodes, tag, nil
+		case strings.HasPrefix(tag, "#"):
+			node, err := p.parseBlock(tag)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, node)
+		default:
+			node, err := parseVarTag(tag)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes, "", nil
+}
+
+// parseBlock parses an {{#if}}, {{#unless}}, or {{#each}} block after its opening tag.
+func (p *mergeParser) parseBlock(tag string) (mergeNode, error) {
+	kind, path, _ := strings.Cut(strings.TrimPrefix(tag, "#"), " ")
+	path = strings.TrimSpace(path)
+	if kind != "if" && kind != "unless" && kind != "each" {
+		return mergeNode{}, fmt.Errorf("merge tags: unknown block {{%s}}", tag)
+	}
+	if path == "" {
+		return mergeNode{}, fmt.Errorf("merge tags: {{%s}} needs a variable", tag)
+	}
+
+	node := mergeNode{kind: kind, path: path}
+	body, end, err := p.parse()
+	if err != nil {
+		return mergeNode{}, err
+	}
+	node.body = body
+	if end == "else" {
+		if node.elseBody, end, err = p.parse(); err != nil {
+			return mergeNode{}, err
+		}
+	}
+	if end != "/"+kind {
+		return mergeNode{}, fmt.Errorf("merge tags: {{%s}} is not closed with {{/%s}}", tag, kind)
+	}
+	return node, nil
+}
+
+// parseVarTag parses "path" or `path | default: "fallback"`.
+func parseVarTag(tag string) (mergeNode, error) {
+	path, filter, hasFilter := strings.Cut(tag, "|")
+	node := mergeNode{kind: "var", path: strings.TrimSpace(path)}
+	if !hasFilter {
+		return node, nil
+	}
+
+	name, arg, _ := strings.Cut(strings.TrimSpace(filter), ":")
+	if strings.TrimSpace(name) != "default" {
+		return mergeNode{}, fmt.Errorf("merge tags: unknown filter %q in {{%s}}", strings.TrimSpace(name), tag)
+	}
+	fallback, err := strconv.Unquote(strings.TrimSpace(arg))
+	if err != nil {
+		return mergeNode{}, fmt.Errorf("merge tags: default value must be quoted in {{%s}}", tag)
+	}
+	node.fallback = &fallback
+	return node, nil
+}
+
+// mergeScope is a value in scope while rendering, with its loop index if any.
+type mergeScope struct {
+	value reflect.Value
+	index int
+}
+
+// mergeRenderer renders parsed nodes against the scope stack.
+type mergeRenderer struct {
+	out     strings.Builder
+	scopes  []mergeScope
+	missing []string
+	escape  bool
+}
+
+func (r *mergeRenderer) render(nodes []mergeNode) {
+	for _, n := range nodes {
+		switch n.kind {
+		case "":
+			r.out.WriteString(n.text)
+		case "var":
+			r.renderVar(n)
+		case "if", "unless":
+			v, _ := r.lookup(n.path)
+			if truthy(v) == (n.kind == "if") {
+				r.render(n.body)
+			} else {
+				r.render(n.elseBody)
+			}
+		case "each":
+			v, _ := r.lookup(n.path)
+			v = indirect(v)
+			if !v.IsValid() || (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) || v.Len() == 0 {
+				r.render(n.elseBody)
+				continue
+			}
+			for i := 0; i < v.Len(); i++ {
+				r.scopes = append(r.scopes, mergeScope{value: v.Index(i), index: i})
+				r.render(n.body)
+				r.scopes = r.scopes[:len(r.scopes)-1]
+			}
+		}
+	}
+}
+
+func (r *mergeRenderer) renderVar(n mergeNode) {
+	v, found := r.lookup(n.path)
+	var s string
+	if found {
+		s = formatMergeValue(v)
+	}
+	if s == "" && n.fallback != nil {
+		s = *n.fallback
+	} else if !found && !slices.Contains(r.missing, n.path) {
+		r.missing = append(r.missing, n.path)
+	}
+
+	if r.escape && !n.raw {
+		s = html.EscapeString(s)
+	}
+	r.out.WriteString(s)
+}
+
+// lookup resolves a dotted path, searching the innermost scope first.
+func (r *mergeRenderer) lookup(path string) (reflect.Value, bool) {
+	top := r.scopes[len(r.scopes)-1]
+	switch path {
+	case "this", ".":
+		return top.value, true
+	case "@index":
+		return reflect.ValueOf(top.index), true
+	}
+	path = strings.TrimPrefix(path, "this.")
+
+	parts := strings.Split(path, ".")
+	for i := len(r.scopes) - 1; i >= 0; i-- {
+		if v, ok := field(r.scopes[i].value, parts[0]); ok {
+			for _, part := range parts[1:] {
+				if v, ok = field(v, part); !ok {
+					return reflect.Value{}, false
+				}
+			}
+			return v, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// field returns the map key or list index name of v.
+func field(v reflect.Value, name string) (reflect.Value, bool) {
+	v = indirect(v)
+	if !v.IsValid() {
+		return reflect.Value{}, false
+	}
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}, false
+		}
+		item := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+		return item, item.IsValid()
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(name)
+		if err != nil || i < 0 || i >= v.Len() {
+			return reflect.Value{}, false
+		}
+		return v.Index(i), true
+	}
+	return reflect.Value{}, false
+}
+
+// indirect unwraps interfaces and pointers.
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// truthy reports whether v counts as true in {{#if}}: not missing, nil, false,
+// zero, or empty.
+func truthy(v reflect.Value) bool {
+	v = indirect(v)
+	if !v.IsValid() {
+		return false
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String:
+		return v.Len() > 0
+	}
+	return !v.IsZero()
+}
+
+// formatMergeValue formats a value for output.
+func formatMergeValue(v reflect.Value) string {
+	v = indirect(v)
+	if !v.IsValid() {
+		return ""
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = formatMergeValue(v.Index(i))
+		}
+		return strings.Join(parts, ", ")
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	}
+	return fmt.Sprint(v.Interface())
+}
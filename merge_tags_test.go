@@ -0,0 +1,128 @@
+package levee
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestRenderMergeTagsData(t *testing.T) {
+	data := map[string]interface{}{
+		"first_name": "Ada",
+		"empty":      "",
+		"html":       "<b>bold</b>",
+		"count":      3,
+		"price":      9.5,
+		"active":     true,
+		"tags":       []string{"vip", "beta"},
+		"address":    map[string]interface{}{"city": "London"},
+		"items": []map[string]interface{}{
+			{"title": "Book", "qty": 1},
+			{"title": "Pen", "qty": 2},
+		},
+		"none": []string{},
+	}
+
+	tests := []struct {
+		name        string
+		text        string
+		want        string
+		wantMissing []string // variables reported by a *MissingVariableError
+		wantErr     bool     // a syntax error
+	}{
+		{name: "plain text", text: "Hello!", want: "Hello!"},
+		{name: "variable", text: "Hi {{first_name}}!", want: "Hi Ada!"},
+		{name: "spaces inside braces", text: "Hi {{ first_name }}!", want: "Hi Ada!"},
+		{name: "escaped", text: "{{html}}", want: "&lt;b&gt;bold&lt;/b&gt;"},
+		{name: "raw", text: "{{{html}}}", want: "<b>bold</b>"},
+		{name: "numbers and booleans", text: "{{count}} {{price}} {{active}}", want: "3 9.5 true"},
+		{name: "list joined", text: "{{tags}}", want: "vip, beta"},
+		{name: "nested field", text: "{{address.city}}", want: "London"},
+		{name: "list element", text: "{{items.1.title}}", want: "Pen"},
+		{name: "default when missing", text: `Hi {{nickname | default: "there"}}`, want: "Hi there"},
+		{name: "default when empty", text: `{{empty | default: "n/a"}}`, want: "n/a"},
+		{name: "default not used when set", text: `{{first_name | default: "there"}}`, want: "Ada"},
+		{name: "default without space", text: `{{nickname|default:"x"}}`, want: "x"},
+		{name: "default is escaped", text: `{{nickname | default: "<i>"}}`, want: "&lt;i&gt;"},
+		{name: "raw default", text: `{{{nickname | default: "<i>"}}}`, want: "<i>"},
+		{name: "empty without default", text: "[{{empty}}]", want: "[]"},
+		{name: "missing", text: "{{nickname}} {{city}} {{nickname}}", wantMissing: []string{"nickname", "city"}},
+		{name: "missing raw", text: "{{{nickname}}}", wantMissing: []string{"nickname"}},
+		{name: "missing nested", text: "{{address.zip}}", wantMissing: []string{"address.zip"}},
+		{name: "if true", text: "{{#if active}}yes{{/if}}", want: "yes"},
+		{name: "if missing", text: "{{#if nickname}}yes{{else}}no{{/if}}", want: "no"},
+		{name: "if empty list", text: "{{#if none}}yes{{else}}no{{/if}}", want: "no"},
+		{name: "unless", text: "{{#unless active}}yes{{else}}no{{/unless}}", want: "no"},
+		{name: "each", text: "{{#each items}}{{@index}}:{{title}}x{{qty}} {{/each}}", want: "0:Bookx1 1:Penx2 "},
+		{name: "each this", text: "{{#each tags}}[{{this}}]{{/each}}", want: "[vip][beta]"},
+		{name: "each reads outer scope", text: "{{#each tags}}{{first_name}}{{/each}}", want: "AdaAda"},
+		{name: "each empty uses else", text: "{{#each none}}x{{else}}none{{/each}}", want: "none"},
+		{name: "nested blocks", text: "{{#each items}}{{#if qty}}{{title}}{{/if}}{{/each}}", want: "BookPen"},
+		{name: "comment", text: "a{{! note }}b", want: "ab"},
+		{name: "unclosed tag", text: "Hi {{first_name", wantErr: true},
+		{name: "unclosed block", text: "{{#if active}}yes", wantErr: true},
+		{name: "mismatched close", text: "{{#if active}}yes{{/each}}", wantErr: true},
+		{name: "stray close", text: "yes{{/if}}", wantErr: true},
+		{name: "unknown block", text: "{{#with address}}{{/with}}", wantErr: true},
+		{name: "block without variable", text: "{{#if}}{{/if}}", wantErr: true},
+		{name: "unknown filter", text: "{{first_name | upper}}", wantErr: true},
+		{name: "unquoted default", text: "{{first_name | default: there}}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderMergeTagsData(tt.text, data)
+
+			var missing *MissingVariableError
+			switch {
+			case tt.wantMissing != nil:
+				if !errors.As(err, &missing) {
+					t.Fatalf("error = %v, want *MissingVariableError", err)
+				}
+				if !slices.Equal(missing.Variables, tt.wantMissing) {
+					t.Errorf("missing = %v, want %v", missing.Variables, tt.wantMissing)
+				}
+			case tt.wantErr:
+				if err == nil || errors.As(err, &missing) {
+					t.Errorf("error = %v, want a syntax error", err)
+				}
+			case err != nil:
+				t.Fatalf("error = %v", err)
+			case got != tt.want:
+				t.Errorf("RenderMergeTagsData(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContactMergeData(t *testing.T) {
+	contact := &Contact{
+		Email:      "ada@example.com",
+		Name:       "Ada King Lovelace",
+		Attributes: map[string]interface{}{"plan": "pro"},
+	}
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"{{email}}", "ada@example.com"},
+		{"{{first_name}}", "Ada"},
+		{"{{last_name}}", "King Lovelace"},
+		{"{{plan}}", "pro"},
+		{"{{attributes.plan}}", "pro"},
+		{`{{company | default: "none"}}`, "none"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := RenderMergeTags(tt.text, contact)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("RenderMergeTags(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
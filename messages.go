@@ -0,0 +1,32 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+)
+
+// Message is a sent email's delivery state with its events timeline.
+type Message struct {
+	EmailStatusResponse
+	// Events is the timeline, oldest first, e.g. "sent", "delivered", "opened".
+	Events []EmailEventInfo
+}
+
+// GetMessage returns a sent email's delivery state and events timeline, e.g.
+// to answer "did this receipt send?" from support tooling. It combines
+// Emails.GetEmailStatus and Emails.ListEmailEvents.
+func (c *Client) GetMessage(ctx context.Context, messageID string) (*Message, error) {
+	if messageID == "" {
+		return nil, fmt.Errorf("message ID is required")
+	}
+
+	status, err := c.Emails.GetEmailStatus(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := c.Emails.ListEmailEvents(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+	return &Message{EmailStatusResponse: *status, Events: events.Events}, nil
+}
@@ -0,0 +1,196 @@
+package levee
+
+import (
+	"bufio"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Metrics receives counters and timings emitted by the SDK.
+// Adapt it to Prometheus, StatsD, OpenTelemetry, etc.; implementations must be
+// safe for concurrent use.
+type Metrics interface {
+	// IncCounter increments the named counter by one.
+	IncCounter(name string, labels map[string]string)
+	// ObserveDuration records a timing for the named histogram.
+	ObserveDuration(name string, labels map[string]string, d time.Duration)
+}
+
+// ValueMetrics is optionally implemented by a Metrics sink to receive
+// counters that grow by more than one, such as LLM token counts and spend.
+type ValueMetrics interface {
+	// AddCounter increments the named counter by value.
+	AddCounter(name string, labels map[string]string, value float64)
+}
+
+// Metric names emitted by the SDK.
+const (
+	// MetricHandlerRequests counts embedded handler requests.
+	// Labels: handler, method, status, bot.
+	MetricHandlerRequests = "levee_handler_requests_total"
+	// MetricHandlerDuration times embedded handler requests. Labels: handler.
+	MetricHandlerDuration = "levee_handler_duration_seconds"
+	// MetricWebhookCallbackErrors counts webhook callbacks that failed after
+	// the delivery was acknowledged. Labels: handler, type.
+	MetricWebhookCallbackErrors = "levee_webhook_callback_errors_total"
+)
+
+// WithLogger sets the structured logger used for handler access logs and
+// background errors (default: discard).
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.state().logger = logger
+	}
+}
+
+// WithMetrics sets the metrics sink for handler counters and timings.
+func WithMetrics(metrics Metrics) ClientOption {
+	return func(c *Client) {
+		c.state().metrics = metrics
+	}
+}
+
+// log returns the configured logger, or one that discards everything.
+func (c *Client) log() *slog.Logger {
+	logger := c.state().logger
+	if logger == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	return logger
+}
+
+// webhookCallbackFailed reports a webhook callback error. The delivery was
+// already acknowledged, so the error is logged and counted instead of retried.
+func (c *Client) webhookCallbackFailed(handler, eventID, eventType string, err error) {
+	c.log().Error("webhook callback failed", "handler", handler, "event_id", eventID, "type", eventType, "error", err)
+	if metrics := c.state().metrics; metrics != nil {
+		metrics.IncCounter(MetricWebhookCallbackErrors, map[string]string{"handler": handler, "type": eventType})
+	}
+}
+
+// handlerNames are the metric and log names of the embedded handlers.
+var handlerNames = map[HandlerSet]string{
+	HandlerOpenTracking:         "open_tracking",
+	HandlerClickTracking:        "click_tracking",
+	HandlerUnsubscribe:          "unsubscribe",
+	HandlerConfirmEmail:         "confirm_email",
+	HandlerStripeWebhook:        "stripe_webhook",
+	HandlerSESWebhook:           "ses_webhook",
+	HandlerLeveeWebhook:         "levee_webhook",
+	HandlerChatWebSocket:        "chat_websocket",
+	HandlerHealth:               "health",
+	HandlerResendConfirmation:   "resend_confirmation",
+	HandlerValidateConfirmToken: "validate_confirm_token",
+	HandlerAMP:                  "amp",
+	HandlerInboundEmail:         "inbound_email",
+	HandlerBillingWebhook:       "billing_webhook",
+}
+
+// String returns the name of a single handler, or the bitmask in hex for combinations.
+func (s HandlerSet) String() string {
+	if name, ok := handlerNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("HandlerSet(%#x)", uint32(s))
+}
+
+// instrument wraps an embedded handler with access logging and metrics.
+// Requests are classified as bots with IsPrefetchRequest. Paths are not logged
+// because they contain tracking tokens.
+func (c *Client) instrument(cfg *HandlerConfig, set HandlerSet, h http.Handler) http.Handler {
+	state := c.state()
+	if state.logger == nil && state.metrics == nil {
+		return h
+	}
+	name := set.String()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w}
+		h.ServeHTTP(rec, r)
+
+		status := rec.statusCode()
+		duration := time.Since(start)
+		bot := IsPrefetchRequest(r)
+
+		if metrics := state.metrics; metrics != nil {
+			metrics.IncCounter(MetricHandlerRequests, map[string]string{
+				"handler": name,
+				"method":  r.Method,
+				"status":  strconv.Itoa(status),
+				"bot":     strconv.FormatBool(bot),
+			})
+			metrics.ObserveDuration(MetricHandlerDuration, map[string]string{"handler": name}, duration)
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+		c.log().LogAttrs(r.Context(), level, "levee handler request",
+			slog.String("handler", name),
+			slog.String("method", r.Method),
+			slog.Int("status", status),
+			slog.Duration("duration", duration),
+			slog.String("ip", cfg.ClientIP(r)),
+			slog.String("user_agent", r.UserAgent()),
+			slog.Bool("bot", bot),
+		)
+	})
+}
+
+// statusRecorder captures the response status for instrumentation.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	if r.status == 0 {
+		r.status = code
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// Flush supports streaming responses.
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack supports the WebSocket upgrade of the chat handler.
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	r.status = http.StatusSwitchingProtocols
+	return h.Hijack()
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+func (r *statusRecorder) statusCode() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
@@ -0,0 +1,119 @@
+package levee
+
+import (
+	"context"
+	"iter"
+)
+
+// Page is one page of a cursor-paginated list.
+type Page[T any] struct {
+	Items []T
+	// NextCursor fetches the following page; empty on the last page.
+	NextCursor string
+	// TotalEstimate is the approximate number of items across all pages (-1 if unknown).
+	TotalEstimate int
+}
+
+// pageFetcher fetches the page starting at cursor (empty for the first page).
+type pageFetcher[T any] func(ctx context.Context, cursor string) (*Page[T], error)
+
+// Iterator walks a cursor-paginated list, fetching pages as needed.
+// It is shared by the SDK's list and search methods.
+//
+//	it := client.SearchContacts(ctx, filter)
+//	for it.Next() {
+//		contact := it.Item()
+//	}
+//	if err := it.Err(); err != nil { ... }
+//
+// Or with range-over-func:
+//
+//	for contact, err := range client.SearchContacts(ctx, filter).All() { ... }
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch pageFetcher[T]
+
+	items   []T
+	index   int
+	cursor  string
+	started bool
+	total   int
+	current T
+	err     error
+}
+
+// newIterator returns an iterator that fetches pages with fetch.
+func newIterator[T any](ctx context.Context, fetch pageFetcher[T]) *Iterator[T] {
+	return &Iterator[T]{ctx: ctx, fetch: fetch, total: -1}
+}
+
+// Next advances to the next item, fetching the next page when the current one is
+// exhausted. It returns false when the list ends or a fetch fails; check Err.
+func (it *Iterator[T]) Next() bool {
+	for it.index >= len(it.items) {
+		if it.err != nil || (it.started && it.cursor == "") {
+			return false
+		}
+		page, err := it.fetch(it.ctx, it.cursor)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if !it.started {
+			it.total = page.TotalEstimate
+		}
+		it.started = true
+		it.items, it.index, it.cursor = page.Items, 0, page.NextCursor
+	}
+
+	it.current = it.items[it.index]
+	it.index++
+	return true
+}
+
+// Item returns the current item.
+func (it *Iterator[T]) Item() T {
+	return it.current
+}
+
+// Err returns the error that stopped iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Total returns the estimated total number of items, or -1 before the first
+// page is fetched or when the API does not report it.
+func (it *Iterator[T]) Total() int {
+	if !it.started && it.err == nil {
+		// Fetch the first page so callers can read the estimate up front
+		if it.Next() {
+			it.index--
+		}
+	}
+	return it.total
+}
+
+// All returns the remaining items as a range-over-func sequence.
+// A fetch error is yielded once as the final element.
+func (it *Iterator[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for it.Next() {
+			if !yield(it.Item(), nil) {
+				return
+			}
+		}
+		if it.err != nil {
+			var zero T
+			yield(zero, it.err)
+		}
+	}
+}
+
+// Collect returns all remaining items, stopping at the first error.
+func (it *Iterator[T]) Collect() ([]T, error) {
+	var items []T
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	return items, it.err
+}
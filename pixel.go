@@ -0,0 +1,83 @@
+package levee
+
+import (
+	"bytes"
+	"net/http"
+	"time"
+)
+
+// PixelFormat is the image format served by the open tracking pixel.
+type PixelFormat string
+
+// Supported tracking pixel formats.
+const (
+	PixelGIF PixelFormat = "gif"
+	PixelPNG PixelFormat = "png"
+	PixelSVG PixelFormat = "svg"
+)
+
+// trackingPixel is a pre-encoded 1x1 transparent image.
+type trackingPixel struct {
+	contentType string
+	etag        string
+	data        []byte
+}
+
+// 1x1 transparent GIF (43 bytes)
+var transparentGIF = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00,
+	0x01, 0x00, 0x80, 0x00, 0x00, 0xff, 0xff, 0xff,
+	0x00, 0x00, 0x00, 0x21, 0xf9, 0x04, 0x01, 0x00,
+	0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44,
+	0x01, 0x00, 0x3b,
+}
+
+// 1x1 transparent PNG (67 bytes)
+var transparentPNG = []byte{
+	0x89, 0x50, 0x4e, 0x47, 0x0d, 0x0a, 0x1a, 0x0a,
+	0x00, 0x00, 0x00, 0x0d, 0x49, 0x48, 0x44, 0x52,
+	0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01,
+	0x08, 0x06, 0x00, 0x00, 0x00, 0x1f, 0x15, 0xc4,
+	0x89, 0x00, 0x00, 0x00, 0x0a, 0x49, 0x44, 0x41,
+	0x54, 0x78, 0x9c, 0x63, 0x00, 0x01, 0x00, 0x00,
+	0x05, 0x00, 0x01, 0x0d, 0x0a, 0x2d, 0xb4, 0x00,
+	0x00, 0x00, 0x00, 0x49, 0x45, 0x4e, 0x44, 0xae,
+	0x42, 0x60, 0x82,
+}
+
+// 1x1 empty SVG
+var transparentSVG = []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="1" height="1"/>`)
+
+var trackingPixels = map[PixelFormat]trackingPixel{
+	PixelGIF: {"image/gif", `"levee-pixel-gif"`, transparentGIF},
+	PixelPNG: {"image/png", `"levee-pixel-png"`, transparentPNG},
+	PixelSVG: {"image/svg+xml", `"levee-pixel-svg"`, transparentSVG},
+}
+
+// pixelModTime is the Last-Modified time of the tracking pixels, which never change.
+var pixelModTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// writePixel serves the tracking pixel in the given format (GIF if unknown).
+// Handles HEAD and conditional requests and sets Content-Length, caching, and
+// security headers so proxies neither cache the pixel nor sniff it as another type.
+func writePixel(format PixelFormat, w http.ResponseWriter, r *http.Request) {
+	pixel, ok := trackingPixels[format]
+	if !ok {
+		pixel = trackingPixels[PixelGIF]
+	}
+
+	h := w.Header()
+	h.Set("Content-Type", pixel.contentType)
+	h.Set("ETag", pixel.etag)
+	h.Set("Cache-Control", "no-store, no-cache, must-revalidate, private")
+	h.Set("Pragma", "no-cache")
+	h.Set("Expires", "0")
+	h.Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+	h.Set("X-Content-Type-Options", "nosniff")
+	h.Set("Referrer-Policy", "no-referrer")
+	h.Set("Cross-Origin-Resource-Policy", "cross-origin")
+
+	// ServeContent sets Content-Length and answers HEAD, If-None-Match, and If-Modified-Since
+	http.ServeContent(w, r, "", pixelModTime, bytes.NewReader(pixel.data))
+}
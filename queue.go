@@ -0,0 +1,183 @@
+package levee
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrQueueFull is returned when an event cannot be queued because the async
+// queue is at capacity (the API is slow or unreachable) or has been closed.
+var ErrQueueFull = errors.New("levee: event queue full or closed")
+
+// Async queue defaults.
+const (
+	defaultQueueCapacity    = 10000
+	defaultQueueBatchSize   = 100
+	defaultQueueFlushPeriod = 2 * time.Second
+	queueFlushTimeout       = 30 * time.Second
+	// maxQueueErrors bounds the delivery errors kept for the next flush, so a
+	// queue that is never flushed doesn't grow them without limit.
+	maxQueueErrors = 16
+)
+
+// asyncQueue buffers items and delivers them in batches from a background worker,
+// flushing when a batch fills up or the flush interval elapses.
+// It backs email tracking events recorded by the handlers and Client.Track.
+type asyncQueue[T any] struct {
+	ch        chan T
+	batchSize int
+	interval  time.Duration
+	send      func(ctx context.Context, batch []T) error
+	onError   func(err error, dropped int)
+
+	mu     sync.RWMutex
+	closed bool
+
+	flushReq chan chan error
+	stop     chan struct{}
+	stopped  chan struct{}
+	// closeErr holds delivery errors since the last flush once stopped is closed.
+	closeErr error
+}
+
+// newAsyncQueue starts a queue worker. send delivers one batch; onError is told
+// about batches that failed.
+func newAsyncQueue[T any](batchSize int, interval time.Duration, send func(context.Context, []T) error, onError func(error, int)) *asyncQueue[T] {
+	if batchSize <= 0 {
+		batchSize = defaultQueueBatchSize
+	}
+	if interval <= 0 {
+		interval = defaultQueueFlushPeriod
+	}
+
+	q := &asyncQueue[T]{
+		ch:        make(chan T, defaultQueueCapacity),
+		batchSize: batchSize,
+		interval:  interval,
+		send:      send,
+		onError:   onError,
+		flushReq:  make(chan chan error),
+		stop:      make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+// enqueue adds an item without blocking. It returns ErrQueueFull if the queue
+// is at capacity or closed.
+func (q *asyncQueue[T]) enqueue(item T) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return ErrQueueFull
+	}
+
+	select {
+	case q.ch <- item:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// flush delivers everything queued so far and waits for it to be sent. It
+// returns the errors of batches that failed since the previous flush.
+func (q *asyncQueue[T]) flush(ctx context.Context) error {
+	done := make(chan error, 1)
+	select {
+	case q.flushReq <- done:
+	case <-q.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// close stops accepting items, flushes the queue, and stops the worker. It
+// returns the errors of batches that failed since the last flush.
+func (q *asyncQueue[T]) close(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.stop)
+	}
+	q.mu.Unlock()
+
+	select {
+	case <-q.stopped:
+		return q.closeErr
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *asyncQueue[T]) run() {
+	defer close(q.stopped)
+
+	ticker := time.NewTicker(q.interval)
+	defer ticker.Stop()
+
+	batch := make([]T, 0, q.batchSize)
+	var errs []error
+	deliver := func() {
+		if len(batch) == 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), queueFlushTimeout)
+		if err := q.send(ctx, batch); err != nil {
+			if len(errs) == maxQueueErrors {
+				errs = errs[1:]
+			}
+			errs = append(errs, err)
+			if q.onError != nil {
+				q.onError(err, len(batch))
+			}
+		}
+		cancel()
+		batch = make([]T, 0, q.batchSize)
+	}
+	drain := func() {
+		for {
+			select {
+			case item := <-q.ch:
+				batch = append(batch, item)
+				if len(batch) >= q.batchSize {
+					deliver()
+				}
+			default:
+				deliver()
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case item := <-q.ch:
+			batch = append(batch, item)
+			if len(batch) >= q.batchSize {
+				deliver()
+			}
+		case <-ticker.C:
+			deliver()
+		case done := <-q.flushReq:
+			drain()
+			done <- errors.Join(errs...)
+			errs = nil
+		case <-q.stop:
+			drain()
+			q.closeErr = errors.Join(errs...)
+			return
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package rag
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Chunker splits documents into overlapping chunks for embedding. Chunks
+// break at sentence and paragraph ends where possible, then at words.
+type Chunker struct {
+	Size    int // maximum characters per chunk (default: 1000)
+	Overlap int // characters of the previous chunk repeated at the start of the next
+}
+
+// DefaultChunker is used by an Index unless WithChunker is given.
+var DefaultChunker = Chunker{Size: 1000, Overlap: 150}
+
+// Split returns the chunks of doc, numbered in order. Chunk IDs are the
+// document ID followed by "#" and the chunk index.
+func (c Chunker) Split(doc Document) []Chunk {
+	texts := c.SplitText(doc.Text)
+	chunks := make([]Chunk, len(texts))
+	for i, text := range texts {
+		chunks[i] = Chunk{
+			ID:         chunkID(doc.ID, i),
+			DocumentID: doc.ID,
+			Index:      i,
+			Text:       text,
+			Metadata:   doc.Metadata,
+		}
+	}
+	return chunks
+}
+
+// SplitText splits text into chunks of at most Size characters.
+func (c Chunker) SplitText(text string) []string {
+	size := c.Size
+	if size <= 0 {
+		size = DefaultChunker.Size
+	}
+	overlap := c.Overlap
+	if overlap < 0 || overlap >= size {
+		overlap = 0
+	}
+
+	var chunks []string
+	var cur []string // segments of the chunk being built
+	var curLen int
+	emit := func() {
+		if chunk := strings.TrimSpace(strings.Join(cur, "")); chunk != "" {
+			chunks = append(chunks, chunk)
+		}
+	}
+	for _, seg := range segments(text, size) {
+		n := utf8.RuneCountInString(seg)
+		if curLen+n > size && len(cur) > 0 {
+			emit()
+			// Carry the trailing segments that fit in the overlap.
+			keep, kept := len(cur), 0
+			for keep > 0 {
+				l := utf8.RuneCountInString(cur[keep-1])
+				if kept+l > overlap || kept+l+n > size {
+					break
+				}
+				kept += l
+				keep--
+			}
+			cur, curLen = append([]string(nil), cur[keep:]...), kept
+		}
+		cur = append(cur, seg)
+		curLen += n
+	}
+	emit()
+	return chunks
+}
+
+// segments splits text into sentences with their trailing whitespace, then
+// splits any longer than size into words, and words into runs of size runes.
+func segments(text string, size int) []string {
+	var segs []string
+	for _, sentence := range splitAfter(text, sentenceEnd) {
+		if utf8.RuneCountInString(sentence) <= size {
+			segs = append(segs, sentence)
+			continue
+		}
+		for _, word := range splitAfter(sentence, wordEnd) {
+			for utf8.RuneCountInString(word) > size {
+				cut := len(string([]rune(word)[:size]))
+				segs = append(segs, word[:cut])
+				word = word[cut:]
+			}
+			segs = append(segs, word)
+		}
+	}
+	return segs
+}
+
+// splitAfter splits text after each whitespace run that ends reports as a
+// boundary, given the rune before it.
+func splitAfter(text string, ends func(prev rune, ws string) bool) []string {
+	var parts []string
+	start := 0
+	var prev rune
+	for i := 0; i < len(text); {
+		r, n := utf8.DecodeRuneInString(text[i:])
+		if !unicode.IsSpace(r) {
+			prev = r
+			i += n
+			continue
+		}
+		j := i
+		for j < len(text) {
+			r, n := utf8.DecodeRuneInString(text[j:])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			j += n
+		}
+		if ends(prev, text[i:j]) {
+			parts = append(parts, text[start:j])
+			start = j
+		}
+		i = j
+	}
+	if start < len(text) {
+		parts = append(parts, text[start:])
+	}
+	return parts
+}
+
+// sentenceEnd reports whether whitespace ends a sentence or paragraph.
+func sentenceEnd(prev rune, ws string) bool {
+	return strings.ContainsRune(ws, '\n') || strings.ContainsRune(".!?", prev)
+}
+
+// wordEnd reports whether whitespace ends a word, which it always does.
+func wordEnd(rune, string) bool {
+	return true
+}
@@ -0,0 +1,115 @@
+package rag
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestChunkerSplitText(t *testing.T) {
+	tests := []struct {
+		name    string
+		chunker Chunker
+		text    string
+		want    []string
+	}{
+		{
+			name:    "short text is one chunk",
+			chunker: Chunker{Size: 100},
+			text:    "Hello there. How are you?",
+			want:    []string{"Hello there. How are you?"},
+		},
+		{
+			name:    "empty text has no chunks",
+			chunker: Chunker{Size: 100},
+			text:    "  \n ",
+		},
+		{
+			name:    "breaks between sentences",
+			chunker: Chunker{Size: 10},
+			text:    "One. Two. Three.",
+			want:    []string{"One. Two.", "Three."},
+		},
+		{
+			name:    "breaks at paragraphs",
+			chunker: Chunker{Size: 14},
+			text:    "Heading\nSome body text",
+			want:    []string{"Heading", "Some body text"},
+		},
+		{
+			name:    "overlap repeats trailing sentences",
+			chunker: Chunker{Size: 8, Overlap: 4},
+			text:    "Aa. Bb. Cc. Dd.",
+			want:    []string{"Aa. Bb.", "Bb. Cc.", "Cc. Dd."},
+		},
+		{
+			name:    "overlap at least size is ignored",
+			chunker: Chunker{Size: 8, Overlap: 8},
+			text:    "Aa. Bb. Cc. Dd.",
+			want:    []string{"Aa. Bb.", "Cc. Dd."},
+		},
+		{
+			name:    "long sentences break at words",
+			chunker: Chunker{Size: 11},
+			text:    "the quick brown fox jumps",
+			want:    []string{"the quick", "brown fox", "jumps"},
+		},
+		{
+			name:    "long words are cut",
+			chunker: Chunker{Size: 4},
+			text:    "abcdefghij",
+			want:    []string{"abcd", "efgh", "ij"},
+		},
+		{
+			name:    "size counts runes, not bytes",
+			chunker: Chunker{Size: 2},
+			text:    "ééééé",
+			want:    []string{"éé", "éé", "é"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.chunker.SplitText(tt.text)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SplitText(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+			for _, chunk := range got {
+				if n := utf8.RuneCountInString(chunk); n > tt.chunker.Size {
+					t.Errorf("chunk %q has %d runes, more than size %d", chunk, n, tt.chunker.Size)
+				}
+			}
+		})
+	}
+}
+
+func TestChunkerDefaultSize(t *testing.T) {
+	text := strings.Repeat("word ", 500)
+	for _, chunk := range (Chunker{}).SplitText(text) {
+		if n := utf8.RuneCountInString(chunk); n > DefaultChunker.Size {
+			t.Errorf("chunk has %d runes, more than the default size %d", n, DefaultChunker.Size)
+		}
+	}
+}
+
+func TestChunkerSplit(t *testing.T) {
+	doc := Document{ID: "faq", Text: "One. Two. Three.", Metadata: map[string]string{"lang": "en"}}
+	chunks := Chunker{Size: 10}.Split(doc)
+
+	want := []struct {
+		id, text string
+	}{
+		{"faq#0", "One. Two."},
+		{"faq#1", "Three."},
+	}
+	if len(chunks) != len(want) {
+		t.Fatalf("got %d chunks, want %d", len(chunks), len(want))
+	}
+	for i, w := range want {
+		c := chunks[i]
+		if c.ID != w.id || c.Text != w.text || c.Index != i || c.DocumentID != doc.ID || c.Metadata["lang"] != "en" {
+			t.Errorf("chunk %d = %+v, want ID %q, text %q", i, c, w.id, w.text)
+		}
+	}
+}
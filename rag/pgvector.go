@@ -0,0 +1,147 @@
+package rag
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PGVectorStore keeps chunks in a Postgres table with a pgvector column and
+// searches it by cosine distance. Create the table with CreateTable or an
+// equivalent migration; the vector extension must be installed.
+type PGVectorStore struct {
+	db    *sql.DB
+	table string
+}
+
+// NewPGVectorStore returns a store using table (e.g. "rag_chunks").
+// table is inserted into queries as is and must be a trusted identifier.
+func NewPGVectorStore(db *sql.DB, table string) *PGVectorStore {
+	return &PGVectorStore{db: db, table: table}
+}
+
+// CreateTable creates the chunk table and its indexes if they don't exist.
+// dimensions is the length of the embedding model's vectors.
+func (s *PGVectorStore) CreateTable(ctx context.Context, dimensions int) error {
+	stmts := []string{
+		"CREATE EXTENSION IF NOT EXISTS vector",
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	id TEXT PRIMARY KEY,
+	document_id TEXT NOT NULL,
+	chunk_index INTEGER NOT NULL,
+	content TEXT NOT NULL,
+	metadata JSONB NOT NULL DEFAULT '{}',
+	embedding vector(%d) NOT NULL
+)`, s.table, dimensions),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_document_idx ON %s (document_id)", s.table, s.table),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_embedding_idx ON %s USING hnsw (embedding vector_cosine_ops)", s.table, s.table),
+	}
+	for _, stmt := range stmts {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to create %s: %w", s.table, err)
+		}
+	}
+	return nil
+}
+
+func (s *PGVectorStore) Upsert(ctx context.Context, chunks ...Chunk) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := s.upsert(ctx, tx, chunks); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Replace deletes a document's chunks and inserts chunks in one transaction.
+func (s *PGVectorStore) Replace(ctx context.Context, documentID string, chunks ...Chunk) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		fmt.Sprintf("DELETE FROM %s WHERE document_id = $1", s.table),
+		documentID); err != nil {
+		return err
+	}
+	if err := s.upsert(ctx, tx, chunks); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// upsert inserts or updates chunks within tx.
+func (s *PGVectorStore) upsert(ctx context.Context, tx *sql.Tx, chunks []Chunk) error {
+	query := fmt.Sprintf(`INSERT INTO %s (id, document_id, chunk_index, content, metadata, embedding)
+VALUES ($1, $2, $3, $4, $5, $6::vector)
+ON CONFLICT (id) DO UPDATE SET document_id = EXCLUDED.document_id, chunk_index = EXCLUDED.chunk_index,
+	content = EXCLUDED.content, metadata = EXCLUDED.metadata, embedding = EXCLUDED.embedding`, s.table)
+	for _, c := range chunks {
+		metadata, err := json.Marshal(c.Metadata)
+		if err != nil {
+			return err
+		}
+		if c.Metadata == nil {
+			metadata = []byte("{}")
+		}
+		if _, err := tx.ExecContext(ctx, query, c.ID, c.DocumentID, c.Index, c.Text, metadata, vectorLiteral(c.Vector)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *PGVectorStore) Search(ctx context.Context, vector []float32, k int) ([]Match, error) {
+	rows, err := s.db.QueryContext(ctx,
+		fmt.Sprintf(`SELECT id, document_id, chunk_index, content, metadata, 1 - (embedding <=> $1::vector)
+FROM %s ORDER BY embedding <=> $1::vector LIMIT $2`, s.table),
+		vectorLiteral(vector), max(k, 0))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var matches []Match
+	for rows.Next() {
+		var m Match
+		var metadata []byte
+		if err := rows.Scan(&m.ID, &m.DocumentID, &m.Index, &m.Text, &metadata, &m.Score); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(metadata, &m.Metadata); err != nil {
+			return nil, fmt.Errorf("invalid metadata of chunk %s: %w", m.ID, err)
+		}
+		matches = append(matches, m)
+	}
+	return matches, rows.Err()
+}
+
+func (s *PGVectorStore) Delete(ctx context.Context, documentID string) error {
+	_, err := s.db.ExecContext(ctx,
+		fmt.Sprintf("DELETE FROM %s WHERE document_id = $1", s.table),
+		documentID)
+	return err
+}
+
+// vectorLiteral formats v in pgvector's text format, e.g. "[0.1,0.2]".
+func vectorLiteral(v []float32) string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, x := range v {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(float64(x), 'g', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
@@ -0,0 +1,287 @@
+// Package rag answers questions from your own documents with Levee's LLM
+// gateway: documents are split into chunks, embedded, and kept in a vector
+// store; at question time the closest chunks are retrieved and passed to the
+// model in the system prompt, with citation metadata returned alongside the
+// reply.
+//
+//	index := rag.New(llm, rag.NewMemoryStore())
+//	err := index.Add(ctx, rag.Document{ID: "refunds", Text: policy, Metadata: map[string]string{"title": "Refund policy"}})
+//	if err != nil {
+//		return err
+//	}
+//	answer, err := index.Chat(ctx, levee.ChatRequest{
+//		Messages: []levee.ChatMessage{{Role: "user", Content: "Can I return an opened item?"}},
+//	})
+//	for _, c := range answer.Citations {
+//		fmt.Printf("[%d] %s (%.2f)\n", c.Number, c.Metadata["title"], c.Score)
+//	}
+package rag
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	levee "github.com/almatuck/levee-go"
+)
+
+// Document is a text to index. Metadata (e.g. title or URL) is kept with each
+// of its chunks and returned in citations.
+type Document struct {
+	ID       string
+	Text     string
+	Metadata map[string]string
+}
+
+// Chunk is a piece of a document, with its embedding once indexed.
+type Chunk struct {
+	ID         string
+	DocumentID string
+	Index      int // position in the document
+	Text       string
+	Metadata   map[string]string
+	Vector     []float32
+}
+
+// Match is a chunk found by a search, with its similarity to the query.
+type Match struct {
+	Chunk
+	Score float64
+}
+
+// Citation is a source given to the model for an answer. The model cites it
+// as [Number].
+type Citation struct {
+	Number     int
+	ChunkID    string
+	DocumentID string
+	Text       string
+	Metadata   map[string]string
+	Score      float64
+	Cited      bool // the reply refers to [Number]
+}
+
+// Answer is a reply grounded in retrieved chunks.
+type Answer struct {
+	*levee.ChatResponse
+	Citations []Citation
+}
+
+// defaultInstructions introduce the retrieved sources in the system prompt.
+const defaultInstructions = "Answer using the numbered sources below. Cite the sources you use by number, like [1]. If they don't contain the answer, say so."
+
+// Index embeds documents into a vector store and answers from them.
+// An Index is safe for concurrent use if its store is.
+type Index struct {
+	llm          *levee.LLMClient
+	store        VectorStore
+	chunker      Chunker
+	model        string
+	topK         int
+	minScore     float64
+	instructions string
+}
+
+// Option configures an Index.
+type Option func(*Index)
+
+// WithChunker sets how documents are split (default: DefaultChunker).
+func WithChunker(c Chunker) Option {
+	return func(x *Index) {
+		x.chunker = c
+	}
+}
+
+// WithEmbeddingModel sets the embedding model (default: the organization's
+// default). Changing it requires re-indexing.
+func WithEmbeddingModel(model string) Option {
+	return func(x *Index) {
+		x.model = model
+	}
+}
+
+// WithTopK sets how many chunks are retrieved per question (default: 4).
+func WithTopK(k int) Option {
+	return func(x *Index) {
+		x.topK = k
+	}
+}
+
+// WithMinScore drops retrieved chunks with a cosine similarity below score.
+func WithMinScore(score float64) Option {
+	return func(x *Index) {
+		x.minScore = score
+	}
+}
+
+// WithInstructions replaces the instructions placed before the sources in the
+// system prompt.
+func WithInstructions(instructions string) Option {
+	return func(x *Index) {
+		x.instructions = instructions
+	}
+}
+
+// New returns an Index that embeds and chats through llm and keeps chunks in
+// store.
+func New(llm *levee.LLMClient, store VectorStore, opts ...Option) *Index {
+	x := &Index{
+		llm:          llm,
+		store:        store,
+		chunker:      DefaultChunker,
+		topK:         4,
+		instructions: defaultInstructions,
+	}
+	for _, opt := range opts {
+		opt(x)
+	}
+	return x
+}
+
+// Add splits, embeds, and stores documents, replacing the chunks of any
+// document already indexed with the same ID. A document's old chunks are
+// only removed once its new ones are embedded, so a failed embedding leaves
+// it indexed as before; stores implementing Replacer swap them atomically.
+func (x *Index) Add(ctx context.Context, docs ...Document) error {
+	for _, doc := range docs {
+		if doc.ID == "" {
+			return fmt.Errorf("document ID is required")
+		}
+		chunks := x.chunker.Split(doc)
+		if len(chunks) > 0 {
+			texts := make([]string, len(chunks))
+			for i, c := range chunks {
+				texts[i] = c.Text
+			}
+			resp, err := x.llm.Embed(ctx, texts, x.model)
+			if err != nil {
+				return fmt.Errorf("failed to embed document %s: %w", doc.ID, err)
+			}
+			for i := range chunks {
+				chunks[i].Vector = resp.Vectors[i]
+			}
+		}
+		if err := x.replace(ctx, doc.ID, chunks); err != nil {
+			return fmt.Errorf("failed to store document %s: %w", doc.ID, err)
+		}
+	}
+	return nil
+}
+
+// replace swaps a document's stored chunks for chunks.
+func (x *Index) replace(ctx context.Context, documentID string, chunks []Chunk) error {
+	if r, ok := x.store.(Replacer); ok {
+		return r.Replace(ctx, documentID, chunks...)
+	}
+	if err := x.store.Delete(ctx, documentID); err != nil {
+		return err
+	}
+	if len(chunks) == 0 {
+		return nil
+	}
+	return x.store.Upsert(ctx, chunks...)
+}
+
+// Remove deletes a document's chunks from the store.
+func (x *Index) Remove(ctx context.Context, documentID string) error {
+	return x.store.Delete(ctx, documentID)
+}
+
+// Retrieve returns the chunks most similar to query, best first.
+func (x *Index) Retrieve(ctx context.Context, query string) ([]Match, error) {
+	resp, err := x.llm.Embed(ctx, []string{query}, x.model)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	matches, err := x.store.Search(ctx, resp.Vectors[0], x.topK)
+	if err != nil {
+		return nil, fmt.Errorf("vector search failed: %w", err)
+	}
+	kept := matches[:0]
+	for _, m := range matches {
+		if m.Score >= x.minScore {
+			kept = append(kept, m)
+		}
+	}
+	return kept, nil
+}
+
+// Chat retrieves the chunks most similar to the last user message of req,
+// adds them to its system prompt as numbered sources, and returns the reply
+// with the sources as citations.
+func (x *Index) Chat(ctx context.Context, req levee.ChatRequest) (*Answer, error) {
+	query := lastUserMessage(req.Messages)
+	if query == "" {
+		return nil, fmt.Errorf("a user message is required")
+	}
+	matches, err := x.Retrieve(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	citations := make([]Citation, len(matches))
+	for i, m := range matches {
+		citations[i] = Citation{
+			Number:     i + 1,
+			ChunkID:    m.ID,
+			DocumentID: m.DocumentID,
+			Text:       m.Text,
+			Metadata:   m.Metadata,
+			Score:      m.Score,
+		}
+	}
+	req.SystemPrompt = x.systemPrompt(req.SystemPrompt, citations)
+
+	resp, err := x.llm.Chat(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range citationRef.FindAllStringSubmatch(resp.Content, -1) {
+		if n, _ := strconv.Atoi(ref[1]); n >= 1 && n <= len(citations) {
+			citations[n-1].Cited = true
+		}
+	}
+	return &Answer{ChatResponse: resp, Citations: citations}, nil
+}
+
+// citationRef matches a source reference such as [2] in a reply.
+var citationRef = regexp.MustCompile(`\[(\d+)\]`)
+
+// systemPrompt appends the instructions and numbered sources to prompt.
+func (x *Index) systemPrompt(prompt string, citations []Citation) string {
+	var b strings.Builder
+	if prompt != "" {
+		b.WriteString(prompt)
+		b.WriteString("\n\n")
+	}
+	b.WriteString(x.instructions)
+	if len(citations) == 0 {
+		b.WriteString("\n\nNo sources were found.")
+	}
+	for _, c := range citations {
+		fmt.Fprintf(&b, "\n\n[%d]", c.Number)
+		if title := c.Metadata["title"]; title != "" {
+			fmt.Fprintf(&b, " %s", title)
+		}
+		b.WriteString("\n")
+		b.WriteString(c.Text)
+	}
+	return b.String()
+}
+
+// lastUserMessage returns the content of the last user message.
+func lastUserMessage(messages []levee.ChatMessage) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "user" {
+			return messages[i].Content
+		}
+	}
+	return ""
+}
+
+// chunkID returns the ID of a document's chunk.
+func chunkID(documentID string, index int) string {
+	return documentID + "#" + strconv.Itoa(index)
+}
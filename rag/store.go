@@ -0,0 +1,98 @@
+package rag
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+
+	levee "github.com/almatuck/levee-go"
+)
+
+// VectorStore holds embedded chunks and finds those nearest a query vector.
+// Implementations must be safe for concurrent use.
+type VectorStore interface {
+	// Upsert adds chunks, replacing any with the same ID.
+	Upsert(ctx context.Context, chunks ...Chunk) error
+	// Search returns up to k chunks most similar to vector, best first, with
+	// their cosine similarity as the score.
+	Search(ctx context.Context, vector []float32, k int) ([]Match, error)
+	// Delete removes every chunk of a document.
+	Delete(ctx context.Context, documentID string) error
+}
+
+// Replacer is implemented by stores that can replace all chunks of a document
+// atomically, so searches never see it half indexed. Index.Add uses it when
+// available and falls back to Delete and Upsert.
+type Replacer interface {
+	// Replace removes every chunk of a document and adds chunks in its place.
+	Replace(ctx context.Context, documentID string, chunks ...Chunk) error
+}
+
+// MemoryStore keeps chunks in process memory and searches them exhaustively,
+// for tests and small corpora.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	chunks map[string]Chunk
+}
+
+// NewMemoryStore returns an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{chunks: make(map[string]Chunk)}
+}
+
+func (s *MemoryStore) Upsert(ctx context.Context, chunks ...Chunk) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range chunks {
+		s.chunks[c.ID] = c
+	}
+	return nil
+}
+
+func (s *MemoryStore) Search(ctx context.Context, vector []float32, k int) ([]Match, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	matches := make([]Match, 0, len(s.chunks))
+	for _, c := range s.chunks {
+		matches = append(matches, Match{Chunk: c, Score: levee.CosineSimilarity(vector, c.Vector)})
+	}
+	slices.SortFunc(matches, func(a, b Match) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.ID, b.ID))
+	})
+	return matches[:min(max(k, 0), len(matches))], nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, documentID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deleteLocked(documentID)
+	return nil
+}
+
+func (s *MemoryStore) Replace(ctx context.Context, documentID string, chunks ...Chunk) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deleteLocked(documentID)
+	for _, c := range chunks {
+		s.chunks[c.ID] = c
+	}
+	return nil
+}
+
+// deleteLocked removes a document's chunks. s.mu must be held.
+func (s *MemoryStore) deleteLocked(documentID string) {
+	for id, c := range s.chunks {
+		if c.DocumentID == documentID {
+			delete(s.chunks, id)
+		}
+	}
+}
+
+// Len returns the number of chunks stored.
+func (s *MemoryStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.chunks)
+}
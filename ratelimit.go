@@ -0,0 +1,63 @@
+package levee
+
+import (
+	"sync"
+	"time"
+)
+
+// maxLimiterKeys bounds the keys a keyedLimiter records per generation.
+const maxLimiterKeys = 10000
+
+// keyedLimiter allows one event per key per interval.
+//
+// Keys are recorded in two generations that rotate every interval, so
+// expired keys are dropped in O(1) without scanning. A key recorded less
+// than an interval ago is always in one of them. A generation that fills
+// up to maxLimiterKeys rotates early, bounding memory under a flood of
+// distinct keys at the cost of forgetting the oldest ones sooner.
+type keyedLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	cur      map[string]time.Time
+	prev     map[string]time.Time
+	rotated  time.Time
+}
+
+// newKeyedLimiter creates a limiter allowing one event per key per interval.
+func newKeyedLimiter(interval time.Duration) *keyedLimiter {
+	return &keyedLimiter{
+		interval: interval,
+		cur:      make(map[string]time.Time),
+		rotated:  time.Now(),
+	}
+}
+
+// allow reports whether an event for key is allowed now, and records it if so.
+func (l *keyedLimiter) allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.rotated) >= l.interval {
+		l.rotate(now)
+	}
+
+	if last, ok := l.cur[key]; ok && now.Sub(last) < l.interval {
+		return false
+	}
+	if last, ok := l.prev[key]; ok && now.Sub(last) < l.interval {
+		return false
+	}
+
+	if len(l.cur) >= maxLimiterKeys {
+		l.rotate(now)
+	}
+	l.cur[key] = now
+	return true
+}
+
+// rotate starts a new generation, dropping the previous one. l.mu must be held.
+func (l *keyedLimiter) rotate(now time.Time) {
+	l.prev, l.cur = l.cur, make(map[string]time.Time, len(l.cur))
+	l.rotated = now
+}
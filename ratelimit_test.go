@@ -0,0 +1,112 @@
+package levee
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestKeyedLimiter(t *testing.T) {
+	type call struct {
+		key string
+		// age backdates the key's recorded events and the last rotation by
+		// this much before the call, standing in for time passing.
+		age  time.Duration
+		want bool
+	}
+	tests := []struct {
+		name  string
+		calls []call
+	}{
+		{
+			name: "first event allowed",
+			calls: []call{
+				{key: "a", want: true},
+			},
+		},
+		{
+			name: "repeat within interval denied",
+			calls: []call{
+				{key: "a", want: true},
+				{key: "a", want: false},
+				{key: "a", want: false},
+			},
+		},
+		{
+			name: "keys are independent",
+			calls: []call{
+				{key: "a", want: true},
+				{key: "b", want: true},
+				{key: "a", want: false},
+				{key: "b", want: false},
+			},
+		},
+		{
+			name: "allowed again after the interval",
+			calls: []call{
+				{key: "a", want: true},
+				{key: "a", age: time.Minute + time.Second, want: true},
+				{key: "a", want: false},
+			},
+		},
+		{
+			name: "still denied just before the interval",
+			calls: []call{
+				{key: "a", want: true},
+				{key: "a", age: time.Minute - time.Second, want: false},
+			},
+		},
+		{
+			name: "remembered across one rotation",
+			calls: []call{
+				{key: "x", want: true},
+				{key: "a", age: 30 * time.Second, want: true},
+				// A minute after the last rotation, this call rotates a,
+				// recorded 40s ago, into the previous generation.
+				{key: "a", age: 40 * time.Second, want: false},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newKeyedLimiter(time.Minute)
+			for i, c := range tt.calls {
+				if c.age > 0 {
+					backdate(l, c.age)
+				}
+				if got := l.allow(c.key); got != c.want {
+					t.Fatalf("call %d: allow(%q) = %v, want %v", i, c.key, got, c.want)
+				}
+			}
+		})
+	}
+}
+
+func TestKeyedLimiterBounded(t *testing.T) {
+	l := newKeyedLimiter(time.Hour)
+	for i := range 3 * maxLimiterKeys {
+		if !l.allow(strconv.Itoa(i)) {
+			t.Fatalf("allow(%d) = false for a new key", i)
+		}
+	}
+	if n := len(l.cur) + len(l.prev); n > 2*maxLimiterKeys {
+		t.Errorf("limiter holds %d keys, want at most %d", n, 2*maxLimiterKeys)
+	}
+	// The most recent keys are still limited after early rotations.
+	if l.allow(strconv.Itoa(3*maxLimiterKeys - 1)) {
+		t.Error("recent key allowed again")
+	}
+}
+
+// backdate moves a limiter's recorded events and last rotation d into the past.
+func backdate(l *keyedLimiter, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, gen := range []map[string]time.Time{l.cur, l.prev} {
+		for k, t := range gen {
+			gen[k] = t.Add(-d)
+		}
+	}
+	l.rotated = l.rotated.Add(-d)
+}
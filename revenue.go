@@ -0,0 +1,132 @@
+package levee
+
+import (
+	"cmp"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// AttributionGroup is the dimension revenue is attributed to.
+type AttributionGroup string
+
+// Revenue attribution groupings.
+const (
+	AttributionByCampaign   AttributionGroup = "campaign"
+	AttributionBySequence   AttributionGroup = "sequence"
+	AttributionByAutomation AttributionGroup = "automation"
+	AttributionByTemplate   AttributionGroup = "template"
+	AttributionByLink       AttributionGroup = "link"
+)
+
+// RevenueAttribution is the revenue attributed to one campaign, sequence,
+// automation, template, or link. Amounts are in the smallest currency unit (e.g. cents).
+type RevenueAttribution struct {
+	// Key is the campaign ID, sequence or template slug, automation ID, or link URL.
+	Key        string `json:"key"`
+	Name       string `json:"name,omitempty"`
+	Recipients int    `json:"recipients"`
+	Clicks     int    `json:"clicks"`
+	// Orders is the number of attributed purchases.
+	Orders  int   `json:"orders"`
+	Revenue int64 `json:"revenue"`
+	// ConversionRate is Orders / Recipients.
+	ConversionRate float64 `json:"conversion_rate"`
+	// RevenuePerRecipient is Revenue / Recipients, in the smallest currency unit.
+	RevenuePerRecipient float64 `json:"revenue_per_recipient"`
+}
+
+// RevenueAttributionReport connects purchases to the email that drove them.
+type RevenueAttributionReport struct {
+	GroupBy AttributionGroup `json:"group_by"`
+	// Model is the account's attribution model, e.g. "last_click".
+	Model string `json:"model"`
+	// WindowDays is how long after a click a purchase is attributed to it.
+	WindowDays int    `json:"window_days"`
+	Currency   string `json:"currency"`
+	// TotalRevenue is all revenue in the range; AttributedRevenue is the part driven by email.
+	TotalRevenue      int64                `json:"total_revenue"`
+	AttributedRevenue int64                `json:"attributed_revenue"`
+	Rows              []RevenueAttribution `json:"rows"`
+}
+
+// GetRevenueAttribution returns the revenue attributed to email over rng,
+// grouped by campaign, sequence, automation, template, or link, so ROI can be
+// reported from your own tools.
+//
+//	report, err := client.GetRevenueAttribution(ctx, levee.LastDays(30), levee.AttributionByCampaign)
+//	for _, row := range report.Top(5) {
+//		log.Printf("%s: $%.2f from %d orders", row.Name, float64(row.Revenue)/100, row.Orders)
+//	}
+func (c *Client) GetRevenueAttribution(ctx context.Context, rng TimeRange, groupBy AttributionGroup) (*RevenueAttributionReport, error) {
+	if groupBy == "" {
+		groupBy = AttributionByCampaign
+	}
+	if !rng.Start.IsZero() && !rng.End.IsZero() && !rng.End.After(rng.Start) {
+		return nil, fmt.Errorf("time range end must be after start")
+	}
+
+	query := url.Values{}
+	query.Set("group_by", string(groupBy))
+	if !rng.Start.IsZero() {
+		query.Set("start_date", rng.Start.UTC().Format(time.RFC3339))
+	}
+	if !rng.End.IsZero() {
+		query.Set("end_date", rng.End.UTC().Format(time.RFC3339))
+	}
+
+	var result RevenueAttributionReport
+	if err := c.do(ctx, http.MethodGet, "/sdk/v1/stats/revenue/attribution", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Top returns the n rows with the most revenue, highest first.
+func (r *RevenueAttributionReport) Top(n int) []RevenueAttribution {
+	rows := slices.Clone(r.Rows)
+	slices.SortStableFunc(rows, func(a, b RevenueAttribution) int {
+		return cmp.Compare(b.Revenue, a.Revenue)
+	})
+	if n >= 0 && n < len(rows) {
+		rows = rows[:n]
+	}
+	return rows
+}
+
+// AttributedShare returns the fraction of total revenue driven by email.
+func (r *RevenueAttributionReport) AttributedShare() float64 {
+	if r.TotalRevenue == 0 {
+		return 0
+	}
+	return float64(r.AttributedRevenue) / float64(r.TotalRevenue)
+}
+
+// WriteCSV writes the rows as CSV with a header, for import into BI tools.
+// Revenue columns are in the smallest currency unit.
+func (r *RevenueAttributionReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"group_by", "key", "name", "recipients", "clicks", "orders", "revenue", "currency", "conversion_rate", "revenue_per_recipient"})
+	for _, row := range r.Rows {
+		cw.Write([]string{
+			string(r.GroupBy),
+			row.Key,
+			row.Name,
+			strconv.Itoa(row.Recipients),
+			strconv.Itoa(row.Clicks),
+			strconv.Itoa(row.Orders),
+			strconv.FormatInt(row.Revenue, 10),
+			r.Currency,
+			strconv.FormatFloat(row.ConversionRate, 'f', -1, 64),
+			strconv.FormatFloat(row.RevenuePerRecipient, 'f', -1, 64),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
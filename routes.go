@@ -0,0 +1,37 @@
+package levee
+
+import "net/http"
+
+// Route is a Levee handler definition for routers that register routes as values.
+// Its fields match go-zero's rest.Route, so a Route converts directly with rest.Route(r).
+type Route struct {
+	Method  string
+	Path    string
+	Handler http.HandlerFunc
+}
+
+// GoZeroRoutes returns the Levee handlers as route definitions for a go-zero server,
+// using ":token" path variables and applying the configured middleware chain.
+// go-zero keeps path variables in the request context rather than r.PathValue,
+// so handlers read tokens from the URL path after the route prefix.
+//
+//	for _, r := range client.GoZeroRoutes("/levee", levee.WithLLMClient(llm)) {
+//		server.AddRoute(rest.Route(r))
+//	}
+func (c *Client) GoZeroRoutes(prefix string, opts ...HandlerOption) []Route {
+	cfg := NewHandlerConfig(opts...)
+
+	routes := c.handlerRoutes(cfg)
+	result := make([]Route, 0, len(routes))
+	for _, rt := range routes {
+		handler := cfg.Wrap(rt.handler).ServeHTTP
+		for _, method := range rt.methods {
+			result = append(result, Route{
+				Method:  method,
+				Path:    prefix + rt.path,
+				Handler: handler,
+			})
+		}
+	}
+	return result
+}
@@ -0,0 +1,195 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RSSFrequency is how often an RSS campaign checks its feed and sends a digest.
+type RSSFrequency string
+
+// RSS campaign frequencies.
+const (
+	RSSDaily   RSSFrequency = "daily"
+	RSSWeekly  RSSFrequency = "weekly"
+	RSSMonthly RSSFrequency = "monthly"
+)
+
+// RSSSchedule is when an RSS campaign sends. A digest is only sent if the
+// feed has at least MinNewItems items published since the previous issue.
+type RSSSchedule struct {
+	Frequency RSSFrequency `json:"frequency"`
+	// DayOfWeek is used by weekly schedules (time.Sunday through time.Saturday).
+	DayOfWeek time.Weekday `json:"day_of_week"`
+	// DayOfMonth is used by monthly schedules (1-28).
+	DayOfMonth int `json:"day_of_month,omitempty"`
+	// Time is the send time as "HH:MM" in Timezone.
+	Time     string `json:"time"`
+	Timezone string `json:"timezone,omitempty"` // IANA name, default UTC
+	// MinNewItems skips an issue with fewer new items (default 1).
+	MinNewItems int `json:"min_new_items,omitempty"`
+}
+
+// RSSCampaign turns new items of a feed, such as a changelog or blog, into
+// recurring email digests.
+type RSSCampaign struct {
+	ID       string           `json:"id,omitempty"`
+	Name     string           `json:"name"`
+	FeedURL  string           `json:"feed_url"`
+	Schedule RSSSchedule      `json:"schedule"`
+	Audience CampaignAudience `json:"audience"`
+	// TemplateSlug renders each issue; it receives the new feed items as {{items}}.
+	TemplateSlug string `json:"template_slug"`
+	// Subject may use merge tags, e.g. "{{items.0.title}} and more".
+	Subject    string `json:"subject"`
+	Status     string `json:"status,omitempty"` // "active", "paused"
+	LastSentAt string `json:"last_sent_at,omitempty"`
+	NextRunAt  string `json:"next_run_at,omitempty"`
+	CreatedAt  string `json:"created_at,omitempty"`
+}
+
+// RSSItem is a feed item included in an issue.
+type RSSItem struct {
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Summary     string `json:"summary,omitempty"`
+	ImageURL    string `json:"image_url,omitempty"`
+	PublishedAt string `json:"published_at,omitempty"`
+}
+
+// RSSIssue is one rendered digest of an RSS campaign.
+type RSSIssue struct {
+	Subject string    `json:"subject"`
+	HTML    string    `json:"html"`
+	Text    string    `json:"text,omitempty"`
+	Items   []RSSItem `json:"items"`
+	// CampaignID is the campaign that sent the issue; empty for a preview not yet sent.
+	CampaignID  string `json:"campaign_id,omitempty"`
+	GeneratedAt string `json:"generated_at"`
+	SentAt      string `json:"sent_at,omitempty"`
+}
+
+// CreateRSSCampaign creates an active RSS-to-email campaign.
+//
+//	rss, err := client.CreateRSSCampaign(ctx, &levee.RSSCampaign{
+//		Name:         "Changelog digest",
+//		FeedURL:      "https://example.com/changelog.xml",
+//		Schedule:     levee.RSSSchedule{Frequency: levee.RSSWeekly, DayOfWeek: time.Monday, Time: "09:00"},
+//		Audience:     levee.CampaignAudience{Lists: []string{"product-updates"}},
+//		TemplateSlug: "changelog-digest",
+//		Subject:      "What's new this week",
+//	})
+func (c *Client) CreateRSSCampaign(ctx context.Context, rss *RSSCampaign) (*RSSCampaign, error) {
+	if rss == nil || rss.FeedURL == "" {
+		return nil, fmt.Errorf("feed url is required")
+	}
+	if rss.TemplateSlug == "" {
+		return nil, fmt.Errorf("template is required")
+	}
+	if err := validateRSSSchedule(rss.Schedule); err != nil {
+		return nil, err
+	}
+
+	var result RSSCampaign
+	if err := c.do(ctx, http.MethodPost, "/sdk/v1/rss-campaigns", nil, rss, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetRSSCampaign returns an RSS campaign.
+func (c *Client) GetRSSCampaign(ctx context.Context, rssID string) (*RSSCampaign, error) {
+	var result RSSCampaign
+	if err := c.do(ctx, http.MethodGet, rssCampaignPath(rssID, ""), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListRSSCampaigns returns all RSS campaigns.
+func (c *Client) ListRSSCampaigns(ctx context.Context) ([]RSSCampaign, error) {
+	var resp struct {
+		Campaigns []RSSCampaign `json:"campaigns"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/sdk/v1/rss-campaigns", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Campaigns, nil
+}
+
+// PauseRSSCampaign stops sending new issues until ResumeRSSCampaign.
+func (c *Client) PauseRSSCampaign(ctx context.Context, rssID string) (*RSSCampaign, error) {
+	return c.rssCampaignAction(ctx, rssID, "pause")
+}
+
+// ResumeRSSCampaign resumes a paused RSS campaign. Items published while it was
+// paused are included in the next issue.
+func (c *Client) ResumeRSSCampaign(ctx context.Context, rssID string) (*RSSCampaign, error) {
+	return c.rssCampaignAction(ctx, rssID, "resume")
+}
+
+// DeleteRSSCampaign deletes an RSS campaign. Issues already sent are kept as campaigns.
+func (c *Client) DeleteRSSCampaign(ctx context.Context, rssID string) error {
+	return c.do(ctx, http.MethodDelete, rssCampaignPath(rssID, ""), nil, nil, nil)
+}
+
+// GetLatestRSSIssue returns the most recently sent issue of an RSS campaign, or
+// with preview set, renders the issue that would be sent next from the current feed.
+func (c *Client) GetLatestRSSIssue(ctx context.Context, rssID string, preview bool) (*RSSIssue, error) {
+	query := url.Values{}
+	if preview {
+		query.Set("preview", "true")
+	}
+
+	var result RSSIssue
+	if err := c.do(ctx, http.MethodGet, rssCampaignPath(rssID, "issues/latest"), query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// rssCampaignAction performs a lifecycle transition and returns the updated campaign.
+func (c *Client) rssCampaignAction(ctx context.Context, rssID, action string) (*RSSCampaign, error) {
+	var result RSSCampaign
+	if err := c.do(ctx, http.MethodPost, rssCampaignPath(rssID, action), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// validateRSSSchedule checks an RSS schedule before it is sent to the API.
+func validateRSSSchedule(s RSSSchedule) error {
+	switch s.Frequency {
+	case RSSDaily, RSSWeekly:
+	case RSSMonthly:
+		if s.DayOfMonth < 1 || s.DayOfMonth > 28 {
+			return fmt.Errorf("monthly schedules need a day of month between 1 and 28")
+		}
+	default:
+		return fmt.Errorf("invalid rss frequency %q", s.Frequency)
+	}
+	if s.Time == "" {
+		return fmt.Errorf("schedule time is required")
+	}
+	if err := validateSchedule("", s.Time); err != nil {
+		return err
+	}
+	if s.Timezone != "" {
+		if _, err := time.LoadLocation(s.Timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", s.Timezone, err)
+		}
+	}
+	return nil
+}
+
+// rssCampaignPath returns the API path of an RSS campaign, or of one of its sub-resources.
+func rssCampaignPath(rssID, sub string) string {
+	path := "/sdk/v1/rss-campaigns/" + url.PathEscape(rssID)
+	if sub != "" {
+		path += "/" + sub
+	}
+	return path
+}
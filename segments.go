@@ -0,0 +1,237 @@
+package levee
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Segment condition operators, in addition to FilterOpEquals, FilterOpContains,
+// FilterOpHasTag, and FilterOpSince.
+const (
+	FilterOpNotEquals     = "neq"
+	FilterOpGreaterThan   = "gt"
+	FilterOpLessThan      = "lt"
+	FilterOpExists        = "exists"
+	FilterOpInList        = "in_list"
+	FilterOpOpenedWithin  = "opened_within"
+	FilterOpClickedWithin = "clicked_within"
+	FilterOpAnd           = "and"
+	FilterOpOr            = "or"
+	FilterOpNot           = "not"
+)
+
+// Condition is a segment condition built with the Go filter DSL:
+//
+//	cond := levee.Attr("plan").Eq("pro").
+//		And(levee.OpenedWithin(30 * 24 * time.Hour)).
+//		And(levee.Not(levee.Tagged("churned")))
+//
+// Leaf conditions compare a contact field; And, Or, and Not combine them.
+type Condition struct {
+	Field      string
+	Op         string
+	Value      interface{}
+	Conditions []Condition
+}
+
+// MarshalJSON encodes leaves as {"field", "op", "value"} and groups as {"op", "conditions"}.
+func (c Condition) MarshalJSON() ([]byte, error) {
+	if len(c.Conditions) > 0 {
+		return json.Marshal(struct {
+			Op         string      `json:"op"`
+			Conditions []Condition `json:"conditions"`
+		}{c.Op, c.Conditions})
+	}
+	return json.Marshal(struct {
+		Field string      `json:"field,omitempty"`
+		Op    string      `json:"op"`
+		Value interface{} `json:"value"`
+	}{c.Field, c.Op, c.Value})
+}
+
+// UnmarshalJSON decodes conditions returned by the API.
+func (c *Condition) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Field      string      `json:"field"`
+		Op         string      `json:"op"`
+		Value      interface{} `json:"value"`
+		Conditions []Condition `json:"conditions"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*c = Condition{Field: raw.Field, Op: raw.Op, Value: raw.Value, Conditions: raw.Conditions}
+	return nil
+}
+
+// And matches when c and all others match.
+func (c Condition) And(others ...Condition) Condition {
+	return combine(FilterOpAnd, c, others)
+}
+
+// Or matches when c or any of others match.
+func (c Condition) Or(others ...Condition) Condition {
+	return combine(FilterOpOr, c, others)
+}
+
+// combine flattens nested groups of the same operator, so a.And(b).And(c)
+// produces one "and" group.
+func combine(op string, first Condition, others []Condition) Condition {
+	group := Condition{Op: op}
+	for _, cond := range append([]Condition{first}, others...) {
+		if cond.Op == op && len(cond.Conditions) > 0 {
+			group.Conditions = append(group.Conditions, cond.Conditions...)
+		} else {
+			group.Conditions = append(group.Conditions, cond)
+		}
+	}
+	return group
+}
+
+// Not matches contacts that do not match c.
+func Not(c Condition) Condition {
+	return Condition{Op: FilterOpNot, Conditions: []Condition{c}}
+}
+
+// AttrRef refers to a contact field in a condition; see Attr and Field.
+type AttrRef struct {
+	field string
+}
+
+// Attr refers to a custom contact attribute.
+func Attr(name string) AttrRef {
+	return AttrRef{field: "attributes." + name}
+}
+
+// Field refers to a built-in contact field (email, name, company, status, source).
+func Field(name string) AttrRef {
+	return AttrRef{field: name}
+}
+
+// Eq matches when the field equals value.
+func (a AttrRef) Eq(value interface{}) Condition {
+	return Condition{Field: a.field, Op: FilterOpEquals, Value: value}
+}
+
+// Neq matches when the field does not equal value.
+func (a AttrRef) Neq(value interface{}) Condition {
+	return Condition{Field: a.field, Op: FilterOpNotEquals, Value: value}
+}
+
+// Contains matches when the field contains substr (case-insensitive).
+func (a AttrRef) Contains(substr string) Condition {
+	return Condition{Field: a.field, Op: FilterOpContains, Value: substr}
+}
+
+// Gt matches when the field is greater than value.
+func (a AttrRef) Gt(value interface{}) Condition {
+	return Condition{Field: a.field, Op: FilterOpGreaterThan, Value: value}
+}
+
+// Lt matches when the field is less than value.
+func (a AttrRef) Lt(value interface{}) Condition {
+	return Condition{Field: a.field, Op: FilterOpLessThan, Value: value}
+}
+
+// Exists matches when the field is set.
+func (a AttrRef) Exists() Condition {
+	return Condition{Field: a.field, Op: FilterOpExists, Value: true}
+}
+
+// Tagged matches contacts carrying tag.
+func Tagged(tag string) Condition {
+	return Condition{Field: "tags", Op: FilterOpHasTag, Value: tag}
+}
+
+// InList matches contacts subscribed to the list with the given slug.
+func InList(slug string) Condition {
+	return Condition{Field: "lists", Op: FilterOpInList, Value: slug}
+}
+
+// OpenedWithin matches contacts who opened an email within d.
+func OpenedWithin(d time.Duration) Condition {
+	return Condition{Field: "last_open_at", Op: FilterOpOpenedWithin, Value: int64(d.Seconds())}
+}
+
+// ClickedWithin matches contacts who clicked an email within d.
+func ClickedWithin(d time.Duration) Condition {
+	return Condition{Field: "last_click_at", Op: FilterOpClickedWithin, Value: int64(d.Seconds())}
+}
+
+// Segment is a saved, dynamically evaluated group of contacts.
+type Segment struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description,omitempty"`
+	Conditions   Condition `json:"conditions"`
+	ContactCount int       `json:"contact_count"`
+	CreatedAt    string    `json:"created_at,omitempty"`
+	UpdatedAt    string    `json:"updated_at,omitempty"`
+}
+
+// CreateSegment saves a segment of the contacts matching cond.
+func (c *Client) CreateSegment(ctx context.Context, name string, cond Condition) (*Segment, error) {
+	if name == "" {
+		return nil, fmt.Errorf("segment name is required")
+	}
+
+	body := struct {
+		Name       string    `json:"name"`
+		Conditions Condition `json:"conditions"`
+	}{name, cond}
+
+	var result Segment
+	err := c.do(ctx, http.MethodPost, "/sdk/v1/segments", nil, body, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SegmentPreview is the match count and a sample of the contacts a condition selects.
+type SegmentPreview struct {
+	Count  int       `json:"count"`
+	Sample []Contact `json:"sample"`
+}
+
+// PreviewSegment evaluates cond without saving a segment.
+func (c *Client) PreviewSegment(ctx context.Context, cond Condition) (*SegmentPreview, error) {
+	var result SegmentPreview
+	body := struct {
+		Conditions Condition `json:"conditions"`
+	}{cond}
+	if err := c.do(ctx, http.MethodPost, "/sdk/v1/segments/preview", nil, body, &result); err != nil {
+		return nil, err
+	}
+	for i := range result.Sample {
+		c.decodeAttributes(ctx, &result.Sample[i])
+	}
+	return &result, nil
+}
+
+// segmentMembersPageSize is the number of members fetched per request.
+const segmentMembersPageSize = 100
+
+// ListSegmentMembers returns an iterator over the contacts currently in a segment.
+func (c *Client) ListSegmentMembers(ctx context.Context, segmentID string) *Iterator[Contact] {
+	path := fmt.Sprintf("/sdk/v1/segments/%s/members", url.PathEscape(segmentID))
+
+	return newIterator(ctx, func(ctx context.Context, cursor string) (*Page[Contact], error) {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(segmentMembersPageSize))
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+
+		var resp searchContactsResponse
+		if err := c.do(ctx, http.MethodGet, path, query, nil, &resp); err != nil {
+			return nil, err
+		}
+		return c.contactPage(ctx, &resp), nil
+	})
+}
@@ -0,0 +1,67 @@
+package levee
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestConditionJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		cond Condition
+		want string
+	}{
+		{"attribute", Attr("plan").Eq("pro"), `{"field":"attributes.plan","op":"eq","value":"pro"}`},
+		{"field", Field("company").Contains("acme"), `{"field":"company","op":"contains","value":"acme"}`},
+		{"not equals", Field("status").Neq("bounced"), `{"field":"status","op":"neq","value":"bounced"}`},
+		{"greater than", Attr("orders").Gt(3), `{"field":"attributes.orders","op":"gt","value":3}`},
+		{"exists", Attr("phone").Exists(), `{"field":"attributes.phone","op":"exists","value":true}`},
+		{"tagged", Tagged("vip"), `{"field":"tags","op":"has_tag","value":"vip"}`},
+		{"in list", InList("news"), `{"field":"lists","op":"in_list","value":"news"}`},
+		{"opened within", OpenedWithin(24 * time.Hour), `{"field":"last_open_at","op":"opened_within","value":86400}`},
+		{
+			"not",
+			Not(Tagged("churned")),
+			`{"op":"not","conditions":[{"field":"tags","op":"has_tag","value":"churned"}]}`,
+		},
+		{
+			"and chain is flattened",
+			Tagged("a").And(Tagged("b")).And(Tagged("c")),
+			`{"op":"and","conditions":[` +
+				`{"field":"tags","op":"has_tag","value":"a"},` +
+				`{"field":"tags","op":"has_tag","value":"b"},` +
+				`{"field":"tags","op":"has_tag","value":"c"}]}`,
+		},
+		{
+			"or inside and is kept",
+			Tagged("a").And(Tagged("b").Or(Tagged("c"))),
+			`{"op":"and","conditions":[` +
+				`{"field":"tags","op":"has_tag","value":"a"},` +
+				`{"op":"or","conditions":[` +
+				`{"field":"tags","op":"has_tag","value":"b"},` +
+				`{"field":"tags","op":"has_tag","value":"c"}]}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.cond)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("json = %s\nwant %s", got, tt.want)
+			}
+
+			var back Condition
+			if err := json.Unmarshal(got, &back); err != nil {
+				t.Fatal(err)
+			}
+			again, _ := json.Marshal(back)
+			if string(again) != string(got) {
+				t.Errorf("round trip = %s, want %s", again, got)
+			}
+		})
+	}
+}
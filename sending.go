@@ -0,0 +1,453 @@
+package levee
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// reservedEmailHeaders are set by Levee from the request fields and cannot be overridden.
+var reservedEmailHeaders = map[string]bool{
+	"From":                      true,
+	"To":                        true,
+	"Cc":                        true,
+	"Bcc":                       true,
+	"Subject":                   true,
+	"Reply-To":                  true,
+	"Date":                      true,
+	"Message-Id":                true,
+	"Mime-Version":              true,
+	"Content-Type":              true,
+	"Content-Transfer-Encoding": true,
+}
+
+// ReservedEmailHeader reports whether Levee sets the header from the request
+// fields, so it cannot be passed in OutgoingEmail.Headers.
+func ReservedEmailHeader(name string) bool {
+	return reservedEmailHeaders[textproto.CanonicalMIMEHeaderKey(name)]
+}
+
+// OutgoingEmail is a transactional email for SendEmail: the generated
+// SendEmailRequest plus the headers, scheduling, and attachments it lacks.
+type OutgoingEmail struct {
+	SendEmailRequest
+
+	// Headers adds custom headers such as "X-Entity-Ref-ID"; address,
+	// subject, and MIME headers are set by Levee.
+	Headers map[string]string `json:"headers,omitempty"`
+	// Cc adds carbon-copy recipients, who receive the same message and are
+	// listed in its Cc header.
+	Cc []string `json:"cc,omitempty"`
+	// SendAt (RFC 3339) or DeliverAtLocalTime ("09:00" in the recipient's
+	// timezone) schedules the send.
+	SendAt             string `json:"send_at,omitempty"`
+	DeliverAtLocalTime string `json:"deliver_at_local_time,omitempty"`
+	// Attachments are read and sent with the message (see Attachment for inline images).
+	Attachments []Attachment `json:"-"`
+}
+
+// SendEmail sends a transactional email and returns its message ID.
+// The email must have a recipient and either a TemplateSlug or a Subject with
+// Body (HTML), TextBody, or Attachments. Scheduled sends can be cancelled with
+// CancelScheduledSend.
+//
+//	id, err := client.SendEmail(ctx, &levee.OutgoingEmail{
+//		SendEmailRequest: levee.SendEmailRequest{
+//			To:        "user@example.com",
+//			FromEmail: "orders@example.com",
+//			Subject:   "Your order has shipped",
+//			Body:      "<p>Your order is on its way.</p>",
+//		},
+//		Headers: map[string]string{"X-Order-ID": "12345"},
+//	})
+func (c *Client) SendEmail(ctx context.Context, req *OutgoingEmail) (string, error) {
+	if err := validateOutgoingEmail(req); err != nil {
+		return "", err
+	}
+
+	var resp *SendEmailResponse
+	var err error
+	if len(req.Attachments) > 0 {
+		resp, err = c.sendWithAttachments(ctx, req)
+	} else {
+		resp = new(SendEmailResponse)
+		err = c.do(ctx, http.MethodPost, "/sdk/v1/emails/", nil, req, resp)
+	}
+	if err != nil {
+		return "", err
+	}
+	if !resp.Success {
+		return "", fmt.Errorf("send failed: %s", resp.Message)
+	}
+	return resp.MessageID, nil
+}
+
+// validateOutgoingEmail checks an email before it reaches the API.
+func validateOutgoingEmail(req *OutgoingEmail) error {
+	if req == nil {
+		return fmt.Errorf("send request is required")
+	}
+	if strings.TrimSpace(req.To) == "" {
+		return fmt.Errorf("recipient is required")
+	}
+	if req.TemplateSlug == "" {
+		if req.Subject == "" {
+			return fmt.Errorf("subject is required without a template")
+		}
+		if req.Body == "" && req.TextBody == "" && len(req.Attachments) == 0 {
+			return fmt.Errorf("body, text body, or attachments are required without a template")
+		}
+	}
+
+	if err := validateSchedule(req.SendAt, req.DeliverAtLocalTime); err != nil {
+		return err
+	}
+
+	for name, value := range req.Headers {
+		canonical := textproto.CanonicalMIMEHeaderKey(name)
+		if !validHeaderName(name) {
+			return fmt.Errorf("invalid header name %q", name)
+		}
+		if reservedEmailHeaders[canonical] {
+			return fmt.Errorf("header %q cannot be set directly", canonical)
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf("header %q contains a line break", canonical)
+		}
+	}
+	return nil
+}
+
+// validateSchedule checks the scheduling fields of a send request.
+func validateSchedule(sendAt, deliverAtLocalTime string) error {
+	if sendAt != "" && deliverAtLocalTime != "" {
+		return fmt.Errorf("send at and deliver at local time are mutually exclusive")
+	}
+	if sendAt != "" {
+		if _, err := time.Parse(time.RFC3339, sendAt); err != nil {
+			return fmt.Errorf("send at must be an RFC 3339 timestamp: %w", err)
+		}
+	}
+	if deliverAtLocalTime != "" {
+		if _, err := time.Parse("15:04", deliverAtLocalTime); err != nil {
+			return fmt.Errorf("deliver at local time must be HH:MM: %w", err)
+		}
+	}
+	return nil
+}
+
+// validHeaderName reports whether name is a valid RFC 5322 header field name.
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if r < '!' || r > '~' || r == ':' {
+			return false
+		}
+	}
+	return true
+}
+
+// sendTemplateRequest is the body of /sdk/v1/emails/template.
+type sendTemplateRequest struct {
+	TemplateSlug       string                 `json:"template_slug"`
+	To                 string                 `json:"to"`
+	Variables          map[string]interface{} `json:"variables,omitempty"`
+	SendAt             string                 `json:"send_at,omitempty"`
+	DeliverAtLocalTime string                 `json:"deliver_at_local_time,omitempty"`
+	PromoCode          *promoCodeVariable     `json:"promo_code,omitempty"`
+}
+
+// SendOption configures a SendTemplate call.
+type SendOption func(*sendTemplateRequest)
+
+// WithSendAt schedules the send for t.
+func WithSendAt(t time.Time) SendOption {
+	return func(r *sendTemplateRequest) {
+		r.SendAt = t.UTC().Format(time.RFC3339)
+	}
+}
+
+// WithDeliverAtLocalTime schedules the send for the next occurrence of hhmm
+// (24-hour "HH:MM") in the recipient's timezone.
+func WithDeliverAtLocalTime(hhmm string) SendOption {
+	return func(r *sendTemplateRequest) {
+		r.DeliverAtLocalTime = hhmm
+	}
+}
+
+// Template send error codes returned by the API.
+const (
+	errCodeTemplateNotFound = "template_not_found"
+	errCodeMissingVariables = "missing_variables"
+)
+
+// UnknownTemplateError is returned by SendTemplate when the template does not exist.
+type UnknownTemplateError struct {
+	Template string
+	Err      *APIError
+}
+
+func (e *UnknownTemplateError) Error() string {
+	return fmt.Sprintf("unknown template %q", e.Template)
+}
+
+func (e *UnknownTemplateError) Unwrap() error { return e.Err }
+
+// MissingVariableError is returned by SendTemplate and RenderMergeTags when the
+// template references variables that were not provided. Err is nil for local renders.
+type MissingVariableError struct {
+	Template  string
+	Variables []string
+	Err       *APIError
+}
+
+func (e *MissingVariableError) Error() string {
+	if e.Template == "" {
+		return "missing variables: " + strings.Join(e.Variables, ", ")
+	}
+	return fmt.Sprintf("template %q is missing variables: %s", e.Template, strings.Join(e.Variables, ", "))
+}
+
+func (e *MissingVariableError) Unwrap() error { return e.Err }
+
+// SendTemplate sends a template rendered server-side with the recipient's variables
+// and returns the message ID. Unlike SendEmailRequest.Variables, values may be any
+// JSON type, so templates can loop over lists and read nested objects.
+// Rendering failures are returned as *UnknownTemplateError or *MissingVariableError.
+// Pass WithSendAt or WithDeliverAtLocalTime to schedule the send.
+//
+//	id, err := client.SendTemplate(ctx, "order-shipped", "user@example.com", map[string]any{
+//		"name":  "Ada",
+//		"items": []map[string]any{{"title": "Book", "qty": 1}},
+//	})
+//	var missing *levee.MissingVariableError
+//	if errors.As(err, &missing) {
+//		log.Printf("add variables %v", missing.Variables)
+//	}
+func (c *Client) SendTemplate(ctx context.Context, templateSlug, to string, variables map[string]interface{}, opts ...SendOption) (string, error) {
+	if templateSlug == "" {
+		return "", fmt.Errorf("template is required")
+	}
+	if strings.TrimSpace(to) == "" {
+		return "", fmt.Errorf("recipient is required")
+	}
+
+	req := &sendTemplateRequest{
+		TemplateSlug: templateSlug,
+		To:           to,
+		Variables:    variables,
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+	if err := validateSchedule(req.SendAt, req.DeliverAtLocalTime); err != nil {
+		return "", err
+	}
+
+	var result SendEmailResponse
+	err := c.do(ctx, http.MethodPost, "/sdk/v1/emails/template", nil, req, &result)
+	if err != nil {
+		return "", templateSendError(templateSlug, err)
+	}
+	if !result.Success {
+		return "", fmt.Errorf("send failed: %s", result.Message)
+	}
+	return result.MessageID, nil
+}
+
+// templateSendError converts template rendering API errors into typed errors.
+// templateSlug is empty for raw content, which is never an unknown template.
+func templateSendError(templateSlug string, err error) error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	switch {
+	case apiErr.Code == errCodeMissingVariables:
+		var details struct {
+			Missing []string `json:"missing"`
+		}
+		json.Unmarshal(apiErr.Details, &details)
+		return &MissingVariableError{Template: templateSlug, Variables: details.Missing, Err: apiErr}
+	case templateSlug != "" && (apiErr.Code == errCodeTemplateNotFound ||
+		apiErr.Code == "" && apiErr.StatusCode == http.StatusNotFound):
+		return &UnknownTemplateError{Template: templateSlug, Err: apiErr}
+	}
+	return err
+}
+
+// MaxBatchSize is the most recipients sent per batch API call.
+// SendBatch splits larger batches into chunks of this size.
+const MaxBatchSize = 500
+
+// BatchRecipient is one recipient of a batch send with its own personalization.
+type BatchRecipient struct {
+	To        string                 `json:"to"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+	Meta      map[string]string      `json:"meta,omitempty"`
+}
+
+// BatchSendRequest sends the same template or content to many recipients.
+type BatchSendRequest struct {
+	TemplateSlug string            `json:"template_slug,omitempty"`
+	Subject      string            `json:"subject,omitempty"`
+	Body         string            `json:"body,omitempty"`
+	TextBody     string            `json:"text_body,omitempty"`
+	FromName     string            `json:"from_name,omitempty"`
+	FromEmail    string            `json:"from_email,omitempty"`
+	ReplyTo      string            `json:"reply_to,omitempty"`
+	Tags         []string          `json:"tags,omitempty"`
+	Headers      map[string]string `json:"headers,omitempty"`
+	Recipients   []BatchRecipient  `json:"recipients"`
+	// SendAt (RFC 3339) or DeliverAtLocalTime ("HH:MM" per recipient timezone) schedules the batch.
+	SendAt             string `json:"send_at,omitempty"`
+	DeliverAtLocalTime string `json:"deliver_at_local_time,omitempty"`
+	// ChunkSize overrides the number of recipients per API call (default and max: MaxBatchSize).
+	ChunkSize int `json:"-"`
+}
+
+// Batch recipient statuses.
+const (
+	BatchStatusQueued = "queued"
+	BatchStatusFailed = "failed"
+)
+
+// BatchSendStatus is the outcome for one recipient of a batch send.
+type BatchSendStatus struct {
+	To        string `json:"to"`
+	MessageID string `json:"message_id,omitempty"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+}
+
+// BatchSendResult reports per-recipient outcomes in the order of the request recipients.
+type BatchSendResult struct {
+	Statuses []BatchSendStatus
+	Sent     int
+	Failed   int
+}
+
+// batchSendResponse is the wire format of /sdk/v1/emails/batch.
+type batchSendResponse struct {
+	Results []BatchSendStatus `json:"results"`
+}
+
+// SendBatch sends to every recipient, splitting the batch into chunks of at most
+// MaxBatchSize. Failures are reported per recipient rather than aborting the batch:
+// if a chunk request fails, its recipients are marked failed and the next chunk is
+// sent. An error is returned only for an invalid request or a cancelled context;
+// on cancellation the result still covers every recipient, those not yet sent
+// marked failed with the context error.
+//
+//	result, err := client.SendBatch(ctx, &levee.BatchSendRequest{
+//		TemplateSlug: "weekly-digest",
+//		Recipients: []levee.BatchRecipient{
+//			{To: "a@example.com", Variables: map[string]any{"name": "Ada"}},
+//			{To: "b@example.com", Variables: map[string]any{"name": "Bob"}},
+//		},
+//	})
+//	for _, s := range result.Statuses {
+//		if s.Status == levee.BatchStatusFailed {
+//			log.Printf("%s: %s", s.To, s.Error)
+//		}
+//	}
+func (c *Client) SendBatch(ctx context.Context, req *BatchSendRequest) (*BatchSendResult, error) {
+	if err := validateBatchSendRequest(req); err != nil {
+		return nil, err
+	}
+
+	size := req.ChunkSize
+	if size <= 0 || size > MaxBatchSize {
+		size = MaxBatchSize
+	}
+
+	result := &BatchSendResult{Statuses: make([]BatchSendStatus, 0, len(req.Recipients))}
+	var ctxErr error
+	for start := 0; start < len(req.Recipients); start += size {
+		if ctxErr = ctx.Err(); ctxErr != nil {
+			for _, r := range req.Recipients[start:] {
+				result.Statuses = append(result.Statuses, BatchSendStatus{To: r.To, Status: BatchStatusFailed, Error: ctxErr.Error()})
+			}
+			break
+		}
+
+		chunk := *req
+		chunk.Recipients = req.Recipients[start:min(start+size, len(req.Recipients))]
+		result.Statuses = append(result.Statuses, c.sendBatchChunk(ctx, &chunk)...)
+	}
+
+	for _, s := range result.Statuses {
+		if s.Status == BatchStatusFailed {
+			result.Failed++
+		} else {
+			result.Sent++
+		}
+	}
+	return result, ctxErr
+}
+
+// sendBatchChunk sends one chunk and returns a status for each of its recipients.
+func (c *Client) sendBatchChunk(ctx context.Context, chunk *BatchSendRequest) []BatchSendStatus {
+	statuses := make([]BatchSendStatus, len(chunk.Recipients))
+
+	var resp batchSendResponse
+	err := c.do(ctx, http.MethodPost, "/sdk/v1/emails/batch", nil, chunk, &resp)
+	if err == nil && len(resp.Results) != len(chunk.Recipients) {
+		err = fmt.Errorf("batch response has %d results for %d recipients", len(resp.Results), len(chunk.Recipients))
+	}
+	if err != nil {
+		for i, r := range chunk.Recipients {
+			statuses[i] = BatchSendStatus{To: r.To, Status: BatchStatusFailed, Error: err.Error()}
+		}
+		return statuses
+	}
+
+	copy(statuses, resp.Results)
+	return statuses
+}
+
+// validateBatchSendRequest checks a batch request before any chunk is sent.
+func validateBatchSendRequest(req *BatchSendRequest) error {
+	if req == nil {
+		return fmt.Errorf("batch request is required")
+	}
+	if len(req.Recipients) == 0 {
+		return fmt.Errorf("at least one recipient is required")
+	}
+	for i, r := range req.Recipients {
+		if strings.TrimSpace(r.To) == "" {
+			return fmt.Errorf("recipient %d has no address", i)
+		}
+	}
+	return validateOutgoingEmail(&OutgoingEmail{
+		SendEmailRequest: SendEmailRequest{
+			To:           req.Recipients[0].To,
+			TemplateSlug: req.TemplateSlug,
+			Subject:      req.Subject,
+			Body:         req.Body,
+			TextBody:     req.TextBody,
+		},
+		Headers:            req.Headers,
+		SendAt:             req.SendAt,
+		DeliverAtLocalTime: req.DeliverAtLocalTime,
+	})
+}
+
+// CancelScheduledSend cancels a send scheduled with SendAt or DeliverAtLocalTime.
+// It fails if the message has already been sent.
+func (c *Client) CancelScheduledSend(ctx context.Context, messageID string) error {
+	if messageID == "" {
+		return fmt.Errorf("message ID is required")
+	}
+	path := fmt.Sprintf("/sdk/v1/emails/%s/cancel", url.PathEscape(messageID))
+	return c.do(ctx, http.MethodPost, path, nil, nil, nil)
+}
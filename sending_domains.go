@@ -0,0 +1,152 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DomainStatus is the verification state of a sending domain.
+type DomainStatus string
+
+// Sending domain statuses.
+const (
+	DomainPending  DomainStatus = "pending"
+	DomainVerified DomainStatus = "verified"
+	DomainFailed   DomainStatus = "failed"
+)
+
+// DNSRecord is a DNS record to publish for a sending domain.
+type DNSRecord struct {
+	// Purpose is "dkim", "spf", "return_path", or "dmarc".
+	Purpose  string `json:"purpose"`
+	Type     string `json:"type"` // "TXT", "CNAME", "MX"
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Priority int    `json:"priority,omitempty"`
+	// Valid reports whether the record was found at the last verification.
+	Valid bool `json:"valid"`
+}
+
+// SendingDomain is a domain Levee sends mail from.
+type SendingDomain struct {
+	ID                 string       `json:"id"`
+	Domain             string       `json:"domain"`
+	Status             DomainStatus `json:"status"`
+	DKIMVerified       bool         `json:"dkim_verified"`
+	SPFVerified        bool         `json:"spf_verified"`
+	ReturnPathVerified bool         `json:"return_path_verified"`
+	Records            []DNSRecord  `json:"records,omitempty"`
+	CreatedAt          string       `json:"created_at,omitempty"`
+	VerifiedAt         string       `json:"verified_at,omitempty"`
+	LastCheckedAt      string       `json:"last_checked_at,omitempty"`
+	Error              string       `json:"error,omitempty"`
+}
+
+// CreateSendingDomain adds a sending domain and generates its DKIM key. The
+// returned Records are the DNS records to publish before calling VerifyDomain.
+func (c *Client) CreateSendingDomain(ctx context.Context, domain string) (*SendingDomain, error) {
+	if domain == "" {
+		return nil, fmt.Errorf("domain is required")
+	}
+
+	var result SendingDomain
+	body := map[string]string{"domain": domain}
+	if err := c.do(ctx, http.MethodPost, "/sdk/v1/domains", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetSendingDomain returns a sending domain and its records.
+func (c *Client) GetSendingDomain(ctx context.Context, domain string) (*SendingDomain, error) {
+	var result SendingDomain
+	if err := c.do(ctx, http.MethodGet, domainPath(domain, ""), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetDomainDNSRecords returns the DKIM, SPF, return-path, and DMARC records
+// to publish for a sending domain, for provisioning with infrastructure-as-code.
+func (c *Client) GetDomainDNSRecords(ctx context.Context, domain string) ([]DNSRecord, error) {
+	var resp struct {
+		Records []DNSRecord `json:"records"`
+	}
+	if err := c.do(ctx, http.MethodGet, domainPath(domain, "dns"), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Records, nil
+}
+
+// VerifyDomain asks Levee to check a sending domain's DNS records now and
+// returns the updated status.
+func (c *Client) VerifyDomain(ctx context.Context, domain string) (*SendingDomain, error) {
+	var result SendingDomain
+	if err := c.do(ctx, http.MethodPost, domainPath(domain, "verify"), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// defaultPollInterval is the polling interval of the Wait helpers when the
+// interval given is not positive.
+const defaultPollInterval = 10 * time.Second
+
+// WaitForDomainVerification calls VerifyDomain every interval (default 10s)
+// until the domain is verified or ctx is done, since DNS changes can take a
+// while to propagate.
+//
+//	ctx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+//	defer cancel()
+//	d, err := client.WaitForDomainVerification(ctx, "mail.example.com", time.Minute)
+func (c *Client) WaitForDomainVerification(ctx context.Context, domain string, interval time.Duration) (*SendingDomain, error) {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		d, err := c.VerifyDomain(ctx, domain)
+		if err != nil {
+			return nil, err
+		}
+		if d.Status == DomainVerified {
+			return d, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return d, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// ListDomains returns all sending domains.
+func (c *Client) ListDomains(ctx context.Context) ([]SendingDomain, error) {
+	var resp struct {
+		Domains []SendingDomain `json:"domains"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/sdk/v1/domains", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Domains, nil
+}
+
+// DeleteSendingDomain removes a sending domain. Mail can no longer be sent from it.
+func (c *Client) DeleteSendingDomain(ctx context.Context, domain string) error {
+	return c.do(ctx, http.MethodDelete, domainPath(domain, ""), nil, nil, nil)
+}
+
+// domainPath returns the API path of a sending domain, or of one of its sub-resources.
+func domainPath(domain, sub string) string {
+	path := "/sdk/v1/domains/" + url.PathEscape(domain)
+	if sub != "" {
+		path += "/" + sub
+	}
+	return path
+}
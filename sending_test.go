@@ -0,0 +1,143 @@
+package levee
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidateOutgoingEmail(t *testing.T) {
+	valid := func(edit func(*OutgoingEmail)) *OutgoingEmail {
+		req := &OutgoingEmail{SendEmailRequest: SendEmailRequest{
+			To:      "user@example.com",
+			Subject: "Hello",
+			Body:    "<p>Hi</p>",
+		}}
+		edit(req)
+		return req
+	}
+
+	tests := []struct {
+		name string
+		req  *OutgoingEmail
+		ok   bool
+	}{
+		{"valid", valid(func(*OutgoingEmail) {}), true},
+		{"nil", nil, false},
+		{"no recipient", valid(func(e *OutgoingEmail) { e.To = " " }), false},
+		{"template without subject", valid(func(e *OutgoingEmail) { e.TemplateSlug, e.Subject, e.Body = "welcome", "", "" }), true},
+		{"no subject", valid(func(e *OutgoingEmail) { e.Subject = "" }), false},
+		{"text body only", valid(func(e *OutgoingEmail) { e.Body, e.TextBody = "", "Hi" }), true},
+		{"no body", valid(func(e *OutgoingEmail) { e.Body = "" }), false},
+		{"attachment only", valid(func(e *OutgoingEmail) { e.Body, e.Attachments = "", []Attachment{{Filename: "a.pdf"}} }), true},
+		{"send at", valid(func(e *OutgoingEmail) { e.SendAt = "2026-11-02T09:00:00Z" }), true},
+		{"bad send at", valid(func(e *OutgoingEmail) { e.SendAt = "tomorrow" }), false},
+		{"local time", valid(func(e *OutgoingEmail) { e.DeliverAtLocalTime = "09:00" }), true},
+		{"bad local time", valid(func(e *OutgoingEmail) { e.DeliverAtLocalTime = "9am" }), false},
+		{"send at and local time", valid(func(e *OutgoingEmail) { e.SendAt, e.DeliverAtLocalTime = "2026-11-02T09:00:00Z", "09:00" }), false},
+		{"custom header", valid(func(e *OutgoingEmail) { e.Headers = map[string]string{"X-Order-ID": "12345"} }), true},
+		{"reserved header", valid(func(e *OutgoingEmail) { e.Headers = map[string]string{"reply-to": "x@example.com"} }), false},
+		{"header name with colon", valid(func(e *OutgoingEmail) { e.Headers = map[string]string{"X-A:B": "1"} }), false},
+		{"header name with space", valid(func(e *OutgoingEmail) { e.Headers = map[string]string{"X Order": "1"} }), false},
+		{"header injection", valid(func(e *OutgoingEmail) { e.Headers = map[string]string{"X-Order-ID": "1\r\nBcc: x@example.com"} }), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateOutgoingEmail(tt.req)
+			if tt.ok && err != nil {
+				t.Errorf("validateOutgoingEmail() = %v, want nil", err)
+			}
+			if !tt.ok && err == nil {
+				t.Error("validateOutgoingEmail() = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestSendEmail(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		wantID   string
+		wantErr  bool
+	}{
+		{"sent", `{"success":true,"message_id":"msg_1","status":"queued"}`, "msg_1", false},
+		{"rejected", `{"success":false,"message":"sender not verified"}`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, calls := newTestClient(t, http.StatusOK, tt.response)
+
+			id, err := client.SendEmail(context.Background(), &OutgoingEmail{
+				SendEmailRequest: SendEmailRequest{To: "user@example.com", Subject: "Hello", Body: "<p>Hi</p>"},
+				Headers:          map[string]string{"X-Order-ID": "12345"},
+			})
+			if (err != nil) != tt.wantErr || id != tt.wantID {
+				t.Errorf("SendEmail() = %q, %v; want %q, error %v", id, err, tt.wantID, tt.wantErr)
+			}
+
+			want := apiCall{"POST", "/sdk/v1/emails/",
+				`{"to":"user@example.com","subject":"Hello","body":"\u003cp\u003eHi\u003c/p\u003e","headers":{"X-Order-ID":"12345"}}`}
+			if len(*calls) != 1 || (*calls)[0] != want {
+				t.Errorf("requests = %+v, want %+v", *calls, want)
+			}
+		})
+	}
+}
+
+func TestSendBatchCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests > 1 {
+			// Cancelled while the second chunk is in flight
+			cancel()
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var chunk BatchSendRequest
+		json.NewDecoder(r.Body).Decode(&chunk)
+		var resp batchSendResponse
+		for _, rcpt := range chunk.Recipients {
+			resp.Results = append(resp.Results, BatchSendStatus{To: rcpt.To, Status: BatchStatusQueued})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+	client, err := NewClient("key", srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := &BatchSendRequest{TemplateSlug: "digest", ChunkSize: 2}
+	for _, to := range []string{"a", "b", "c", "d", "e"} {
+		req.Recipients = append(req.Recipients, BatchRecipient{To: to + "@example.com"})
+	}
+	result, err := client.SendBatch(ctx, req)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", err)
+	}
+	if result == nil || len(result.Statuses) != len(req.Recipients) {
+		t.Fatalf("result = %+v, want a status per recipient", result)
+	}
+	if result.Sent != 2 || result.Failed != 3 {
+		t.Errorf("sent %d, failed %d; want 2 and 3", result.Sent, result.Failed)
+	}
+	for i, s := range result.Statuses {
+		if s.To != req.Recipients[i].To {
+			t.Errorf("status %d is for %s, want %s", i, s.To, req.Recipients[i].To)
+		}
+	}
+	if last := result.Statuses[4]; last.Status != BatchStatusFailed || last.Error != context.Canceled.Error() {
+		t.Errorf("unsent recipient = %+v, want failed with %q", last, context.Canceled)
+	}
+}
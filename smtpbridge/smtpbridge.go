@@ -0,0 +1,224 @@
+// Package smtpbridge lets code written against net/smtp or gomail send through
+// Levee's send API instead of an SMTP server, so messages get Levee's click and
+// open tracking, suppression checks, and message log without code changes.
+//
+// With net/smtp, replace smtp.SendMail with the bridge's SendMail:
+//
+//	bridge := smtpbridge.New(client)
+//	err := bridge.SendMail("", nil, from, []string{to}, msg)
+//
+// With gomail, the Bridge is a gomail.SendCloser:
+//
+//	err := gomail.Send(smtpbridge.New(client), m)
+package smtpbridge
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	levee "github.com/almatuck/levee-go"
+)
+
+// transportHeaders describe the original transport and are not passed through
+// as custom headers, along with those Levee sets (see levee.ReservedEmailHeader).
+var transportHeaders = map[string]bool{
+	"Received":       true,
+	"Return-Path":    true,
+	"Dkim-Signature": true,
+}
+
+// Bridge sends raw MIME messages through a Levee client.
+// A Bridge is safe for concurrent use.
+type Bridge struct {
+	client  *levee.Client
+	timeout time.Duration
+	tags    []string
+}
+
+// Option configures a Bridge.
+type Option func(*Bridge)
+
+// WithTimeout bounds each send made without a context (default: 30s).
+func WithTimeout(d time.Duration) Option {
+	return func(b *Bridge) {
+		b.timeout = d
+	}
+}
+
+// WithTags tags every message sent through the bridge, e.g. "legacy-smtp".
+func WithTags(tags ...string) Option {
+	return func(b *Bridge) {
+		b.tags = append(b.tags, tags...)
+	}
+}
+
+// New returns a Bridge that sends through client.
+func New(client *levee.Client, opts ...Option) *Bridge {
+	b := &Bridge{client: client, timeout: 30 * time.Second}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// SendMail has the signature of smtp.SendMail. addr and auth are ignored; the
+// message is sent through Levee to each address in to.
+func (b *Bridge) SendMail(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	_, err := b.SendMailContext(ctx, from, to, msg)
+	return err
+}
+
+// Send implements gomail.Sender.
+func (b *Bridge) Send(from string, to []string, msg io.WriterTo) error {
+	var buf bytes.Buffer
+	if _, err := msg.WriteTo(&buf); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	return b.SendMail("", nil, from, to, buf.Bytes())
+}
+
+// Close implements gomail.SendCloser. It is a no-op.
+func (b *Bridge) Close() error {
+	return nil
+}
+
+// SendMailContext sends a raw MIME message to each envelope recipient in to and
+// returns the Levee message IDs in the same order. Each recipient gets its own
+// message, so opens and clicks are tracked per recipient; Bcc recipients never
+// appear in the headers others see. Recipients listed in the Cc header are
+// copied on the first message instead, so the Cc header is kept and they
+// share its ID. from is used when the message has no From header.
+// On failure the IDs of messages already sent are returned with the error.
+func (b *Bridge) SendMailContext(ctx context.Context, from string, to []string, msg []byte) ([]string, error) {
+	if len(to) == 0 {
+		return nil, fmt.Errorf("recipient is required")
+	}
+
+	parsed, err := levee.ParseInboundEmail(msg)
+	if err != nil {
+		return nil, err
+	}
+	req := b.sendRequest(from, parsed)
+
+	// Only Cc addresses in the envelope are delivered, as over SMTP
+	headerCc := map[string]bool{}
+	for _, addr := range parsed.Cc {
+		headerCc[strings.ToLower(addr.Address)] = true
+	}
+	var cc, direct []string
+	for _, rcpt := range to {
+		if headerCc[strings.ToLower(rcpt)] {
+			cc = append(cc, rcpt)
+		} else {
+			direct = append(direct, rcpt)
+		}
+	}
+	if len(direct) == 0 {
+		direct, cc = cc[:1], cc[1:]
+	}
+
+	sent := make(map[string]string, len(to))
+	for i, rcpt := range direct {
+		r := *req
+		r.To = rcpt
+		if i == 0 {
+			r.Cc = cc
+		}
+		r.Attachments = attachments(parsed.Attachments) // sending consumes the readers
+		id, err := b.client.SendEmail(ctx, &r)
+		if err != nil {
+			return orderedIDs(to, sent), fmt.Errorf("failed to send to %s: %w", rcpt, err)
+		}
+		sent[rcpt] = id
+		if i == 0 {
+			for _, addr := range cc {
+				sent[addr] = id
+			}
+		}
+	}
+	return orderedIDs(to, sent), nil
+}
+
+// orderedIDs returns the message IDs of the recipients in to that were sent,
+// in the order of to.
+func orderedIDs(to []string, sent map[string]string) []string {
+	ids := make([]string, 0, len(sent))
+	for _, rcpt := range to {
+		if id, ok := sent[rcpt]; ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// sendRequest converts a parsed message into a send request without a
+// recipient or attachments.
+func (b *Bridge) sendRequest(from string, parsed *levee.InboundMessage) *levee.OutgoingEmail {
+	req := &levee.OutgoingEmail{SendEmailRequest: levee.SendEmailRequest{
+		Subject:   parsed.Subject,
+		Body:      parsed.HTML,
+		TextBody:  parsed.Text,
+		FromEmail: from,
+		Tags:      b.tags,
+	}}
+	if parsed.From != nil {
+		req.FromName = parsed.From.Name
+		req.FromEmail = parsed.From.Address
+	}
+	if len(parsed.ReplyTo) > 0 {
+		req.ReplyTo = parsed.ReplyTo[0].Address
+	}
+
+	for name, values := range parsed.Header {
+		canonical := textproto.CanonicalMIMEHeaderKey(name)
+		if levee.ReservedEmailHeader(canonical) || transportHeaders[canonical] || len(values) == 0 {
+			continue
+		}
+		if req.Headers == nil {
+			req.Headers = map[string]string{}
+		}
+		// Headers holds one value per name, so repeated headers are joined
+		decoded := make([]string, len(values))
+		for i, v := range values {
+			decoded[i] = decodeHeader(v)
+		}
+		req.Headers[canonical] = strings.Join(decoded, ", ")
+	}
+
+	return req
+}
+
+// attachments converts parsed attachments into send attachments.
+func attachments(parsed []levee.InboundAttachment) []levee.Attachment {
+	var atts []levee.Attachment
+	for _, a := range parsed {
+		att := levee.Attachment{
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			Content:     bytes.NewReader(a.Data),
+		}
+		if a.Inline {
+			att.ContentID = a.ContentID
+		}
+		atts = append(atts, att)
+	}
+	return atts
+}
+
+// decodeHeader decodes RFC 2047 encoded words, returning s unchanged on error.
+func decodeHeader(s string) string {
+	decoded, err := new(mime.WordDecoder).DecodeHeader(s)
+	if err != nil {
+		return s
+	}
+	return decoded
+}
@@ -0,0 +1,119 @@
+package levee
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Stripe event types with typed payloads on StripeEvent.
+const (
+	StripeInvoicePaid                  = "invoice.paid"
+	StripeInvoicePaymentFailed         = "invoice.payment_failed"
+	StripeInvoicePaymentActionRequired = "invoice.payment_action_required"
+	StripeInvoiceUpcoming              = "invoice.upcoming"
+	StripeSubscriptionCreated          = "customer.subscription.created"
+	StripeSubscriptionUpdated          = "customer.subscription.updated"
+	StripeSubscriptionDeleted          = "customer.subscription.deleted"
+	StripeSubscriptionPaused           = "customer.subscription.paused"
+	StripeSubscriptionResumed          = "customer.subscription.resumed"
+)
+
+// StripeEvent is a Stripe webhook event received by HandleStripeWebhook.
+type StripeEvent struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Created int64  `json:"created"`
+	Data    struct {
+		Object json.RawMessage `json:"object"`
+	} `json:"data"`
+
+	// Invoice is populated for invoice.* events.
+	Invoice *StripeInvoice `json:"-"`
+	// Subscription is populated for customer.subscription.* events.
+	Subscription *StripeSubscription `json:"-"`
+}
+
+// StripeInvoice is the subset of a Stripe invoice used for dunning and access control.
+// Amounts are in the smallest currency unit (e.g. cents); times are Unix seconds.
+type StripeInvoice struct {
+	ID                 string `json:"id"`
+	Customer           string `json:"customer"`
+	CustomerEmail      string `json:"customer_email"`
+	Subscription       string `json:"subscription"`
+	Status             string `json:"status"`
+	Currency           string `json:"currency"`
+	AmountDue          int64  `json:"amount_due"`
+	AmountPaid         int64  `json:"amount_paid"`
+	AttemptCount       int    `json:"attempt_count"`
+	NextPaymentAttempt int64  `json:"next_payment_attempt"`
+	HostedInvoiceURL   string `json:"hosted_invoice_url"`
+	BillingReason      string `json:"billing_reason"`
+}
+
+// StripeSubscription is the subset of a Stripe subscription used for access control.
+// Times are Unix seconds.
+type StripeSubscription struct {
+	ID                string `json:"id"`
+	Customer          string `json:"customer"`
+	Status            string `json:"status"`
+	CancelAtPeriodEnd bool   `json:"cancel_at_period_end"`
+	CurrentPeriodEnd  int64  `json:"current_period_end"`
+	TrialEnd          int64  `json:"trial_end"`
+}
+
+// Decode unmarshals the event's data object into v.
+func (e *StripeEvent) Decode(v interface{}) error {
+	if len(e.Data.Object) == 0 {
+		return fmt.Errorf("stripe event %s has no data", e.ID)
+	}
+	if err := json.Unmarshal(e.Data.Object, v); err != nil {
+		return fmt.Errorf("failed to decode %s event data: %w", e.Type, err)
+	}
+	return nil
+}
+
+// ParseStripeEvent parses a Stripe webhook payload, populating the typed field
+// that matches the event type.
+func ParseStripeEvent(payload []byte) (*StripeEvent, error) {
+	var event StripeEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("failed to parse stripe event: %w", err)
+	}
+	if event.Type == "" {
+		return nil, fmt.Errorf("stripe event is missing type")
+	}
+
+	switch {
+	case strings.HasPrefix(event.Type, "invoice."):
+		event.Invoice = &StripeInvoice{}
+		if err := event.Decode(event.Invoice); err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(event.Type, "customer.subscription."):
+		event.Subscription = &StripeSubscription{}
+		if err := event.Decode(event.Subscription); err != nil {
+			return nil, err
+		}
+	}
+	return &event, nil
+}
+
+// StripeEventHandler handles a Stripe webhook event after it is forwarded to Levee.
+// The delivery is acknowledged regardless, so Stripe does not retry it and
+// forward it twice; returned errors are logged and counted as
+// MetricWebhookCallbackErrors.
+type StripeEventHandler func(ctx context.Context, event *StripeEvent) error
+
+// dispatchStripeEvent calls the handlers registered for the event type and for EventAll.
+func dispatchStripeEvent(ctx context.Context, handlers map[string][]StripeEventHandler, event *StripeEvent) error {
+	for _, key := range []string{event.Type, EventAll} {
+		for _, h := range handlers[key] {
+			if err := h(ctx, event); err != nil {
+				return fmt.Errorf("%s handler failed: %w", event.Type, err)
+			}
+		}
+	}
+	return nil
+}
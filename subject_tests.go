@@ -0,0 +1,152 @@
+package levee
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Subject test defaults.
+const (
+	defaultSubjectTestSample   = 20
+	defaultSubjectTestDuration = 4
+	maxSubjectBriefLength      = 4000
+)
+
+// SubjectTestOptions configures CreateSubjectTest.
+type SubjectTestOptions struct {
+	Variants int // subject lines to generate (default: 3)
+	// Brief describes the email; empty uses the campaign's subject and body.
+	Brief    string
+	Audience string
+	Tone     string
+	// KeepCurrent also tests the campaign's current subject, as "Current".
+	KeepCurrent bool
+
+	SamplePercent     int             // default: 20
+	WinningCriteria   WinningCriteria // default: WinByOpenRate
+	TestDurationHours int             // default: 4
+	Model             string          // LLM model (default: "sonnet")
+}
+
+// SubjectTest is an A/B test of generated subject lines.
+type SubjectTest struct {
+	CampaignID string
+	ABTest     *ABTest
+	// Candidates are the generated subject lines, best first by the model's
+	// score, in the order of the test's variants (after "Current", if kept).
+	Candidates []EmailCandidate
+	Response   *ChatResponse // tokens and cost of the generation
+}
+
+// CreateSubjectTest generates subject lines for a draft or scheduled campaign
+// with llm and configures an A/B test of them. When the campaign sends, Levee
+// picks the winner after the test duration and sends it to the rest of the
+// audience; WaitForABTestWinner returns it.
+//
+//	test, err := client.CreateSubjectTest(ctx, llm, campaignID, levee.SubjectTestOptions{Variants: 4})
+//	if err != nil {
+//		return err
+//	}
+//	_, err = client.SendCampaignNow(ctx, campaignID)
+//	// ...
+//	waitCtx, cancel := context.WithTimeout(ctx, 2*time.Hour)
+//	defer cancel()
+//	results, err := client.WaitForABTestWinner(waitCtx, campaignID, 30*time.Second)
+//	if err != nil {
+//		return err
+//	}
+//	log.Printf("winner: %q", results.Winner().Name)
+func (c *Client) CreateSubjectTest(ctx context.Context, llm *LLMClient, campaignID string, opts SubjectTestOptions) (*SubjectTest, error) {
+	campaign, err := c.GetCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	brief := opts.Brief
+	if brief == "" {
+		brief = campaignBrief(campaign)
+	}
+	candidates, resp, err := llm.GenerateSubjectLines(ctx, EmailBrief{
+		Brief:    brief,
+		Audience: opts.Audience,
+		Tone:     opts.Tone,
+		Variants: opts.Variants,
+		Model:    opts.Model,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	test := &ABTest{
+		SamplePercent:     cmp.Or(opts.SamplePercent, defaultSubjectTestSample),
+		WinningCriteria:   cmp.Or(opts.WinningCriteria, WinByOpenRate),
+		TestDurationHours: cmp.Or(opts.TestDurationHours, defaultSubjectTestDuration),
+	}
+	if opts.KeepCurrent && campaign.Content.Subject != "" {
+		test.Variants = append(test.Variants, CampaignVariant{Name: "Current", Subject: campaign.Content.Subject})
+	}
+	for i, candidate := range candidates {
+		test.Variants = append(test.Variants, CampaignVariant{
+			Name:    fmt.Sprintf("Subject %c", 'A'+i),
+			Subject: candidate.Text,
+		})
+	}
+
+	configured, err := c.ConfigureABTest(ctx, campaignID, test)
+	if err != nil {
+		return nil, err
+	}
+	return &SubjectTest{CampaignID: campaignID, ABTest: configured, Candidates: candidates, Response: resp}, nil
+}
+
+// WaitForABTestWinner polls a campaign's A/B test every interval (default 10s)
+// until a winner is decided, or ctx is done.
+func (c *Client) WaitForABTestWinner(ctx context.Context, campaignID string, interval time.Duration) (*ABTestResults, error) {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		results, err := c.GetABTestResults(ctx, campaignID)
+		if err != nil {
+			return nil, err
+		}
+		if results.Status == ABTestDecided && results.Winner() != nil {
+			return results, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return results, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// campaignBrief describes a campaign for subject line generation.
+func campaignBrief(campaign *Campaign) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Campaign %q.", campaign.Name)
+	if campaign.Content.Subject != "" {
+		fmt.Fprintf(&b, " Current subject line: %q.", campaign.Content.Subject)
+	}
+	if campaign.Content.PreviewText != "" {
+		fmt.Fprintf(&b, " Preview text: %q.", campaign.Content.PreviewText)
+	}
+	body := cmp.Or(campaign.Content.TextBody, campaign.Content.Body)
+	if body != "" {
+		if utf8.RuneCountInString(body) > maxSubjectBriefLength {
+			body = string([]rune(body)[:maxSubjectBriefLength])
+		}
+		fmt.Fprintf(&b, "\nEmail content:\n%s", body)
+	} else if campaign.Content.TemplateSlug != "" {
+		fmt.Fprintf(&b, " Sent with the %q template.", campaign.Content.TemplateSlug)
+	}
+	return b.String()
+}
@@ -0,0 +1,264 @@
+package levee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Subscription statuses, as reported by Stripe.
+const (
+	SubscriptionStatusActive     = "active"
+	SubscriptionStatusTrialing   = "trialing"
+	SubscriptionStatusPastDue    = "past_due"
+	SubscriptionStatusCanceled   = "canceled"
+	SubscriptionStatusUnpaid     = "unpaid"
+	SubscriptionStatusIncomplete = "incomplete"
+)
+
+// Subscription is a contact's current subscription as tracked by Levee from
+// billing webhooks.
+type Subscription struct {
+	SDKSubscriptionInfo
+	// Entitlements are the feature keys granted by the subscribed product,
+	// from WithEntitlements, or else the product name.
+	Entitlements []string
+}
+
+// Active reports whether the subscription grants access: it is active,
+// trialing, or past due while Stripe retries the payment.
+func (s *Subscription) Active() bool {
+	if s == nil {
+		return false
+	}
+	switch s.Status {
+	case SubscriptionStatusActive, SubscriptionStatusTrialing, SubscriptionStatusPastDue:
+		return true
+	}
+	return false
+}
+
+// HasEntitlement reports whether the subscription is active and grants feature.
+func (s *Subscription) HasEntitlement(feature string) bool {
+	return s.Active() && slices.Contains(s.Entitlements, feature)
+}
+
+// defaultSubscriptionCacheTTL is how long GetSubscription results are reused.
+const defaultSubscriptionCacheTTL = time.Minute
+
+// subscriptionCacheMaxEntries triggers pruning of expired entries.
+const subscriptionCacheMaxEntries = 10000
+
+// subscriptionCache caches subscriptions by email, including "no subscription".
+type subscriptionCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	set     bool // ttl was configured with WithSubscriptionCacheTTL
+	entries map[string]cachedSubscription
+
+	entitlements map[string][]string // product name -> feature keys
+}
+
+type cachedSubscription struct {
+	sub     *Subscription
+	expires time.Time
+}
+
+func (s *subscriptionCache) lifetime() time.Duration {
+	if !s.set {
+		return defaultSubscriptionCacheTTL
+	}
+	return s.ttl
+}
+
+func (s *subscriptionCache) get(email string) (*Subscription, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[email]
+	if !ok || time.Now().After(e.expires) {
+		return nil, false
+	}
+	return e.sub, true
+}
+
+func (s *subscriptionCache) store(email string, sub *Subscription) {
+	ttl := s.lifetime()
+	if ttl <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.entries == nil {
+		s.entries = make(map[string]cachedSubscription)
+	}
+	if len(s.entries) >= subscriptionCacheMaxEntries {
+		for k, e := range s.entries {
+			if now.After(e.expires) {
+				delete(s.entries, k)
+			}
+		}
+	}
+	s.entries[email] = cachedSubscription{sub: sub, expires: now.Add(ttl)}
+}
+
+func (s *subscriptionCache) invalidate(email string) {
+	s.mu.Lock()
+	delete(s.entries, email)
+	s.mu.Unlock()
+}
+
+func (s *subscriptionCache) clear() {
+	s.mu.Lock()
+	clear(s.entries)
+	s.mu.Unlock()
+}
+
+// WithSubscriptionCacheTTL sets how long GetSubscription and HasEntitlement
+// reuse a subscription before asking Levee again (default: 1m, 0 disables caching).
+func WithSubscriptionCacheTTL(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		cache := &c.state().subscriptions
+		cache.ttl = ttl
+		cache.set = true
+	}
+}
+
+// WithEntitlements sets the feature keys each product grants, keyed by
+// product name. Products not listed grant a feature named after the product.
+//
+//	levee.WithEntitlements(map[string][]string{
+//		"Pro":      {"reports", "api"},
+//		"Business": {"reports", "api", "sso"},
+//	})
+func WithEntitlements(products map[string][]string) ClientOption {
+	return func(c *Client) {
+		c.state().subscriptions.entitlements = products
+	}
+}
+
+// GetSubscription returns the current subscription of the contact with the
+// given email, or nil if they have none: an active one if any, else the most
+// recently created. Results are cached briefly (see WithSubscriptionCacheTTL)
+// so it can run on every request.
+func (c *Client) GetSubscription(ctx context.Context, email string) (*Subscription, error) {
+	key := strings.ToLower(strings.TrimSpace(email))
+	if key == "" {
+		return nil, fmt.Errorf("email is required")
+	}
+	cache := &c.state().subscriptions
+	if sub, ok := cache.get(key); ok {
+		return sub, nil
+	}
+
+	// The Customers.ListCustomerSubscriptions endpoint, through c.do so that
+	// contacts who never became customers (404) read as unsubscribed.
+	var resp ListCustomerSubscriptionsResponse
+	err := c.do(ctx, http.MethodGet, "/sdk/v1/customers/"+url.PathEscape(key)+"/subscriptions", nil, nil, &resp)
+	var apiErr *APIError
+	if err != nil && !(errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound) {
+		return nil, err
+	}
+
+	sub := currentSubscription(resp.Subscriptions)
+	if sub != nil {
+		sub.Entitlements = cache.entitlements[sub.ProductName]
+		if _, ok := cache.entitlements[sub.ProductName]; !ok {
+			sub.Entitlements = []string{sub.ProductName}
+		}
+	}
+	cache.store(key, sub)
+	return sub, nil
+}
+
+// currentSubscription picks the subscription that decides access: an active
+// one if any, else the most recently created.
+func currentSubscription(subs []SDKSubscriptionInfo) *Subscription {
+	var best *Subscription
+	for _, info := range subs {
+		s := &Subscription{SDKSubscriptionInfo: info}
+		if best == nil || s.Active() && !best.Active() ||
+			s.Active() == best.Active() && s.CreatedAt > best.CreatedAt {
+			best = s
+		}
+	}
+	return best
+}
+
+// HasEntitlement reports whether the contact's subscription is active and
+// grants feature. Contacts without a subscription have no entitlements.
+func (c *Client) HasEntitlement(ctx context.Context, email, feature string) (bool, error) {
+	sub, err := c.GetSubscription(ctx, email)
+	if err != nil {
+		return false, err
+	}
+	return sub.HasEntitlement(feature), nil
+}
+
+// InvalidateSubscription drops the cached subscription of email, e.g. right
+// after checkout. HandleStripeWebhook and HandleBillingWebhook call it for the
+// events they receive.
+func (c *Client) InvalidateSubscription(email string) {
+	c.state().subscriptions.invalidate(strings.ToLower(strings.TrimSpace(email)))
+}
+
+// invalidateBillingEmail drops the cached subscription of email after a
+// billing event, or every cached subscription when the event has no email
+// (e.g. Stripe subscription events, which only carry the customer ID).
+func (c *Client) invalidateBillingEmail(email string) {
+	if email != "" {
+		c.InvalidateSubscription(email)
+		return
+	}
+	c.state().subscriptions.clear()
+}
+
+// invalidateStripeEvent drops the cached subscriptions a Stripe event may change.
+func (c *Client) invalidateStripeEvent(event *StripeEvent) {
+	switch {
+	case event.Invoice != nil:
+		c.invalidateBillingEmail(event.Invoice.CustomerEmail)
+	case event.Subscription != nil:
+		c.invalidateBillingEmail("")
+	}
+}
+
+// RequireEntitlement returns middleware that responds 403 Forbidden unless the
+// user identified by emailFunc has feature. Requests without an email get 401,
+// and subscription lookup failures get 503. Errors are written with the
+// ErrorRenderer of opts (see WithErrorRenderer).
+//
+//	mux.Handle("/reports/", client.RequireEntitlement("reports", currentUserEmail)(reportsHandler))
+func (c *Client) RequireEntitlement(feature string, emailFunc func(r *http.Request) string, opts ...HandlerOption) func(http.Handler) http.Handler {
+	cfg := NewHandlerConfig(opts...)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			email := emailFunc(r)
+			if email == "" {
+				cfg.renderError(w, r, http.StatusUnauthorized, ErrCodeMissingEmail)
+				return
+			}
+
+			ok, err := c.HasEntitlement(r.Context(), email, feature)
+			if err != nil {
+				c.log().Error("entitlement check failed", "feature", feature, "error", err)
+				cfg.renderError(w, r, http.StatusServiceUnavailable, ErrCodeEntitlementCheckFailed)
+				return
+			}
+			if !ok {
+				cfg.renderError(w, r, http.StatusForbidden, ErrCodeEntitlementRequired)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package levee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SuppressionCategory is the reason an address is suppressed.
+type SuppressionCategory string
+
+// Suppression categories.
+const (
+	SuppressionBounce      SuppressionCategory = "bounce"
+	SuppressionComplaint   SuppressionCategory = "complaint"
+	SuppressionManual      SuppressionCategory = "manual"
+	SuppressionUnsubscribe SuppressionCategory = "unsubscribe"
+)
+
+// Suppression is an address Levee will not send to.
+type Suppression struct {
+	Email     string              `json:"email"`
+	Category  SuppressionCategory `json:"category"`
+	Reason    string              `json:"reason,omitempty"`
+	CreatedAt string              `json:"created_at,omitempty"`
+}
+
+// AddSuppression suppresses an address. Category defaults to SuppressionManual.
+func (c *Client) AddSuppression(ctx context.Context, email string, category SuppressionCategory, reason string) error {
+	if !strings.Contains(email, "@") {
+		return fmt.Errorf("email %q is invalid", email)
+	}
+	if category == "" {
+		category = SuppressionManual
+	}
+	return c.do(ctx, http.MethodPost, "/sdk/v1/suppressions", nil, &Suppression{
+		Email:    email,
+		Category: category,
+		Reason:   reason,
+	}, nil)
+}
+
+// RemoveSuppression lets Levee send to a suppressed address again.
+func (c *Client) RemoveSuppression(ctx context.Context, email string) error {
+	if email == "" {
+		return fmt.Errorf("email is required")
+	}
+	return c.do(ctx, http.MethodDelete, suppressionPath(email), nil, nil, nil)
+}
+
+// suppressionsPageSize is the number of suppressions fetched per request.
+const suppressionsPageSize = 100
+
+// ListSuppressions returns an iterator over suppressed addresses, optionally
+// limited to one category (empty lists all).
+func (c *Client) ListSuppressions(ctx context.Context, category SuppressionCategory) *Iterator[Suppression] {
+	return newIterator(ctx, func(ctx context.Context, cursor string) (*Page[Suppression], error) {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(suppressionsPageSize))
+		if category != "" {
+			query.Set("category", string(category))
+		}
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+
+		var resp struct {
+			Suppressions []Suppression `json:"suppressions"`
+			NextCursor   string        `json:"next_cursor"`
+			Total        *int          `json:"total"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/sdk/v1/suppressions", query, nil, &resp); err != nil {
+			return nil, err
+		}
+		page := &Page[Suppression]{Items: resp.Suppressions, NextCursor: resp.NextCursor, TotalEstimate: -1}
+		if resp.Total != nil {
+			page.TotalEstimate = *resp.Total
+		}
+		return page, nil
+	})
+}
+
+// GetSuppression returns the suppression for an address, or nil if it is not suppressed.
+func (c *Client) GetSuppression(ctx context.Context, email string) (*Suppression, error) {
+	if email == "" {
+		return nil, fmt.Errorf("email is required")
+	}
+
+	var result Suppression
+	err := c.do(ctx, http.MethodGet, suppressionPath(email), nil, nil, &result)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// IsSuppressed reports whether Levee would refuse to send to email, so apps can
+// skip suppressed recipients before calling SendEmail.
+func (c *Client) IsSuppressed(ctx context.Context, email string) (bool, error) {
+	s, err := c.GetSuppression(ctx, email)
+	if err != nil {
+		return false, err
+	}
+	return s != nil, nil
+}
+
+func suppressionPath(email string) string {
+	return "/sdk/v1/suppressions/" + url.PathEscape(strings.ToLower(strings.TrimSpace(email)))
+}
@@ -0,0 +1,71 @@
+package levee
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestSuppressionRequests(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		call     func(*Client) error
+		wantCall apiCall
+	}{
+		{
+			name:     "add defaults to manual",
+			call:     func(c *Client) error { return c.AddSuppression(ctx, "ada@example.com", "", "asked by phone") },
+			wantCall: apiCall{"POST", "/sdk/v1/suppressions", `{"email":"ada@example.com","category":"manual","reason":"asked by phone"}`},
+		},
+		{
+			name:     "add bounce",
+			call:     func(c *Client) error { return c.AddSuppression(ctx, "ada@example.com", SuppressionBounce, "") },
+			wantCall: apiCall{"POST", "/sdk/v1/suppressions", `{"email":"ada@example.com","category":"bounce"}`},
+		},
+		{
+			name:     "remove normalizes the address",
+			call:     func(c *Client) error { return c.RemoveSuppression(ctx, " Ada@Example.com ") },
+			wantCall: apiCall{"DELETE", "/sdk/v1/suppressions/ada@example.com", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, calls := newTestClient(t, http.StatusOK, `{}`)
+
+			if err := tt.call(client); err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if len(*calls) != 1 || (*calls)[0] != tt.wantCall {
+				t.Errorf("requests = %+v, want %+v", *calls, tt.wantCall)
+			}
+		})
+	}
+}
+
+func TestIsSuppressed(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		response string
+		want     bool
+		wantErr  bool
+	}{
+		{"suppressed", http.StatusOK, `{"email":"ada@example.com","category":"bounce"}`, true, false},
+		{"not suppressed", http.StatusNotFound, `{"code":"not_found"}`, false, false},
+		{"server error", http.StatusInternalServerError, `{"code":"internal"}`, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, tt.status, tt.response)
+
+			got, err := client.IsSuppressed(context.Background(), "ada@example.com")
+			if got != tt.want || (err != nil) != tt.wantErr {
+				t.Errorf("IsSuppressed() = %v, %v; want %v, error %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
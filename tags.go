@@ -0,0 +1,111 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Tag is a contact tag with its usage count.
+type Tag struct {
+	Name         string `json:"name"`
+	ContactCount int    `json:"contact_count"`
+}
+
+// TagContact adds tags to a contact by ID or email address.
+func (c *Client) TagContact(ctx context.Context, idOrEmail string, tags ...string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	resp, err := c.Contacts.AddContactTags(ctx, idOrEmail, &AddContactTagsRequest{Tags: tags})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("failed to tag contact: %s", resp.Message)
+	}
+	return nil
+}
+
+// UntagContact removes tags from a contact by ID or email address.
+func (c *Client) UntagContact(ctx context.Context, idOrEmail string, tags ...string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	resp, err := c.Contacts.RemoveContactTags(ctx, idOrEmail, &RemoveContactTagsRequest{Tags: tags})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("failed to untag contact: %s", resp.Message)
+	}
+	return nil
+}
+
+// ListTags returns every tag in use with the number of contacts carrying it.
+func (c *Client) ListTags(ctx context.Context) ([]Tag, error) {
+	var result struct {
+		Tags []Tag `json:"tags"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/sdk/v1/tags", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Tags, nil
+}
+
+// ContactsByTag returns an iterator over the contacts tagged with tag.
+func (c *Client) ContactsByTag(ctx context.Context, tag string) *Iterator[Contact] {
+	return c.SearchContacts(ctx, NewContactFilter().HasTag(tag))
+}
+
+// maxBulkTagContacts is the most contacts tagged per bulk API call.
+const maxBulkTagContacts = 1000
+
+// BulkTagResult reports the outcome of a bulk tag or untag.
+type BulkTagResult struct {
+	// Updated is the number of contacts changed.
+	Updated int `json:"updated"`
+	// NotFound lists the contacts that do not exist.
+	NotFound []string `json:"not_found,omitempty"`
+}
+
+// bulkTagRequest is the body of /sdk/v1/tags/bulk.
+type bulkTagRequest struct {
+	Action   string   `json:"action"` // "add" or "remove"
+	Contacts []string `json:"contacts"`
+	Tags     []string `json:"tags"`
+}
+
+// BulkTagContacts adds tags to many contacts by ID or email address,
+// in chunks of up to 1000 contacts per request.
+func (c *Client) BulkTagContacts(ctx context.Context, contacts []string, tags ...string) (*BulkTagResult, error) {
+	return c.bulkTag(ctx, "add", contacts, tags)
+}
+
+// BulkUntagContacts removes tags from many contacts by ID or email address,
+// in chunks of up to 1000 contacts per request.
+func (c *Client) BulkUntagContacts(ctx context.Context, contacts []string, tags ...string) (*BulkTagResult, error) {
+	return c.bulkTag(ctx, "remove", contacts, tags)
+}
+
+func (c *Client) bulkTag(ctx context.Context, action string, contacts, tags []string) (*BulkTagResult, error) {
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("at least one tag is required")
+	}
+
+	total := &BulkTagResult{}
+	for start := 0; start < len(contacts); start += maxBulkTagContacts {
+		var result BulkTagResult
+		err := c.do(ctx, http.MethodPost, "/sdk/v1/tags/bulk", nil, &bulkTagRequest{
+			Action:   action,
+			Contacts: contacts[start:min(start+maxBulkTagContacts, len(contacts))],
+			Tags:     tags,
+		}, &result)
+		if err != nil {
+			return total, fmt.Errorf("bulk %s tags failed after %d contacts: %w", action, start, err)
+		}
+		total.Updated += result.Updated
+		total.NotFound = append(total.NotFound, result.NotFound...)
+	}
+	return total, nil
+}
@@ -0,0 +1,262 @@
+package levee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+)
+
+// Template is a reusable email template, referenced by slug when sending.
+// Each update creates a new immutable version; sends use the latest one.
+type Template struct {
+	ID          string   `json:"id,omitempty"`
+	Slug        string   `json:"slug"`
+	Name        string   `json:"name,omitempty"`
+	Subject     string   `json:"subject"`
+	PreviewText string   `json:"preview_text,omitempty"`
+	HTML        string   `json:"html,omitempty"`
+	Text        string   `json:"text,omitempty"`
+	FromName    string   `json:"from_name,omitempty"`
+	FromEmail   string   `json:"from_email,omitempty"`
+	ReplyTo     string   `json:"reply_to,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	// Version is the current version number, set by Levee.
+	Version int `json:"version,omitempty"`
+	// ChangeNote describes an update, e.g. a git commit SHA; stored on the new version.
+	ChangeNote string `json:"change_note,omitempty"`
+	CreatedAt  string `json:"created_at,omitempty"`
+	UpdatedAt  string `json:"updated_at,omitempty"`
+}
+
+// TemplateVersion is a past revision of a template.
+type TemplateVersion struct {
+	Version     int    `json:"version"`
+	Subject     string `json:"subject"`
+	PreviewText string `json:"preview_text,omitempty"`
+	HTML        string `json:"html,omitempty"`
+	Text        string `json:"text,omitempty"`
+	ChangeNote  string `json:"change_note,omitempty"`
+	CreatedAt   string `json:"created_at,omitempty"`
+}
+
+// CreateTemplate creates a template at version 1.
+func (c *Client) CreateTemplate(ctx context.Context, tmpl *Template) (*Template, error) {
+	if tmpl == nil || tmpl.Slug == "" {
+		return nil, fmt.Errorf("template slug is required")
+	}
+	if tmpl.HTML == "" && tmpl.Text == "" {
+		return nil, fmt.Errorf("template html or text is required")
+	}
+
+	var result Template
+	if err := c.do(ctx, http.MethodPost, "/sdk/v1/templates", nil, tmpl, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetTemplate returns the current version of a template by ID or slug.
+func (c *Client) GetTemplate(ctx context.Context, idOrSlug string) (*Template, error) {
+	var result Template
+	if err := c.do(ctx, http.MethodGet, templatePath(idOrSlug, ""), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateTemplate replaces a template's content, creating a new version.
+// If tmpl.Version is set, the update fails with a 409 APIError when the
+// template has changed since that version was read.
+func (c *Client) UpdateTemplate(ctx context.Context, idOrSlug string, tmpl *Template) (*Template, error) {
+	if tmpl == nil {
+		return nil, fmt.Errorf("template is required")
+	}
+
+	var result Template
+	if err := c.do(ctx, http.MethodPut, templatePath(idOrSlug, ""), nil, tmpl, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteTemplate deletes a template and all its versions.
+func (c *Client) DeleteTemplate(ctx context.Context, idOrSlug string) error {
+	return c.do(ctx, http.MethodDelete, templatePath(idOrSlug, ""), nil, nil, nil)
+}
+
+// templatesPageSize is the number of templates fetched per request.
+const templatesPageSize = 100
+
+// ListTemplates returns an iterator over templates at their current version.
+func (c *Client) ListTemplates(ctx context.Context) *Iterator[Template] {
+	return newIterator(ctx, func(ctx context.Context, cursor string) (*Page[Template], error) {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(templatesPageSize))
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+
+		var resp struct {
+			Templates  []Template `json:"templates"`
+			NextCursor string     `json:"next_cursor"`
+			Total      *int       `json:"total"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/sdk/v1/templates", query, nil, &resp); err != nil {
+			return nil, err
+		}
+		page := &Page[Template]{Items: resp.Templates, NextCursor: resp.NextCursor, TotalEstimate: -1}
+		if resp.Total != nil {
+			page.TotalEstimate = *resp.Total
+		}
+		return page, nil
+	})
+}
+
+// ListTemplateVersions returns a template's versions, newest first.
+func (c *Client) ListTemplateVersions(ctx context.Context, idOrSlug string) ([]TemplateVersion, error) {
+	var resp struct {
+		Versions []TemplateVersion `json:"versions"`
+	}
+	if err := c.do(ctx, http.MethodGet, templatePath(idOrSlug, "versions"), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Versions, nil
+}
+
+// GetTemplateVersion returns one version of a template.
+func (c *Client) GetTemplateVersion(ctx context.Context, idOrSlug string, version int) (*TemplateVersion, error) {
+	var result TemplateVersion
+	if err := c.do(ctx, http.MethodGet, templatePath(idOrSlug, "versions/"+strconv.Itoa(version)), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RollbackTemplate makes a past version current again by copying it to a new version.
+func (c *Client) RollbackTemplate(ctx context.Context, idOrSlug string, version int) (*Template, error) {
+	var result Template
+	body := map[string]int{"version": version}
+	if err := c.do(ctx, http.MethodPost, templatePath(idOrSlug, "rollback"), nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SyncTemplate creates the template with tmpl.Slug if it does not exist, or
+// updates it if its content differs, so templates kept in source control can be
+// pushed on every deploy without piling up identical versions.
+// It reports whether a new version was written.
+//
+//	for _, tmpl := range templatesFromRepo {
+//		tmpl.ChangeNote = gitSHA
+//		if _, changed, err := client.SyncTemplate(ctx, tmpl); err != nil {
+//			return err
+//		} else if changed {
+//			log.Printf("updated template %s", tmpl.Slug)
+//		}
+//	}
+func (c *Client) SyncTemplate(ctx context.Context, tmpl *Template) (*Template, bool, error) {
+	if tmpl == nil || tmpl.Slug == "" {
+		return nil, false, fmt.Errorf("template slug is required")
+	}
+
+	current, err := c.GetTemplate(ctx, tmpl.Slug)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		created, err := c.CreateTemplate(ctx, tmpl)
+		return created, err == nil, err
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if templateContentEqual(current, tmpl) {
+		return current, false, nil
+	}
+	update := *tmpl
+	update.Version = current.Version
+	updated, err := c.UpdateTemplate(ctx, tmpl.Slug, &update)
+	return updated, err == nil, err
+}
+
+// RenderRequest is the input to RenderTemplate: a saved template, or raw
+// content when Template is empty.
+type RenderRequest struct {
+	// Template is the ID or slug of a saved template.
+	Template string `json:"-"`
+	// Version renders a specific template version instead of the current one.
+	Version int `json:"version,omitempty"`
+
+	// Subject, HTML, and Text are rendered when Template is empty.
+	Subject string `json:"subject,omitempty"`
+	HTML    string `json:"html,omitempty"`
+	Text    string `json:"text,omitempty"`
+
+	Variables map[string]interface{} `json:"variables,omitempty"`
+	// ContactEmail merges the contact's attributes, as a real send would.
+	// Variables take precedence over contact attributes.
+	ContactEmail string `json:"contact_email,omitempty"`
+}
+
+// RenderedTemplate is the output of RenderTemplate.
+type RenderedTemplate struct {
+	Subject     string `json:"subject"`
+	PreviewText string `json:"preview_text,omitempty"`
+	HTML        string `json:"html"`
+	// Text is the plaintext part, generated from HTML when the template has none.
+	Text string `json:"text"`
+}
+
+// RenderTemplate renders a template or raw content without sending it, for
+// snapshot tests of email output or previewing merges for a specific contact.
+// Missing variables are returned as *MissingVariableError, and a Template that
+// does not exist as *UnknownTemplateError.
+//
+//	out, err := client.RenderTemplate(ctx, &levee.RenderRequest{
+//		Template:     "order-shipped",
+//		ContactEmail: "user@example.com",
+//		Variables:    map[string]any{"order_id": "1234"},
+//	})
+func (c *Client) RenderTemplate(ctx context.Context, req *RenderRequest) (*RenderedTemplate, error) {
+	if req == nil || (req.Template == "" && req.HTML == "" && req.Text == "") {
+		return nil, fmt.Errorf("template or html is required")
+	}
+
+	// Raw content has its own endpoint so it can't collide with a template slug
+	path := "/sdk/v1/emails/render"
+	if req.Template != "" {
+		path = templatePath(req.Template, "render")
+	}
+
+	var result RenderedTemplate
+	if err := c.do(ctx, http.MethodPost, path, nil, req, &result); err != nil {
+		return nil, templateSendError(req.Template, err)
+	}
+	return &result, nil
+}
+
+// templateContentEqual reports whether two templates have the same editable content.
+func templateContentEqual(a, b *Template) bool {
+	return a.Name == b.Name &&
+		a.Subject == b.Subject &&
+		a.PreviewText == b.PreviewText &&
+		a.HTML == b.HTML &&
+		a.Text == b.Text &&
+		a.FromName == b.FromName &&
+		a.FromEmail == b.FromEmail &&
+		a.ReplyTo == b.ReplyTo &&
+		slices.Equal(a.Tags, b.Tags)
+}
+
+// templatePath returns the API path of a template, or of one of its sub-resources.
+func templatePath(idOrSlug, sub string) string {
+	path := "/sdk/v1/templates/" + url.PathEscape(idOrSlug)
+	if sub != "" {
+		path += "/" + sub
+	}
+	return path
+}
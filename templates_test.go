@@ -0,0 +1,104 @@
+package levee
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestSyncTemplate(t *testing.T) {
+	const saved = `{"slug":"welcome","subject":"Hi","html":"<p>Hi</p>","version":3}`
+	local := &Template{Slug: "welcome", Subject: "Hi", HTML: "<p>Hi</p>", ChangeNote: "abc123"}
+
+	tests := []struct {
+		name        string
+		getStatus   int
+		getBody     string
+		tmpl        *Template
+		wantChanged bool
+		wantCalls   []string
+	}{
+		{
+			name:        "missing template is created",
+			getStatus:   http.StatusNotFound,
+			getBody:     `{"code":"not_found"}`,
+			tmpl:        local,
+			wantChanged: true,
+			wantCalls:   []string{"GET /sdk/v1/templates/welcome", "POST /sdk/v1/templates"},
+		},
+		{
+			name:      "unchanged template is left alone",
+			getStatus: http.StatusOK,
+			getBody:   saved,
+			tmpl:      local,
+			wantCalls: []string{"GET /sdk/v1/templates/welcome"},
+		},
+		{
+			name:        "changed template is updated",
+			getStatus:   http.StatusOK,
+			getBody:     saved,
+			tmpl:        &Template{Slug: "welcome", Subject: "Hello", HTML: "<p>Hi</p>"},
+			wantChanged: true,
+			wantCalls:   []string{"GET /sdk/v1/templates/welcome", "PUT /sdk/v1/templates/welcome"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, r.Method+" "+r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				if r.Method == http.MethodGet {
+					w.WriteHeader(tt.getStatus)
+					io.WriteString(w, tt.getBody)
+					return
+				}
+				io.WriteString(w, saved)
+			}))
+			defer srv.Close()
+			client, err := NewClient("key", srv.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, changed, err := client.SyncTemplate(context.Background(), tt.tmpl)
+			if err != nil {
+				t.Fatalf("SyncTemplate() error = %v", err)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if !slices.Equal(calls, tt.wantCalls) {
+				t.Errorf("requests = %v, want %v", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRenderTemplatePath(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *RenderRequest
+		want string
+	}{
+		{"saved template", &RenderRequest{Template: "order-shipped"}, "/sdk/v1/templates/order-shipped/render"},
+		{"raw html", &RenderRequest{Subject: "Hi", HTML: "<p>Hi</p>"}, "/sdk/v1/emails/render"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, calls := newTestClient(t, http.StatusOK, `{"subject":"Hi","html":"<p>Hi</p>","text":"Hi"}`)
+
+			if _, err := client.RenderTemplate(context.Background(), tt.req); err != nil {
+				t.Fatalf("RenderTemplate() error = %v", err)
+			}
+			if len(*calls) != 1 || (*calls)[0].path != tt.want {
+				t.Errorf("requests = %+v, want POST %s", *calls, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Thread is a conversation: a sent message and the replies exchanged with it.
+type Thread struct {
+	ID      string `json:"id"`
+	Subject string `json:"subject"`
+	// Messages are the sent and received messages, oldest first.
+	Messages []ThreadMessage `json:"messages"`
+}
+
+// Thread message directions.
+const (
+	DirectionOutbound = "outbound"
+	DirectionInbound  = "inbound"
+)
+
+// ThreadMessage is one message of a Thread.
+type ThreadMessage struct {
+	// ID is the Levee message ID.
+	ID string `json:"id"`
+	// Direction is DirectionOutbound for sent messages and DirectionInbound for replies.
+	Direction string `json:"direction"`
+	// InternetMessageID is the RFC 5322 Message-ID header, without angle brackets.
+	InternetMessageID string   `json:"internet_message_id,omitempty"`
+	InReplyTo         string   `json:"in_reply_to,omitempty"`
+	References        []string `json:"references,omitempty"`
+	From              string   `json:"from"`
+	To                string   `json:"to"`
+	Subject           string   `json:"subject"`
+	Text              string   `json:"text,omitempty"`
+	HTML              string   `json:"html,omitempty"`
+	CreatedAt         string   `json:"created_at"`
+}
+
+// ReplyEventData is the payload of email.replied events, sent when Levee
+// receives a reply to a message it sent.
+type ReplyEventData struct {
+	// MessageID is the Levee ID of the reply.
+	MessageID string `json:"message_id"`
+	// InReplyTo is the Levee ID of the message replied to.
+	InReplyTo string `json:"in_reply_to"`
+	ThreadID  string `json:"thread_id"`
+	From      string `json:"from"`
+	ContactID string `json:"contact_id,omitempty"`
+	Subject   string `json:"subject"`
+	Text      string `json:"text,omitempty"`
+	HTML      string `json:"html,omitempty"`
+	// StrippedText is the reply text without the quoted original and signature.
+	StrippedText string `json:"stripped_text,omitempty"`
+	Timestamp    string `json:"timestamp"`
+}
+
+// GetThread returns the conversation a message belongs to, including replies.
+func (c *Client) GetThread(ctx context.Context, messageID string) (*Thread, error) {
+	if messageID == "" {
+		return nil, fmt.Errorf("message ID is required")
+	}
+
+	var result Thread
+	if err := c.do(ctx, http.MethodGet, "/sdk/v1/messages/"+url.PathEscape(messageID)+"/thread", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SendReply sends req as a follow-up to a previously sent message. To
+// defaults to the original recipient and Subject to "Re: " plus the original
+// subject, from Emails.GetEmailStatus. The email status doesn't include the
+// original's Message-ID header, so mail clients only thread the follow-up if
+// req.Headers carries ReplyHeaders for it.
+func (c *Client) SendReply(ctx context.Context, messageID string, req *OutgoingEmail) (string, error) {
+	if messageID == "" {
+		return "", fmt.Errorf("message ID is required")
+	}
+	if req == nil {
+		return "", fmt.Errorf("send request is required")
+	}
+
+	orig, err := c.Emails.GetEmailStatus(ctx, messageID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get message %s: %w", messageID, err)
+	}
+
+	reply := *req
+	if reply.To == "" {
+		reply.To = orig.To
+	}
+	if reply.Subject == "" && reply.TemplateSlug == "" {
+		reply.Subject = ReplySubject(orig.Subject)
+	}
+	return c.SendEmail(ctx, &reply)
+}
+
+// SendInboundReply sends req as a reply to an inbound email, e.g. from a
+// support inbox. To defaults to the message's Reply-To, or else its sender.
+func (c *Client) SendInboundReply(ctx context.Context, msg *InboundMessage, req *OutgoingEmail) (string, error) {
+	if msg == nil {
+		return "", fmt.Errorf("inbound message is required")
+	}
+	if req == nil {
+		return "", fmt.Errorf("send request is required")
+	}
+	if msg.MessageID == "" {
+		return "", fmt.Errorf("inbound message has no Message-ID to reply to")
+	}
+
+	var to string
+	if len(msg.ReplyTo) > 0 {
+		to = msg.ReplyTo[0].Address
+	} else if msg.From != nil {
+		to = msg.From.Address
+	}
+	references := msg.References
+	if len(references) == 0 && msg.InReplyTo != "" {
+		references = []string{msg.InReplyTo}
+	}
+
+	reply := replyRequest(req, to, msg.Subject, msg.MessageID, references)
+	return c.SendEmail(ctx, reply)
+}
+
+// ReplyHeaders returns the In-Reply-To and References headers of a reply to
+// the message with the given Message-ID and References.
+func ReplyHeaders(messageID string, references []string) map[string]string {
+	refs := make([]string, 0, len(references)+1)
+	for _, id := range references {
+		refs = append(refs, "<"+trimAngle(id)+">")
+	}
+	refs = append(refs, "<"+trimAngle(messageID)+">")
+
+	return map[string]string{
+		"In-Reply-To": "<" + trimAngle(messageID) + ">",
+		"References":  strings.Join(refs, " "),
+	}
+}
+
+// ReplySubject prefixes subject with "Re: " unless it already has the prefix.
+func ReplySubject(subject string) string {
+	if len(subject) >= 3 && strings.EqualFold(subject[:3], "re:") {
+		return subject
+	}
+	return "Re: " + subject
+}
+
+// replyRequest returns a copy of req threaded as a reply.
+func replyRequest(req *OutgoingEmail, to, subject, messageID string, references []string) *OutgoingEmail {
+	reply := *req
+	if reply.To == "" {
+		reply.To = to
+	}
+	if reply.Subject == "" && reply.TemplateSlug == "" {
+		reply.Subject = ReplySubject(subject)
+	}
+	reply.Headers = maps.Clone(req.Headers)
+	if reply.Headers == nil {
+		reply.Headers = map[string]string{}
+	}
+	maps.Copy(reply.Headers, ReplyHeaders(messageID, references))
+	return &reply
+}
+
+// OnReply registers a handler for replies to sent messages (email.replied
+// events), received by HandleLeveeWebhook and ConsumeEvents.
+func (c *Client) OnReply(fn func(ctx context.Context, reply *ReplyEventData) error) {
+	c.On(EventEmailReplied, func(ctx context.Context, event *WebhookEvent) error {
+		if event.Reply == nil {
+			return nil
+		}
+		return fn(ctx, event.Reply)
+	})
+}
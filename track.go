@@ -0,0 +1,184 @@
+package levee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// clientQueues holds the client's async event queues, created on first use.
+type clientQueues struct {
+	mu        sync.Mutex
+	batchSize int
+	interval  time.Duration
+	closed    bool
+	tracking  *asyncQueue[*TrackingEvent]
+	events    *asyncQueue[*EventRequest]
+}
+
+// WithEventBatching configures the async queues behind Track and the tracking
+// handlers: events are sent when batchSize accumulate or every flushInterval
+// (defaults: 100 events, 2s).
+func WithEventBatching(batchSize int, flushInterval time.Duration) ClientOption {
+	return func(c *Client) {
+		q := &c.state().queues
+		q.batchSize = batchSize
+		q.interval = flushInterval
+	}
+}
+
+// trackingQueue returns the queue that records email tracking events, or nil after Close.
+func (c *Client) trackingQueue() *asyncQueue[*TrackingEvent] {
+	q := &c.state().queues
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.tracking == nil && !q.closed {
+		q.tracking = newAsyncQueue(q.batchSize, q.interval, c.sendTrackingEvents, c.queueError("tracking"))
+	}
+	return q.tracking
+}
+
+// eventQueue returns the queue that sends custom events, or nil after Close.
+func (c *Client) eventQueue() *asyncQueue[*EventRequest] {
+	q := &c.state().queues
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.events == nil && !q.closed {
+		q.events = newAsyncQueue(q.batchSize, q.interval, c.sendEvents, c.queueError("custom"))
+	}
+	return q.events
+}
+
+// trackingConcurrency bounds the tracking events recorded in parallel per batch.
+const trackingConcurrency = 8
+
+// sendTrackingEvents records a batch of tracking events, up to
+// trackingConcurrency at a time, since the API records them one by one.
+func (c *Client) sendTrackingEvents(ctx context.Context, batch []*TrackingEvent) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	sem := make(chan struct{}, trackingConcurrency)
+	for _, event := range batch {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			if err := c.RecordTrackingEvent(ctx, event); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s event: %w", event.Type, err))
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// sendEvents sends a batch of custom events in one request.
+func (c *Client) sendEvents(ctx context.Context, batch []*EventRequest) error {
+	body := struct {
+		Events []*EventRequest `json:"events"`
+	}{batch}
+	return c.do(ctx, http.MethodPost, "/sdk/v1/events/batch", nil, body, nil)
+}
+
+// queueError logs failed batches, since queued events have no caller to return errors to.
+func (c *Client) queueError(queue string) func(error, int) {
+	return func(err error, batchSize int) {
+		c.log().Warn("failed to send queued events",
+			slog.String("queue", queue),
+			slog.Int("batch_size", batchSize),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// recordInBackground queues a tracking event without blocking the response.
+func (c *Client) recordInBackground(event *TrackingEvent) {
+	q := c.trackingQueue()
+	if q == nil {
+		c.log().Warn("dropped tracking event: client closed", slog.String("type", event.Type))
+		return
+	}
+	if err := q.enqueue(event); err != nil {
+		c.log().Warn("dropped tracking event", slog.String("type", event.Type), slog.String("error", err.Error()))
+	}
+}
+
+// Track records a custom behavioral event, such as "signed_up" or "cart_abandoned",
+// that segments and automations can key off. Events are queued and sent in batches
+// in the background; call Flush or Close before the process exits.
+// It returns ErrQueueFull if the event cannot be queued.
+//
+//	client.Track(ctx, "user@example.com", "plan_upgraded", map[string]any{
+//		"from": "free",
+//		"to":   "pro",
+//	})
+func (c *Client) Track(ctx context.Context, email, eventName string, properties map[string]interface{}) error {
+	if eventName == "" {
+		return fmt.Errorf("event name is required")
+	}
+	if email == "" {
+		return fmt.Errorf("email is required")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	q := c.eventQueue()
+	if q == nil {
+		return ErrQueueFull
+	}
+	return q.enqueue(&EventRequest{
+		Event:      eventName,
+		Email:      email,
+		Properties: properties,
+	})
+}
+
+// Flush sends all queued tracking and custom events and waits until they are
+// delivered. It returns the delivery errors of batches that failed since the
+// previous Flush, including batches sent in the background.
+func (c *Client) Flush(ctx context.Context) error {
+	q := &c.state().queues
+	q.mu.Lock()
+	tracking, events := q.tracking, q.events
+	q.mu.Unlock()
+
+	var errs []error
+	if tracking != nil {
+		errs = append(errs, tracking.flush(ctx))
+	}
+	if events != nil {
+		errs = append(errs, events.flush(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+// Close flushes the event queues and stops their workers. Events tracked after
+// Close are dropped. Call it during graceful shutdown.
+func (c *Client) Close(ctx context.Context) error {
+	q := &c.state().queues
+	q.mu.Lock()
+	q.closed = true
+	tracking, events := q.tracking, q.events
+	q.mu.Unlock()
+
+	var errs []error
+	if tracking != nil {
+		errs = append(errs, tracking.close(ctx))
+	}
+	if events != nil {
+		errs = append(errs, events.close(ctx))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,83 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// TrackingDomainVerificationPath is where Levee fetches the verification token
+// when verifying a branded tracking domain. It must be served at the domain root.
+const TrackingDomainVerificationPath = "/.well-known/levee-verification"
+
+// TrackingDomain is a branded domain (e.g. click.example.com) used in tracking URLs.
+type TrackingDomain struct {
+	Domain            string `json:"domain"`
+	Status            string `json:"status"` // "pending", "verified", "failed"
+	Verified          bool   `json:"verified"`
+	CNAMETarget       string `json:"cname_target"`
+	VerificationToken string `json:"verification_token"`
+	VerifiedAt        string `json:"verified_at,omitempty"`
+	Error             string `json:"error,omitempty"`
+}
+
+// AddTrackingDomain registers a branded tracking domain and returns the CNAME target
+// and verification token to publish.
+func (c *Client) AddTrackingDomain(ctx context.Context, domain string) (*TrackingDomain, error) {
+	var result TrackingDomain
+	err := c.do(ctx, http.MethodPost, "/sdk/v1/tracking/domains", nil, map[string]string{
+		"domain": domain,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// VerifyTrackingDomain asks Levee to check the domain's CNAME record and verification
+// file, returning the updated status.
+func (c *Client) VerifyTrackingDomain(ctx context.Context, domain string) (*TrackingDomain, error) {
+	var result TrackingDomain
+	path := "/sdk/v1/tracking/domains/" + url.PathEscape(domain) + "/verify"
+	if err := c.do(ctx, http.MethodPost, path, nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CheckTrackingCNAME resolves domain locally and reports whether its CNAME points at
+// target, so setup tooling can check DNS before calling VerifyTrackingDomain.
+func CheckTrackingCNAME(ctx context.Context, domain, target string) (bool, error) {
+	cname, err := net.DefaultResolver.LookupCNAME(ctx, domain)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve CNAME for %s: %w", domain, err)
+	}
+	return strings.EqualFold(strings.TrimSuffix(cname, "."), strings.TrimSuffix(target, ".")), nil
+}
+
+// HandleTrackingDomainVerification returns a handler serving the verification token
+// configured with WithTrackingDomainToken. Mount it at the root of the tracking domain:
+//
+//	mux.Handle(levee.TrackingDomainVerificationPath, client.HandleTrackingDomainVerification(cfg))
+//
+// Route: GET /.well-known/levee-verification
+func (c *Client) HandleTrackingDomainVerification(cfg *HandlerConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			cfg.renderError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed)
+			return
+		}
+
+		if cfg.TrackingDomainToken == "" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.Write([]byte(cfg.TrackingDomainToken))
+	}
+}
@@ -0,0 +1,206 @@
+package levee
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strings"
+)
+
+// Unsubscribe survey reasons.
+const (
+	UnsubscribeReasonTooFrequent   = "too_frequent"
+	UnsubscribeReasonNotRelevant   = "not_relevant"
+	UnsubscribeReasonNeverSignedUp = "never_signed_up"
+	UnsubscribeReasonOther         = "other"
+)
+
+// UnsubscribeSurveyOption is a selectable answer in the unsubscribe survey.
+type UnsubscribeSurveyOption struct {
+	Value string
+	Label string
+}
+
+// UnsubscribeSurveyData is passed to the unsubscribe survey template.
+type UnsubscribeSurveyData struct {
+	// Action is the URL the form must POST to.
+	Action string
+	// SkipURL is where the user lands when skipping the survey.
+	SkipURL string
+	Options []UnsubscribeSurveyOption
+}
+
+// defaultUnsubscribeSurveyOptions are the answers offered by the survey.
+var defaultUnsubscribeSurveyOptions = []UnsubscribeSurveyOption{
+	{UnsubscribeReasonTooFrequent, "I get too many emails"},
+	{UnsubscribeReasonNotRelevant, "The content isn't relevant to me"},
+	{UnsubscribeReasonNeverSignedUp, "I never signed up"},
+	{UnsubscribeReasonOther, "Other"},
+}
+
+// defaultUnsubscribeSurveyTemplate is the built-in survey page.
+var defaultUnsubscribeSurveyTemplate = template.Must(template.New("unsubscribe-survey").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>You've been unsubscribed</title>
+<style>
+body{font-family:system-ui,sans-serif;max-width:32rem;margin:3rem auto;padding:0 1rem;color:#222}
+label{display:block;margin:.5rem 0}
+textarea{width:100%;min-height:4rem;margin-top:.5rem}
+button{margin-top:1rem;padding:.5rem 1rem}
+</style>
+</head>
+<body>
+<h1>You've been unsubscribed</h1>
+<p>You won't receive these emails anymore. Would you tell us why?</p>
+<form method="post" action="{{.Action}}">
+{{range .Options}}<label><input type="radio" name="reason" value="{{.Value}}" required> {{.Label}}</label>
+{{end}}<textarea name="comment" placeholder="Anything else? (optional)" maxlength="1000"></textarea>
+<button type="submit">Send feedback</button>
+</form>
+<p><a href="{{.SkipURL}}">Skip</a></p>
+</body>
+</html>
+`))
+
+// UnsubscribeConfirmData is passed to the unsubscribe confirm template.
+type UnsubscribeConfirmData struct {
+	// Action is the URL the form must POST to.
+	Action string
+}
+
+// defaultUnsubscribeConfirmTemplate is the built-in two-step unsubscribe page.
+var defaultUnsubscribeConfirmTemplate = template.Must(template.New("unsubscribe-confirm").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Unsubscribe</title>
+<style>
+body{font-family:system-ui,sans-serif;max-width:32rem;margin:3rem auto;padding:0 1rem;color:#222}
+button{margin-top:1rem;padding:.5rem 1rem}
+</style>
+</head>
+<body>
+<h1>Unsubscribe</h1>
+<p>Click below to stop receiving these emails.</p>
+<form method="post" action="{{.Action}}">
+<button type="submit">Unsubscribe</button>
+</form>
+</body>
+</html>
+`))
+
+// renderUnsubscribeConfirm renders the confirm button page of two-step unsubscribe.
+// In JSON mode it responds with ConfirmRequired so the frontend can POST.
+func renderUnsubscribeConfirm(cfg *HandlerConfig, w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	if cfg.JSONResponses {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(HandlerResult{ConfirmRequired: true})
+		return
+	}
+
+	tmpl := cfg.UnsubscribeConfirmTemplate
+	if tmpl == nil {
+		tmpl = defaultUnsubscribeConfirmTemplate
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	tmpl.Execute(w, UnsubscribeConfirmData{Action: r.URL.Path})
+}
+
+// prefetchUserAgents are substrings of user agents used by link scanners,
+// security gateways, and preview bots that follow links in received email.
+var prefetchUserAgents = []string{
+	"barracuda",
+	"proofpoint",
+	"mimecast",
+	"forcepoint",
+	"symantec",
+	"trendmicro",
+	"safelinks",
+	"googleimageproxy",
+	"slackbot",
+	"linkexpanding",
+	"facebookexternalhit",
+	"discordbot",
+	"whatsapp",
+	"bot",
+	"crawler",
+	"spider",
+	"preview",
+	"curl/",
+	"wget/",
+	"python-requests",
+	"go-http-client",
+}
+
+// IsPrefetchRequest reports whether r looks like an automated fetch rather than a
+// person clicking a link: speculative prefetch headers, HEAD requests, a missing
+// user agent, or a user agent of a known link scanner or preview bot.
+func IsPrefetchRequest(r *http.Request) bool {
+	if r.Method == http.MethodHead {
+		return true
+	}
+	for _, header := range []string{"Purpose", "Sec-Purpose", "X-Purpose", "X-Moz"} {
+		v := strings.ToLower(r.Header.Get(header))
+		if strings.Contains(v, "prefetch") || strings.Contains(v, "preview") {
+			return true
+		}
+	}
+
+	ua := strings.ToLower(r.UserAgent())
+	if ua == "" {
+		return true
+	}
+	for _, s := range prefetchUserAgents {
+		if strings.Contains(ua, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// renderUnsubscribeSurvey renders the reason form after a successful unsubscribe.
+func renderUnsubscribeSurvey(cfg *HandlerConfig, w http.ResponseWriter, r *http.Request) {
+	tmpl := cfg.UnsubscribeSurveyTemplate
+	if tmpl == nil {
+		tmpl = defaultUnsubscribeSurveyTemplate
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	tmpl.Execute(w, UnsubscribeSurveyData{
+		Action:  r.URL.Path,
+		SkipURL: cfg.UnsubscribeRedirect,
+		Options: defaultUnsubscribeSurveyOptions,
+	})
+}
+
+// handleUnsubscribeReason records a submitted survey answer and redirects.
+// The form has already been parsed by HandleUnsubscribe.
+// Failures to record the reason are not surfaced: the unsubscribe itself already succeeded.
+func (c *Client) handleUnsubscribeReason(cfg *HandlerConfig, w http.ResponseWriter, r *http.Request, token string) {
+	reason := r.PostForm.Get("reason")
+	if !validUnsubscribeReason(reason) {
+		reason = UnsubscribeReasonOther
+	}
+	_ = c.RecordUnsubscribeReason(r.Context(), token, reason, r.PostForm.Get("comment"))
+
+	cfg.redirect(w, r, cfg.UnsubscribeRedirect, true)
+}
+
+// validUnsubscribeReason reports whether reason is one of the survey answers.
+func validUnsubscribeReason(reason string) bool {
+	for _, opt := range defaultUnsubscribeSurveyOptions {
+		if opt.Value == reason {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,201 @@
+package levee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/mail"
+	"slices"
+	"strings"
+)
+
+// EmailVerdict is the overall result of ValidateEmail.
+type EmailVerdict string
+
+// Email verdicts, from best to worst.
+const (
+	EmailDeliverable   EmailVerdict = "deliverable"
+	EmailRisky         EmailVerdict = "risky"
+	EmailUndeliverable EmailVerdict = "undeliverable"
+)
+
+// Reasons reported by the local checks of ValidateEmail. The verification
+// endpoint may add others, such as "mailbox_not_found" or "catch_all".
+const (
+	ReasonInvalidSyntax   = "invalid_syntax"
+	ReasonNoMXRecords     = "no_mx_records"
+	ReasonMXLookupFailed  = "mx_lookup_failed"
+	ReasonDisposableEmail = "disposable_domain"
+)
+
+// EmailValidation is the result of ValidateEmail.
+type EmailValidation struct {
+	Email   string       `json:"email"`
+	Verdict EmailVerdict `json:"verdict"`
+	// Reasons explains a risky or undeliverable verdict.
+	Reasons    []string `json:"reasons,omitempty"`
+	Disposable bool     `json:"disposable"`
+	// HasMX reports that the domain accepts mail: it has MX records or, lacking
+	// them, an address record used as an implicit MX (RFC 5321 §5.1).
+	HasMX bool `json:"has_mx"`
+	// Suggestion is a likely correction for a mistyped domain, e.g. "user@gmail.com".
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// ValidateEmail checks an address before it is added to a list. Local checks
+// (syntax, MX records, disposable domains) run first; addresses that fail them
+// are rejected without calling the API. Otherwise Levee's verification endpoint
+// is consulted and the worse of the two verdicts is returned.
+//
+//	v, err := client.ValidateEmail(ctx, form.Email)
+//	if err != nil {
+//		return err
+//	}
+//	if v.Verdict == levee.EmailUndeliverable {
+//		return fmt.Errorf("please check your email address (%s)", strings.Join(v.Reasons, ", "))
+//	}
+func (c *Client) ValidateEmail(ctx context.Context, email string) (*EmailValidation, error) {
+	result := validateEmailLocal(ctx, email)
+	if result.Verdict == EmailUndeliverable {
+		return result, nil
+	}
+
+	var remote EmailValidation
+	body := map[string]string{"email": result.Email}
+	if err := c.do(ctx, http.MethodPost, "/sdk/v1/emails/validate", nil, body, &remote); err != nil {
+		return nil, fmt.Errorf("failed to verify email: %w", err)
+	}
+
+	result.Verdict = worseVerdict(result.Verdict, remote.Verdict)
+	for _, reason := range remote.Reasons {
+		if !slices.Contains(result.Reasons, reason) {
+			result.Reasons = append(result.Reasons, reason)
+		}
+	}
+	result.Disposable = result.Disposable || remote.Disposable
+	result.Suggestion = remote.Suggestion
+	return result, nil
+}
+
+// validateEmailLocal runs the syntax, MX, and disposable-domain checks.
+func validateEmailLocal(ctx context.Context, email string) *EmailValidation {
+	result := &EmailValidation{Email: strings.TrimSpace(email), Verdict: EmailDeliverable}
+
+	addr, err := mail.ParseAddress(result.Email)
+	if err != nil || addr.Name != "" || addr.Address != result.Email {
+		result.Verdict = EmailUndeliverable
+		result.Reasons = []string{ReasonInvalidSyntax}
+		return result
+	}
+	_, domain, _ := strings.Cut(strings.ToLower(addr.Address), "@")
+	if !strings.Contains(domain, ".") {
+		result.Verdict = EmailUndeliverable
+		result.Reasons = []string{ReasonInvalidSyntax}
+		return result
+	}
+
+	if IsDisposableDomain(domain) {
+		result.Disposable = true
+		result.Verdict = EmailRisky
+		result.Reasons = append(result.Reasons, ReasonDisposableEmail)
+	}
+
+	mx, err := net.DefaultResolver.LookupMX(ctx, domain)
+	var dnsErr *net.DNSError
+	switch {
+	case err == nil && len(mx) == 1 && mx[0].Host == ".":
+		// A null MX (RFC 7505): the domain accepts no mail.
+		result.Verdict = EmailUndeliverable
+		result.Reasons = append(result.Reasons, ReasonNoMXRecords)
+	case err == nil && len(mx) > 0:
+		result.HasMX = true
+	case err == nil, errors.As(err, &dnsErr) && dnsErr.IsNotFound:
+		// No MX records: mail goes to the domain's own address, if it has one.
+		checkImplicitMX(ctx, domain, result)
+	default:
+		result.Verdict = worseVerdict(result.Verdict, EmailRisky)
+		result.Reasons = append(result.Reasons, ReasonMXLookupFailed)
+	}
+
+	return result
+}
+
+// checkImplicitMX looks up the A and AAAA records of a domain without MX
+// records, which senders use as an implicit MX (RFC 5321 §5.1).
+func checkImplicitMX(ctx context.Context, domain string, result *EmailValidation) {
+	addrs, err := net.DefaultResolver.LookupHost(ctx, domain)
+	var dnsErr *net.DNSError
+	switch {
+	case err == nil && len(addrs) > 0:
+		result.HasMX = true
+	case err == nil, errors.As(err, &dnsErr) && dnsErr.IsNotFound:
+		result.Verdict = EmailUndeliverable
+		result.Reasons = append(result.Reasons, ReasonNoMXRecords)
+	default:
+		result.Verdict = worseVerdict(result.Verdict, EmailRisky)
+		result.Reasons = append(result.Reasons, ReasonMXLookupFailed)
+	}
+}
+
+// worseVerdict returns the worse of two verdicts. Unknown verdicts are ignored.
+func worseVerdict(a, b EmailVerdict) EmailVerdict {
+	rank := map[EmailVerdict]int{EmailDeliverable: 0, EmailRisky: 1, EmailUndeliverable: 2}
+	if rb, ok := rank[b]; ok && rb > rank[a] {
+		return b
+	}
+	return a
+}
+
+// disposableDomains lists common disposable email providers.
+var disposableDomains = map[string]bool{
+	"10minutemail.com":  true,
+	"20minutemail.com":  true,
+	"33mail.com":        true,
+	"dispostable.com":   true,
+	"dropmail.me":       true,
+	"emailondeck.com":   true,
+	"fakeinbox.com":     true,
+	"getairmail.com":    true,
+	"getnada.com":       true,
+	"guerrillamail.com": true,
+	"guerrillamail.net": true,
+	"guerrillamail.org": true,
+	"harakirimail.com":  true,
+	"maildrop.cc":       true,
+	"mailinator.com":    true,
+	"mailnesia.com":     true,
+	"mintemail.com":     true,
+	"mohmal.com":        true,
+	"mytemp.email":      true,
+	"sharklasers.com":   true,
+	"spamgourmet.com":   true,
+	"temp-mail.org":     true,
+	"tempail.com":       true,
+	"tempmail.com":      true,
+	"tempmailo.com":     true,
+	"tempr.email":       true,
+	"throwawaymail.com": true,
+	"trashmail.com":     true,
+	"yopmail.com":       true,
+	"yopmail.net":       true,
+}
+
+// IsDisposableDomain reports whether domain, or a parent domain, belongs to a
+// known disposable email provider. The built-in list covers common providers;
+// Levee's verification endpoint checks a larger, regularly updated list.
+func IsDisposableDomain(domain string) bool {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	for domain != "" {
+		if disposableDomains[domain] {
+			return true
+		}
+		_, parent, ok := strings.Cut(domain, ".")
+		if !ok {
+			return false
+		}
+		domain = parent
+	}
+	return false
+}
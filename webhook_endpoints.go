@@ -0,0 +1,88 @@
+package levee
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// WebhookSecretRotation is the result of RotateWebhookSecret.
+type WebhookSecretRotation struct {
+	Secret string `json:"secret"`
+	// PreviousSecretExpiresAt is when Levee stops signing with the old secret (RFC 3339).
+	PreviousSecretExpiresAt string `json:"previous_secret_expires_at,omitempty"`
+}
+
+// CreateWebhookEndpoint registers an active endpoint receiving events (e.g.
+// EventEmailBounced, or EventAll for everything) and returns it with its signing secret.
+func (c *Client) CreateWebhookEndpoint(ctx context.Context, endpointURL string, events []string) (*WebhookInfo, string, error) {
+	if endpointURL == "" {
+		return nil, "", fmt.Errorf("webhook url is required")
+	}
+	if len(events) == 0 {
+		return nil, "", fmt.Errorf("at least one event type is required")
+	}
+
+	resp, err := c.Webhooks.RegisterWebhook(ctx, &RegisterWebhookRequest{Url: endpointURL, Events: events, Active: true})
+	if err != nil {
+		return nil, "", err
+	}
+	if !resp.Success || resp.WebhookID == "" {
+		return nil, "", fmt.Errorf("failed to register webhook: %s", resp.Message)
+	}
+	return &WebhookInfo{ID: resp.WebhookID, Url: endpointURL, Events: events, Active: true}, resp.Secret, nil
+}
+
+// ListWebhookEndpoints returns all registered endpoints.
+func (c *Client) ListWebhookEndpoints(ctx context.Context) ([]WebhookInfo, error) {
+	resp, err := c.Webhooks.ListWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Webhooks, nil
+}
+
+// RotateWebhookSecret issues a new signing secret for an endpoint. Levee keeps
+// signing with the old secret as well for gracePeriod (0 revokes it immediately),
+// so receivers can deploy the new secret with WithLeveeWebhookSecret(newSecret, oldSecret).
+func (c *Client) RotateWebhookSecret(ctx context.Context, endpointID string, gracePeriod time.Duration) (*WebhookSecretRotation, error) {
+	if endpointID == "" {
+		return nil, fmt.Errorf("webhook ID is required")
+	}
+	body := map[string]int{"grace_period_seconds": int(gracePeriod.Seconds())}
+
+	var result WebhookSecretRotation
+	path := "/sdk/v1/webhooks/" + url.PathEscape(endpointID) + "/rotate-secret"
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// EnsureWebhookEndpoint makes sure an active endpoint for endpointURL exists
+// with the given events, registering or updating it as needed. It is safe to
+// call on every deploy. The secret is only returned when the endpoint was registered.
+func (c *Client) EnsureWebhookEndpoint(ctx context.Context, endpointURL string, events []string) (*WebhookInfo, string, error) {
+	if endpointURL == "" {
+		return nil, "", fmt.Errorf("webhook url is required")
+	}
+
+	existing, err := c.ListWebhookEndpoints(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, e := range existing {
+		if e.Url != endpointURL {
+			continue
+		}
+		if e.Active && slices.Equal(slices.Sorted(slices.Values(e.Events)), slices.Sorted(slices.Values(events))) {
+			return &e, "", nil
+		}
+		updated, err := c.Webhooks.UpdateWebhook(ctx, e.ID, &UpdateWebhookRequest{Url: endpointURL, Events: events, Active: true})
+		return updated, "", err
+	}
+	return c.CreateWebhookEndpoint(ctx, endpointURL, events)
+}
@@ -0,0 +1,185 @@
+package levee
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Levee webhook event types
+const (
+	EventContactCreated      = "contact.created"
+	EventContactUpdated      = "contact.updated"
+	EventContactUnsubscribed = "contact.unsubscribed"
+	EventContactBounced      = "contact.bounced"
+
+	EventEmailSent       = "email.sent"
+	EventEmailDelivered  = "email.delivered"
+	EventEmailOpened     = "email.opened"
+	EventEmailClicked    = "email.clicked"
+	EventEmailBounced    = "email.bounced"
+	EventEmailComplained = "email.complained"
+	EventEmailReplied    = "email.replied"
+
+	EventSequenceEnrolled  = "sequence.enrolled"
+	EventSequenceCompleted = "sequence.completed"
+	EventSequencePaused    = "sequence.paused"
+	EventSequenceResumed   = "sequence.resumed"
+
+	EventPaymentSucceeded = "payment.succeeded"
+	EventPaymentFailed    = "payment.failed"
+	EventPaymentRefunded  = "payment.refunded"
+
+	EventSubscriptionCreated   = "subscription.created"
+	EventSubscriptionUpdated   = "subscription.updated"
+	EventSubscriptionCancelled = "subscription.cancelled"
+	EventSubscriptionRenewed   = "subscription.renewed"
+
+	EventOrderCreated   = "order.created"
+	EventOrderCompleted = "order.completed"
+	EventOrderRefunded  = "order.refunded"
+
+	// EventAll matches every event type when registering a handler.
+	EventAll = "*"
+)
+
+// LeveeSignatureHeader is the header carrying the signature of Levee webhook deliveries.
+const LeveeSignatureHeader = "Levee-Signature"
+
+// DefaultWebhookTolerance is the maximum age of a signed webhook delivery.
+const DefaultWebhookTolerance = 5 * time.Minute
+
+// WebhookEvent is an event pushed by Levee to a registered webhook endpoint.
+type WebhookEvent struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt string          `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+
+	// Email is populated for email.* events.
+	Email *EmailEventData `json:"-"`
+	// Contact is populated for contact.* events.
+	Contact *ContactEventData `json:"-"`
+	// Reply is populated for email.replied events (Email is nil for them).
+	Reply *ReplyEventData `json:"-"`
+}
+
+// EmailEventData is the payload of email.* events.
+type EmailEventData struct {
+	MessageID    string            `json:"message_id"`
+	Email        string            `json:"email"`
+	ContactID    string            `json:"contact_id,omitempty"`
+	Subject      string            `json:"subject,omitempty"`
+	TemplateSlug string            `json:"template_slug,omitempty"`
+	SequenceSlug string            `json:"sequence_slug,omitempty"`
+	CampaignID   string            `json:"campaign_id,omitempty"`
+	URL          string            `json:"url,omitempty"`         // email.clicked
+	BounceType   string            `json:"bounce_type,omitempty"` // email.bounced: "hard", "soft"
+	Reason       string            `json:"reason,omitempty"`
+	IP           string            `json:"ip,omitempty"`
+	UserAgent    string            `json:"user_agent,omitempty"`
+	Tags         []string          `json:"tags,omitempty"`
+	Meta         map[string]string `json:"meta,omitempty"`
+	Timestamp    string            `json:"timestamp"`
+}
+
+// ContactEventData is the payload of contact.* events.
+type ContactEventData struct {
+	Contact SDKContactInfo `json:"contact"`
+	// Changes maps changed field names to their new values (contact.updated).
+	Changes map[string]interface{} `json:"changes,omitempty"`
+	Reason  string                 `json:"reason,omitempty"`
+}
+
+// Decode unmarshals the raw event data into v.
+// Use this for event types without a typed field on WebhookEvent.
+func (e *WebhookEvent) Decode(v interface{}) error {
+	if len(e.Data) == 0 {
+		return fmt.Errorf("event %s has no data", e.ID)
+	}
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return fmt.Errorf("failed to decode %s event data: %w", e.Type, err)
+	}
+	return nil
+}
+
+// ParseWebhookEvent parses a Levee webhook payload into a WebhookEvent,
+// populating the typed data field that matches the event type.
+func ParseWebhookEvent(payload []byte) (*WebhookEvent, error) {
+	var event WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook event: %w", err)
+	}
+	if event.Type == "" {
+		return nil, fmt.Errorf("webhook event is missing type")
+	}
+
+	switch {
+	case event.Type == EventEmailReplied:
+		event.Reply = &ReplyEventData{}
+		if err := event.Decode(event.Reply); err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(event.Type, "email."):
+		event.Email = &EmailEventData{}
+		if err := event.Decode(event.Email); err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(event.Type, "contact."):
+		event.Contact = &ContactEventData{}
+		if err := event.Decode(event.Contact); err != nil {
+			return nil, err
+		}
+	}
+
+	return &event, nil
+}
+
+// VerifyWebhookSignature verifies the Levee-Signature header of a webhook delivery.
+// The header has the form "t=<unix timestamp>,v1=<hex hmac-sha256>", where the
+// HMAC is computed over "<timestamp>.<payload>" with the webhook secret.
+// Deliveries older than tolerance are rejected; a zero tolerance disables the check.
+func VerifyWebhookSignature(payload []byte, signature, secret string, tolerance time.Duration) bool {
+	timestamp, sig := parseSignatureHeader(signature)
+	if timestamp == "" || sig == "" {
+		return false
+	}
+
+	if tolerance > 0 {
+		ts, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return false
+		}
+		if time.Since(time.Unix(ts, 0)).Abs() > tolerance {
+			return false
+		}
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + string(payload)))
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	return hmac.Equal([]byte(expected), []byte(sig))
+}
+
+// EventHandler handles a Levee webhook event.
+// Returning an error makes the webhook handler respond with a 5xx so Levee retries the delivery.
+type EventHandler func(ctx context.Context, event *WebhookEvent) error
+
+// dispatchEvent calls the handlers registered for the event type and for EventAll.
+func dispatchEvent(ctx context.Context, handlers map[string][]EventHandler, event *WebhookEvent) error {
+	for _, key := range []string{event.Type, EventAll} {
+		for _, h := range handlers[key] {
+			if err := h(ctx, event); err != nil {
+				return fmt.Errorf("%s handler failed: %w", event.Type, err)
+			}
+		}
+	}
+	return nil
+}
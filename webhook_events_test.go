@@ -0,0 +1,121 @@
+package levee
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// hmacHex returns the hex HMAC-SHA256 of message under secret.
+func hmacHex(secret, message string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(message))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	const secret = "levee-secret"
+	payload := []byte(`{"id":"evt_1","type":"email.opened"}`)
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	sign := func(secret, ts string) string {
+		return "t=" + ts + ",v1=" + hmacHex(secret, ts+"."+string(payload))
+	}
+
+	tests := []struct {
+		name      string
+		signature string
+		tolerance time.Duration
+		ok        bool
+	}{
+		{"valid", sign(secret, now), DefaultWebhookTolerance, true},
+		{"fields in any order", "v1=" + hmacHex(secret, now+"."+string(payload)) + ",t=" + now, DefaultWebhookTolerance, true},
+		{"unknown fields ignored", sign(secret, now) + ",v0=legacy", DefaultWebhookTolerance, true},
+		{"wrong secret", sign("other", now), DefaultWebhookTolerance, false},
+		{"stale", sign(secret, stale), DefaultWebhookTolerance, false},
+		{"stale without tolerance", sign(secret, stale), 0, true},
+		{"timestamp not a number", sign(secret, "now"), DefaultWebhookTolerance, false},
+		{"missing v1", "t=" + now, DefaultWebhookTolerance, false},
+		{"missing timestamp", "v1=" + hmacHex(secret, now+"."+string(payload)), 0, false},
+		{"empty", "", DefaultWebhookTolerance, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyWebhookSignature(payload, tt.signature, secret, tt.tolerance); got != tt.ok {
+				t.Errorf("VerifyWebhookSignature(%q) = %v, want %v", tt.signature, got, tt.ok)
+			}
+		})
+	}
+}
+
+func TestVerifyLeveeSignatureRotation(t *testing.T) {
+	payload := []byte(`{"id":"evt_1"}`)
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	cfg := &HandlerConfig{
+		LeveeWebhookSecret:          "current",
+		LeveeWebhookPreviousSecrets: []string{"", "previous"},
+	}
+
+	tests := []struct {
+		secret string
+		ok     bool
+	}{
+		{"current", true},
+		{"previous", true},
+		{"retired", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.secret, func(t *testing.T) {
+			signature := "t=" + now + ",v1=" + hmacHex(tt.secret, now+"."+string(payload))
+			if got := cfg.verifyLeveeSignature(payload, signature); got != tt.ok {
+				t.Errorf("signed with %q: verifyLeveeSignature() = %v, want %v", tt.secret, got, tt.ok)
+			}
+		})
+	}
+}
+
+func TestHandleLeveeWebhook(t *testing.T) {
+	payload := `{"id":"evt_1","type":"custom.event","data":{}}`
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	signature := "t=" + now + ",v1=" + hmacHex("current", now+"."+payload)
+
+	tests := []struct {
+		name      string
+		opts      []HandlerOption
+		signature string
+		body      string
+		want      int
+	}{
+		{"signed", []HandlerOption{WithLeveeWebhookSecret("current")}, signature, payload, http.StatusOK},
+		{"bad signature", []HandlerOption{WithLeveeWebhookSecret("other")}, signature, payload, http.StatusUnauthorized},
+		{"no secret configured", nil, signature, payload, http.StatusUnauthorized},
+		{"unsigned without secret", nil, "", payload, http.StatusUnauthorized},
+		{"body too large", []HandlerOption{WithLeveeWebhookSecret("current")}, signature, strings.Repeat("x", maxLeveeWebhookSize+1), http.StatusBadRequest},
+	}
+
+	client, err := NewClient("key", "https://levee.test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/levee/webhooks/levee", strings.NewReader(tt.body))
+			r.Header.Set(LeveeSignatureHeader, tt.signature)
+			w := httptest.NewRecorder()
+
+			client.HandleLeveeWebhook(NewHandlerConfig(tt.opts...))(w, r)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
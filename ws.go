@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/almatuck/levee-go/llmpb"
 	"github.com/gorilla/websocket"
@@ -23,6 +24,11 @@ const (
 	WSMsgTypeStarted    = "started"
 	WSMsgTypeToolCall   = "tool_call"
 	WSMsgTypeToolResult = "tool_result"
+	WSMsgTypeAudio      = "audio"
+
+	// Tool calls the model makes together, and their results
+	WSMsgTypeToolCalls   = "tool_calls"
+	WSMsgTypeToolResults = "tool_results"
 )
 
 // WSMessage is the base WebSocket message envelope.
@@ -38,11 +44,40 @@ type WSStartRequest struct {
 	MaxTokens    int32         `json:"max_tokens,omitempty"`
 	Temperature  float32       `json:"temperature,omitempty"`
 	Messages     []ChatMessage `json:"messages,omitempty"`
+	// SessionID resumes a conversation from the LLM client's ConversationStore.
+	SessionID string `json:"session_id,omitempty"`
+	// Voice enables spoken replies: each completion is followed by "audio"
+	// messages with the synthesized speech. AudioFormat defaults to "mp3".
+	Voice       string `json:"voice,omitempty"`
+	AudioFormat string `json:"audio_format,omitempty"`
+	// Provider and Routing select the provider ("anthropic", "openai",
+	// "local") or routing policy ("cheapest", "fastest", "sticky").
+	Provider Provider      `json:"provider,omitempty"`
+	Routing  RoutingPolicy `json:"routing,omitempty"`
+	// ResumeSessionID reattaches to a gateway session after a dropped
+	// connection, from the session_id of its "started" message. Chunks after
+	// LastChunkIndex of a turn in flight are replayed; omit it to replay the
+	// whole turn. Other fields are ignored when resuming.
+	ResumeSessionID string `json:"resume_session_id,omitempty"`
+	LastChunkIndex  *int32 `json:"last_chunk_index,omitempty"`
+	// Sampling parameters; zero values use the model defaults.
+	StopSequences    []string `json:"stop_sequences,omitempty"`
+	TopP             float32  `json:"top_p,omitempty"`
+	TopK             int32    `json:"top_k,omitempty"`
+	FrequencyPenalty float32  `json:"frequency_penalty,omitempty"`
+	PresencePenalty  float32  `json:"presence_penalty,omitempty"`
+	Seed             *int64   `json:"seed,omitempty"`
+	Deterministic    bool     `json:"deterministic,omitempty"`
+	// Examples are few-shot input/reply pairs placed before the conversation;
+	// they are not stored.
+	Examples []ExamplePair `json:"examples,omitempty"`
 }
 
 // WSUserMessage sends a user message.
 type WSUserMessage struct {
-	Content string `json:"content"`
+	Content string      `json:"content"`
+	Images  []ImagePart `json:"images,omitempty"` // url, or base64 data with media_type
+	Audio   []AudioPart `json:"audio,omitempty"`  // speech, transcribed by the gateway
 }
 
 // WSAbortRequest aborts the current generation.
@@ -57,17 +92,39 @@ type WSToolResult struct {
 	IsError    bool   `json:"is_error,omitempty"`
 }
 
+// WSToolResults provides results of several tool calls, keyed by
+// tool_call_id.
+type WSToolResults struct {
+	Results []WSToolResult `json:"results"`
+}
+
 // WSStartedResponse confirms session started.
 type WSStartedResponse struct {
 	SessionID string `json:"session_id"`
 	Provider  string `json:"provider"`
 	Model     string `json:"model"`
+	// Resumed is set when the session was resumed; Generating when a turn
+	// was in flight and its remaining chunks follow.
+	Resumed    bool `json:"resumed,omitempty"`
+	Generating bool `json:"generating,omitempty"`
 }
 
 // WSChunkResponse streams content chunks.
 type WSChunkResponse struct {
 	Content string `json:"content"`
 	Index   int32  `json:"index"`
+	// Running token counts of the reply, when the gateway reports them.
+	InputTokens  int64 `json:"input_tokens,omitempty"`
+	OutputTokens int64 `json:"output_tokens,omitempty"`
+}
+
+// WSAudioResponse streams synthesized speech of a reply. The last message of
+// a reply has Final set and no data.
+type WSAudioResponse struct {
+	Data      []byte `json:"data,omitempty"` // base64
+	MediaType string `json:"media_type,omitempty"`
+	Index     int32  `json:"index"`
+	Final     bool   `json:"final,omitempty"`
 }
 
 // WSToolCallResponse indicates LLM wants to call a tool.
@@ -77,6 +134,12 @@ type WSToolCallResponse struct {
 	ArgumentsJSON string `json:"arguments_json"`
 }
 
+// WSToolCallsResponse holds tool calls the LLM made together. Reply with one
+// "tool_results" message or a "tool_result" per call.
+type WSToolCallsResponse struct {
+	Calls []WSToolCallResponse `json:"calls"`
+}
+
 // WSCompletionResponse indicates generation complete.
 type WSCompletionResponse struct {
 	FullContent  string  `json:"full_content"`
@@ -85,6 +148,15 @@ type WSCompletionResponse struct {
 	OutputTokens int64   `json:"output_tokens"`
 	CostUSD      float64 `json:"cost_usd"`
 	LatencyMs    int64   `json:"latency_ms"`
+	Provider     string  `json:"provider,omitempty"`
+	Model        string  `json:"model,omitempty"`
+	QueueWaitMs  int64   `json:"queue_wait_ms,omitempty"`
+	Fingerprint  string  `json:"fingerprint,omitempty"`
+	// Streaming latency: time to first chunk, and mean and longest time
+	// between chunks.
+	TTFTMs        int64 `json:"ttft_ms,omitempty"`
+	ChunkGapMs    int64 `json:"chunk_gap_ms,omitempty"`
+	MaxChunkGapMs int64 `json:"max_chunk_gap_ms,omitempty"`
 }
 
 // WSErrorResponse indicates an error.
@@ -149,17 +221,41 @@ func (c *Client) HandleChatWebSocket(llm *LLMClient, opts ...WSOption) http.Hand
 		}
 
 		session.run()
+
+		session.streamMu.Lock()
+		session.releaseGeneration()
+		session.streamMu.Unlock()
 	}
 }
 
 // wsSession manages a single WebSocket chat session.
 type wsSession struct {
-	conn     *websocket.Conn
-	llm      *LLMClient
-	ctx      context.Context
-	stream   llmpb.LLMService_ChatClient
-	sendMu   sync.Mutex
-	started  bool
+	conn    *websocket.Conn
+	llm     *LLMClient
+	ctx     context.Context
+	stream  llmpb.LLMService_ChatClient
+	sendMu  sync.Mutex
+	started bool
+
+	// streamMu guards stream and the conversation replayed on reconnect.
+	streamMu  sync.Mutex
+	start     *llmpb.StartChatRequest
+	history   []*llmpb.Message
+	pending   *ChatMessage   // user message of the turn in flight
+	sessionID string         // ConversationStore key, if any
+	speech    *SpeechRequest // voice settings for spoken replies, if enabled
+
+	sentAt time.Time   // when the turn in flight was sent, for usage metrics
+	timer  streamTimer // streaming latency of the turn in flight
+
+	gatewayID string // gateway session ID, for audit records
+
+	release   func()        // frees the generation slot of the turn in flight
+	queueWait time.Duration // time the turn in flight waited for its slot
+
+	guard     *guardRun // output guards of the turn in flight
+	violated  bool      // the turn in flight broke an output guard
+	lastIndex int32     // index of the last chunk of the turn in flight
 }
 
 // run is the main loop for the WebSocket session.
@@ -170,6 +266,7 @@ func (s *wsSession) run() {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				// Log error if needed
 			}
+			s.abandon()
 			return
 		}
 
@@ -188,6 +285,8 @@ func (s *wsSession) run() {
 			s.handleAbort(msg.Data)
 		case WSMsgTypeToolResult:
 			s.handleToolResult(msg.Data)
+		case WSMsgTypeToolResults:
+			s.handleToolResults(msg.Data)
 		default:
 			s.sendError("unknown_type", fmt.Sprintf("Unknown message type: %s", msg.Type), false)
 		}
@@ -213,40 +312,57 @@ func (s *wsSession) handleStart(data json.RawMessage) {
 		return
 	}
 
-	// Start bidirectional stream
-	stream, err := s.llm.client.Chat(s.ctx)
+	if req.ResumeSessionID != "" {
+		s.resume(req)
+		return
+	}
+
+	stored, err := s.llm.loadHistory(s.ctx, req.SessionID)
 	if err != nil {
-		s.sendError("stream_failed", err.Error(), true)
+		s.sendError("history_load_failed", err.Error(), true)
 		return
 	}
-	s.stream = stream
+	s.sessionID = req.SessionID
+	if req.Voice != "" {
+		s.speech = &SpeechRequest{Voice: req.Voice, Format: req.AudioFormat}
+	}
 
 	// Convert messages
-	messages := make([]*llmpb.Message, 0, len(req.Messages))
-	for _, msg := range req.Messages {
-		messages = append(messages, &llmpb.Message{
-			Role:    msg.Role,
-			Content: msg.Content,
-		})
-	}
-
-	// Send start request to gRPC
-	err = stream.Send(&llmpb.ChatRequest{
-		Request: &llmpb.ChatRequest_Start{
-			Start: &llmpb.StartChatRequest{
-				ApiKey:       s.llm.apiKey,
-				SystemPrompt: req.SystemPrompt,
-				Model:        req.Model,
-				MaxTokens:    req.MaxTokens,
-				Temperature:  req.Temperature,
-				Messages:     messages,
-			},
-		},
-	})
+	messages := withExamples(req.Examples, append(stored, req.Messages...))
+
+	// Open the gRPC stream and send the start request
+	s.start = &llmpb.StartChatRequest{
+		ApiKey:       s.llm.apiKey,
+		SystemPrompt: req.SystemPrompt,
+		Model:        req.Model,
+		MaxTokens:    req.MaxTokens,
+		Temperature:  req.Temperature,
+		Messages:     messages,
+		Tools:        s.llm.toolDefinitions(),
+
+		ParallelToolCalls: true,
+	}
+	sampling := ChatRequest{
+		Provider:         req.Provider,
+		Routing:          req.Routing,
+		StopSequences:    req.StopSequences,
+		TopP:             req.TopP,
+		TopK:             req.TopK,
+		FrequencyPenalty: req.FrequencyPenalty,
+		PresencePenalty:  req.PresencePenalty,
+		Seed:             req.Seed,
+		Deterministic:    req.Deterministic,
+	}
+	s.start.Provider, s.start.Routing = s.llm.route(sampling)
+	s.start.Params = s.llm.params(sampling)
+	stream, err := s.llm.openChat(s.ctx, s.start)
 	if err != nil {
 		s.sendError("start_failed", err.Error(), true)
 		return
 	}
+	s.streamMu.Lock()
+	s.stream = stream
+	s.streamMu.Unlock()
 
 	s.started = true
 
@@ -254,12 +370,70 @@ func (s *wsSession) handleStart(data json.RawMessage) {
 	go s.readGRPCResponses()
 }
 
-// handleMessage sends a user message to the gRPC stream.
+// resume reattaches to a gateway session and replays the rest of a turn in
+// flight.
+func (s *wsSession) resume(req WSStartRequest) {
+	lastChunk := int32(-1)
+	if req.LastChunkIndex != nil {
+		lastChunk = *req.LastChunkIndex
+	}
+	stream, started, err := s.llm.resumeChat(s.ctx, req.ResumeSessionID, lastChunk)
+	if err != nil {
+		s.sendError("resume_failed", err.Error(), true)
+		return
+	}
+	if req.Voice != "" {
+		s.speech = &SpeechRequest{Voice: req.Voice, Format: req.AudioFormat}
+	}
+
+	s.streamMu.Lock()
+	s.start = &llmpb.StartChatRequest{
+		ApiKey: s.llm.apiKey,
+		Model:  started.Model,
+		Tools:  s.llm.toolDefinitions(),
+
+		ParallelToolCalls: true,
+	}
+	s.history = started.Messages
+	s.gatewayID = started.SessionId
+	if n := len(s.history); started.Generating && n > 0 && s.history[n-1].Role == "user" {
+		user := messagesFromPB(s.history[n-1:])[0]
+		s.pending = &user
+		s.sentAt = time.Now()
+		s.timer = streamTimer{start: s.sentAt}
+		s.guard = s.llm.newGuardRun()
+		s.history = s.history[:n-1]
+	}
+	s.stream = stream
+	s.streamMu.Unlock()
+
+	s.started = true
+	s.send(WSMsgTypeStarted, WSStartedResponse{
+		SessionID:  started.SessionId,
+		Provider:   started.Provider,
+		Model:      started.Model,
+		Resumed:    true,
+		Generating: started.Generating,
+	})
+
+	go s.readGRPCResponses()
+}
+
+// handleMessage sends a user message to the gRPC stream. A message sent while
+// a reply is still in flight is rejected; the browser resends it once the turn
+// completes.
 func (s *wsSession) handleMessage(data json.RawMessage) {
-	if !s.started || s.stream == nil {
+	if !s.ready() {
 		s.sendError("not_started", "Session not started", false)
 		return
 	}
+	s.streamMu.Lock()
+	busy := s.pending != nil
+	s.streamMu.Unlock()
+	if busy {
+		s.sendError("turn_in_flight", "A reply is still being generated", true)
+		return
+	}
 
 	var msg WSUserMessage
 	if err := json.Unmarshal(data, &msg); err != nil {
@@ -267,39 +441,60 @@ func (s *wsSession) handleMessage(data json.RawMessage) {
 		return
 	}
 
-	err := s.stream.Send(&llmpb.ChatRequest{
+	content, err := s.llm.filterInput(s.ctx, msg.Content)
+	if err != nil {
+		s.sendError("content_blocked", err.Error(), false)
+		return
+	}
+
+	release, wait, err := s.llm.acquireGeneration(s.ctx)
+	if err != nil {
+		s.sendError("queue_timeout", err.Error(), true)
+		return
+	}
+
+	s.streamMu.Lock()
+	defer s.streamMu.Unlock()
+	s.release, s.queueWait = release, wait
+
+	err = s.stream.Send(&llmpb.ChatRequest{
 		Request: &llmpb.ChatRequest_Message{
 			Message: &llmpb.UserMessage{
-				Content: msg.Content,
+				Content: content,
+				Images:  imagesToPB(msg.Images),
+				Audio:   audioPartsToPB(msg.Audio),
 			},
 		},
 	})
 	if err != nil {
+		s.releaseGeneration()
 		s.sendError("send_failed", err.Error(), true)
+		return
 	}
+	s.pending = &ChatMessage{Role: "user", Content: content, Images: msg.Images, Audio: msg.Audio}
+	s.sentAt = time.Now()
+	s.timer = streamTimer{start: s.sentAt}
+	s.guard, s.violated = s.llm.newGuardRun(), false
 }
 
 // handleAbort aborts the current generation.
 func (s *wsSession) handleAbort(data json.RawMessage) {
-	if !s.started || s.stream == nil {
+	if !s.ready() {
 		return
 	}
 
 	var req WSAbortRequest
 	json.Unmarshal(data, &req)
 
-	s.stream.Send(&llmpb.ChatRequest{
-		Request: &llmpb.ChatRequest_Abort{
-			Abort: &llmpb.AbortRequest{
-				Reason: req.Reason,
-			},
-		},
-	})
+	s.streamMu.Lock()
+	defer s.streamMu.Unlock()
+
+	s.stream.Send(abortRequest(req.Reason))
 }
 
 // handleToolResult sends a tool result to the gRPC stream.
 func (s *wsSession) handleToolResult(data json.RawMessage) {
-	if !s.started || s.stream == nil {
+	if !s.ready() {
 		s.sendError("not_started", "Session not started", false)
 		return
 	}
@@ -310,6 +505,9 @@ func (s *wsSession) handleToolResult(data json.RawMessage) {
 		return
 	}
 
+	s.streamMu.Lock()
+	defer s.streamMu.Unlock()
+
 	err := s.stream.Send(&llmpb.ChatRequest{
 		Request: &llmpb.ChatRequest_ToolResult{
 			ToolResult: &llmpb.ToolResult{
@@ -324,20 +522,63 @@ func (s *wsSession) handleToolResult(data json.RawMessage) {
 	}
 }
 
+// handleToolResults sends a batch of tool results to the gRPC stream.
+func (s *wsSession) handleToolResults(data json.RawMessage) {
+	if !s.ready() {
+		s.sendError("not_started", "Session not started", false)
+		return
+	}
+
+	var batch WSToolResults
+	if err := json.Unmarshal(data, &batch); err != nil {
+		s.sendError("invalid_data", "Invalid tool results", false)
+		return
+	}
+
+	results := make([]*llmpb.ToolResult, len(batch.Results))
+	for i, r := range batch.Results {
+		results[i] = &llmpb.ToolResult{
+			ToolCallId: r.ToolCallID,
+			Result:     r.Result,
+			IsError:    r.IsError,
+		}
+	}
+
+	s.streamMu.Lock()
+	defer s.streamMu.Unlock()
+
+	err := s.stream.Send(&llmpb.ChatRequest{
+		Request: &llmpb.ChatRequest_ToolResults{ToolResults: &llmpb.ToolResultBatch{Results: results}},
+	})
+	if err != nil {
+		s.sendError("send_failed", err.Error(), true)
+	}
+}
+
 // readGRPCResponses reads from the gRPC stream and forwards to WebSocket.
 func (s *wsSession) readGRPCResponses() {
 	for {
-		resp, err := s.stream.Recv()
+		s.streamMu.Lock()
+		stream := s.stream
+		s.streamMu.Unlock()
+
+		resp, err := stream.Recv()
 		if err == io.EOF {
 			return
 		}
 		if err != nil {
+			if isStreamBroken(err) && s.reconnect() {
+				continue
+			}
 			s.sendError("stream_error", err.Error(), false)
 			return
 		}
 
 		switch r := resp.Response.(type) {
 		case *llmpb.ChatResponse_SessionStarted:
+			s.streamMu.Lock()
+			s.gatewayID = r.SessionStarted.SessionId
+			s.streamMu.Unlock()
 			s.send(WSMsgTypeStarted, WSStartedResponse{
 				SessionID: r.SessionStarted.SessionId,
 				Provider:  r.SessionStarted.Provider,
@@ -345,29 +586,141 @@ func (s *wsSession) readGRPCResponses() {
 			})
 
 		case *llmpb.ChatResponse_Chunk:
+			s.streamMu.Lock()
+			s.llm.recordChunk(&s.timer, s.start.Model, s.start.Provider)
+			s.lastIndex = r.Chunk.Index
+			text, err := s.guardChunk(r.Chunk.Content)
+			s.streamMu.Unlock()
+			if err != nil {
+				s.sendError("policy_violation", err.Error(), false)
+				continue
+			}
+			// Output filters screen the complete reply, sent as one chunk.
+			if len(s.llm.outputFilters) > 0 || text == "" {
+				continue
+			}
 			s.send(WSMsgTypeChunk, WSChunkResponse{
-				Content: r.Chunk.Content,
-				Index:   r.Chunk.Index,
+				Content:      text,
+				Index:        r.Chunk.Index,
+				InputTokens:  r.Chunk.InputTokens,
+				OutputTokens: r.Chunk.OutputTokens,
 			})
 
 		case *llmpb.ChatResponse_ToolCall:
+			// Registered tools run server-side; others go to the browser.
+			if s.llm.hasTool(r.ToolCall.Name) {
+				go s.runTool(r.ToolCall)
+				continue
+			}
 			s.send(WSMsgTypeToolCall, WSToolCallResponse{
 				ToolCallID:    r.ToolCall.ToolCallId,
 				Name:          r.ToolCall.Name,
 				ArgumentsJSON: r.ToolCall.ArgumentsJson,
 			})
 
+		case *llmpb.ChatResponse_ToolCalls:
+			// Registered tools run server-side, concurrently; the rest go to
+			// the browser as one batch.
+			var local []*llmpb.ToolCallRequest
+			var remote []WSToolCallResponse
+			for _, call := range r.ToolCalls.Calls {
+				if s.llm.hasTool(call.Name) {
+					local = append(local, call)
+					continue
+				}
+				remote = append(remote, WSToolCallResponse{
+					ToolCallID:    call.ToolCallId,
+					Name:          call.Name,
+					ArgumentsJSON: call.ArgumentsJson,
+				})
+			}
+			if len(local) > 0 {
+				go s.runTools(local)
+			}
+			if len(remote) > 0 {
+				s.send(WSMsgTypeToolCalls, WSToolCallsResponse{Calls: remote})
+			}
+
 		case *llmpb.ChatResponse_Completion:
+			s.streamMu.Lock()
+			s.llm.recordCompletion(r.Completion, time.Since(s.sentAt))
+			s.audit(&ChatResponse{
+				Content:      r.Completion.FullContent,
+				Model:        r.Completion.Model,
+				Provider:     r.Completion.Provider,
+				StopReason:   r.Completion.StopReason,
+				InputTokens:  r.Completion.InputTokens,
+				OutputTokens: r.Completion.OutputTokens,
+				CostUSD:      r.Completion.CostUsd,
+			}, nil)
+			// A resumed turn whose user message the gateway did not
+			// replay has no pending message; its reply is not recorded.
+			user := s.pending
+			if user != nil {
+				s.history = append(s.history,
+					messagesToPB([]ChatMessage{*user})[0],
+					&llmpb.Message{Role: "assistant", Content: r.Completion.FullContent},
+				)
+			}
+			s.pending = nil
+			queueWait := s.queueWait
+			var latency ChatResponse
+			s.timer.apply(&latency)
+			s.releaseGeneration()
+			violated, lastIndex := s.violated, s.lastIndex
+			content, rest, err := s.guard.final(r.Completion.FullContent)
+			s.streamMu.Unlock()
+
+			if violated {
+				continue
+			}
+			if err != nil {
+				s.sendError("policy_violation", err.Error(), false)
+				continue
+			}
+			if rest != "" && len(s.llm.outputFilters) == 0 {
+				s.send(WSMsgTypeChunk, WSChunkResponse{Content: rest, Index: lastIndex})
+			}
+			if len(s.llm.outputFilters) > 0 {
+				if content, err = s.llm.filterOutput(s.ctx, content); err != nil {
+					s.sendError("content_blocked", err.Error(), false)
+					continue
+				}
+				s.send(WSMsgTypeChunk, WSChunkResponse{Content: content})
+			}
+
+			if user != nil {
+				if err := s.llm.saveTurn(s.ctx, s.sessionID, *user, content); err != nil {
+					s.sendError("history_save_failed", err.Error(), false)
+				}
+			}
+
 			s.send(WSMsgTypeCompletion, WSCompletionResponse{
-				FullContent:  r.Completion.FullContent,
+				FullContent:  content,
 				StopReason:   r.Completion.StopReason,
 				InputTokens:  r.Completion.InputTokens,
 				OutputTokens: r.Completion.OutputTokens,
 				CostUSD:      r.Completion.CostUsd,
 				LatencyMs:    r.Completion.LatencyMs,
+				Provider:     r.Completion.Provider,
+				Model:        r.Completion.Model,
+				QueueWaitMs:  queueWait.Milliseconds(),
+				Fingerprint:  r.Completion.SystemFingerprint,
+
+				TTFTMs:        latency.TTFTMs,
+				ChunkGapMs:    latency.ChunkGapMs,
+				MaxChunkGapMs: latency.MaxChunkGapMs,
 			})
+			if s.speech != nil && content != "" {
+				go s.speak(content)
+			}
 
 		case *llmpb.ChatResponse_Error:
+			s.streamMu.Lock()
+			s.audit(nil, &LLMError{Code: r.Error.Code, Message: r.Error.Message, Retryable: r.Error.Retryable})
+			s.pending = nil
+			s.releaseGeneration()
+			s.streamMu.Unlock()
 			s.send(WSMsgTypeError, WSErrorResponse{
 				Code:      r.Error.Code,
 				Message:   r.Error.Message,
@@ -375,6 +728,15 @@ func (s *wsSession) readGRPCResponses() {
 			})
 
 		case *llmpb.ChatResponse_Aborted:
+			s.streamMu.Lock()
+			s.audit(nil, fmt.Errorf("generation aborted: %s", r.Aborted.Reason))
+			s.pending = nil
+			s.releaseGeneration()
+			violated := s.violated
+			s.streamMu.Unlock()
+			if violated {
+				continue // reported as a policy violation
+			}
 			s.send(WSMsgTypeError, WSErrorResponse{
 				Code:    "aborted",
 				Message: r.Aborted.Reason,
@@ -383,6 +745,123 @@ func (s *wsSession) readGRPCResponses() {
 	}
 }
 
+// runTool executes a registered tool and sends its result to the gRPC stream.
+func (s *wsSession) runTool(call *llmpb.ToolCallRequest) {
+	result := s.llm.executeTool(s.ctx, call)
+
+	s.streamMu.Lock()
+	defer s.streamMu.Unlock()
+	if err := s.stream.Send(result); err != nil {
+		s.sendError("send_failed", err.Error(), true)
+	}
+}
+
+// runTools executes registered tools concurrently and sends their results to
+// the gRPC stream together.
+func (s *wsSession) runTools(calls []*llmpb.ToolCallRequest) {
+	result := s.llm.executeTools(s.ctx, calls)
+
+	s.streamMu.Lock()
+	defer s.streamMu.Unlock()
+	if err := s.stream.Send(result); err != nil {
+		s.sendError("send_failed", err.Error(), true)
+	}
+}
+
+// speak streams synthesized speech of a reply to the browser.
+func (s *wsSession) speak(text string) {
+	req := *s.speech
+	req.Text = text
+	err := s.llm.Synthesize(s.ctx, req, func(chunk AudioChunk) error {
+		s.send(WSMsgTypeAudio, WSAudioResponse{Data: chunk.Data, MediaType: chunk.MediaType, Index: chunk.Index})
+		return nil
+	})
+	if err != nil {
+		s.sendError("speech_failed", err.Error(), true)
+		return
+	}
+	s.send(WSMsgTypeAudio, WSAudioResponse{Final: true})
+}
+
+// audit records the outcome of the turn in flight. The caller holds streamMu.
+func (s *wsSession) audit(resp *ChatResponse, err error) {
+	if s.pending == nil {
+		return
+	}
+	rec := AuditRecord{
+		Source:           AuditWebSocket,
+		SessionID:        s.sessionID,
+		GatewaySessionID: s.gatewayID,
+		Model:            s.start.Model,
+		SystemPrompt:     s.start.SystemPrompt,
+		Messages:         []ChatMessage{*s.pending},
+	}
+	s.llm.audit(s.ctx, rec.withResult(resp, err))
+}
+
+// guardChunk applies the output guards to a chunk of the turn in flight. On
+// a violation the generation is aborted, and later chunks are dropped. The
+// caller holds streamMu.
+func (s *wsSession) guardChunk(text string) (string, error) {
+	if s.violated {
+		return "", nil
+	}
+	text, err := s.guard.chunk(text)
+	if err != nil {
+		s.violated = true
+		s.stream.Send(abortRequest(err.Error()))
+	}
+	return text, err
+}
+
+// ready reports whether the session has a gRPC stream to send to.
+func (s *wsSession) ready() bool {
+	s.streamMu.Lock()
+	defer s.streamMu.Unlock()
+	return s.started && s.stream != nil
+}
+
+// releaseGeneration frees the generation slot of the turn in flight. The
+// caller holds streamMu.
+func (s *wsSession) releaseGeneration() {
+	if s.release != nil {
+		s.release()
+		s.release = nil
+	}
+}
+
+// abandon aborts the reply in flight when the browser has disconnected, so
+// the gateway stops generating tokens nobody will read.
+func (s *wsSession) abandon() {
+	s.streamMu.Lock()
+	defer s.streamMu.Unlock()
+	if s.stream == nil || s.pending == nil {
+		return
+	}
+	s.stream.Send(abortRequest("client disconnected"))
+}
+
+// reconnect re-opens a broken gRPC stream with the completed turns replayed, so
+// sessions survive gateway restarts. A turn in flight is lost; the browser gets
+// a retryable "stream_interrupted" error and can resend it.
+func (s *wsSession) reconnect() bool {
+	s.streamMu.Lock()
+	defer s.streamMu.Unlock()
+
+	stream, err := s.llm.reopenChat(s.ctx, s.start, s.history)
+	if err != nil {
+		return false
+	}
+	s.stream = stream
+
+	if s.pending != nil {
+		s.pending = nil
+		s.releaseGeneration()
+		s.sendError("stream_interrupted", "Connection to the LLM gateway was lost; resend the message", true)
+	}
+	return true
+}
+
 // send marshals and sends a message over WebSocket.
 func (s *wsSession) send(msgType string, data interface{}) {
 	s.sendMu.Lock()